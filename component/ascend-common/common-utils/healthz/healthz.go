@@ -70,6 +70,8 @@ var (
 	globalCheckers []HealthChecker
 	checkerMu      sync.RWMutex
 	globalLimiter  = rate.NewLimiter(rate.Limit(defaultRateLimit), defaultRateBurst)
+	extraHandlers  = map[string]http.Handler{}
+	handlerMu      sync.RWMutex
 )
 
 // RegisterHealthChecker appends a health check callback to the checker chain.
@@ -95,6 +97,26 @@ func ResetLimiter() {
 	globalLimiter = rate.NewLimiter(rate.Limit(defaultRateLimit), defaultRateBurst)
 }
 
+// RegisterHandler mounts an extra handler, such as a metrics handler, on the healthz server.
+// It must be called before StartServe, and the health check path "/" can not be overridden.
+func RegisterHandler(path string, handler http.Handler) error {
+	if path == "" || path == healthzPath || handler == nil {
+		return fmt.Errorf("invalid handler path %q or nil handler", path)
+	}
+	handlerMu.Lock()
+	defer handlerMu.Unlock()
+	extraHandlers[path] = handler
+	return nil
+}
+
+// ClearHandlers removes all extra handlers registered by RegisterHandler.
+// Primarily useful for tests to reset state between cases.
+func ClearHandlers() {
+	handlerMu.Lock()
+	defer handlerMu.Unlock()
+	extraHandlers = map[string]http.Handler{}
+}
+
 func getHealthCheckers() []HealthChecker {
 	checkerMu.RLock()
 	defer checkerMu.RUnlock()
@@ -174,6 +196,11 @@ func StartServe(ctx context.Context, cfg *Config) error {
 
 	mux := http.NewServeMux()
 	mux.HandleFunc(healthzPath, healthzHandler)
+	handlerMu.RLock()
+	for path, handler := range extraHandlers {
+		mux.Handle(path, handler)
+	}
+	handlerMu.RUnlock()
 
 	server := &http.Server{
 		Handler:      mux,
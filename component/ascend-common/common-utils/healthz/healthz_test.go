@@ -453,3 +453,33 @@ func TestHealthzHandlerRateLimit(t *testing.T) {
 		t.Error("expected at least one rate-limited request (429), but all passed")
 	}
 }
+
+func TestRegisterHandler(t *testing.T) {
+	ResetLimiter()
+	defer ClearHandlers()
+	if err := RegisterHandler(healthzPath, http.NotFoundHandler()); err == nil {
+		t.Error("expected error for registering health check path, got nil")
+	}
+	err := RegisterHandler("/metrics", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	if err != nil {
+		t.Fatalf("RegisterHandler failed: %v", err)
+	}
+
+	cfg := NewConfig()
+	cfg.HealthzAddress = "11262"
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := StartServe(ctx, cfg); err != nil {
+		t.Fatalf("StartServe failed: %v", err)
+	}
+	resp, err := http.Get("http://localhost:11262/metrics")
+	if err != nil {
+		t.Fatalf("GET /metrics failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		t.Errorf("expected 202, got %d", resp.StatusCode)
+	}
+}
@@ -84,6 +84,14 @@ const (
 	logCriticalLv
 )
 
+var levelNames = map[int]string{
+	logDebugLv:    "DEBUG",
+	logInfoLv:     "INFO",
+	logWarnLv:     "WARN",
+	logErrorLv:    "ERROR",
+	logCriticalLv: "CRITICAL",
+}
+
 type logger struct {
 	lgDebug    *log.Logger
 	lgInfo     *log.Logger
@@ -93,9 +101,19 @@ type logger struct {
 	lgCtrl     *LogLimiter
 	lgLevel    int
 	lgMaxLine  int
+	lgJSON     bool
 }
 
 func (lg *logger) initLogWriter(w io.Writer) {
+	if lg.lgJSON {
+		// time and level are written in the json object of each line
+		lg.lgDebug = log.New(w, "", 0)
+		lg.lgInfo = log.New(w, "", 0)
+		lg.lgWarn = log.New(w, "", 0)
+		lg.lgError = log.New(w, "", 0)
+		lg.lgCritical = log.New(w, "", 0)
+		return
+	}
 	// Use custom logger writer, note that we don't use log.Ldate|log.Lmicroseconds flag to avoid duplicate timestamps
 	// Custom writer will handle timestamp formatting
 	customWriter := NewCustomLoggerWriter(w)
@@ -152,6 +170,7 @@ func (lg *logger) setLogger(config *LogConfig) error {
 	if err := validateLogConfigFiled(config); err != nil {
 		return err
 	}
+	lg.lgJSON = config.JSONFormat
 	lg.setLoggerWriter(config)
 	lg.setLoggerLevel(config.LogLevel)
 	lg.setLoggerMaxLine(config.MaxLineLength)
@@ -189,7 +208,7 @@ func (lg *logger) DebugWithCtx(ctx context.Context, args ...interface{}) {
 		return
 	}
 	if lg.validate() {
-		printHelper(lg.lgDebug, fmt.Sprint(args...), lg.lgMaxLine, ctx)
+		lg.output(lg.lgDebug, logDebugLv, fmt.Sprint(args...), ctx)
 	}
 }
 
@@ -199,7 +218,7 @@ func (lg *logger) DebugfWithCtx(ctx context.Context, format string, args ...inte
 		return
 	}
 	if lg.validate() {
-		printHelper(lg.lgDebug, fmt.Sprintf(format, args...), lg.lgMaxLine, ctx)
+		lg.output(lg.lgDebug, logDebugLv, fmt.Sprintf(format, args...), ctx)
 	}
 }
 
@@ -219,7 +238,7 @@ func (lg *logger) InfoWithCtx(ctx context.Context, args ...interface{}) {
 		return
 	}
 	if lg.validate() {
-		printHelper(lg.lgInfo, fmt.Sprint(args...), lg.lgMaxLine, ctx)
+		lg.output(lg.lgInfo, logInfoLv, fmt.Sprint(args...), ctx)
 	}
 }
 
@@ -229,7 +248,7 @@ func (lg *logger) InfofWithCtx(ctx context.Context, format string, args ...inter
 		return
 	}
 	if lg.validate() {
-		printHelper(lg.lgInfo, fmt.Sprintf(format, args...), lg.lgMaxLine, ctx)
+		lg.output(lg.lgInfo, logInfoLv, fmt.Sprintf(format, args...), ctx)
 	}
 }
 
@@ -249,7 +268,7 @@ func (lg *logger) WarnWithCtx(ctx context.Context, args ...interface{}) {
 		return
 	}
 	if lg.validate() {
-		printHelper(lg.lgWarn, fmt.Sprint(args...), lg.lgMaxLine, ctx)
+		lg.output(lg.lgWarn, logWarnLv, fmt.Sprint(args...), ctx)
 	}
 }
 
@@ -259,7 +278,7 @@ func (lg *logger) WarnfWithCtx(ctx context.Context, format string, args ...inter
 		return
 	}
 	if lg.validate() {
-		printHelper(lg.lgWarn, fmt.Sprintf(format, args...), lg.lgMaxLine, ctx)
+		lg.output(lg.lgWarn, logWarnLv, fmt.Sprintf(format, args...), ctx)
 	}
 }
 
@@ -308,7 +327,7 @@ func (lg *logger) ErrorWithCtx(ctx context.Context, args ...interface{}) {
 		return
 	}
 	if lg.validate() {
-		printHelper(lg.lgError, fmt.Sprint(args...), lg.lgMaxLine, ctx)
+		lg.output(lg.lgError, logErrorLv, fmt.Sprint(args...), ctx)
 	}
 }
 
@@ -318,7 +337,7 @@ func (lg *logger) ErrorfWithCtx(ctx context.Context, format string, args ...inte
 		return
 	}
 	if lg.validate() {
-		printHelper(lg.lgError, fmt.Sprintf(format, args...), lg.lgMaxLine, ctx)
+		lg.output(lg.lgError, logErrorLv, fmt.Sprintf(format, args...), ctx)
 	}
 }
 
@@ -338,7 +357,7 @@ func (lg *logger) CriticalWithCtx(ctx context.Context, args ...interface{}) {
 		return
 	}
 	if lg.validate() {
-		printHelper(lg.lgCritical, fmt.Sprint(args...), lg.lgMaxLine, ctx)
+		lg.output(lg.lgCritical, logCriticalLv, fmt.Sprint(args...), ctx)
 	}
 }
 
@@ -348,8 +367,17 @@ func (lg *logger) CriticalfWithCtx(ctx context.Context, format string, args ...i
 		return
 	}
 	if lg.validate() {
-		printHelper(lg.lgCritical, fmt.Sprintf(format, args...), lg.lgMaxLine, ctx)
+		lg.output(lg.lgCritical, logCriticalLv, fmt.Sprintf(format, args...), ctx)
+	}
+}
+
+// output print the log line in text or json format
+func (lg *logger) output(lgr *log.Logger, level int, msg string, ctx context.Context) {
+	if lg.lgJSON {
+		printJSONHelper(lgr, levelNames[level], msg, lg.lgMaxLine, ctx)
+		return
 	}
+	printHelper(lgr, msg, lg.lgMaxLine, ctx)
 }
 
 func (lg *logger) validate() bool {
@@ -0,0 +1,211 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package hwlog provides the capability of processing Huawei log rules.
+package hwlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// fieldsKey used for context value key of the fields of log lines
+	fieldsKey    ContextKey = "hwlogFields"
+	fieldPairLen            = 2
+	// jsonTimeLayout has fixed length, so the json log lines can be cut by the log limiter like the text ones
+	jsonTimeLayout = "2006-01-02T15:04:05.000000-07:00"
+	// fieldKeyPrefix prefix of the fields whose keys conflict with the keys of json log line
+	fieldKeyPrefix = "field."
+)
+
+var reservedKeys = map[string]bool{
+	"time": true, "level": true, "goroutine": true, "caller": true, "userID": true, "requestID": true, "msg": true,
+}
+
+// WithFields returns a copy of ctx carrying the key-value pairs, the fields are printed in each log line
+// with the ctx, such as jobId=xxx in text format or "jobId":"xxx" in json format
+func WithFields(ctx context.Context, keysAndValues ...interface{}) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if len(keysAndValues) == 0 {
+		return ctx
+	}
+	parent := fieldsFromContext(ctx)
+	fields := make([]interface{}, 0, len(parent)+len(keysAndValues)+1)
+	fields = append(fields, parent...)
+	fields = append(fields, keysAndValues...)
+	if len(keysAndValues)%fieldPairLen != 0 {
+		// the value of the last key is missing
+		fields = append(fields, nil)
+	}
+	return context.WithValue(ctx, fieldsKey, fields)
+}
+
+func fieldsFromContext(ctx context.Context) []interface{} {
+	fields, _ := ctx.Value(fieldsKey).([]interface{}) // security type assertions, invalid values are ignored
+	return fields
+}
+
+// textFieldValue value of field in text log line, the value with space is quoted
+func textFieldValue(value interface{}) string {
+	str := fmt.Sprint(value)
+	if strings.ContainsAny(str, " \t\r\n\"") {
+		return strconv.Quote(str)
+	}
+	return str
+}
+
+// jsonFieldValue value of field in json log line, the values not basic types are printed as string
+func jsonFieldValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil, string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return v
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// json build the json object of the log line, time, level and goroutine are in front of caller, so the log limiter
+// can skip them by the caller key
+func (info logContext) json(level, msg string) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	writeJSONField(&buf, "time", time.Now().Format(jsonTimeLayout), true)
+	writeJSONField(&buf, "level", level, false)
+	writeJSONField(&buf, "goroutine", info.goroutineID, false)
+	writeJSONField(&buf, "caller", info.callerPath, false)
+	if info.userID != nil {
+		writeJSONField(&buf, "userID", jsonFieldValue(info.userID), false)
+	}
+	if info.traceID != nil {
+		writeJSONField(&buf, "requestID", jsonFieldValue(info.traceID), false)
+	}
+	writeJSONField(&buf, "msg", msg, false)
+	for i := 0; i+1 < len(info.fields); i += fieldPairLen {
+		key := fmt.Sprint(info.fields[i])
+		if reservedKeys[key] {
+			key = fieldKeyPrefix + key
+		}
+		writeJSONField(&buf, key, jsonFieldValue(info.fields[i+1]), false)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes()
+}
+
+func writeJSONField(buf *bytes.Buffer, key string, value interface{}, first bool) {
+	if !first {
+		buf.WriteByte(',')
+	}
+	keyBytes, err := json.Marshal(key)
+	if err != nil {
+		keyBytes = []byte(strconv.Quote(key))
+	}
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		valueBytes = []byte(strconv.Quote(fmt.Sprint(value)))
+	}
+	buf.Write(keyBytes)
+	buf.WriteByte(':')
+	buf.Write(valueBytes)
+}
+
+// Entry logger carrying fields, the fields are printed in each log line
+type Entry struct {
+	lg  *logger
+	ctx context.Context
+}
+
+// With returns an entry carrying the key-value pairs, such as RunLog.With("jobId", id, "node", n).Info("msg")
+func (lg *logger) With(keysAndValues ...interface{}) *Entry {
+	return &Entry{lg: lg, ctx: WithFields(context.Background(), keysAndValues...)}
+}
+
+// WithContext returns an entry carrying the values of ctx, including the fields, UserID and ReqID
+func (lg *logger) WithContext(ctx context.Context) *Entry {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return &Entry{lg: lg, ctx: ctx}
+}
+
+// With returns a new entry carrying the fields of e and the key-value pairs
+func (e *Entry) With(keysAndValues ...interface{}) *Entry {
+	return &Entry{lg: e.lg, ctx: WithFields(e.ctx, keysAndValues...)}
+}
+
+// Context returns the context carrying the fields of e, it can be passed to the methods with ctx
+func (e *Entry) Context() context.Context {
+	return e.ctx
+}
+
+// Debug record debug not format
+func (e *Entry) Debug(args ...interface{}) {
+	e.lg.DebugWithCtx(DeepIncrease(e.ctx), args...)
+}
+
+// Debugf record debug
+func (e *Entry) Debugf(format string, args ...interface{}) {
+	e.lg.DebugfWithCtx(DeepIncrease(e.ctx), format, args...)
+}
+
+// Info record info not format
+func (e *Entry) Info(args ...interface{}) {
+	e.lg.InfoWithCtx(DeepIncrease(e.ctx), args...)
+}
+
+// Infof record info
+func (e *Entry) Infof(format string, args ...interface{}) {
+	e.lg.InfofWithCtx(DeepIncrease(e.ctx), format, args...)
+}
+
+// Warn record warn not format
+func (e *Entry) Warn(args ...interface{}) {
+	e.lg.WarnWithCtx(DeepIncrease(e.ctx), args...)
+}
+
+// Warnf record warn
+func (e *Entry) Warnf(format string, args ...interface{}) {
+	e.lg.WarnfWithCtx(DeepIncrease(e.ctx), format, args...)
+}
+
+// Error record error not format
+func (e *Entry) Error(args ...interface{}) {
+	e.lg.ErrorWithCtx(DeepIncrease(e.ctx), args...)
+}
+
+// Errorf record error
+func (e *Entry) Errorf(format string, args ...interface{}) {
+	e.lg.ErrorfWithCtx(DeepIncrease(e.ctx), format, args...)
+}
+
+// Critical record critical not format
+func (e *Entry) Critical(args ...interface{}) {
+	e.lg.CriticalWithCtx(DeepIncrease(e.ctx), args...)
+}
+
+// Criticalf record critical
+func (e *Entry) Criticalf(format string, args ...interface{}) {
+	e.lg.CriticalfWithCtx(DeepIncrease(e.ctx), format, args...)
+}
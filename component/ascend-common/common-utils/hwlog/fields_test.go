@@ -0,0 +1,103 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package hwlog test file
+package hwlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+func newBufferLogger(isJSON bool) (*logger, *bytes.Buffer) {
+	buf := &bytes.Buffer{}
+	lg := &logger{lgJSON: isJSON, lgLevel: logDebugLv, lgMaxLine: defaultMaxEachLineLen}
+	lg.initLogWriter(buf)
+	return lg, buf
+}
+
+func TestWithFields(t *testing.T) {
+	convey.Convey("test func 'WithFields'", t, func() {
+		ctx := WithFields(nil, "jobId", "job1")
+		ctx = WithFields(ctx, "node")
+		convey.So(fieldsFromContext(ctx), convey.ShouldResemble, []interface{}{"jobId", "job1", "node", nil})
+		convey.So(WithFields(ctx), convey.ShouldEqual, ctx)
+	})
+}
+
+func TestEntryTextFormat(t *testing.T) {
+	convey.Convey("test text format of Entry", t, func() {
+		lg, buf := newBufferLogger(false)
+		lg.With("jobId", "job1", "reason", "link down").Warnf("npu %d is unhealthy", 0)
+		line := buf.String()
+		convey.So(line, convey.ShouldContainSubstring, "[WARN]")
+		convey.So(line, convey.ShouldContainSubstring, "hwlog/fields_test.go:")
+		convey.So(line, convey.ShouldContainSubstring, `jobId=job1 reason="link down" npu 0 is unhealthy`)
+
+		buf.Reset()
+		ctx := context.WithValue(context.Background(), ReqID, "req1")
+		lg.InfoWithCtx(WithFields(ctx, "node", "node1"), "hello")
+		convey.So(buf.String(), convey.ShouldContainSubstring, `{<nil>}-{"req1"} node=node1 hello`)
+
+		buf.Reset()
+		lg.Info("no fields")
+		convey.So(buf.String(), convey.ShouldContainSubstring, "hwlog/fields_test.go:")
+	})
+}
+
+func TestEntryJSONFormat(t *testing.T) {
+	convey.Convey("test json format of Entry", t, func() {
+		lg, buf := newBufferLogger(true)
+		entry := lg.With("jobId", "job1", "rank", 3).With("err", errors.New("failed"), "msg", "conflict")
+		entry.Error("first\nsecond")
+		line := buf.Bytes()
+		convey.So(string(line), convey.ShouldStartWith, `{"time":"`)
+		obj := make(map[string]interface{})
+		convey.So(json.Unmarshal(line, &obj), convey.ShouldBeNil)
+		convey.So(obj["level"], convey.ShouldEqual, "ERROR")
+		convey.So(obj["msg"], convey.ShouldEqual, "first second")
+		convey.So(obj["jobId"], convey.ShouldEqual, "job1")
+		convey.So(obj["rank"], convey.ShouldEqual, 3)
+		convey.So(obj["err"], convey.ShouldEqual, "failed")
+		convey.So(obj["field.msg"], convey.ShouldEqual, "conflict")
+		convey.So(obj["caller"], convey.ShouldStartWith, "hwlog/fields_test.go:")
+
+		buf.Reset()
+		lg.lgMaxLine = 3
+		lg.WithContext(entry.Context()).Debug("abcdef")
+		convey.So(json.Unmarshal(buf.Bytes(), &obj), convey.ShouldBeNil)
+		convey.So(obj["msg"], convey.ShouldEqual, "abc")
+		convey.So(obj["caller"], convey.ShouldStartWith, "hwlog/fields_test.go:")
+	})
+}
+
+func TestLimitKey(t *testing.T) {
+	convey.Convey("test func 'limitKey'", t, func() {
+		lg, buf := newBufferLogger(true)
+		var keys []string
+		for i := 0; i < 2; i++ {
+			buf.Reset()
+			lg.Info("same message")
+			keys = append(keys, limitKey(buf.Bytes()))
+		}
+		convey.So(keys[0], convey.ShouldStartWith, `"caller":`)
+		convey.So(keys[0], convey.ShouldEqual, keys[1])
+		convey.So(limitKey([]byte("short")), convey.ShouldEqual, "short")
+	})
+}
@@ -16,6 +16,7 @@
 package hwlog
 
 import (
+	"bytes"
 	"fmt"
 	"sync"
 	"time"
@@ -38,7 +39,8 @@ const (
 )
 
 var (
-	errorMap sync.Map
+	errorMap      sync.Map
+	jsonCallerKey = []byte(`"caller":`)
 )
 
 // LogLimiter encapsulates Logs and provides the log traffic limiting capability
@@ -80,13 +82,27 @@ func (l *LogLimiter) Write(d []byte) (int, error) {
 	if l.logCache == nil {
 		l.logCache = cache.New(DefaultCacheSize)
 	}
-	if !l.logCache.SetIfNX(string(d[cutPreLen:]), "v", l.logExpiredTime) {
+	if !l.logCache.SetIfNX(limitKey(d), "v", l.logExpiredTime) {
 		return 0, nil
 	}
 
 	return l.Logs.Write(d)
 }
 
+// limitKey the key of duplicate logs, the time, level and goroutine of the log line are skipped
+func limitKey(d []byte) string {
+	if len(d) > 0 && d[0] == '{' {
+		if idx := bytes.Index(d, jsonCallerKey); idx >= 0 {
+			return string(d[idx:])
+		}
+		return string(d)
+	}
+	if len(d) <= cutPreLen {
+		return string(d)
+	}
+	return string(d[cutPreLen:])
+}
+
 // Close implements io.Closer. It encapsulates the Close method of Logs.
 func (l *LogLimiter) Close() error {
 	if l == nil {
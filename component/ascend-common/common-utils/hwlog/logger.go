@@ -47,7 +47,7 @@ const (
 	LogDirMode            = 0750
 	backUpLogRegex        = `^.+-[0-9]{4}-[0-9]{2}-[0-9T]{5}-[0-9]{2}-[0-9]{2}\.[0-9]{2,4}`
 	bitsize               = 64
-	stackDeep             = 3
+	stackDeep             = 4
 	pathLen               = 2
 	minLogLevel           = -1
 	maxLogLevel           = 3
@@ -79,6 +79,8 @@ type LogConfig struct {
 	ExpiredTime int
 	// Size of log cache space, default: 10240
 	CacheSize int
+	// JSONFormat write each log line as a json object, default value: false
+	JSONFormat bool
 }
 
 var reg = regexp.MustCompile(backUpLogRegex)
@@ -27,27 +27,59 @@ import (
 // printHelper helper function for log printing
 func printHelper(lg *log.Logger, msg string, maxLogLength int, ctx ...context.Context) {
 	str := getCallerInfo(ctx...)
+	lg.Println(str + trimLogMsg(msg, maxLogLength))
+}
+
+// printJSONHelper helper function for log printing in json format
+func printJSONHelper(lg *log.Logger, level, msg string, maxLogLength int, ctx ...context.Context) {
+	info := collectLogContext(stackDeep, ctx...)
+	lg.Println(string(info.json(level, trimLogMsg(msg, maxLogLength))))
+}
+
+// trimLogMsg replaces the line breaks of msg and cuts it to maxLogLength
+func trimLogMsg(msg string, maxLogLength int) string {
 	trimMsg := strings.Replace(msg, "\r", " ", -1)
 	trimMsg = strings.Replace(trimMsg, "\n", " ", -1)
 	runeArr := []rune(trimMsg)
 	if length := len(runeArr); length > maxLogLength {
 		trimMsg = string(runeArr[:maxLogLength])
 	}
-	lg.Println(str + trimMsg)
+	return trimMsg
+}
+
+// logContext information of the log line besides the message
+type logContext struct {
+	goroutineID string
+	callerPath  string
+	userID      interface{}
+	traceID     interface{}
+	fields      []interface{}
 }
 
 // getCallerInfo gets the caller's information
 func getCallerInfo(ctx ...context.Context) string {
-	var deep = stackDeep
-	var userID interface{}
-	var traceID interface{}
+	info := collectLogContext(stackDeep+1, ctx...)
+	str := fmt.Sprintf("%-8s%s    ", info.goroutineID, info.callerPath)
+	if info.userID != nil || info.traceID != nil {
+		str = fmt.Sprintf("%s{%#v}-{%#v} ", str, info.userID, info.traceID)
+	}
+	for i := 0; i+1 < len(info.fields); i += fieldPairLen {
+		str = fmt.Sprintf("%s%v=%s ", str, info.fields[i], textFieldValue(info.fields[i+1]))
+	}
+	return str
+}
+
+// collectLogContext collects the caller of deep and the values of ctx
+func collectLogContext(deep int, ctx ...context.Context) logContext {
+	var info logContext
 	for _, c := range ctx {
 		if c == nil {
 			deep++
 			continue
 		}
-		userID = c.Value(UserID)
-		traceID = c.Value(ReqID)
+		info.userID = c.Value(UserID)
+		info.traceID = c.Value(ReqID)
+		info.fields = fieldsFromContext(c)
 		if val := c.Value(extraDeepKey); val != nil {
 			currentVal, _ := val.(int) // security type assertions, invalid values are automatically zeroed
 			deep += currentVal
@@ -65,13 +97,9 @@ func getCallerInfo(ctx ...context.Context) string {
 	} else if l > pathLen {
 		funcName = fmt.Sprintf("%s/%s", p[l-pathLen], p[l-1])
 	}
-	callerPath := fmt.Sprintf("%s:%d", funcName, codeLine)
-	goroutineID := getGoroutineID()
-	str := fmt.Sprintf("%-8s%s    ", goroutineID, callerPath)
-	if userID != nil || traceID != nil {
-		str = fmt.Sprintf("%s{%#v}-{%#v} ", str, userID, traceID)
-	}
-	return str
+	info.callerPath = fmt.Sprintf("%s:%d", funcName, codeLine)
+	info.goroutineID = getGoroutineID()
+	return info
 }
 
 // getCallerGoroutineID gets the goroutineID
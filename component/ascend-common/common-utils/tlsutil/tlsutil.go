@@ -0,0 +1,275 @@
+/* Copyright(C) 2025. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package tlsutil offer mutual tls configs whose certificates are reloaded when the files are rotated
+package tlsutil
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"ascend-common/common-utils/hwlog"
+	"ascend-common/common-utils/utils"
+)
+
+const (
+	// DefaultReloadInterval default interval of checking whether the files are rotated
+	DefaultReloadInterval = 30 * time.Second
+	maxPemFileSize        = 1024 * 1024
+	maxTokenFileSize      = 64 * 1024
+	authorizationKey      = "authorization"
+	bearerPrefix          = "Bearer "
+)
+
+// Config files of mutual tls
+type Config struct {
+	CertFile string
+	KeyFile  string
+	CAFile   string
+}
+
+// Enabled whether any file of tls is configured
+func (c Config) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != "" || c.CAFile != ""
+}
+
+// HasCertificate whether the cert and key file are set, a client may only verify the server with ca file
+func (c Config) HasCertificate() bool {
+	return c.CertFile != "" && c.KeyFile != ""
+}
+
+// Validate the ca file must be set when tls is enabled, the cert and key file must be both set or both empty
+func (c Config) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if c.CAFile == "" {
+		return errors.New("ca file must be set when tls is enabled")
+	}
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return errors.New("cert file and key file must be both set or both empty")
+	}
+	return nil
+}
+
+// Reloader hold the certificate and ca pool, and reload them when the files are rotated
+type Reloader struct {
+	cfg      Config
+	lock     sync.RWMutex
+	cert     *tls.Certificate
+	caPool   *x509.CertPool
+	versions map[string]string
+}
+
+// NewReloader load the certificate and ca of config
+func NewReloader(cfg Config) (*Reloader, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if !cfg.Enabled() {
+		return nil, errors.New("tls is not configured")
+	}
+	r := &Reloader{cfg: cfg}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Reloader) currentVersions() map[string]string {
+	versions := map[string]string{r.cfg.CAFile: utils.MountedFileVersion(r.cfg.CAFile)}
+	if r.cfg.HasCertificate() {
+		versions[r.cfg.CertFile] = utils.MountedFileVersion(r.cfg.CertFile)
+		versions[r.cfg.KeyFile] = utils.MountedFileVersion(r.cfg.KeyFile)
+	}
+	return versions
+}
+
+func (r *Reloader) changed() bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	for file, version := range r.currentVersions() {
+		if r.versions[file] != version {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Reloader) loadCertificate() (*tls.Certificate, error) {
+	if !r.cfg.HasCertificate() {
+		// an empty certificate means no certificate is sent to the server
+		return &tls.Certificate{}, nil
+	}
+	certPem, err := utils.ReadMountedFile(r.cfg.CertFile, maxPemFileSize)
+	if err != nil {
+		return nil, fmt.Errorf("read cert file failed: %v", err)
+	}
+	keyPem, err := utils.ReadMountedFile(r.cfg.KeyFile, maxPemFileSize)
+	if err != nil {
+		return nil, fmt.Errorf("read key file failed: %v", err)
+	}
+	cert, err := tls.X509KeyPair(certPem, keyPem)
+	if err != nil {
+		return nil, fmt.Errorf("parse key pair failed: %v", err)
+	}
+	return &cert, nil
+}
+
+func (r *Reloader) reload() error {
+	versions := r.currentVersions()
+	cert, err := r.loadCertificate()
+	if err != nil {
+		return err
+	}
+	caPem, err := utils.ReadMountedFile(r.cfg.CAFile, maxPemFileSize)
+	if err != nil {
+		return fmt.Errorf("read ca file failed: %v", err)
+	}
+	caPool := x509.NewCertPool()
+	if !caPool.AppendCertsFromPEM(caPem) {
+		return errors.New("no valid certificate found in ca file")
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.cert = cert
+	r.caPool = caPool
+	r.versions = versions
+	return nil
+}
+
+// Watch check the files every interval until ctx done, reload them when rotated. The old certificate is kept
+// when the new files are invalid, for example only part of them have been written
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) {
+	if r == nil {
+		return
+	}
+	if interval <= 0 {
+		interval = DefaultReloadInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !r.changed() {
+				continue
+			}
+			if err := r.reload(); err != nil {
+				hwlog.RunLog.Errorf("reload tls files failed, keep using the old one, err: %v", err)
+				continue
+			}
+			hwlog.RunLog.Infof("tls files of ca %s reloaded", r.cfg.CAFile)
+		}
+	}
+}
+
+// Certificate get the current certificate
+func (r *Reloader) Certificate() *tls.Certificate {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.cert
+}
+
+// CAPool get the current ca pool
+func (r *Reloader) CAPool() *x509.CertPool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.caPool
+}
+
+// ServerConfig get tls config of server, every handshake use the current certificate and ca pool.
+// The cert and key file are required by server
+func (r *Reloader) ServerConfig(clientAuth tls.ClientAuthType) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*r.Certificate()},
+				ClientCAs:    r.CAPool(),
+				ClientAuth:   clientAuth,
+			}, nil
+		},
+	}
+}
+
+// ClientConfig get tls config of client, every handshake use the current certificate and ca pool. tls has no hook
+// to get the root cas on handshake, so the server certificate is verified by VerifyConnection instead
+func (r *Reloader) ClientConfig(serverName string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: serverName,
+		// the server certificate is still verified, by verifyServer with the current ca pool
+		InsecureSkipVerify: true,
+		VerifyConnection:   r.verifyServer,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return r.Certificate(), nil
+		},
+	}
+}
+
+// verifyServer verify the certificate chain and name of server with the current ca pool
+func (r *Reloader) verifyServer(state tls.ConnectionState) error {
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("no certificate is sent by server")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       state.ServerName,
+		Roots:         r.CAPool(),
+		Intermediates: intermediates,
+	})
+	return err
+}
+
+// TokenFileCredentials per rpc credentials of grpc, send the projected service account token as bearer token.
+// The token is read on every call since kubelet rotates it
+type TokenFileCredentials struct {
+	path string
+}
+
+// NewTokenFileCredentials create credentials reading token from path
+func NewTokenFileCredentials(path string) *TokenFileCredentials {
+	return &TokenFileCredentials{path: path}
+}
+
+// GetRequestMetadata implement credentials.PerRPCCredentials of grpc
+func (t *TokenFileCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	token, err := utils.ReadMountedFile(t.path, maxTokenFileSize)
+	if err != nil {
+		return nil, fmt.Errorf("read token file failed: %v", err)
+	}
+	trimmed := strings.TrimSpace(string(token))
+	if trimmed == "" {
+		return nil, errors.New("token file is empty")
+	}
+	return map[string]string{authorizationKey: bearerPrefix + trimmed}, nil
+}
+
+// RequireTransportSecurity implement credentials.PerRPCCredentials of grpc, token must not be sent in plaintext
+func (t *TokenFileCredentials) RequireTransportSecurity() bool {
+	return true
+}
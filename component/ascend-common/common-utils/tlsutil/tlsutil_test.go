@@ -0,0 +1,241 @@
+/* Copyright(C) 2025. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tlsutil
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/smartystreets/goconvey/convey"
+
+	"ascend-common/common-utils/hwlog"
+)
+
+const (
+	testServerName = "clusterd-grpc-svc"
+	fileMode       = 0600
+)
+
+func init() {
+	if err := hwlog.InitRunLogger(&hwlog.LogConfig{OnlyToStdout: true}, context.Background()); err != nil {
+		return
+	}
+}
+
+func writePem(t *testing.T, path, blockType string, der []byte) {
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), fileMode); err != nil {
+		t.Fatalf("write %s failed: %v", path, err)
+	}
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key failed: %v", err)
+	}
+	return key
+}
+
+// writeTestCerts write a ca and a certificate signed by it into dir, return the config of them
+func writeTestCerts(t *testing.T, dir, commonName string, serial int64) Config {
+	caKey := newKey(t)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDer, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("create ca failed: %v", err)
+	}
+	key := newKey(t)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial + 1),
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{testServerName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caTemplate, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("create cert failed: %v", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key failed: %v", err)
+	}
+	cfg := Config{
+		CertFile: filepath.Join(dir, "tls.crt"),
+		KeyFile:  filepath.Join(dir, "tls.key"),
+		CAFile:   filepath.Join(dir, "ca.crt"),
+	}
+	writePem(t, cfg.CAFile, "CERTIFICATE", caDer)
+	writePem(t, cfg.CertFile, "CERTIFICATE", der)
+	writePem(t, cfg.KeyFile, "EC PRIVATE KEY", keyDer)
+	return cfg
+}
+
+func TestConfigValidate(t *testing.T) {
+	convey.Convey("test method 'Validate'", t, func() {
+		convey.So(Config{}.Validate(), convey.ShouldBeNil)
+		convey.So(Config{}.Enabled(), convey.ShouldBeFalse)
+		convey.So(Config{CertFile: "a", KeyFile: "b"}.Validate(), convey.ShouldNotBeNil)
+		convey.So(Config{CertFile: "a", CAFile: "c"}.Validate(), convey.ShouldNotBeNil)
+		convey.So(Config{CAFile: "c"}.Validate(), convey.ShouldBeNil)
+		convey.So(Config{CAFile: "c"}.HasCertificate(), convey.ShouldBeFalse)
+		convey.So(Config{CertFile: "a", KeyFile: "b", CAFile: "c"}.Validate(), convey.ShouldBeNil)
+	})
+}
+
+func handshake(serverCfg, clientCfg *tls.Config) (*tls.ConnectionState, error) {
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", serverCfg)
+	if err != nil {
+		return nil, err
+	}
+	defer listener.Close()
+	type result struct {
+		state tls.ConnectionState
+		err   error
+	}
+	resultCh := make(chan result, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			resultCh <- result{err: err}
+			return
+		}
+		defer conn.Close()
+		tlsConn, ok := conn.(*tls.Conn)
+		if !ok {
+			resultCh <- result{err: net.ErrClosed}
+			return
+		}
+		err = tlsConn.Handshake()
+		resultCh <- result{state: tlsConn.ConnectionState(), err: err}
+	}()
+	clientConn, err := tls.Dial("tcp", listener.Addr().String(), clientCfg)
+	if err != nil {
+		return nil, err
+	}
+	defer clientConn.Close()
+	res := <-resultCh
+	if res.err != nil {
+		return nil, res.err
+	}
+	return &res.state, nil
+}
+
+func TestReloader(t *testing.T) {
+	convey.Convey("test mutual tls of reloader", t, func() {
+		dir := t.TempDir()
+		cfg := writeTestCerts(t, dir, "noded", 1)
+		r, err := NewReloader(cfg)
+		convey.So(err, convey.ShouldBeNil)
+		convey.Convey("client with certificate of the same ca should pass", func() {
+			state, err := handshake(r.ServerConfig(tls.RequireAndVerifyClientCert), r.ClientConfig(testServerName))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(state.PeerCertificates[0].Subject.CommonName, convey.ShouldEqual, "noded")
+		})
+		convey.Convey("client with certificate of another ca should be rejected", func() {
+			other, err := NewReloader(writeTestCerts(t, t.TempDir(), "other", 1))
+			convey.So(err, convey.ShouldBeNil)
+			clientCfg := other.ClientConfig(testServerName)
+			clientCfg.VerifyConnection = r.verifyServer
+			_, err = handshake(r.ServerConfig(tls.RequireAndVerifyClientCert), clientCfg)
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("client without certificate should only pass when server not require it", func() {
+			caOnly, err := NewReloader(Config{CAFile: cfg.CAFile})
+			convey.So(err, convey.ShouldBeNil)
+			state, err := handshake(r.ServerConfig(tls.VerifyClientCertIfGiven), caOnly.ClientConfig(testServerName))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(len(state.PeerCertificates), convey.ShouldEqual, 0)
+			_, err = handshake(r.ServerConfig(tls.RequireAndVerifyClientCert), caOnly.ClientConfig(testServerName))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("rotated files should be reloaded", func() {
+			convey.So(r.changed(), convey.ShouldBeFalse)
+			time.Sleep(10 * time.Millisecond)
+			writeTestCerts(t, dir, "rotated", 3)
+			convey.So(r.changed(), convey.ShouldBeTrue)
+			ctx, cancel := context.WithCancel(context.Background())
+			go func() {
+				time.Sleep(50 * time.Millisecond)
+				cancel()
+			}()
+			r.Watch(ctx, 10*time.Millisecond)
+			leaf, err := x509.ParseCertificate(r.Certificate().Certificate[0])
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(leaf.Subject.CommonName, convey.ShouldEqual, "rotated")
+		})
+		convey.Convey("client config should verify server with the reloaded ca", func() {
+			client, err := NewReloader(cfg)
+			convey.So(err, convey.ShouldBeNil)
+			clientCfg := client.ClientConfig(testServerName)
+			_, err = handshake(r.ServerConfig(tls.RequireAndVerifyClientCert), clientCfg)
+			convey.So(err, convey.ShouldBeNil)
+			writeTestCerts(t, dir, "rotated", 3)
+			convey.So(r.reload(), convey.ShouldBeNil)
+			convey.So(client.reload(), convey.ShouldBeNil)
+			_, err = handshake(r.ServerConfig(tls.RequireAndVerifyClientCert), clientCfg)
+			convey.So(err, convey.ShouldBeNil)
+		})
+		convey.Convey("server of another ca should be rejected by client", func() {
+			other, err := NewReloader(writeTestCerts(t, t.TempDir(), "other", 1))
+			convey.So(err, convey.ShouldBeNil)
+			_, err = handshake(other.ServerConfig(tls.NoClientCert), r.ClientConfig(testServerName))
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = handshake(r.ServerConfig(tls.NoClientCert), r.ClientConfig("unknown-server"))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("invalid files should keep the old certificate", func() {
+			convey.So(os.WriteFile(cfg.CertFile, []byte("invalid"), fileMode), convey.ShouldBeNil)
+			convey.So(r.reload(), convey.ShouldNotBeNil)
+			convey.So(r.Certificate(), convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestTokenFileCredentials(t *testing.T) {
+	convey.Convey("test TokenFileCredentials", t, func() {
+		path := filepath.Join(t.TempDir(), "token")
+		creds := NewTokenFileCredentials(path)
+		convey.So(creds.RequireTransportSecurity(), convey.ShouldBeTrue)
+		_, err := creds.GetRequestMetadata(context.Background())
+		convey.So(err, convey.ShouldNotBeNil)
+		convey.So(os.WriteFile(path, []byte("token-value\n"), fileMode), convey.ShouldBeNil)
+		md, err := creds.GetRequestMetadata(context.Background())
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(md[authorizationKey], convey.ShouldEqual, "Bearer token-value")
+	})
+}
@@ -89,6 +89,33 @@ func ReadLimitBytesWithSymlink(path string, limitLength int, validateRealPath fu
 	return buf[0:l], nil
 }
 
+// ReadMountedFile read limit length of contents from a file mounted from kubernetes secret or configmap,
+// which is a symlink resolved inside the directory of itself
+func ReadMountedFile(path string, limitLength int) ([]byte, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("get the absolute path failed: %v", err)
+	}
+	dir := filepath.Dir(absPath)
+	return ReadLimitBytesWithSymlink(absPath, limitLength, func(realPath string) bool {
+		return strings.HasPrefix(realPath, dir+string(filepath.Separator))
+	})
+}
+
+// MountedFileVersion identify content of a mounted file by its real path and modify time, the real path changes
+// when kubernetes updates the mounted secret or configmap. Return empty string if the file is not accessible
+func MountedFileVersion(path string) string {
+	realPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return ""
+	}
+	info, err := os.Stat(realPath)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%s@%d", realPath, info.ModTime().UnixNano())
+}
+
 // LoadFile load file content
 func LoadFile(filePath string) ([]byte, error) {
 	if filePath == "" {
@@ -167,3 +167,30 @@ func TestCopyFile(t *testing.T) {
 		fmt.Print("remove util_test file failed")
 	}
 }
+
+func TestReadMountedFile(t *testing.T) {
+	convey.Convey("test ReadMountedFile and MountedFileVersion func", t, func() {
+		const limitLength = 10
+		dir := t.TempDir()
+		dataDir := filepath.Join(dir, "..data_1")
+		convey.So(os.Mkdir(dataDir, FileMode|0100), convey.ShouldBeNil)
+		convey.So(os.WriteFile(filepath.Join(dataDir, "key"), []byte("value"), FileMode), convey.ShouldBeNil)
+		linkPath := filepath.Join(dir, "key")
+		convey.So(os.Symlink(filepath.Join("..data_1", "key"), linkPath), convey.ShouldBeNil)
+		convey.Convey("symlink inside the directory should be read", func() {
+			res, err := ReadMountedFile(linkPath, limitLength)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(string(res), convey.ShouldEqual, "value")
+			convey.So(MountedFileVersion(linkPath), convey.ShouldStartWith, filepath.Join(dataDir, "key"))
+		})
+		convey.Convey("symlink outside the directory should be rejected", func() {
+			outFile := filepath.Join(dir, "out")
+			convey.So(os.WriteFile(outFile, []byte("value"), FileMode), convey.ShouldBeNil)
+			outLink := filepath.Join(dataDir, "out")
+			convey.So(os.Symlink(outFile, outLink), convey.ShouldBeNil)
+			_, err := ReadMountedFile(outLink, limitLength)
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(MountedFileVersion(filepath.Join(dir, "not-exist")), convey.ShouldBeEmpty)
+		})
+	})
+}
@@ -105,8 +105,12 @@ func getDeviceInfoForInit(commonDevMgr DeviceInterface) (common.ChipInfo, common
 	return chipInfo, boardInfo, nil
 }
 
-// AutoInit auto detect npu chip type and return the corresponding processing object
+// AutoInit auto detect npu chip type and return the corresponding processing object, a simulated device manager is
+// returned when the scenario file is set by SimScenarioEnv
 func AutoInit(dType string, resetTimeout int) (DeviceInterface, error) {
+	if path := readSimScenarioEnv(); path != "" {
+		return newSimulatedDeviceManager(dType, path)
+	}
 	var devMgr DeviceInterface
 	devCommonSetMgr, err := DetectDcmiApiVersion(resetTimeout)
 	if err != nil {
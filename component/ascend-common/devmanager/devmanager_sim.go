@@ -0,0 +1,658 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package devmanager this for simulated device manager
+package devmanager
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"ascend-common/api"
+	"ascend-common/common-utils/hwlog"
+	"ascend-common/devmanager/common"
+	"ascend-common/devmanager/dcmi"
+)
+
+const (
+	// SimScenarioEnv env of the scenario file, AutoInit returns a simulated device manager when it is set
+	SimScenarioEnv = "ASCEND_DEVICE_SIMULATOR_SCENARIO"
+
+	simTickInterval  = 100 * time.Millisecond
+	simVDevIDBase    = 100
+	simBaseTemp      = 40
+	simTempPerUtil   = 5
+	simHealthOK      = 0
+	simHealthFault   = 2
+	simNetHealthDown = 1
+	simPcieBusBase   = 0x61
+)
+
+var _ DeviceInterface = &DeviceManagerSim{}
+
+type simChip struct {
+	health    uint32
+	netHealth uint32
+	// utilBase base of the utilization curve, overrides the base of scenario when utilSet is true
+	utilBase   uint32
+	utilSet    bool
+	faultCodes []int64
+	// resetUntil the chip is resetting before the time
+	resetUntil time.Time
+	vDevs      []common.CgoVDevQueryStru
+}
+
+// DeviceManagerSim simulated device manager driven by a scenario, the topology of chips is built from the scenario
+// and the events of the scenario are applied by the time since the simulator started. interfaces not related to the
+// scenario return the values of DeviceManagerMock
+type DeviceManagerSim struct {
+	DeviceManagerMock
+	scenario *SimScenario
+	mu       sync.Mutex
+	chips    []*simChip
+	// now returns current time, replaced in tests
+	now       func() time.Time
+	start     time.Time
+	nextEvent int
+	nextVDev  uint32
+	faultFunc func(common.DevFaultInfo)
+	stopCh    chan struct{}
+	stopOnce  sync.Once
+}
+
+// NewDeviceManagerSim create a simulated device manager of the scenario, the timeline starts when Init is called
+func NewDeviceManagerSim(scenario *SimScenario) *DeviceManagerSim {
+	chips := make([]*simChip, scenario.chipNum())
+	for i := range chips {
+		chips[i] = &simChip{}
+	}
+	return &DeviceManagerSim{
+		DeviceManagerMock: DeviceManagerMock{DevType: scenario.devType},
+		scenario:          scenario,
+		chips:             chips,
+		now:               time.Now,
+		nextVDev:          simVDevIDBase,
+		stopCh:            make(chan struct{}),
+	}
+}
+
+func newSimulatedDeviceManager(dType, path string) (DeviceInterface, error) {
+	scenario, err := LoadSimScenario(path)
+	if err != nil {
+		return nil, err
+	}
+	if dType != "" && scenario.devType != dType {
+		return nil, fmt.Errorf("the value of dType(%s) is inconsistent with the simulated chip type(%s)",
+			dType, scenario.devType)
+	}
+	sim := NewDeviceManagerSim(scenario)
+	if err = sim.Init(); err != nil {
+		return nil, err
+	}
+	hwlog.RunLog.Warnf("device simulator is enabled by scenario %s, devType: %s, chip number: %d, events: %d",
+		path, scenario.devType, scenario.chipNum(), len(scenario.Events))
+	return sim, nil
+}
+
+// Init start the timeline of the scenario
+func (d *DeviceManagerSim) Init() error {
+	d.mu.Lock()
+	if !d.start.IsZero() {
+		d.mu.Unlock()
+		return nil
+	}
+	d.start = d.now()
+	d.mu.Unlock()
+	go d.run()
+	return nil
+}
+
+// ShutDown stop the timeline of the scenario
+func (d *DeviceManagerSim) ShutDown() error {
+	d.stopOnce.Do(func() {
+		close(d.stopCh)
+	})
+	return nil
+}
+
+func (d *DeviceManagerSim) run() {
+	ticker := time.NewTicker(simTickInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.stopCh:
+			return
+		case <-ticker.C:
+			d.applyEvents()
+		}
+	}
+}
+
+// applyEvents applies the events whose time has come, fault event callback is called without lock
+func (d *DeviceManagerSim) applyEvents() {
+	d.mu.Lock()
+	if d.start.IsZero() {
+		d.mu.Unlock()
+		return
+	}
+	elapsed := d.now().Sub(d.start)
+	var faults []common.DevFaultInfo
+	for ; d.nextEvent < len(d.scenario.Events) && d.scenario.Events[d.nextEvent].At <= elapsed; d.nextEvent++ {
+		event := d.scenario.Events[d.nextEvent]
+		if fault, ok := d.applyEvent(event); ok {
+			faults = append(faults, fault)
+		}
+	}
+	faultFunc := d.faultFunc
+	d.mu.Unlock()
+	if faultFunc == nil {
+		return
+	}
+	for _, fault := range faults {
+		faultFunc(fault)
+	}
+}
+
+func (d *DeviceManagerSim) applyEvent(event SimEvent) (common.DevFaultInfo, bool) {
+	hwlog.RunLog.Infof("simulator applies event %s on chip %d at %v", event.Type, event.PhyID, event.At)
+	chip := d.chips[event.PhyID]
+	switch event.Type {
+	case SimEventFault:
+		chip.faultCodes = appendFaultCode(chip.faultCodes, event.faultCode)
+		chip.health = simHealthFault
+		if event.Health != 0 {
+			chip.health = event.Health
+		}
+		return d.faultInfo(event, common.FaultOccur), true
+	case SimEventFaultRecover:
+		chip.faultCodes = removeFaultCode(chip.faultCodes, event.faultCode)
+		if len(chip.faultCodes) == 0 {
+			chip.health = simHealthOK
+		}
+		return d.faultInfo(event, common.FaultRecover), true
+	case SimEventHealth:
+		chip.health = event.Health
+	case SimEventUtilization:
+		chip.utilBase, chip.utilSet = event.Value, true
+	case SimEventLinkDown:
+		chip.netHealth = simNetHealthDown
+	case SimEventLinkUp:
+		chip.netHealth = simHealthOK
+	case SimEventReset:
+		duration := event.Duration
+		if duration <= 0 {
+			duration = defaultSimReset
+		}
+		d.resetChip(chip, duration)
+	case SimEventVNPUCreate:
+		if _, err := d.createVDev(chip, event.Template); err != nil {
+			hwlog.RunLog.Warnf("simulator create vnpu on chip %d failed: %v", event.PhyID, err)
+		}
+	case SimEventVNPUDestroy:
+		if err := destroyVDev(chip, event.VDevID); err != nil {
+			hwlog.RunLog.Warnf("simulator destroy vnpu on chip %d failed: %v", event.PhyID, err)
+		}
+	default:
+	}
+	return common.DevFaultInfo{}, false
+}
+
+func (d *DeviceManagerSim) faultInfo(event SimEvent, assertion int8) common.DevFaultInfo {
+	return common.DevFaultInfo{
+		EventID:         event.faultCode,
+		LogicID:         event.PhyID,
+		Severity:        event.Severity,
+		Assertion:       assertion,
+		AlarmRaisedTime: d.now().UnixMilli(),
+	}
+}
+
+func (d *DeviceManagerSim) resetChip(chip *simChip, duration time.Duration) {
+	chip.resetUntil = d.now().Add(duration)
+	chip.faultCodes = nil
+	chip.health = simHealthOK
+	chip.netHealth = simHealthOK
+}
+
+func appendFaultCode(codes []int64, code int64) []int64 {
+	for _, c := range codes {
+		if c == code {
+			return codes
+		}
+	}
+	return append(codes, code)
+}
+
+func removeFaultCode(codes []int64, code int64) []int64 {
+	result := make([]int64, 0, len(codes))
+	for _, c := range codes {
+		if c != code {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// getChip returns the chip of logic id, logic id is the same as physic id in simulator. the chip is not available
+// when it is resetting
+func (d *DeviceManagerSim) getChip(logicID int32) (*simChip, error) {
+	if logicID < 0 || logicID >= int32(len(d.chips)) {
+		return nil, fmt.Errorf("invalid logic id %d", logicID)
+	}
+	chip := d.chips[logicID]
+	if d.now().Before(chip.resetUntil) {
+		return nil, fmt.Errorf("chip %d is resetting", logicID)
+	}
+	return chip, nil
+}
+
+// withChip applies due events and calls f with the chip under lock
+func (d *DeviceManagerSim) withChip(logicID int32, f func(chip *simChip) error) error {
+	d.applyEvents()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	chip, err := d.getChip(logicID)
+	if err != nil {
+		return err
+	}
+	return f(chip)
+}
+
+func (d *DeviceManagerSim) utilization(logicID int32, chip *simChip) uint32 {
+	curve := d.scenario.Utilization
+	base := curve.Base
+	if chip.utilSet {
+		base = chip.utilBase
+	}
+	value := float64(base)
+	if curve.Amplitude > 0 && curve.Period > 0 {
+		phase := 2 * math.Pi * (float64(d.now().Sub(d.start)) / float64(curve.Period))
+		value += float64(curve.Amplitude) * math.Sin(phase+float64(logicID))
+	}
+	return uint32(math.Round(math.Max(0, math.Min(maxSimUtilization, value))))
+}
+
+// GetDevType return the device type of scenario
+func (d *DeviceManagerSim) GetDevType() string {
+	return d.scenario.devType
+}
+
+// GetDcmiVersion return the driver version of scenario
+func (d *DeviceManagerSim) GetDcmiVersion() string {
+	return d.scenario.DriverVersion
+}
+
+// GetAllDeviceCount get npu device count
+func (d *DeviceManagerSim) GetAllDeviceCount() (int32, error) {
+	return d.scenario.chipNum(), nil
+}
+
+// GetCardList get all card list
+func (d *DeviceManagerSim) GetCardList() (int32, []int32, error) {
+	cards := make([]int32, 0, d.scenario.CardNum)
+	for i := int32(0); i < d.scenario.CardNum; i++ {
+		cards = append(cards, i)
+	}
+	return d.scenario.CardNum, cards, nil
+}
+
+// GetDeviceNumInCard get all device list in one card
+func (d *DeviceManagerSim) GetDeviceNumInCard(cardID int32) (int32, error) {
+	if cardID < 0 || cardID >= d.scenario.CardNum {
+		return 0, fmt.Errorf("invalid card id %d", cardID)
+	}
+	return d.scenario.ChipsPerCard, nil
+}
+
+// GetDeviceList get all device logicID list
+func (d *DeviceManagerSim) GetDeviceList() (int32, []int32, error) {
+	logicIDs := make([]int32, 0, len(d.chips))
+	for i := range d.chips {
+		logicIDs = append(logicIDs, int32(i))
+	}
+	return int32(len(logicIDs)), logicIDs, nil
+}
+
+// GetChipBaseInfos get all chip base info
+func (d *DeviceManagerSim) GetChipBaseInfos() ([]*common.ChipBaseInfo, error) {
+	infos := make([]*common.ChipBaseInfo, 0, len(d.chips))
+	for i := range d.chips {
+		id := int32(i)
+		infos = append(infos, &common.ChipBaseInfo{PhysicID: id, LogicID: id,
+			CardID: id / d.scenario.ChipsPerCard, DeviceID: id % d.scenario.ChipsPerCard})
+	}
+	return infos, nil
+}
+
+// GetDeviceHealth query npu device health status
+func (d *DeviceManagerSim) GetDeviceHealth(logicID int32) (uint32, error) {
+	var health uint32
+	err := d.withChip(logicID, func(chip *simChip) error {
+		health = chip.health
+		return nil
+	})
+	return health, err
+}
+
+// GetDeviceNetWorkHealth query npu device network health status
+func (d *DeviceManagerSim) GetDeviceNetWorkHealth(logicID int32) (uint32, error) {
+	var health uint32
+	err := d.withChip(logicID, func(chip *simChip) error {
+		health = chip.netHealth
+		return nil
+	})
+	return health, err
+}
+
+// GetDeviceUtilizationRate get npu device utilization by the utilization curve
+func (d *DeviceManagerSim) GetDeviceUtilizationRate(logicID int32, deviceType common.DeviceType) (uint32, error) {
+	var util uint32
+	err := d.withChip(logicID, func(chip *simChip) error {
+		util = d.utilization(logicID, chip)
+		return nil
+	})
+	return util, err
+}
+
+// GetDeviceUtilizationRateV2 get npu device utilization by the utilization curve
+func (d *DeviceManagerSim) GetDeviceUtilizationRateV2(logicID int32) (common.DcmiMultiUtilizationInfo, error) {
+	util, err := d.GetDeviceUtilizationRate(logicID, common.AICore)
+	return common.DcmiMultiUtilizationInfo{AicUtil: util, AivUtil: util, AicoreUtil: util, NpuUtil: util}, err
+}
+
+// GetDeviceUtilizationRateV2Period get npu device utilization by the utilization curve
+func (d *DeviceManagerSim) GetDeviceUtilizationRateV2Period(logicID int32) (common.DcmiMultiUtilizationInfo, error) {
+	return d.GetDeviceUtilizationRateV2(logicID)
+}
+
+// GetDeviceUtilizationRateCommon get npu device utilization by the utilization curve
+func (d *DeviceManagerSim) GetDeviceUtilizationRateCommon(logicID int32) (common.DcmiMultiUtilizationInfo, error) {
+	return d.GetDeviceUtilizationRateV2(logicID)
+}
+
+// GetDeviceTemperature get npu device temperature, it rises with the utilization
+func (d *DeviceManagerSim) GetDeviceTemperature(logicID int32) (int32, error) {
+	util, err := d.GetDeviceUtilizationRate(logicID, common.AICore)
+	return simBaseTemp + int32(util)/simTempPerUtil, err
+}
+
+// GetDeviceMemoryInfo get npu memory information, the usage follows the utilization
+func (d *DeviceManagerSim) GetDeviceMemoryInfo(logicID int32) (*common.MemoryInfo, error) {
+	util, err := d.GetDeviceUtilizationRate(logicID, common.AICore)
+	if err != nil {
+		return nil, err
+	}
+	size := d.scenario.HbmSize
+	return &common.MemoryInfo{MemorySize: size, MemoryAvailable: size - size*uint64(util)/maxSimUtilization,
+		Utilization: util}, nil
+}
+
+// GetDeviceHbmInfo get npu HBM module memory information, the usage follows the utilization
+func (d *DeviceManagerSim) GetDeviceHbmInfo(logicID int32) (*common.HbmInfo, error) {
+	util, err := d.GetDeviceUtilizationRate(logicID, common.AICore)
+	if err != nil {
+		return nil, err
+	}
+	return &common.HbmInfo{MemorySize: d.scenario.HbmSize, Usage: d.scenario.HbmSize * uint64(util) /
+		maxSimUtilization, Temp: simBaseTemp + int32(util)/simTempPerUtil, BandWidthUtilRate: util}, nil
+}
+
+// GetDeviceErrorCode get the first fault code of the chip
+func (d *DeviceManagerSim) GetDeviceErrorCode(logicID int32) (int32, int64, error) {
+	errCount, codes, err := d.GetDeviceAllErrorCode(logicID)
+	if err != nil || errCount == 0 {
+		return 0, 0, err
+	}
+	return errCount, codes[0], nil
+}
+
+// GetDeviceAllErrorCode get all fault codes of the chip
+func (d *DeviceManagerSim) GetDeviceAllErrorCode(logicID int32) (int32, []int64, error) {
+	var codes []int64
+	err := d.withChip(logicID, func(chip *simChip) error {
+		codes = append(codes, chip.faultCodes...)
+		return nil
+	})
+	return int32(len(codes)), codes, err
+}
+
+// GetDeviceAllErrorCodeWithTimeOut get all fault codes of the chip
+func (d *DeviceManagerSim) GetDeviceAllErrorCodeWithTimeOut(logicID int32, _ time.Duration) (int32, []int64,
+	error) {
+	return d.GetDeviceAllErrorCode(logicID)
+}
+
+// SubscribeDeviceFaultEvent fault events of all chips are reported by the simulator
+func (d *DeviceManagerSim) SubscribeDeviceFaultEvent(logicID int32) error {
+	return nil
+}
+
+// SetFaultEventCallFunc set the callback of fault and faultRecover events
+func (d *DeviceManagerSim) SetFaultEventCallFunc(businessFunc func(common.DevFaultInfo)) error {
+	if businessFunc == nil {
+		return fmt.Errorf("business func is nil")
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.faultFunc = businessFunc
+	return nil
+}
+
+// GetChipInfo get chip info of scenario
+func (d *DeviceManagerSim) GetChipInfo(logicID int32) (*common.ChipInfo, error) {
+	if logicID < 0 || logicID >= int32(len(d.chips)) {
+		return nil, fmt.Errorf("invalid logic id %d", logicID)
+	}
+	return &common.ChipInfo{Type: d.scenario.ChipType, Name: d.scenario.ChipName, Version: d.scenario.ChipVersion,
+		AICoreCnt: d.scenario.AICoreCnt}, nil
+}
+
+// GetValidChipInfo get chip info of scenario
+func (d *DeviceManagerSim) GetValidChipInfo() (common.ChipInfo, error) {
+	chip, err := d.GetChipInfo(0)
+	if err != nil {
+		return common.ChipInfo{}, err
+	}
+	return *chip, nil
+}
+
+// GetBoardInfo get board info of scenario
+func (d *DeviceManagerSim) GetBoardInfo(logicID int32) (common.BoardInfo, error) {
+	return common.BoardInfo{BoardId: d.scenario.BoardID}, nil
+}
+
+// GetValidBoardInfo get board info of scenario
+func (d *DeviceManagerSim) GetValidBoardInfo() (common.BoardInfo, error) {
+	return d.GetBoardInfo(0)
+}
+
+// GetMainBoardId get main board id of scenario
+func (d *DeviceManagerSim) GetMainBoardId() uint32 {
+	return d.scenario.MainBoardID
+}
+
+// GetValidMainBoardInfo get main board id of scenario
+func (d *DeviceManagerSim) GetValidMainBoardInfo() (uint32, error) {
+	return d.scenario.MainBoardID, nil
+}
+
+// IsTrainingCard chips of 910 series are training cards
+func (d *DeviceManagerSim) IsTrainingCard() bool {
+	switch d.scenario.devType {
+	case api.Ascend910A, api.Ascend910B, api.Ascend910A3, api.Ascend910A5:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetPhysicIDFromLogicID logic id is the same as physic id in simulator
+func (d *DeviceManagerSim) GetPhysicIDFromLogicID(logicID int32) (int32, error) {
+	if logicID < 0 || logicID >= int32(len(d.chips)) {
+		return 0, fmt.Errorf("invalid logic id %d", logicID)
+	}
+	return logicID, nil
+}
+
+// GetLogicIDFromPhysicID logic id is the same as physic id in simulator
+func (d *DeviceManagerSim) GetLogicIDFromPhysicID(physicID int32) (int32, error) {
+	return d.GetPhysicIDFromLogicID(physicID)
+}
+
+// GetDeviceLogicID get logic id of the chip on the card
+func (d *DeviceManagerSim) GetDeviceLogicID(cardID, deviceID int32) (int32, error) {
+	if cardID < 0 || cardID >= d.scenario.CardNum || deviceID < 0 || deviceID >= d.scenario.ChipsPerCard {
+		return 0, fmt.Errorf("invalid card id %d or device id %d", cardID, deviceID)
+	}
+	return cardID*d.scenario.ChipsPerCard + deviceID, nil
+}
+
+// GetCardIDDeviceID get card id and device id of the chip
+func (d *DeviceManagerSim) GetCardIDDeviceID(logicID int32) (int32, int32, error) {
+	if logicID < 0 || logicID >= int32(len(d.chips)) {
+		return 0, 0, fmt.Errorf("invalid logic id %d", logicID)
+	}
+	return logicID / d.scenario.ChipsPerCard, logicID % d.scenario.ChipsPerCard, nil
+}
+
+// GetBrotherCardID get the logic id of the other chip on the same card
+func (d *DeviceManagerSim) GetBrotherCardID(logicID int32) (int32, error) {
+	cardID, deviceID, err := d.GetCardIDDeviceID(logicID)
+	if err != nil {
+		return 0, err
+	}
+	if d.scenario.ChipsPerCard < 2 {
+		return 0, fmt.Errorf("chip %d has no brother chip", logicID)
+	}
+	return cardID*d.scenario.ChipsPerCard + (deviceID+1)%d.scenario.ChipsPerCard, nil
+}
+
+// GetDeviceIPAddress get ip of the chip
+func (d *DeviceManagerSim) GetDeviceIPAddress(logicID, ipType int32) (string, error) {
+	if logicID < 0 || logicID >= int32(len(d.chips)) {
+		return "", fmt.Errorf("invalid logic id %d", logicID)
+	}
+	return d.scenario.chipIP(logicID), nil
+}
+
+// GetPCIeBusInfo get pcie bus info of the chip
+func (d *DeviceManagerSim) GetPCIeBusInfo(logicID int32) (string, error) {
+	return fmt.Sprintf("0000:%02x:00.0", simPcieBusBase+logicID), nil
+}
+
+// GetDieID get a die id unique in the node
+func (d *DeviceManagerSim) GetDieID(logicID int32, dcmiDieType dcmi.DieType) (string, error) {
+	return fmt.Sprintf("%08X%08X%024X", d.scenario.ServerID, int32(dcmiDieType), logicID), nil
+}
+
+// GetSuperPodInfo get super pod info of scenario, the sdid is unique in the super pod
+func (d *DeviceManagerSim) GetSuperPodInfo(logicID int32) (common.CgoSuperPodInfo, error) {
+	if logicID < 0 || logicID >= int32(len(d.chips)) {
+		return common.CgoSuperPodInfo{}, fmt.Errorf("invalid logic id %d", logicID)
+	}
+	return common.CgoSuperPodInfo{SdId: d.scenario.ServerID*uint32(d.scenario.chipNum()) + uint32(logicID),
+		SuperPodId: d.scenario.SuperPodID, ServerId: d.scenario.ServerID, RackId: d.scenario.RackID}, nil
+}
+
+// SetDeviceReset reset the chip, it is not available in the default reset duration
+func (d *DeviceManagerSim) SetDeviceReset(logicID int32) error {
+	return d.withChip(logicID, func(chip *simChip) error {
+		d.resetChip(chip, defaultSimReset)
+		return nil
+	})
+}
+
+// GetDeviceBootStatus the boot of the chip is finished when it is not resetting
+func (d *DeviceManagerSim) GetDeviceBootStatus(logicID int32) (int, error) {
+	err := d.withChip(logicID, func(chip *simChip) error {
+		return nil
+	})
+	if err != nil {
+		return 0, nil
+	}
+	return common.BootStartFinish, nil
+}
+
+// CreateVirtualDevice create a vnpu on the chip
+func (d *DeviceManagerSim) CreateVirtualDevice(logicID int32, vDevInfo common.CgoCreateVDevRes) (
+	common.CgoCreateVDevOut, error) {
+	var out common.CgoCreateVDevOut
+	err := d.withChip(logicID, func(chip *simChip) error {
+		vDevID, err := d.createVDev(chip, vDevInfo.TemplateName)
+		out.VDevID = vDevID
+		return err
+	})
+	return out, err
+}
+
+func (d *DeviceManagerSim) createVDev(chip *simChip, template string) (uint32, error) {
+	aiCore, err := simTemplateAICore(template)
+	if err != nil {
+		return 0, err
+	}
+	if usedAICore(chip)+aiCore > float32(d.scenario.AICoreCnt) {
+		return 0, fmt.Errorf("ai core of the chip is not enough for template %s", template)
+	}
+	vDevID := d.nextVDev
+	d.nextVDev++
+	chip.vDevs = append(chip.vDevs, common.CgoVDevQueryStru{VDevID: vDevID, QueryInfo: common.CgoVDevQueryInfo{
+		Name: template, Computing: common.CgoComputingResource{Aic: aiCore,
+			MemorySize: d.scenario.HbmSize * uint64(aiCore) / uint64(d.scenario.AICoreCnt)}}})
+	return vDevID, nil
+}
+
+func usedAICore(chip *simChip) float32 {
+	var used float32
+	for _, vDev := range chip.vDevs {
+		used += vDev.QueryInfo.Computing.Aic
+	}
+	return used
+}
+
+// GetVirtualDeviceInfo get vnpu of the chip
+func (d *DeviceManagerSim) GetVirtualDeviceInfo(logicID int32) (common.VirtualDevInfo, error) {
+	var info common.VirtualDevInfo
+	err := d.withChip(logicID, func(chip *simChip) error {
+		total := float32(d.scenario.AICoreCnt)
+		info.TotalResource = common.CgoSocTotalResource{VDevNum: uint32(len(chip.vDevs)),
+			Computing: common.CgoComputingResource{Aic: total, MemorySize: d.scenario.HbmSize}}
+		info.FreeResource = common.CgoSocFreeResource{Computing: common.CgoComputingResource{
+			Aic: total - usedAICore(chip)}}
+		for _, vDev := range chip.vDevs {
+			info.TotalResource.VDevID = append(info.TotalResource.VDevID, vDev.VDevID)
+		}
+		info.VDevInfo = append(info.VDevInfo, chip.vDevs...)
+		return nil
+	})
+	return info, err
+}
+
+// DestroyVirtualDevice destroy the vnpu of the chip
+func (d *DeviceManagerSim) DestroyVirtualDevice(logicID int32, vDevID uint32) error {
+	return d.withChip(logicID, func(chip *simChip) error {
+		return destroyVDev(chip, vDevID)
+	})
+}
+
+func destroyVDev(chip *simChip, vDevID uint32) error {
+	for i, vDev := range chip.vDevs {
+		if vDev.VDevID == vDevID {
+			chip.vDevs = append(chip.vDevs[:i], chip.vDevs[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("vnpu %d is not found", vDevID)
+}
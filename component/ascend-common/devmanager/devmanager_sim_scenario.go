@@ -0,0 +1,260 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package devmanager this for scenario of simulated device manager
+package devmanager
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"ascend-common/common-utils/utils"
+	"ascend-common/devmanager/common"
+)
+
+const (
+	// SimEventFault a fault code occurs on the chip, the registered fault event callback is called
+	SimEventFault = "fault"
+	// SimEventFaultRecover a fault code of the chip recovers, the registered fault event callback is called
+	SimEventFaultRecover = "faultRecover"
+	// SimEventHealth the health status of the chip changes
+	SimEventHealth = "health"
+	// SimEventUtilization the base of the utilization curve of the chip changes
+	SimEventUtilization = "utilization"
+	// SimEventLinkDown the network of the chip is down
+	SimEventLinkDown = "linkDown"
+	// SimEventLinkUp the network of the chip is up
+	SimEventLinkUp = "linkUp"
+	// SimEventReset the chip is hot reset, all fault codes are cleared after reset
+	SimEventReset = "reset"
+	// SimEventVNPUCreate a vnpu is created on the chip
+	SimEventVNPUCreate = "vnpuCreate"
+	// SimEventVNPUDestroy a vnpu is destroyed on the chip
+	SimEventVNPUDestroy = "vnpuDestroy"
+
+	maxSimScenarioSize = 1024 * 1024
+	maxSimChipNum      = 64
+	maxSimUtilization  = 100
+	defaultSimHbmSize  = 65536
+	defaultSimAICore   = 24
+	defaultSimIPBase   = "192.168.100.10"
+	defaultSimVersion  = "simulator"
+	defaultSimReset    = 5 * time.Second
+)
+
+// SimScenario topology of the simulated node and the timeline of events injected into it
+type SimScenario struct {
+	// ChipName name of chip reported by GetChipInfo, device type is detected by it and BoardID like AutoInit
+	ChipName    string `yaml:"chipName"`
+	ChipType    string `yaml:"chipType"`
+	ChipVersion string `yaml:"chipVersion"`
+	BoardID     uint32 `yaml:"boardId"`
+	MainBoardID uint32 `yaml:"mainBoardId"`
+	// CardNum number of cards, each card has ChipsPerCard chips
+	CardNum      int32 `yaml:"cardNum"`
+	ChipsPerCard int32 `yaml:"chipsPerCard"`
+	// AICoreCnt number of ai core of each chip, it is also the computing resource of vnpu
+	AICoreCnt  int    `yaml:"aiCoreCnt"`
+	SuperPodID uint32 `yaml:"superPodId"`
+	ServerID   uint32 `yaml:"serverId"`
+	RackID     uint32 `yaml:"rackId"`
+	// HbmSize memory size of each chip, unit is MB
+	HbmSize uint64 `yaml:"hbmSize"`
+	// IPBase ip of chip 0, ip of the other chips are increased by physic id
+	IPBase        string   `yaml:"ipBase"`
+	DriverVersion string   `yaml:"driverVersion"`
+	Utilization   SimCurve `yaml:"utilization"`
+	// Events sorted by At when the scenario is loaded
+	Events []SimEvent `yaml:"events"`
+
+	devType string
+	ipBase  net.IP
+}
+
+// SimCurve utilization of chips is Base + Amplitude * sin(2π * t / Period), the phase of each chip is shifted by its
+// physic id
+type SimCurve struct {
+	Base      uint32        `yaml:"base"`
+	Amplitude uint32        `yaml:"amplitude"`
+	Period    time.Duration `yaml:"period"`
+}
+
+// SimEvent an event injected into a chip at the time since the simulator started
+type SimEvent struct {
+	At    time.Duration `yaml:"at"`
+	PhyID int32         `yaml:"phyId"`
+	Type  string        `yaml:"type"`
+	// FaultCode hex fault code of fault and faultRecover events, such as 80E01801
+	FaultCode string `yaml:"faultCode"`
+	// Health health status of health event, or the health status after the fault event when it is not 0
+	Health   uint32 `yaml:"health"`
+	Severity int8   `yaml:"severity"`
+	// Value base utilization of utilization event
+	Value uint32 `yaml:"value"`
+	// Duration duration of reset event
+	Duration time.Duration `yaml:"duration"`
+	// Template vnpu template name of vnpuCreate event, such as vir02
+	Template string `yaml:"template"`
+	// VDevID vnpu id of vnpuDestroy event
+	VDevID uint32 `yaml:"vdevId"`
+
+	faultCode int64
+}
+
+// LoadSimScenario load and check the scenario of simulated device manager from yaml file
+func LoadSimScenario(path string) (*SimScenario, error) {
+	data, err := utils.ReadLimitBytes(path, maxSimScenarioSize)
+	if err != nil {
+		return nil, fmt.Errorf("read simulator scenario %s failed: %v", path, err)
+	}
+	return ParseSimScenario(data)
+}
+
+// ParseSimScenario parse and check the scenario of simulated device manager
+func ParseSimScenario(data []byte) (*SimScenario, error) {
+	scenario := &SimScenario{}
+	if err := yaml.Unmarshal(data, scenario); err != nil {
+		return nil, fmt.Errorf("unmarshal simulator scenario failed: %v", err)
+	}
+	scenario.setDefaults()
+	if err := scenario.check(); err != nil {
+		return nil, fmt.Errorf("simulator scenario is invalid: %v", err)
+	}
+	sort.SliceStable(scenario.Events, func(i, j int) bool {
+		return scenario.Events[i].At < scenario.Events[j].At
+	})
+	return scenario, nil
+}
+
+func (s *SimScenario) setDefaults() {
+	if s.ChipName == "" {
+		s.ChipName = "910B3"
+	}
+	if s.ChipType == "" {
+		s.ChipType = "Ascend"
+	}
+	if s.ChipVersion == "" {
+		s.ChipVersion = "V1"
+	}
+	if s.ChipsPerCard == 0 {
+		s.ChipsPerCard = 1
+	}
+	if s.AICoreCnt == 0 {
+		s.AICoreCnt = defaultSimAICore
+	}
+	if s.HbmSize == 0 {
+		s.HbmSize = defaultSimHbmSize
+	}
+	if s.IPBase == "" {
+		s.IPBase = defaultSimIPBase
+	}
+	if s.DriverVersion == "" {
+		s.DriverVersion = defaultSimVersion
+	}
+}
+
+func (s *SimScenario) check() error {
+	s.devType = common.GetDevType(s.ChipName, s.BoardID)
+	if s.devType == "" {
+		return fmt.Errorf("unsupported chip name %s and board id %d", s.ChipName, s.BoardID)
+	}
+	if s.CardNum <= 0 || s.ChipsPerCard <= 0 || s.CardNum*s.ChipsPerCard > maxSimChipNum {
+		return fmt.Errorf("the number of chips should be in [1, %d]", maxSimChipNum)
+	}
+	if s.ipBase = net.ParseIP(s.IPBase).To4(); s.ipBase == nil {
+		return fmt.Errorf("ip base %s is not an ipv4 address", s.IPBase)
+	}
+	if s.Utilization.Base > maxSimUtilization || s.Utilization.Amplitude > maxSimUtilization {
+		return fmt.Errorf("utilization should not be more than %d", maxSimUtilization)
+	}
+	if s.Utilization.Amplitude > 0 && s.Utilization.Period <= 0 {
+		return errors.New("period of utilization should be positive")
+	}
+	for i := range s.Events {
+		if err := s.checkEvent(&s.Events[i]); err != nil {
+			return fmt.Errorf("event %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+func (s *SimScenario) checkEvent(event *SimEvent) error {
+	if event.At < 0 {
+		return errors.New("at should not be negative")
+	}
+	if event.PhyID < 0 || event.PhyID >= s.chipNum() {
+		return fmt.Errorf("physic id %d is out of range", event.PhyID)
+	}
+	switch event.Type {
+	case SimEventFault, SimEventFaultRecover:
+		code, err := strconv.ParseInt(strings.TrimPrefix(strings.ToLower(event.FaultCode), "0x"), 16, 64)
+		if err != nil {
+			return fmt.Errorf("fault code %s is not a hex number", event.FaultCode)
+		}
+		event.faultCode = code
+	case SimEventUtilization:
+		if event.Value > maxSimUtilization {
+			return fmt.Errorf("utilization should not be more than %d", maxSimUtilization)
+		}
+	case SimEventVNPUCreate:
+		if _, err := simTemplateAICore(event.Template); err != nil {
+			return err
+		}
+	case SimEventHealth, SimEventLinkDown, SimEventLinkUp, SimEventReset, SimEventVNPUDestroy:
+	default:
+		return fmt.Errorf("unknown event type %s", event.Type)
+	}
+	return nil
+}
+
+func (s *SimScenario) chipNum() int32 {
+	return s.CardNum * s.ChipsPerCard
+}
+
+// chipIP ip of chip is the ip base increased by its physic id
+func (s *SimScenario) chipIP(phyID int32) string {
+	ip := make(net.IP, len(s.ipBase))
+	copy(ip, s.ipBase)
+	for i, carry := len(ip)-1, uint32(phyID); i >= 0 && carry > 0; i-- {
+		sum := uint32(ip[i]) + carry
+		ip[i] = byte(sum)
+		carry = sum >> 8
+	}
+	return ip.String()
+}
+
+// simTemplateAICore ai core of vnpu template, such as 2 of vir02 and vir02_1c
+func simTemplateAICore(template string) (float32, error) {
+	digits := strings.TrimPrefix(template, "vir")
+	if idx := strings.Index(digits, "_"); idx >= 0 {
+		digits = digits[:idx]
+	}
+	aiCore, err := strconv.Atoi(digits)
+	if digits == template || err != nil || aiCore <= 0 {
+		return 0, fmt.Errorf("vnpu template %s is invalid", template)
+	}
+	return float32(aiCore), nil
+}
+
+func readSimScenarioEnv() string {
+	return os.Getenv(SimScenarioEnv)
+}
@@ -0,0 +1,181 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package devmanager for simulated device manager
+package devmanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/smartystreets/goconvey/convey"
+
+	"ascend-common/api"
+	"ascend-common/devmanager/common"
+)
+
+const (
+	testSimScenario = "testdata/sim_16npu.yaml"
+	testSimChipNum  = 16
+	testSimFaultID  = 3
+	testSimLinkID   = 5
+	testSimResetID  = 9
+	testSimVNPUID   = 12
+	testSimFault    = 0x80E01801
+)
+
+type simClock struct {
+	now time.Time
+}
+
+func (c *simClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestSim() (*DeviceManagerSim, *simClock) {
+	scenario, err := LoadSimScenario(testSimScenario)
+	convey.So(err, convey.ShouldBeNil)
+	clock := &simClock{now: time.Unix(0, 0)}
+	sim := NewDeviceManagerSim(scenario)
+	sim.now = func() time.Time { return clock.now }
+	convey.So(sim.Init(), convey.ShouldBeNil)
+	// stop the ticker, events are applied by the getters with the fake clock
+	convey.So(sim.ShutDown(), convey.ShouldBeNil)
+	return sim, clock
+}
+
+func TestParseSimScenario(t *testing.T) {
+	convey.Convey("test func 'ParseSimScenario'", t, func() {
+		convey.Convey("events should be sorted and fault code should be parsed", func() {
+			scenario, err := ParseSimScenario([]byte("cardNum: 2\nevents:\n" +
+				"- {at: 2s, phyId: 1, type: fault, faultCode: 0x80E01801}\n- {at: 1s, phyId: 0, type: linkDown}\n"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(scenario.devType, convey.ShouldEqual, api.Ascend910B)
+			convey.So(scenario.Events[0].Type, convey.ShouldEqual, SimEventLinkDown)
+			convey.So(scenario.Events[1].faultCode, convey.ShouldEqual, testSimFault)
+			convey.So(scenario.chipIP(1), convey.ShouldEqual, "192.168.100.11")
+		})
+		convey.Convey("invalid scenario should return error", func() {
+			invalids := []string{
+				"cardNum: 0",
+				"cardNum: 65",
+				"cardNum: 1\nipBase: ::1",
+				"cardNum: 1\nutilization: {base: 10, amplitude: 5}",
+				"cardNum: 1\nevents: [{phyId: 1, type: linkDown}]",
+				"cardNum: 1\nevents: [{type: fault, faultCode: xyz}]",
+				"cardNum: 1\nevents: [{type: vnpuCreate, template: abc}]",
+				"cardNum: 1\nevents: [{type: unknown}]",
+			}
+			for _, data := range invalids {
+				_, err := ParseSimScenario([]byte(data))
+				convey.So(err, convey.ShouldNotBeNil)
+			}
+		})
+	})
+}
+
+func TestDeviceManagerSimTopology(t *testing.T) {
+	convey.Convey("test topology of DeviceManagerSim", t, func() {
+		sim, _ := newTestSim()
+		convey.So(sim.GetDevType(), convey.ShouldEqual, api.Ascend910A3)
+		num, err := sim.GetAllDeviceCount()
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(num, convey.ShouldEqual, testSimChipNum)
+		infos, err := sim.GetChipBaseInfos()
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(*infos[testSimFaultID], convey.ShouldResemble, common.ChipBaseInfo{PhysicID: 3, LogicID: 3,
+			CardID: 1, DeviceID: 1})
+		brother, err := sim.GetBrotherCardID(testSimFaultID)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(brother, convey.ShouldEqual, 2)
+		ip, err := sim.GetDeviceIPAddress(testSimFaultID, 0)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(ip, convey.ShouldEqual, "192.168.100.13")
+		_, err = sim.GetPhysicIDFromLogicID(testSimChipNum)
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+}
+
+func TestDeviceManagerSimEvents(t *testing.T) {
+	convey.Convey("test timeline of DeviceManagerSim", t, func() {
+		sim, clock := newTestSim()
+		var faults []common.DevFaultInfo
+		convey.So(sim.SetFaultEventCallFunc(func(info common.DevFaultInfo) {
+			faults = append(faults, info)
+		}), convey.ShouldBeNil)
+
+		clock.advance(30 * time.Second)
+		_, code, err := sim.GetDeviceErrorCode(testSimFaultID)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(code, convey.ShouldEqual, testSimFault)
+		convey.So(len(faults), convey.ShouldEqual, 1)
+		convey.So(faults[0].Assertion, convey.ShouldEqual, common.FaultOccur)
+
+		clock.advance(time.Minute)
+		errCount, _, err := sim.GetDeviceAllErrorCode(testSimFaultID)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(errCount, convey.ShouldEqual, 0)
+		convey.So(faults[1].Assertion, convey.ShouldEqual, common.FaultRecover)
+		health, err := sim.GetDeviceNetWorkHealth(testSimLinkID)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(health, convey.ShouldEqual, simNetHealthDown)
+
+		clock.advance(time.Minute + 30*time.Second)
+		_, err = sim.GetDeviceHealth(testSimResetID)
+		convey.So(err, convey.ShouldNotBeNil)
+		status, err := sim.GetDeviceBootStatus(testSimResetID)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(status, convey.ShouldNotEqual, common.BootStartFinish)
+		util, err := sim.GetDeviceUtilizationRate(7, common.AICore)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(util, convey.ShouldBeLessThanOrEqualTo, 30)
+
+		clock.advance(30 * time.Second)
+		health, err = sim.GetDeviceHealth(testSimResetID)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(health, convey.ShouldEqual, simHealthOK)
+		info, err := sim.GetVirtualDeviceInfo(testSimVNPUID)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(info.TotalResource.VDevID, convey.ShouldResemble, []uint32{simVDevIDBase})
+
+		clock.advance(30 * time.Second)
+		info, err = sim.GetVirtualDeviceInfo(testSimVNPUID)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(info.VDevInfo), convey.ShouldEqual, 0)
+	})
+}
+
+func TestDeviceManagerSimVirtualDevice(t *testing.T) {
+	convey.Convey("test vnpu of DeviceManagerSim", t, func() {
+		sim, _ := newTestSim()
+		out, err := sim.CreateVirtualDevice(0, common.CgoCreateVDevRes{TemplateName: "vir16"})
+		convey.So(err, convey.ShouldBeNil)
+		_, err = sim.CreateVirtualDevice(0, common.CgoCreateVDevRes{TemplateName: "vir16"})
+		convey.So(err, convey.ShouldNotBeNil)
+		convey.So(sim.DestroyVirtualDevice(0, out.VDevID), convey.ShouldBeNil)
+		convey.So(sim.DestroyVirtualDevice(0, out.VDevID), convey.ShouldNotBeNil)
+	})
+}
+
+func TestAutoInitWithSimulator(t *testing.T) {
+	convey.Convey("test func 'AutoInit' with simulator scenario", t, func() {
+		t.Setenv(SimScenarioEnv, testSimScenario)
+		devMgr, err := AutoInit(api.Ascend910A3, 0)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(devMgr.GetDevType(), convey.ShouldEqual, api.Ascend910A3)
+		convey.So(devMgr.ShutDown(), convey.ShouldBeNil)
+		_, err = AutoInit(api.Ascend310P, 0)
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+}
@@ -194,7 +194,11 @@ func doTestGetDeviceAllErrorCodeWithTimeOut(t *testing.T, testCases []struct {
 
 // TestGetCardIdAndDeviceId test the getCardIdAndDeviceId function
 func TestGetCardIdAndDeviceId(t *testing.T) {
-	idCache.Clear()
+	// sync.Map.Clear requires go1.23, the module is go1.21
+	idCache.Range(func(key, _ interface{}) bool {
+		idCache.Delete(key)
+		return true
+	})
 	var (
 		cardId, deviceId = int32(0), int32(0)
 		err              error
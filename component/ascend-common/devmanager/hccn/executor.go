@@ -0,0 +1,262 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package hccn this for executor of hccn_tool
+package hccn
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"ascend-common/common-utils/hwlog"
+	"ascend-common/common-utils/limiter"
+	"ascend-common/common-utils/utils"
+)
+
+const (
+	// RecordDirEnv env of the directory where the raw output of hccn_tool is recorded
+	RecordDirEnv = "ASCEND_HCCN_TOOL_RECORD_DIR"
+	// ReplayDirEnv env of the directory where the recorded output is served instead of running hccn_tool
+	ReplayDirEnv = "ASCEND_HCCN_TOOL_REPLAY_DIR"
+	// MaxCacheTTL max ttl of the result cache of hccn_tool
+	MaxCacheTTL = time.Minute
+
+	hccnToolPath     = "/usr/local/Ascend/driver/tools/hccn_tool"
+	hccnToolName     = "hccn_tool"
+	fixtureSuffix    = ".out"
+	fixtureDirMode   = 0700
+	maxCacheEntryNum = 1024
+)
+
+// Executor runs hccn_tool with the args and returns its standard output
+type Executor interface {
+	Exec(args ...string) (string, error)
+}
+
+var (
+	executorMu      sync.RWMutex
+	currentExecutor Executor
+)
+
+// SetExecutor replace the executor used by all functions of the package, the default executor is used when it is nil
+func SetExecutor(e Executor) {
+	executorMu.Lock()
+	defer executorMu.Unlock()
+	currentExecutor = e
+}
+
+// SetCacheTTL cache the successful results of the current executor for ttl, the cache is disabled when ttl is 0
+func SetCacheTTL(ttl time.Duration) error {
+	if ttl < 0 || ttl > MaxCacheTTL {
+		return fmt.Errorf("ttl of hccn_tool cache should be in [0, %v]", MaxCacheTTL)
+	}
+	executorMu.Lock()
+	defer executorMu.Unlock()
+	e := currentExecutor
+	if e == nil {
+		e = defaultExecutor()
+	}
+	if cached, ok := e.(*CachedExecutor); ok {
+		e = cached.inner
+	}
+	if ttl > 0 {
+		e = NewCachedExecutor(e, ttl)
+	}
+	currentExecutor = e
+	return nil
+}
+
+func getExecutor() Executor {
+	executorMu.RLock()
+	e := currentExecutor
+	executorMu.RUnlock()
+	if e != nil {
+		return e
+	}
+	executorMu.Lock()
+	defer executorMu.Unlock()
+	if currentExecutor == nil {
+		currentExecutor = defaultExecutor()
+	}
+	return currentExecutor
+}
+
+// defaultExecutor runs hccn_tool, the output is replayed or recorded when ReplayDirEnv or RecordDirEnv is set
+func defaultExecutor() Executor {
+	if dir := os.Getenv(ReplayDirEnv); dir != "" {
+		hwlog.RunLog.Warnf("output of %s is replayed from %s", hccnToolName, dir)
+		return NewReplayExecutor(dir)
+	}
+	if dir := os.Getenv(RecordDirEnv); dir != "" {
+		hwlog.RunLog.Warnf("output of %s is recorded to %s", hccnToolName, dir)
+		return NewRecordExecutor(&ToolExecutor{}, dir)
+	}
+	return &ToolExecutor{}
+}
+
+// ToolExecutor runs hccn_tool of the driver
+type ToolExecutor struct{}
+
+// Exec runs hccn_tool with the args
+func (t *ToolExecutor) Exec(args ...string) (string, error) {
+	if _, err := utils.CheckPath(hccnToolPath); err != nil {
+		return "", err
+	}
+	cmd := exec.Command(hccnToolPath, args...)
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		utils.LdLibPath + "=" + os.Getenv(utils.LdLibPath),
+	}
+	limitStdout := limiter.NewLimitedWriter(limitSize)
+	cmd.Stdout = limitStdout
+	cmd.Stderr = limiter.NewLimitedWriter(limitSize)
+	if err := cmd.Run(); err != nil {
+		return "", err
+	}
+	return string(limitStdout.GetBufferBytes()), nil
+}
+
+// fixtureName name of the file of recorded output, such as hccn_tool_-i_0_-link_-g.out
+func fixtureName(args []string) string {
+	var builder strings.Builder
+	builder.WriteString(hccnToolName)
+	for _, arg := range args {
+		builder.WriteByte('_')
+		for _, c := range arg {
+			if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '-' || c == '.' {
+				builder.WriteRune(c)
+				continue
+			}
+			builder.WriteByte('_')
+		}
+	}
+	return builder.String() + fixtureSuffix
+}
+
+// RecordExecutor records the raw output of each args of the inner executor as a fixture of ReplayExecutor
+type RecordExecutor struct {
+	inner Executor
+	dir   string
+	mu    sync.Mutex
+}
+
+// NewRecordExecutor create a record executor writing fixtures to dir
+func NewRecordExecutor(inner Executor, dir string) *RecordExecutor {
+	return &RecordExecutor{inner: inner, dir: dir}
+}
+
+// Exec runs the inner executor and records its output when it succeeds
+func (r *RecordExecutor) Exec(args ...string) (string, error) {
+	out, err := r.inner.Exec(args...)
+	if err != nil {
+		return out, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err = r.record(args, out); err != nil {
+		hwlog.RunLog.Warnf("record output of %s %v failed, err: %v", hccnToolName, args, err)
+	}
+	return out, nil
+}
+
+func (r *RecordExecutor) record(args []string, out string) error {
+	if err := os.MkdirAll(r.dir, fixtureDirMode); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(r.dir, fixtureName(args)), []byte(out), utils.FileMode)
+}
+
+// ReplayExecutor serves the fixtures recorded by RecordExecutor, an error is returned for args not recorded
+type ReplayExecutor struct {
+	dir string
+}
+
+// NewReplayExecutor create a replay executor reading fixtures from dir
+func NewReplayExecutor(dir string) *ReplayExecutor {
+	return &ReplayExecutor{dir: dir}
+}
+
+// Exec returns the recorded output of the args
+func (r *ReplayExecutor) Exec(args ...string) (string, error) {
+	path, err := utils.CheckPath(filepath.Join(r.dir, fixtureName(args)))
+	if err != nil {
+		return "", fmt.Errorf("no recorded output of %s %v: %v", hccnToolName, args, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if info.Size() > limitSize {
+		return "", fmt.Errorf("recorded output %s is larger than %d", path, limitSize)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+type cacheEntry struct {
+	out      string
+	expireAt time.Time
+}
+
+// CachedExecutor caches the successful results of the inner executor for each args in ttl
+type CachedExecutor struct {
+	inner   Executor
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+	// now returns current time, replaced in tests
+	now func() time.Time
+}
+
+// NewCachedExecutor create a cached executor
+func NewCachedExecutor(inner Executor, ttl time.Duration) *CachedExecutor {
+	return &CachedExecutor{inner: inner, ttl: ttl, entries: make(map[string]cacheEntry), now: time.Now}
+}
+
+// Exec returns the cached result of the args or runs the inner executor when it is expired
+func (c *CachedExecutor) Exec(args ...string) (string, error) {
+	key := strings.Join(args, "\x00")
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && c.now().Before(entry.expireAt) {
+		return entry.out, nil
+	}
+	out, err := c.inner.Exec(args...)
+	if err != nil {
+		return out, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	if len(c.entries) >= maxCacheEntryNum {
+		for k, e := range c.entries {
+			if !now.Before(e.expireAt) {
+				delete(c.entries, k)
+			}
+		}
+	}
+	if len(c.entries) < maxCacheEntryNum {
+		c.entries[key] = cacheEntry{out: out, expireAt: now.Add(c.ttl)}
+	}
+	return out, nil
+}
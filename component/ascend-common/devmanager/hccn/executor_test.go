@@ -0,0 +1,139 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package hccn this for executor of hccn_tool
+package hccn
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"ascend-common/devmanager/common"
+)
+
+const testFixtureDir = "testdata"
+
+// fakeExecutor returns the outputs by the joined args and counts the calls
+type fakeExecutor struct {
+	outputs map[string]string
+	calls   int
+}
+
+func (f *fakeExecutor) Exec(args ...string) (string, error) {
+	f.calls++
+	out, ok := f.outputs[strings.Join(args, space)]
+	if !ok {
+		return "", errors.New("command failed")
+	}
+	return out, nil
+}
+
+func TestFixtureName(t *testing.T) {
+	got := fixtureName([]string{"-i", "0", "-link", "-g"})
+	if got != "hccn_tool_-i_0_-link_-g.out" {
+		t.Errorf("fixtureName() = %s", got)
+	}
+	if got = fixtureName([]string{"-i", "../0 1"}); got != "hccn_tool_-i_.._0_1.out" {
+		t.Errorf("fixtureName() = %s", got)
+	}
+}
+
+// TestRecordAndReplay verifies that the output recorded by RecordExecutor is served by ReplayExecutor
+func TestRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	inner := &fakeExecutor{outputs: map[string]string{"-i 1 -link -g": "link status: DOWN\n"}}
+	recorder := NewRecordExecutor(inner, dir)
+	if _, err := recorder.Exec("-i", "1", "-link", "-g"); err != nil {
+		t.Fatalf("record failed: %v", err)
+	}
+	if _, err := recorder.Exec("-i", "2", "-link", "-g"); err == nil {
+		t.Fatal("error of inner executor should be returned")
+	}
+
+	replayer := NewReplayExecutor(dir)
+	out, err := replayer.Exec("-i", "1", "-link", "-g")
+	if err != nil || out != "link status: DOWN\n" {
+		t.Errorf("replay got %q, %v", out, err)
+	}
+	if _, err = replayer.Exec("-i", "2", "-link", "-g"); err == nil {
+		t.Error("args not recorded should return error")
+	}
+}
+
+// TestCachedExecutor verifies that the successful results are cached in ttl
+func TestCachedExecutor(t *testing.T) {
+	inner := &fakeExecutor{outputs: map[string]string{"-i 0 -link -g": "link status: UP\n"}}
+	now := time.Unix(0, 0)
+	cached := NewCachedExecutor(inner, time.Second)
+	cached.now = func() time.Time { return now }
+	for i := 0; i < 3; i++ {
+		if _, err := cached.Exec("-i", "0", "-link", "-g"); err != nil {
+			t.Fatalf("exec failed: %v", err)
+		}
+		if _, err := cached.Exec("-i", "1", "-link", "-g"); err == nil {
+			t.Fatal("error should not be cached")
+		}
+	}
+	if inner.calls != 4 {
+		t.Errorf("inner executor called %d times, want 4", inner.calls)
+	}
+	now = now.Add(time.Second)
+	if _, err := cached.Exec("-i", "0", "-link", "-g"); err != nil || inner.calls != 5 {
+		t.Errorf("expired result should be refreshed, calls: %d, err: %v", inner.calls, err)
+	}
+}
+
+func TestSetCacheTTL(t *testing.T) {
+	defer SetExecutor(nil)
+	replayer := NewReplayExecutor(testFixtureDir)
+	SetExecutor(replayer)
+	if err := SetCacheTTL(MaxCacheTTL + time.Second); err == nil {
+		t.Error("ttl out of range should return error")
+	}
+	if err := SetCacheTTL(time.Second); err != nil {
+		t.Fatalf("set cache ttl failed: %v", err)
+	}
+	cached, ok := getExecutor().(*CachedExecutor)
+	if !ok || cached.inner != replayer {
+		t.Fatal("executor should be cached")
+	}
+	if err := SetCacheTTL(0); err != nil || getExecutor() != replayer {
+		t.Error("cache should be disabled when ttl is 0")
+	}
+}
+
+// TestHccnToolWithReplay verifies the functions of the package with the recorded output in testdata
+func TestHccnToolWithReplay(t *testing.T) {
+	SetExecutor(NewReplayExecutor(testFixtureDir))
+	defer SetExecutor(nil)
+	ubPortEnabledCache = make(map[int32]map[string]bool)
+	defer func() {
+		ubPortEnabledCache = make(map[int32]map[string]bool)
+	}()
+
+	status, err := GetNPULinkStatus(0)
+	if err != nil || status != LinkUp {
+		t.Errorf("GetNPULinkStatus() = %s, %v", status, err)
+	}
+	if _, err = GetNPULinkStatus(1); err == nil {
+		t.Error("GetNPULinkStatus() of chip not recorded should return error")
+	}
+	snapshot, err := GetUBPortsDownSnapshot(0)
+	want := common.UBPortsDownSnapshot{BondingDownCnt: 1, UBDownCnt: 1}
+	if err != nil || snapshot != want {
+		t.Errorf("GetUBPortsDownSnapshot() = %+v, %v, want %+v", snapshot, err, want)
+	}
+}
@@ -17,15 +17,11 @@ package hccn
 
 import (
 	"fmt"
-	"os"
-	"os/exec"
 	"strconv"
 	"strings"
 	"sync"
 
 	"ascend-common/common-utils/hwlog"
-	"ascend-common/common-utils/limiter"
-	"ascend-common/common-utils/utils"
 	"ascend-common/devmanager/common"
 )
 
@@ -84,24 +80,7 @@ var (
 )
 
 func getInfoFromHccnTool(args ...string) (string, error) {
-	const hccnTool = "/usr/local/Ascend/driver/tools/hccn_tool"
-	if _, err := utils.CheckPath(hccnTool); err != nil {
-		return "", err
-	}
-	cmd := exec.Command(hccnTool, args...)
-	cmd.Env = []string{
-		"PATH=" + os.Getenv("PATH"),
-		utils.LdLibPath + "=" + os.Getenv(utils.LdLibPath),
-	}
-	limitStdout := limiter.NewLimitedWriter(limitSize)
-	cmd.Stdout = limitStdout
-	cmd.Stderr = limiter.NewLimitedWriter(limitSize)
-	err := cmd.Run()
-	if err != nil {
-		return "", err
-	}
-
-	return string(limitStdout.GetBufferBytes()), nil
+	return getExecutor().Exec(args...)
 }
 
 // GetNPULinkStatus exec "hccn_tool -i * -link -g" to get link status
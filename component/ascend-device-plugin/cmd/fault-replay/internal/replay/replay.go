@@ -0,0 +1,285 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package replay replays the fault events by the fault handling of the device plugin offline. The fault handling
+// caches of the process are used, so it is only imported by the fault-replay tool and a process replays once
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"Ascend-device-plugin/pkg/common"
+	"ascend-common/common-utils/hwlog"
+	npuCommon "ascend-common/devmanager/common"
+)
+
+const (
+	defaultIntervalSeconds = 5
+	maxIntervalSeconds     = 3600
+	maxTailSeconds         = 7 * 24 * 3600
+	maxEvents              = 100000
+	maxCycles              = 1000000
+)
+
+// replayed the fault handling caches are not cleared after a replay, so a process can replay only once
+var replayed atomic.Bool
+
+// Scenario the fault events replayed by the fault handling of the device plugin offline
+type Scenario struct {
+	// CardType the real card type, such as Ascend910B, the fault handling steps differ by it
+	CardType string `json:"cardType"`
+	// LogicIDs the chips replayed, default the chips of the events
+	LogicIDs []int32 `json:"logicIDs"`
+	// IntervalSeconds the period of the fault handling cycle, default 5 seconds
+	IntervalSeconds int64 `json:"intervalSeconds"`
+	// TailSeconds the time replayed after the last event, such as the time waiting for the fault duration timeout
+	TailSeconds int64 `json:"tailSeconds"`
+	// Events the replayed events, they are sorted by AlarmRaisedTime when parsed
+	Events []Event `json:"events"`
+}
+
+// Event the fault event reported by the subscribe interface. AlarmRaisedTime is the unix time in milliseconds and
+// Assertion is 0-recover, 1-occur, 2-once
+type Event struct {
+	npuCommon.DevFaultInfo
+	// EventIDHex the hex event id, such as 80e01801, it is used when EventID is not set
+	EventIDHex string `json:"EventIDHex,omitempty"`
+}
+
+// Record the state of a chip at the end of a fault handling cycle, recorded when events of the chip are handled or
+// the state changes
+type Record struct {
+	Time              int64    `json:"time"`
+	OffsetSeconds     int64    `json:"offsetSeconds"`
+	LogicID           int32    `json:"logicID"`
+	Events            []string `json:"events,omitempty"`
+	Steps             []string `json:"steps,omitempty"`
+	FaultCodes        []string `json:"faultCodes"`
+	NetworkFaultCodes []string `json:"networkFaultCodes"`
+	FaultType         string   `json:"faultType"`
+	NetworkFaultType  string   `json:"networkFaultType"`
+}
+
+// Result the fault type timeline of the chips and the final fault type of each chip, which is the more serious one
+// of the chip fault type and the network fault type
+type Result struct {
+	Cycles     int              `json:"cycles"`
+	Timeline   []Record         `json:"timeline"`
+	FinalTypes map[int32]string `json:"finalTypes"`
+}
+
+// ParseScenario parses and checks the json scenario of the fault replay
+func ParseScenario(data []byte) (*Scenario, error) {
+	scenario := &Scenario{}
+	if err := json.Unmarshal(data, scenario); err != nil {
+		return nil, fmt.Errorf("unmarshal fault replay scenario failed: %v", err)
+	}
+	if scenario.IntervalSeconds == 0 {
+		scenario.IntervalSeconds = defaultIntervalSeconds
+	}
+	if scenario.IntervalSeconds < 0 || scenario.IntervalSeconds > maxIntervalSeconds {
+		return nil, fmt.Errorf("intervalSeconds %d is out of range [1, %d]", scenario.IntervalSeconds,
+			maxIntervalSeconds)
+	}
+	if scenario.TailSeconds < 0 || scenario.TailSeconds > maxTailSeconds {
+		return nil, fmt.Errorf("tailSeconds %d is out of range [0, %d]", scenario.TailSeconds, maxTailSeconds)
+	}
+	if len(scenario.Events) == 0 || len(scenario.Events) > maxEvents {
+		return nil, fmt.Errorf("number of events %d is out of range [1, %d]", len(scenario.Events), maxEvents)
+	}
+	for i := range scenario.Events {
+		event := &scenario.Events[i]
+		if event.EventID == 0 && event.EventIDHex != "" {
+			id, err := strconv.ParseInt(event.EventIDHex, common.Hex, 0)
+			if err != nil {
+				return nil, fmt.Errorf("event %d has invalid EventIDHex %s", i, event.EventIDHex)
+			}
+			event.EventID = id
+		}
+		if event.AlarmRaisedTime <= 0 {
+			return nil, fmt.Errorf("event %d has invalid AlarmRaisedTime %d", i, event.AlarmRaisedTime)
+		}
+		if common.GetFaultAssertionName(event.Assertion) == "" {
+			return nil, fmt.Errorf("event %d has invalid Assertion %d", i, event.Assertion)
+		}
+	}
+	sort.SliceStable(scenario.Events, func(i, j int) bool {
+		return scenario.Events[i].AlarmRaisedTime < scenario.Events[j].AlarmRaisedTime
+	})
+	first, last := scenario.Events[0].AlarmRaisedTime, scenario.Events[len(scenario.Events)-1].AlarmRaisedTime
+	if (last-first)/common.SecondMagnification+scenario.TailSeconds > scenario.IntervalSeconds*maxCycles {
+		return nil, fmt.Errorf("replay needs more than %d cycles, enlarge intervalSeconds", maxCycles)
+	}
+	return scenario, nil
+}
+
+type replayer struct {
+	scenario *Scenario
+	now      int64
+	devices  []*common.NpuDevice
+	steps    map[int32][]string
+	events   map[int32][]string
+	last     map[int32]Record
+	result   *Result
+}
+
+// Run replays the events by the fault handling cycles of the device plugin with the loaded fault code and fault
+// customization, the fault handling time is the time of the replayed cycle. The card type of the scenario should
+// be set to common.ParamOption before
+func Run(scenario *Scenario) (*Result, error) {
+	if !replayed.CompareAndSwap(false, true) {
+		return nil, errors.New("fault events can be replayed only once in a process")
+	}
+	r := newReplayer(scenario)
+	start := scenario.Events[0].AlarmRaisedTime
+	end := scenario.Events[len(scenario.Events)-1].AlarmRaisedTime + scenario.TailSeconds*common.SecondMagnification
+	next := 0
+	for r.now = start; ; r.now += scenario.IntervalSeconds * common.SecondMagnification {
+		for ; next < len(scenario.Events) && scenario.Events[next].AlarmRaisedTime <= r.now; next++ {
+			r.deliver(scenario.Events[next].DevFaultInfo)
+		}
+		r.runCycle()
+		r.result.Cycles++
+		if r.now >= end {
+			break
+		}
+	}
+	for _, device := range r.devices {
+		last := r.last[device.LogicID]
+		r.result.FinalTypes[device.LogicID] = common.GetMostSeriousFaultType([]string{last.FaultType,
+			last.NetworkFaultType})
+	}
+	return r.result, nil
+}
+
+func newReplayer(scenario *Scenario) *replayer {
+	logicIDs := sets.NewInt32(scenario.LogicIDs...)
+	for _, event := range scenario.Events {
+		logicIDs.Insert(event.LogicID)
+	}
+	r := &replayer{
+		scenario: scenario,
+		steps:    make(map[int32][]string, logicIDs.Len()),
+		events:   make(map[int32][]string, logicIDs.Len()),
+		last:     make(map[int32]Record, logicIDs.Len()),
+		result:   &Result{FinalTypes: make(map[int32]string, logicIDs.Len())},
+	}
+	for _, logicID := range logicIDs.List() {
+		r.devices = append(r.devices, &common.NpuDevice{LogicID: logicID, DeviceName: fmt.Sprintf("chip-%d", logicID)})
+		r.last[logicID] = Record{FaultType: common.NormalNPU, NetworkFaultType: common.NormalNPU}
+	}
+	return r
+}
+
+func (r *replayer) clock() time.Time {
+	return time.UnixMilli(r.now)
+}
+
+func (r *replayer) deliver(faultInfo npuCommon.DevFaultInfo) {
+	r.events[faultInfo.LogicID] = append(r.events[faultInfo.LogicID], fmt.Sprintf("%s %s",
+		strings.ToUpper(common.FormatFaultCodeHex(faultInfo.EventID)), common.GetFaultAssertionName(faultInfo.Assertion)))
+	common.CacheDevFaultInfo(faultInfo, false)
+}
+
+// runSteps executes the fault handling steps and records the names of them for the chips
+func (r *replayer) runSteps(steps []common.FaultHandlingStep, logicIDs ...int32) {
+	for _, step := range steps {
+		for _, logicID := range logicIDs {
+			r.steps[logicID] = append(r.steps[logicID], step.Name)
+		}
+		step.Do()
+	}
+}
+
+// runCycle handles the cached events as writeNewFaultCode of the device plugin, and clears the once recover faults
+// at the end of the cycle
+func (r *replayer) runCycle() {
+	devFaultInfoMap := common.GetAndCleanFaultInfo()
+	logicIDs := make([]int32, 0, len(r.devices))
+	for _, device := range r.devices {
+		logicID := device.LogicID
+		logicIDs = append(logicIDs, logicID)
+		classified := common.ClassifyFaultInfos(devFaultInfoMap[logicID])
+		r.runSteps(common.GetChipFaultHandlingSteps(logicID, classified[common.ChipFaultKey], device, nil,
+			r.clock()), logicID)
+		r.runSteps(common.GetParameterPlaneFaultHandlingSteps(logicID, classified[common.ParameterPlaneFaultKey],
+			device), logicID)
+		r.runSteps(common.GetHyperPlaneFaultHandlingSteps(logicID, classified[common.HyperPlaneFaultKey], device),
+			logicID)
+		common.CountFaultDurationAt(device, devFaultInfoMap, r.clock())
+	}
+	r.runSteps(common.GetHyperPlaneOverallFaultHandlingSteps(r.devices, r.clock()), logicIDs...)
+	for _, device := range r.devices {
+		r.record(device)
+	}
+	if resetIDs := common.GetAndCleanLogicID(); len(resetIDs) != 0 {
+		hwlog.RunLog.Infof("devices %v are reset in replay", resetIDs)
+	}
+	common.DelOnceRecoverFault(map[string][]*common.NpuDevice{"": r.devices})
+	common.DelOnceFrequencyFault()
+	r.steps = make(map[int32][]string, len(r.devices))
+	r.events = make(map[int32][]string, len(r.devices))
+}
+
+// record appends the record of the chip when events of it are handled or the state changes
+func (r *replayer) record(device *common.NpuDevice) {
+	chipCodes := faultCodes(device.FaultCodes, device.LogicID, common.ChipFaultMode)
+	networkCodes := faultCodes(device.NetworkFaultCodes, device.LogicID, common.NetworkFaultMode)
+	record := Record{
+		Time:              r.now,
+		OffsetSeconds:     (r.now - r.scenario.Events[0].AlarmRaisedTime) / common.SecondMagnification,
+		LogicID:           device.LogicID,
+		Events:            r.events[device.LogicID],
+		FaultCodes:        hexCodes(chipCodes),
+		NetworkFaultCodes: hexCodes(networkCodes),
+		FaultType:         common.GetFaultTypeAt(chipCodes, device.LogicID, r.clock()),
+		NetworkFaultType:  common.GetNetworkFaultTypeAt(networkCodes, device.LogicID, r.clock()),
+	}
+	last := r.last[device.LogicID]
+	changed := record.FaultType != last.FaultType || record.NetworkFaultType != last.NetworkFaultType ||
+		!slices.Equal(record.FaultCodes, last.FaultCodes) || !slices.Equal(record.NetworkFaultCodes, last.NetworkFaultCodes)
+	if len(record.Events) == 0 && !changed {
+		return
+	}
+	record.Steps = r.steps[device.LogicID]
+	r.last[device.LogicID] = record
+	r.result.Timeline = append(r.result.Timeline, record)
+}
+
+// faultCodes the fault codes and the upgraded fault codes, the same as the ones reported by the device plugin
+func faultCodes(codes []int64, logicID int32, mode string) []int64 {
+	all := sets.NewInt64(codes...)
+	for code := range common.GetUpgradeFaultLevelAndTime(logicID, mode) {
+		all.Insert(code)
+	}
+	return all.List()
+}
+
+func hexCodes(codes []int64) []string {
+	hex := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hex = append(hex, strings.ToUpper(common.FormatFaultCodeHex(code)))
+	}
+	return hex
+}
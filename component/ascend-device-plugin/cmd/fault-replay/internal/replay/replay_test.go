@@ -0,0 +1,99 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package replay test for fault replay
+package replay
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+
+	"Ascend-device-plugin/pkg/common"
+	"ascend-common/common-utils/hwlog"
+)
+
+const (
+	testFaultCode = `{"RestartBusinessCodes": ["80E01801"], "NotHandleFaultCodes": ["81078603"]}`
+	// 80E01801 occurred twice is ManuallySeparateNPU, 81078603 lasting 20 seconds is PreSeparateNPU
+	testCustomization = `{
+  "FaultFrequency": [{"EventId": ["80E01801"], "TimeWindow": 86400, "Times": 2,
+    "FaultHandling": "ManuallySeparateNPU"}],
+  "FaultDuration": [{"EventId": ["81078603"], "FaultTimeout": 20, "RecoverTimeout": 60,
+    "FaultHandling": "PreSeparateNPU"}]
+}`
+	testScenario = `{"cardType": "Ascend910B", "logicIDs": [2], "tailSeconds": 10, "events": [
+  {"EventIDHex": "80E01801", "LogicID": 1, "Assertion": 2, "AlarmRaisedTime": 1760000040000},
+  {"EventIDHex": "81078603", "LogicID": 0, "Assertion": 1, "AlarmRaisedTime": 1760000000000},
+  {"EventIDHex": "80E01801", "LogicID": 1, "Assertion": 2, "AlarmRaisedTime": 1760000010000}
+]}`
+)
+
+func init() {
+	hwLogConfig := hwlog.LogConfig{
+		OnlyToStdout: true,
+	}
+	hwlog.InitRunLogger(&hwLogConfig, context.Background())
+}
+
+func TestParseScenario(t *testing.T) {
+	convey.Convey("test ParseScenario", t, func() {
+		scenario, err := ParseScenario([]byte(testScenario))
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(scenario.IntervalSeconds, convey.ShouldEqual, defaultIntervalSeconds)
+		convey.So(scenario.Events[0].EventID, convey.ShouldEqual, 0x81078603)
+		convey.So(scenario.Events[2].AlarmRaisedTime, convey.ShouldEqual, 1760000040000)
+
+		_, err = ParseScenario([]byte(`{"events": [{"EventIDHex": "zz", "AlarmRaisedTime": 1}]}`))
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = ParseScenario([]byte(`{"events": [{"EventID": 1, "Assertion": 5, "AlarmRaisedTime": 1}]}`))
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = ParseScenario([]byte(`{"intervalSeconds": -1, "events": [{"AlarmRaisedTime": 1}]}`))
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = ParseScenario([]byte(`{"events": []}`))
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+}
+
+func TestRun(t *testing.T) {
+	originCardType := common.ParamOption.RealCardType
+	defer func() { common.ParamOption.RealCardType = originCardType }()
+	convey.Convey("test Run", t, func() {
+		convey.So(common.LoadFaultCode([]byte(testFaultCode)), convey.ShouldBeNil)
+		convey.So(common.LoadFaultCustomization([]byte(testCustomization)), convey.ShouldBeNil)
+		scenario, err := ParseScenario([]byte(testScenario))
+		convey.So(err, convey.ShouldBeNil)
+		common.ParamOption.RealCardType = scenario.CardType
+		result, err := Run(scenario)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(result.Cycles, convey.ShouldEqual, 11)
+		convey.So(result.FinalTypes, convey.ShouldResemble,
+			map[int32]string{0: common.PreSeparateNPU, 1: common.ManuallySeparateNPU, 2: common.NormalNPU})
+
+		types := make(map[int32][]string)
+		for _, record := range result.Timeline {
+			types[record.LogicID] = append(types[record.LogicID], record.FaultType+"/"+record.NetworkFaultType)
+		}
+		convey.So(types[0], convey.ShouldResemble, []string{common.NormalNPU + "/" + common.NotHandleFault,
+			common.NormalNPU + "/" + common.PreSeparateNPU})
+		convey.So(types[1], convey.ShouldResemble, []string{common.RestartBusiness + "/" + common.NormalNPU,
+			common.NormalNPU + "/" + common.NormalNPU, common.ManuallySeparateNPU + "/" + common.NormalNPU})
+		convey.So(result.Timeline[0].Events, convey.ShouldResemble, []string{"81078603 Occur"})
+		convey.So(result.Timeline[0].Steps, convey.ShouldContain, "baseParameterPlaneFaultOccur")
+
+		_, err = Run(scenario)
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,122 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package main fault-replay replays the fault events by the fault handling of the device plugin offline with the
+// fault code and fault customization files, and prints the fault type timeline and handling steps of each chip
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"Ascend-device-plugin/cmd/fault-replay/internal/replay"
+	"Ascend-device-plugin/pkg/common"
+	"ascend-common/common-utils/hwlog"
+	"ascend-common/common-utils/utils"
+)
+
+const (
+	defaultLogLevel = 2
+	outputFileMode  = 0640
+)
+
+var (
+	faultCodeFile          string
+	faultCustomizationFile string
+	eventsFile             string
+	outputFile             string
+	logLevel               int
+)
+
+func init() {
+	flag.StringVar(&faultCodeFile, "faultCode", "", "fault code file, such as faultCode.json")
+	flag.StringVar(&faultCustomizationFile, "faultCustomization", "",
+		"fault customization file, such as faultCustomization.json, default no customization")
+	flag.StringVar(&eventsFile, "events", "", "json scenario file holding the replayed fault events")
+	flag.StringVar(&outputFile, "output", "", "file to write json result, default stdout")
+	flag.IntVar(&logLevel, "logLevel", defaultLogLevel, "log level of device plugin, -1-debug, 0-info, "+
+		"1-warning, 2-error, 3-critical(default 2)")
+}
+
+func main() {
+	flag.Parse()
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	if faultCodeFile == "" || eventsFile == "" {
+		return fmt.Errorf("fault code file and events file must be specified")
+	}
+	if err := hwlog.InitRunLogger(&hwlog.LogConfig{OnlyToStdout: true, LogLevel: logLevel},
+		context.Background()); err != nil {
+		return fmt.Errorf("init log failed, %v", err)
+	}
+	if err := loadFaultConfig(); err != nil {
+		return err
+	}
+	data, err := utils.LoadFile(eventsFile)
+	if err != nil {
+		return fmt.Errorf("read events file failed, %v", err)
+	}
+	scenario, err := replay.ParseScenario(data)
+	if err != nil {
+		return err
+	}
+	if scenario.CardType != "" {
+		common.ParamOption.RealCardType = scenario.CardType
+	}
+	result, err := replay.Run(scenario)
+	if err != nil {
+		return err
+	}
+	output, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal result failed, %v", err)
+	}
+	if outputFile == "" {
+		fmt.Println(string(output))
+		return nil
+	}
+	if err = os.WriteFile(outputFile, output, outputFileMode); err != nil {
+		return fmt.Errorf("write result failed, %v", err)
+	}
+	return nil
+}
+
+func loadFaultConfig() error {
+	data, err := utils.LoadFile(faultCodeFile)
+	if err != nil {
+		return fmt.Errorf("read fault code file failed, %v", err)
+	}
+	if err = common.LoadFaultCode(data); err != nil {
+		return err
+	}
+	common.ResetFaultCustomizationCache()
+	if faultCustomizationFile == "" {
+		return nil
+	}
+	if data, err = utils.LoadFile(faultCustomizationFile); err != nil {
+		return fmt.Errorf("read fault customization file failed, %v", err)
+	}
+	if err = common.LoadFaultCustomization(data); err != nil {
+		return fmt.Errorf("load fault customization failed, %v", err)
+	}
+	return nil
+}
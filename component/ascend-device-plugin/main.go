@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"Ascend-device-plugin/pkg/common"
 	"Ascend-device-plugin/pkg/duplicatedetector"
@@ -34,6 +35,7 @@ import (
 	"ascend-common/common-utils/healthz"
 	"ascend-common/common-utils/hwlog"
 	"ascend-common/common-utils/utils"
+	"ascend-common/devmanager/hccn"
 )
 
 const (
@@ -100,6 +102,19 @@ var (
 		"A3 card whether to use single die mode")
 	getPodFromKubelet = flag.Bool("getPodFromKubelet", false,
 		"Whether to get pod information from kubelet instead of apiserver")
+	hccnToolCacheTime = flag.Int("hccnToolCacheTime", 0,
+		"seconds to reuse the result of the same hccn_tool command, 0 means disabled, range [0, 60]")
+	useCDI = flag.Bool("useCDI", false, "Whether to inject devices by cdi spec files instead of "+
+		"ascend-docker-runtime, the container runtime must enable cdi (default false)")
+	cdiSpecDir = flag.String("cdiSpecDir", common.DefaultCDISpecDir, "The dir of cdi spec files written by "+
+		"device-plugin, only used when useCDI is true")
+	duplicateDevicePolicy = flag.String("duplicateDevicePolicy", types.PolicyLog, "The policy for NPU devices "+
+		"mounted by multiple containers, log-only log them, enforce-also report pod events and mark the devices "+
+		"unhealthy (default log)")
+	duplicateDeviceEvict = flag.Bool("duplicateDeviceEvict", false, "Whether to evict the pods not allocated the "+
+		"duplicate mounted device by kubelet, only used when duplicateDevicePolicy is enforce (default false)")
+	duplicateDeviceDryRun = flag.Bool("duplicateDeviceDryRun", false, "Whether to only log the actions of the "+
+		"enforce duplicateDevicePolicy without executing them (default false)")
 	hzFlags = healthz.RegisterFlags()
 )
 
@@ -148,6 +163,9 @@ func checkParam() bool {
 		checkThirdPartyScanDelay,
 		checkDeviceResetTimeout,
 		checkSoftShareDevParam,
+		checkHccnToolCacheTime,
+		checkCDIParam,
+		checkDuplicateDevicePolicy,
 	}
 	for _, check := range checks {
 		if !check() {
@@ -261,6 +279,39 @@ func checkDeviceResetTimeout() bool {
 	return true
 }
 
+func checkHccnToolCacheTime() bool {
+	maxCacheTime := int(hccn.MaxCacheTTL / time.Second)
+	if *hccnToolCacheTime < 0 || *hccnToolCacheTime > maxCacheTime {
+		hwlog.RunLog.Errorf("hccnToolCacheTime %d out of range [0,%d]", *hccnToolCacheTime, maxCacheTime)
+		return false
+	}
+	return true
+}
+
+func checkCDIParam() bool {
+	if !*useCDI {
+		return true
+	}
+	if !filepath.IsAbs(*cdiSpecDir) {
+		hwlog.RunLog.Errorf("cdiSpecDir %s is not an absolute path", *cdiSpecDir)
+		return false
+	}
+	if *use310PMixedInsert {
+		hwlog.RunLog.Error("cdi is not supported in 310P mixed insert mode")
+		return false
+	}
+	return true
+}
+
+func checkDuplicateDevicePolicy() bool {
+	if *duplicateDevicePolicy != types.PolicyLog && *duplicateDevicePolicy != types.PolicyEnforce {
+		hwlog.RunLog.Errorf("duplicateDevicePolicy %s is invalid, only support %s and %s", *duplicateDevicePolicy,
+			types.PolicyLog, types.PolicyEnforce)
+		return false
+	}
+	return true
+}
+
 func checkShareDevCount() bool {
 	if *shareDevCount < 1 || *shareDevCount > common.MaxShareDevCount {
 		hwlog.RunLog.Error("share device function params invalid")
@@ -313,10 +364,17 @@ func main() {
 	go hdm.ListenDevice(ctx)
 	// start goroutine to dump topo of rack A5 for ras
 	go topology.RasTopoWriteTask(ctx, hdm)
-	duplicatedetector.CheckDuplicateDevices(ctx, &types.DetectorConfig{
+	detectorConfig := &types.DetectorConfig{
 		CriEndpoint: "",
 		RuntimeType: hdm.ContainerRuntime,
-	})
+		Policy:      *duplicateDevicePolicy,
+		DryRun:      *duplicateDeviceDryRun,
+		Evict:       *duplicateDeviceEvict,
+	}
+	if *duplicateDevicePolicy == types.PolicyEnforce {
+		detectorConfig.Enforcer = hdm.NewDuplicateEnforcer()
+	}
+	duplicatedetector.CheckDuplicateDevices(ctx, detectorConfig)
 	hwlog.RunLog.Infof("device plugin started.")
 	hdm.SignCatch(cancel)
 }
@@ -341,6 +399,11 @@ func setParameters() {
 		SoftShareDevConfigDir: *softShareDevConfigDir,
 		UseSingleDieMode:      *useSingleDieMode,
 		GetPodFromKubelet:     *getPodFromKubelet,
+		UseCDI:                *useCDI,
+		CDISpecDir:            filepath.Clean(*cdiSpecDir),
+	}
+	if err := hccn.SetCacheTTL(time.Duration(*hccnToolCacheTime) * time.Second); err != nil {
+		hwlog.RunLog.Warnf("set cache time of hccn_tool failed, err: %v", err)
 	}
 }
 
@@ -358,6 +421,10 @@ func setUseAscendDocker() {
 	if len(common.ParamOption.ProductTypes) == 1 && common.ParamOption.ProductTypes[0] == common.Atlas200ISoc {
 		useAscendDocker = false
 	}
+	if common.ParamOption.UseCDI {
+		useAscendDocker = false
+		hwlog.RunLog.Debugf("cdi mode do not use npu docker")
+	}
 
 	common.ParamOption.UseAscendDocker = useAscendDocker
 	hwlog.RunLog.Infof("device-plugin set npu docker as: %v", useAscendDocker)
@@ -74,6 +74,8 @@ const (
 	DeviceInfoCmUpgradeFaultReasonKey = "UpgradeFaultReason"
 	// EventReleaseUpgradeFault the event reason of release upgrade fault
 	EventReleaseUpgradeFault = "EventReleaseUpgradeFault"
+	// EventDuplicateNPUMount the event reason of npu mounted by multiple containers
+	EventDuplicateNPUMount = "DuplicateNPUMount"
 	// DescriptionKey for deviceinfo configmap Description key
 	DescriptionKey = "Description"
 	// DescriptionValue for deviceinfo configmap Description value
@@ -106,8 +108,19 @@ const (
 	MetaData = "metadata"
 	// ResetInfoAnnotationKey is the key of reset fail information in node annotation
 	ResetInfoAnnotationKey = "ResetInfo"
+	// NPUCordonAnnotationKey is the key of the npu cordon requests of the administrator in node annotation
+	NPUCordonAnnotationKey = "huawei.com/npu-cordon"
+	// NPUCordonStatusAnnotationKey is the key of the npu cordon status written by device plugin in node annotation
+	NPUCordonStatusAnnotationKey = "huawei.com/npu-cordon-status"
+	// NPUCordonStateDraining the npu waits for the pods using it to finish before being cordoned, it keeps healthy
+	// to kubelet and is only left out of the available devices and the preferred allocation
+	NPUCordonStateDraining = "Draining"
+	// NPUCordonStateCordoned the npu is reported unhealthy and excluded from the available devices
+	NPUCordonStateCordoned = "Cordoned"
 	// DefaultScanDelay default delay time before scanning devices reset by third party, seconds
 	DefaultScanDelay = 300
+	// DefaultCDISpecDir default dir of the cdi spec files, it is one of the dirs watched by containerd and cri-o
+	DefaultCDISpecDir = "/var/run/cdi"
 
 	// SlowNodeStepTimeEnvNum is the number of environment value for step time cm
 	SlowNodeStepTimeEnvNum = 2
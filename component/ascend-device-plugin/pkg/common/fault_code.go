@@ -365,6 +365,13 @@ type FaultHandlingStep struct {
 	Do   func()
 }
 
+// runFaultHandlingSteps executes the steps in order
+func runFaultHandlingSteps(steps []FaultHandlingStep) {
+	for _, step := range steps {
+		step.Do()
+	}
+}
+
 // faultCategoryFilter defines a filter predicate and the fault category name for classification.
 type faultCategoryFilter struct {
 	name    string
@@ -490,10 +497,10 @@ func getHyperPlaneOverallFaultPreSteps(devices []*NpuDevice) []FaultHandlingStep
 	return []FaultHandlingStep{}
 }
 
-func getA950HyperPlaneNewOverallFaultSteps(devices []*NpuDevice) []FaultHandlingStep {
+func getA950HyperPlaneNewOverallFaultSteps(devices []*NpuDevice, now time.Time) []FaultHandlingStep {
 	return []FaultHandlingStep{
 		{Name: "a950HyperPlaneNewOverallFaultModify", Do: func() {
-			a950HyperPlaneNewOverallFaultModify(devices)
+			a950HyperPlaneNewOverallFaultModify(devices, now)
 		}},
 	}
 }
@@ -555,7 +562,7 @@ func (h *HbmFaultManager) aicFaultEventInQue(faultInfo common.DevFaultInfo) {
 		faultInfo.EventID, faultInfo.LogicID, h.AicFaultEventQue[faultInfo.LogicID])
 }
 
-func (h *HbmFaultManager) aicFaultEventOutQue(logicId int32) []common.DevFaultInfo {
+func (h *HbmFaultManager) aicFaultEventOutQue(logicId int32, now time.Time) []common.DevFaultInfo {
 	faultInfoList := make([]common.DevFaultInfo, 0)
 	faultEventQue, ok := h.AicFaultEventQue[logicId]
 	if !ok {
@@ -565,7 +572,7 @@ func (h *HbmFaultManager) aicFaultEventOutQue(logicId int32) []common.DevFaultIn
 		h.HbmOccurTimeCache[logicId] = 0
 	}
 	newFaultEventQue := make([]common.DevFaultInfo, 0)
-	nowTime := time.Now().UnixMilli()
+	nowTime := now.UnixMilli()
 	for i := 0; i < len(faultEventQue); i++ {
 		// The fault aic error occurring ten seconds before and after the occurrence of hbm error should be deleted,
 		if Int64Tool.Abs(h.HbmOccurTimeCache[logicId], faultEventQue[i].AlarmRaisedTime) <
@@ -1129,6 +1136,11 @@ func GetNetworkFaultTypeByCode(faultCodes []int64) string {
 // GetFaultType will return the fault type from fault codes,
 // fault frequency, fault duration and ManuallySeparateNPU cache
 func GetFaultType(faultCodes []int64, logicId int32) string {
+	return GetFaultTypeAt(faultCodes, logicId, time.Now())
+}
+
+// GetFaultTypeAt is GetFaultType with the fault frequency time window ending at now
+func GetFaultTypeAt(faultCodes []int64, logicId int32, now time.Time) string {
 	newFaultCodes := make([]int64, 0)
 	for _, faultCode := range faultCodes {
 		if !NetworkFaultCodes.Has(faultCode) {
@@ -1138,17 +1150,22 @@ func GetFaultType(faultCodes []int64, logicId int32) string {
 
 	faultTypes := make([]string, 0, len(FaultTypeSet))
 	faultTypes = append(faultTypes, GetFaultTypeByCode(newFaultCodes))
-	faultTypes = append(faultTypes, GetFaultTypeFromFaultFrequency(logicId, ChipFaultMode))
+	faultTypes = append(faultTypes, getFaultTypeFromFaultFrequency(logicId, ChipFaultMode, now))
 	faultTypes = append(faultTypes, GetFaultTypeFromFaultDuration(logicId, ChipFaultMode))
 	faultLevelAndTime := GetUpgradeFaultLevelAndTime(logicId, ChipFaultMode)
 	for _, levelAndTime := range faultLevelAndTime {
 		faultTypes = append(faultTypes, levelAndTime.FaultLevel)
 	}
-	return getMostSeriousFaultType(faultTypes)
+	return GetMostSeriousFaultType(faultTypes)
 }
 
 // GetNetworkFaultType will return the fault type from network fault codes, fault duration
 func GetNetworkFaultType(faultCodes []int64, logicId int32) string {
+	return GetNetworkFaultTypeAt(faultCodes, logicId, time.Now())
+}
+
+// GetNetworkFaultTypeAt is GetNetworkFaultType with the fault frequency time window ending at now
+func GetNetworkFaultTypeAt(faultCodes []int64, logicId int32, now time.Time) string {
 	newNetworkFaultCodes := make([]int64, 0)
 	for _, faultCode := range faultCodes {
 		if NetworkFaultCodes.Has(faultCode) {
@@ -1158,13 +1175,13 @@ func GetNetworkFaultType(faultCodes []int64, logicId int32) string {
 
 	faultTypes := make([]string, 0, len(FaultTypeSet))
 	faultTypes = append(faultTypes, GetNetworkFaultTypeByCode(newNetworkFaultCodes))
-	faultTypes = append(faultTypes, GetFaultTypeFromFaultFrequency(logicId, NetworkFaultMode))
+	faultTypes = append(faultTypes, getFaultTypeFromFaultFrequency(logicId, NetworkFaultMode, now))
 	faultTypes = append(faultTypes, GetFaultTypeFromFaultDuration(logicId, NetworkFaultMode))
 	faultLevelAndTime := GetUpgradeFaultLevelAndTime(logicId, NetworkFaultMode)
 	for _, levelAndTime := range faultLevelAndTime {
 		faultTypes = append(faultTypes, levelAndTime.FaultLevel)
 	}
-	return getMostSeriousFaultType(faultTypes)
+	return GetMostSeriousFaultType(faultTypes)
 }
 
 // GetFaultTypeByCode get fault type by fault code. if code not record, default SeparateNPU0
@@ -1199,6 +1216,10 @@ func GetFaultTypeByCode(faultCodes []int64) string {
 // GetFaultTypeFromFaultFrequency refreshes the cache of FaultFrequency, delete the faults those not in time window,
 // and return the fault level if the occurrence times of fault >= the set value
 func GetFaultTypeFromFaultFrequency(logicId int32, mode string) string {
+	return getFaultTypeFromFaultFrequency(logicId, mode, time.Now())
+}
+
+func getFaultTypeFromFaultFrequency(logicId int32, mode string, now time.Time) string {
 	if mode != ChipFaultMode && mode != NetworkFaultMode {
 		return NormalNPU
 	}
@@ -1221,14 +1242,15 @@ func GetFaultTypeFromFaultFrequency(logicId int32, mode string) string {
 		if !ok {
 			continue
 		}
-		faultTypes = append(faultTypes, handleFrequencyFault(logicId, frequencyCache, eventId)...)
+		faultTypes = append(faultTypes, handleFrequencyFault(logicId, frequencyCache, eventId, now)...)
 	}
-	return getMostSeriousFaultType(faultTypes)
+	return GetMostSeriousFaultType(faultTypes)
 }
 
-func handleFrequencyFault(logicId int32, frequencyCache *FaultFrequencyCache, eventId string) []string {
+func handleFrequencyFault(logicId int32, frequencyCache *FaultFrequencyCache, eventId string,
+	now time.Time) []string {
 	faultTypes := make([]string, 0)
-	timeWindowStart := time.Now().Unix() - frequencyCache.TimeWindow
+	timeWindowStart := now.Unix() - frequencyCache.TimeWindow
 	// delete the occurrence times those less than the start of time window
 	index := 0
 	for _, occurrenceTime := range frequencyCache.Frequency[logicId] {
@@ -1258,7 +1280,7 @@ func handleFrequencyFault(logicId int32, frequencyCache *FaultFrequencyCache, ev
 			frequencyCache.FaultHandling, FrequencyUpgradeType)
 	} else {
 		if lastRecoverTime >= lastFaultTime &&
-			time.Now().UnixMilli()-lastRecoverTime > frequencyCache.ReleaseTimeWindow*SecondMagnification {
+			now.UnixMilli()-lastRecoverTime > frequencyCache.ReleaseTimeWindow*SecondMagnification {
 			RemoveTimeoutReasonCache(LogicId(logicId), CodeMatcher(eventId), TypeMatcher(FrequencyUpgradeType))
 		} else {
 			// if fault has in upgrade reason then update the fault time
@@ -1316,7 +1338,7 @@ func GetFaultTypeFromFaultDuration(logicId int32, mode string) string {
 			}
 		}
 	}
-	return getMostSeriousFaultType(faultTypes)
+	return GetMostSeriousFaultType(faultTypes)
 }
 
 func getFaultTypeBySeverity(faultCodes []int64) string {
@@ -1333,7 +1355,8 @@ func getFaultTypeBySeverity(faultCodes []int64) string {
 	return NotHandleFault
 }
 
-func getMostSeriousFaultType(fautTypes []string) string {
+// GetMostSeriousFaultType returns the most serious one of the fault types
+func GetMostSeriousFaultType(fautTypes []string) string {
 	faultTypeSet := sets.NewString(fautTypes...)
 	if faultTypeSet.Has(ManuallySeparateNPU) {
 		return ManuallySeparateNPU
@@ -1404,6 +1427,13 @@ func SetNewFaultAndCacheOnceRecoverFault(logicID int32, chipFaultInfos []common.
 		hwlog.RunLog.Error("param device is nil in SetNewFaultAndCacheOnceRecoverFault")
 		return
 	}
+	runFaultHandlingSteps(GetChipFaultHandlingSteps(logicID, chipFaultInfos, device, getCurFaultCodes, time.Now()))
+}
+
+// GetChipFaultHandlingSteps returns the steps of SetNewFaultAndCacheOnceRecoverFault, the aic faults around a hbm
+// fault are handled at now. device must not be nil
+func GetChipFaultHandlingSteps(logicID int32, chipFaultInfos []common.DevFaultInfo, device *NpuDevice,
+	getCurFaultCodes func(logicID int32) sets.Int64, now time.Time) []FaultHandlingStep {
 	curFaultCodesMap := sets.Int64{}
 	for _, faultInfo := range chipFaultInfos {
 		if faultInfo.Assertion == common.FaultRecover && getCurFaultCodes != nil {
@@ -1413,7 +1443,7 @@ func SetNewFaultAndCacheOnceRecoverFault(logicID int32, chipFaultInfos []common.
 	}
 	newChipFaultInfos := chipFaultInfos
 	if _, ok := faultDurationMap[HbmDoubleBitFaultCodeStr]; ok {
-		newChipFaultInfos = newFaultInfosForHBMErr(logicID, newChipFaultInfos)
+		newChipFaultInfos = newFaultInfosForHBMErr(logicID, newChipFaultInfos, now)
 	}
 	steps := getChipFaultPreSteps(logicID, newChipFaultInfos)
 	if isA950CardType() {
@@ -1421,10 +1451,7 @@ func SetNewFaultAndCacheOnceRecoverFault(logicID int32, chipFaultInfos []common.
 	} else {
 		steps = append(steps, getBaseChipFaultSteps(logicID, newChipFaultInfos, curFaultCodesMap, device)...)
 	}
-	steps = append(steps, getChipFaultPostSteps(device)...)
-	for _, step := range steps {
-		step.Do()
-	}
+	return append(steps, getChipFaultPostSteps(device)...)
 }
 
 // SetNetworkNewFaultAndCacheOnceRecoverFault set new network fault code and cache once recover network fault
@@ -1433,16 +1460,20 @@ func SetNetworkNewFaultAndCacheOnceRecoverFault(logicID int32, networkFaultInfos
 		hwlog.RunLog.Error("param device is nil in SetNetworkNewFaultAndCacheOnceRecoverFault")
 		return
 	}
+	runFaultHandlingSteps(GetParameterPlaneFaultHandlingSteps(logicID, networkFaultInfos, device))
+}
+
+// GetParameterPlaneFaultHandlingSteps returns the steps of SetNetworkNewFaultAndCacheOnceRecoverFault,
+// device must not be nil
+func GetParameterPlaneFaultHandlingSteps(logicID int32, networkFaultInfos []common.DevFaultInfo,
+	device *NpuDevice) []FaultHandlingStep {
 	steps := getParameterPlaneFaultPreSteps(logicID, networkFaultInfos)
 	if isA950CardType() {
 		steps = append(steps, getA950ParameterPlaneFaultSteps(logicID, networkFaultInfos, device)...)
 	} else {
 		steps = append(steps, getBaseParameterPlaneFaultSteps(logicID, networkFaultInfos, device)...)
 	}
-	steps = append(steps, getParameterPlaneFaultPostSteps(device)...)
-	for _, step := range steps {
-		step.Do()
-	}
+	return append(steps, getParameterPlaneFaultPostSteps(device)...)
 }
 
 // SetHyperPlaneNewFaultAndCacheOnceRecoverFault set new hyper plane fault code and cache once recover hyper plane fault
@@ -1451,13 +1482,18 @@ func SetHyperPlaneNewFaultAndCacheOnceRecoverFault(logicID int32, hyperPlaneFaul
 		hwlog.RunLog.Error("param device is nil in SetHyperPlaneNewFaultAndCacheOnceRecoverFault")
 		return
 	}
+	runFaultHandlingSteps(GetHyperPlaneFaultHandlingSteps(logicID, hyperPlaneFaultInfos, device))
+}
+
+// GetHyperPlaneFaultHandlingSteps returns the steps of SetHyperPlaneNewFaultAndCacheOnceRecoverFault,
+// device must not be nil
+func GetHyperPlaneFaultHandlingSteps(logicID int32, hyperPlaneFaultInfos []common.DevFaultInfo,
+	device *NpuDevice) []FaultHandlingStep {
 	steps := getHyperPlaneFaultPreSteps(logicID, hyperPlaneFaultInfos)
 	if isA950CardType() {
 		steps = append(steps, getA950HyperPlaneFaultSteps(logicID, hyperPlaneFaultInfos, device)...)
 	}
-	for _, step := range steps {
-		step.Do()
-	}
+	return steps
 }
 
 // SetHyperPlaneNewOverallFault set new hyper plane overall fault code and cache once recover hyper plane overall fault
@@ -1468,13 +1504,17 @@ func SetHyperPlaneNewOverallFault(devices []*NpuDevice) {
 			return
 		}
 	}
+	runFaultHandlingSteps(GetHyperPlaneOverallFaultHandlingSteps(devices, time.Now()))
+}
+
+// GetHyperPlaneOverallFaultHandlingSteps returns the steps of SetHyperPlaneNewOverallFault, the generated faults
+// are raised at now. devices must not be nil
+func GetHyperPlaneOverallFaultHandlingSteps(devices []*NpuDevice, now time.Time) []FaultHandlingStep {
 	steps := getHyperPlaneOverallFaultPreSteps(devices)
 	if isA950CardType() {
-		steps = append(steps, getA950HyperPlaneNewOverallFaultSteps(devices)...)
-	}
-	for _, step := range steps {
-		step.Do()
+		steps = append(steps, getA950HyperPlaneNewOverallFaultSteps(devices, now)...)
 	}
+	return steps
 }
 
 func baseChipFaultOccur(newFaultInfos []common.DevFaultInfo, device *NpuDevice) {
@@ -1559,7 +1599,7 @@ func updateDeviceFaultTimeMap(device *NpuDevice, faultInfo common.DevFaultInfo,
 	}
 }
 
-func newFaultInfosForHBMErr(logicID int32, faultInfos []common.DevFaultInfo) []common.DevFaultInfo {
+func newFaultInfosForHBMErr(logicID int32, faultInfos []common.DevFaultInfo, now time.Time) []common.DevFaultInfo {
 	var newFaultInfos []common.DevFaultInfo
 	// dealing with Hbm and Aic/Aiv associated faults
 	for i := 0; i < len(faultInfos); i++ {
@@ -1572,7 +1612,7 @@ func newFaultInfosForHBMErr(logicID int32, faultInfos []common.DevFaultInfo) []c
 		}
 		newFaultInfos = append(newFaultInfos, faultInfos[i])
 	}
-	return append(newFaultInfos, hbmTool.aicFaultEventOutQue(logicID)...)
+	return append(newFaultInfos, hbmTool.aicFaultEventOutQue(logicID, now)...)
 }
 
 func baseParameterPlaneFaultRecover(logicID int32, faultInfos []common.DevFaultInfo, device *NpuDevice) {
@@ -1749,7 +1789,7 @@ func a950HyperPlaneFaultOccur(hyperPlaneFaultInfos []common.DevFaultInfo, device
 	}
 }
 
-func a950HyperPlaneNewOverallFaultModify(devices []*NpuDevice) {
+func a950HyperPlaneNewOverallFaultModify(devices []*NpuDevice, now time.Time) {
 	allHaveHyperPlaneFaultCode := true
 	for _, device := range devices {
 		if !Int64Tool.Contains(device.FaultCodes, UBPortDownCode) {
@@ -1758,7 +1798,7 @@ func a950HyperPlaneNewOverallFaultModify(devices []*NpuDevice) {
 	}
 	// if all device has UB port down fault code, then convert status from separate fault code to sub heal fault code
 	if allHaveHyperPlaneFaultCode {
-		curTime := time.Now().Unix()
+		curTime := now.Unix()
 		for _, device := range devices {
 			if Int64Tool.Contains(device.FaultCodes, UBSeparateFaultCode) {
 				device.FaultCodes = Int64Tool.Remove(device.FaultCodes, UBSeparateFaultCode)
@@ -1841,6 +1881,12 @@ func DoSaveDevFaultInfo(devFaultInfo common.DevFaultInfo, enableDelay bool) {
 	defer func() {
 		TriggerUpdate("A fault has occurred")
 	}()
+	CacheDevFaultInfo(devFaultInfo, enableDelay)
+}
+
+// CacheDevFaultInfo saves the dev fault info to the cache handled in the next fault handling cycle, it is not
+// limited by the rate of the subscribe callback
+func CacheDevFaultInfo(devFaultInfo common.DevFaultInfo, enableDelay bool) {
 	hwlog.RunLog.Infof("receive devFaultInfo: %#v, hex code: %v", devFaultInfo,
 		FormatFaultCodeHex(devFaultInfo.EventID))
 	if devFaultInfo.EventID == 0 {
@@ -1979,6 +2025,11 @@ func DeleteManuallyFaultInfo(logicID int32) {
 
 // CountFaultDuration used to calculate each fault duration
 func CountFaultDuration(device *NpuDevice, devFaultInfoMap map[int32][]common.DevFaultInfo) {
+	CountFaultDurationAt(device, devFaultInfoMap, time.Now())
+}
+
+// CountFaultDurationAt is CountFaultDuration with the duration of the last fault event counted until now
+func CountFaultDurationAt(device *NpuDevice, devFaultInfoMap map[int32][]common.DevFaultInfo, now time.Time) {
 	if device == nil {
 		return
 	}
@@ -1997,7 +2048,7 @@ func CountFaultDuration(device *NpuDevice, devFaultInfoMap map[int32][]common.De
 
 		// update the fault code timeout status, fault duration time, fault recover duration time
 		// and clear fault queue cache through timeout judgment and recovery judgment algorithm
-		handleFaultQueue(device.LogicID, eventId)
+		handleFaultQueue(device.LogicID, eventId, now)
 	}
 }
 
@@ -2087,7 +2138,7 @@ func clearFirstEventBasedOnFaultStatus(faultDurationData *FaultDurationData) {
 	}
 }
 
-func handleFaultQueue(logicID int32, eventId string) {
+func handleFaultQueue(logicID int32, eventId string, now time.Time) {
 	if _, ok := faultDurationMap[eventId]; !ok {
 		return
 	}
@@ -2105,7 +2156,7 @@ func handleFaultQueue(logicID int32, eventId string) {
 	exitTag := false
 	for !exitTag {
 		faultDurationData = faultDurationMap[eventId].Duration[logicID]
-		exitTag = timeoutOrRecoveryAlgorithm(logicID, eventId, !faultDurationData.TimeoutStatus)
+		exitTag = timeoutOrRecoveryAlgorithm(logicID, eventId, !faultDurationData.TimeoutStatus, now)
 	}
 	faultDurationData = faultDurationMap[eventId].Duration[logicID]
 	hwlog.RunLog.Debugf("NPU logic id: %v, after timeout or recovery algorithm handling, %v fault timeout "+
@@ -2140,7 +2191,7 @@ func handleFaultQueue(logicID int32, eventId string) {
 	}
 }
 
-func timeoutOrRecoveryAlgorithm(logicID int32, eventId string, timeoutStatus bool) bool {
+func timeoutOrRecoveryAlgorithm(logicID int32, eventId string, timeoutStatus bool, now time.Time) bool {
 	process := getProcessInFaultDuration(timeoutStatus)
 	faultQueueLen := len(faultDurationMap[eventId].Duration[logicID].FaultEventQueue)
 	if faultQueueLen == 0 {
@@ -2170,7 +2221,7 @@ func timeoutOrRecoveryAlgorithm(logicID int32, eventId string, timeoutStatus boo
 	}
 	if i*halfDivisor+1 == faultQueueLen {
 		faultDurationData := faultDurationMap[eventId].Duration[logicID]
-		currentHostTime := time.Now().UnixMilli()
+		currentHostTime := now.UnixMilli()
 		lastAlarmTime := faultDurationData.FaultEventQueue[i*halfDivisor].AlarmRaisedTime
 		duration = currentHostTime - lastAlarmTime
 		if duration <= timeoutThreshold*SecondMagnification {
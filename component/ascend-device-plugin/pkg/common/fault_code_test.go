@@ -1018,7 +1018,7 @@ func TestHandleFaultQueueCase01(t *testing.T) {
 		collectEachFaultEvent(logicID, faultInfos)
 		sortFaultEventsInAscendingOrder(logicID, linkDownFaultCodeStr)
 		cleanFaultQueue(logicID, linkDownFaultCodeStr)
-		handleFaultQueue(logicID, linkDownFaultCodeStr)
+		handleFaultQueue(logicID, linkDownFaultCodeStr, time.Now())
 
 		faultDurationData := faultDurationMap[linkDownFaultCodeStr].Duration[logicID]
 		faultDurationTime := int64(31)
@@ -1057,7 +1057,7 @@ func TestHandleFaultQueueCase02(t *testing.T) {
 		collectEachFaultEvent(logicID, faultInfos)
 		sortFaultEventsInAscendingOrder(logicID, linkDownFaultCodeStr)
 		cleanFaultQueue(logicID, linkDownFaultCodeStr)
-		handleFaultQueue(logicID, linkDownFaultCodeStr)
+		handleFaultQueue(logicID, linkDownFaultCodeStr, time.Now())
 
 		faultDurationData := faultDurationMap[linkDownFaultCodeStr].Duration[logicID]
 		convey.So(faultDurationData.TimeoutStatus, convey.ShouldEqual, false)
@@ -1099,7 +1099,7 @@ func TestHandleFaultQueueCase03(t *testing.T) {
 		collectEachFaultEvent(logicID, faultInfos)
 		sortFaultEventsInAscendingOrder(logicID, linkDownFaultCodeStr)
 		cleanFaultQueue(logicID, linkDownFaultCodeStr)
-		handleFaultQueue(logicID, linkDownFaultCodeStr)
+		handleFaultQueue(logicID, linkDownFaultCodeStr, time.Now())
 
 		alarmRaisedTime30 := int64(30)
 		faultDurationData := faultDurationMap[linkDownFaultCodeStr].Duration[logicID]
@@ -1138,7 +1138,7 @@ func TestHandleFaultQueueCase04(t *testing.T) {
 		collectEachFaultEvent(logicID, faultInfos)
 		sortFaultEventsInAscendingOrder(logicID, linkDownFaultCodeStr)
 		cleanFaultQueue(logicID, linkDownFaultCodeStr)
-		handleFaultQueue(logicID, linkDownFaultCodeStr)
+		handleFaultQueue(logicID, linkDownFaultCodeStr, time.Now())
 
 		alarmRaisedTime60 := int64(60)
 		faultDurationData := faultDurationMap[linkDownFaultCodeStr].Duration[logicID]
@@ -1179,7 +1179,7 @@ func TestHandleFaultQueueCase05(t *testing.T) {
 		collectEachFaultEvent(logicID, faultInfos)
 		sortFaultEventsInAscendingOrder(logicID, linkDownFaultCodeStr)
 		cleanFaultQueue(logicID, linkDownFaultCodeStr)
-		handleFaultQueue(logicID, linkDownFaultCodeStr)
+		handleFaultQueue(logicID, linkDownFaultCodeStr, time.Now())
 
 		AlarmRaisedTime1, AlarmRaisedTime61 := int64(1), int64(61)
 		faultDurationData := faultDurationMap[linkDownFaultCodeStr].Duration[logicID]
@@ -1227,7 +1227,7 @@ func TestHandleFaultQueueCase06(t *testing.T) {
 		collectEachFaultEvent(logicID, faultInfos)
 		sortFaultEventsInAscendingOrder(logicID, linkDownFaultCodeStr)
 		cleanFaultQueue(logicID, linkDownFaultCodeStr)
-		handleFaultQueue(logicID, linkDownFaultCodeStr)
+		handleFaultQueue(logicID, linkDownFaultCodeStr, time.Now())
 
 		faultDurationData := faultDurationMap[linkDownFaultCodeStr].Duration[logicID]
 		AlarmRaisedTime31, AlarmRaisedTime61 := int64(31), int64(61)
@@ -1660,43 +1660,43 @@ func TestLoadFaultDurationCustomizationCase2(t *testing.T) {
 func TestGetMostSeriousFaultType(t *testing.T) {
 	convey.Convey("test getMostSeriousFaultType success case1", t, func() {
 		fautTypes := []string{NotHandleFault, ManuallySeparateNPU}
-		convey.So(getMostSeriousFaultType(fautTypes), convey.ShouldEqual, ManuallySeparateNPU)
+		convey.So(GetMostSeriousFaultType(fautTypes), convey.ShouldEqual, ManuallySeparateNPU)
 	})
 	convey.Convey("test getMostSeriousFaultType success case2", t, func() {
 		fautTypes := []string{NotHandleFault, SeparateNPU}
-		convey.So(getMostSeriousFaultType(fautTypes), convey.ShouldEqual, SeparateNPU)
+		convey.So(GetMostSeriousFaultType(fautTypes), convey.ShouldEqual, SeparateNPU)
 	})
 	convey.Convey("test getMostSeriousFaultType success case3", t, func() {
 		fautTypes := []string{NotHandleFault, PreSeparateNPU}
-		convey.So(getMostSeriousFaultType(fautTypes), convey.ShouldEqual, PreSeparateNPU)
+		convey.So(GetMostSeriousFaultType(fautTypes), convey.ShouldEqual, PreSeparateNPU)
 	})
 	convey.Convey("test getMostSeriousFaultType success case4", t, func() {
 		fautTypes := []string{NotHandleFault, RestartNPU}
-		convey.So(getMostSeriousFaultType(fautTypes), convey.ShouldEqual, RestartNPU)
+		convey.So(GetMostSeriousFaultType(fautTypes), convey.ShouldEqual, RestartNPU)
 	})
 	convey.Convey("test getMostSeriousFaultType success case5", t, func() {
 		fautTypes := []string{NotHandleFault, FreeRestartNPU}
-		convey.So(getMostSeriousFaultType(fautTypes), convey.ShouldEqual, FreeRestartNPU)
+		convey.So(GetMostSeriousFaultType(fautTypes), convey.ShouldEqual, FreeRestartNPU)
 	})
 	convey.Convey("test getMostSeriousFaultType success case6", t, func() {
 		fautTypes := []string{NotHandleFault, RestartBusiness}
-		convey.So(getMostSeriousFaultType(fautTypes), convey.ShouldEqual, RestartBusiness)
+		convey.So(GetMostSeriousFaultType(fautTypes), convey.ShouldEqual, RestartBusiness)
 	})
 	convey.Convey("test getMostSeriousFaultType success case7", t, func() {
 		fautTypes := []string{NotHandleFault, RestartRequest}
-		convey.So(getMostSeriousFaultType(fautTypes), convey.ShouldEqual, RestartRequest)
+		convey.So(GetMostSeriousFaultType(fautTypes), convey.ShouldEqual, RestartRequest)
 	})
 	convey.Convey("test getMostSeriousFaultType success case8", t, func() {
 		fautTypes := []string{NotHandleFault, SubHealthFault}
-		convey.So(getMostSeriousFaultType(fautTypes), convey.ShouldEqual, SubHealthFault)
+		convey.So(GetMostSeriousFaultType(fautTypes), convey.ShouldEqual, SubHealthFault)
 	})
 	convey.Convey("test getMostSeriousFaultType success case9", t, func() {
 		fautTypes := []string{NotHandleFault, NotHandleFault}
-		convey.So(getMostSeriousFaultType(fautTypes), convey.ShouldEqual, NotHandleFault)
+		convey.So(GetMostSeriousFaultType(fautTypes), convey.ShouldEqual, NotHandleFault)
 	})
 	convey.Convey("test getMostSeriousFaultType success case10", t, func() {
 		fautTypes := make([]string, 0)
-		convey.So(getMostSeriousFaultType(fautTypes), convey.ShouldEqual, NormalNPU)
+		convey.So(GetMostSeriousFaultType(fautTypes), convey.ShouldEqual, NormalNPU)
 	})
 }
 
@@ -2227,7 +2227,7 @@ func TestHbmFaultManager(t *testing.T) {
 			AlarmRaisedTime: 100000000,
 		}
 		hbmFaultManager.aicFaultEventInQue(faultInfo)
-		faultInfoList := hbmFaultManager.aicFaultEventOutQue(1)
+		faultInfoList := hbmFaultManager.aicFaultEventOutQue(1, time.Now())
 		convey.So(len(faultInfoList), convey.ShouldEqual, 1)
 		convey.So(faultInfoList[0].AlarmRaisedTime, convey.ShouldEqual, 100000000)
 		convey.So(faultInfoList[0].EventID, convey.ShouldEqual, AicBusFaultCode)
@@ -2240,7 +2240,7 @@ func TestHbmFaultManager(t *testing.T) {
 			AlarmRaisedTime: 300,
 		}
 		hbmFaultManager.aicFaultEventInQue(faultInfo)
-		faultInfoList := hbmFaultManager.aicFaultEventOutQue(1)
+		faultInfoList := hbmFaultManager.aicFaultEventOutQue(1, time.Now())
 		convey.So(len(faultInfoList), convey.ShouldEqual, 0)
 	})
 }
@@ -2294,7 +2294,7 @@ func TestNewFaultInfosForHBMErr(t *testing.T) {
 				Assertion:       common.FaultOccur,
 			},
 		}
-		newFaultInfos := newFaultInfosForHBMErr(1, faultInfo)
+		newFaultInfos := newFaultInfosForHBMErr(1, faultInfo, time.Now())
 		convey.So(len(newFaultInfos), convey.ShouldEqual, 1)
 		convey.So(newFaultInfos[0], convey.ShouldResemble, faultInfo[1])
 	})
@@ -178,6 +178,8 @@ type Option struct {
 	SoftShareDevConfigDir string   // soft share device config dir
 	UseSingleDieMode      bool     // use single die mode
 	GetPodFromKubelet     bool     // get pod information from kubelet instead of apiserver
+	UseCDI                bool     // inject devices by cdi instead of ascend-docker-runtime
+	CDISpecDir            string   // dir of the cdi spec files
 }
 
 // GetAllDeviceInfoTypeList Get All Device Info Type List
@@ -658,6 +658,7 @@ func (tool *AscendTools) getDevStatesDevSet(classifyDevs map[string][]*common.Np
 		if !common.ParamOption.PresetVDevice {
 			freeDevs = freeDevs.Difference(podUsedDev)
 		}
+		freeDevs = freeDevs.Difference(drainingDevices(classifyDev))
 		totalFreeDevices[devType] = freeDevs
 		totalUHDevices = totalUHDevices.Union(partDevStatusSet.UnHealthyDevice)
 		totalNetUHDevices = totalNetUHDevices.Union(partDevStatusSet.NetUnHealthyDevice)
@@ -1046,6 +1047,8 @@ func (tool *AscendTools) UpdateHealth(groupDevice map[string][]*common.NpuDevice
 	tool.writeNewFaultCode(groupDevice, runMode)
 
 	setHealthyIfDuoCard(groupDevice)
+	tool.applyNPUCordon(groupDevice)
+	setDuplicateMountUnhealthy(groupDevice)
 	setAICoreHealthyIfVNpu(groupDevice, aiCoreDevs)
 }
 
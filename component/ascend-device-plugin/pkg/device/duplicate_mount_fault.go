@@ -0,0 +1,64 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package device a series of device function
+package device
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kubelet/pkg/apis/deviceplugin/v1beta1"
+
+	"Ascend-device-plugin/pkg/common"
+	"ascend-common/common-utils/hwlog"
+)
+
+var (
+	// physical ids of the npus mounted by multiple containers, marked fault by the duplicate detector
+	duplicateMountFaults = sets.NewInt32()
+	duplicateMountLock   sync.RWMutex
+)
+
+// SetDuplicateMountFault marks or clears the duplicate mount fault of the npu
+func SetDuplicateMountFault(phyID int32, fault bool) {
+	duplicateMountLock.Lock()
+	changed := duplicateMountFaults.Has(phyID) != fault
+	if fault {
+		duplicateMountFaults.Insert(phyID)
+	} else {
+		duplicateMountFaults.Delete(phyID)
+	}
+	duplicateMountLock.Unlock()
+	if changed {
+		hwlog.RunLog.Infof("duplicate mount fault of npu %d is set to %v", phyID, fault)
+		common.TriggerUpdate("duplicate mount fault changed")
+	}
+}
+
+// setDuplicateMountUnhealthy sets the npus mounted by multiple containers unhealthy
+func setDuplicateMountUnhealthy(groupDevice map[string][]*common.NpuDevice) {
+	duplicateMountLock.RLock()
+	defer duplicateMountLock.RUnlock()
+	if duplicateMountFaults.Len() == 0 {
+		return
+	}
+	for _, devices := range groupDevice {
+		for _, device := range devices {
+			if duplicateMountFaults.Has(device.PhyID) {
+				device.Health = v1beta1.Unhealthy
+			}
+		}
+	}
+}
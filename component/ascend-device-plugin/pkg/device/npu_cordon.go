@@ -0,0 +1,321 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package device a series of device function
+package device
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/kubelet/pkg/apis/deviceplugin/v1beta1"
+
+	"Ascend-device-plugin/pkg/common"
+	"Ascend-device-plugin/pkg/kubeclient"
+	"ascend-common/common-utils/hwlog"
+)
+
+const (
+	maxCordonRequests  = 1024
+	maxCordonTextLen   = 256
+	emptyCordonStatus  = "[]"
+	cordonUpdateReason = "npu cordon requests changed"
+)
+
+// CordonRequest the request of the administrator taking an npu out of service, written in the node annotation
+// huawei.com/npu-cordon as a json list, the npu is uncordoned when the request is removed
+type CordonRequest struct {
+	PhyID int32 `json:"phyID"`
+	// Drain waits for the pods using the npu to finish before cordoning it. the npu being drained keeps healthy to
+	// kubelet, so that the running pod is not taken as using a fault npu. it is left out of the available devices and
+	// the preferred allocation, but kubelet may still allocate it to a new pod when the preferred allocation is not
+	// used, or the pod requests more npus than the others left, such as a pod requesting all healthy npus of the node.
+	// cordon without drain takes the npu out of allocation at once
+	Drain    bool   `json:"drain,omitempty"`
+	Operator string `json:"operator,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// CordonStatus the cordon status of an npu, written in the node annotation huawei.com/npu-cordon-status
+type CordonStatus struct {
+	CordonRequest
+	DeviceName string `json:"deviceName"`
+	State      string `json:"state"`
+	// Since the unix time the npu entered the state
+	Since int64 `json:"since"`
+}
+
+// CordonMgr mgr for the npu cordon requests of the administrator
+type CordonMgr struct {
+	client     *kubeclient.ClientK8s
+	requests   map[int32]CordonRequest
+	status     map[int32]CordonStatus
+	lastStatus string
+	seeded     bool
+	mu         sync.Mutex
+}
+
+var (
+	cordonMgr  = newCordonMgr(nil)
+	cordonOnce sync.Once
+)
+
+func newCordonMgr(client *kubeclient.ClientK8s) *CordonMgr {
+	return &CordonMgr{
+		client:     client,
+		requests:   make(map[int32]CordonRequest),
+		status:     make(map[int32]CordonStatus),
+		lastStatus: emptyCordonStatus,
+	}
+}
+
+// InitCordonMgr watches the cordon requests in the annotation of the current node
+func InitCordonMgr(ctx context.Context, client *kubeclient.ClientK8s) {
+	if client == nil {
+		hwlog.RunLog.Warn("client is nil, npu cordon is not supported")
+		return
+	}
+	cordonOnce.Do(func() {
+		cordonMgr = newCordonMgr(client)
+		factory := informers.NewSharedInformerFactoryWithOptions(client.Clientset, 0,
+			informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+				options.FieldSelector = "metadata.name=" + client.NodeName
+			}))
+		nodeInformer := factory.Core().V1().Nodes().Informer()
+		nodeInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: cordonMgr.handleNode,
+			UpdateFunc: func(_, newObj interface{}) {
+				cordonMgr.handleNode(newObj)
+			},
+		})
+		factory.Start(ctx.Done())
+	})
+}
+
+func (cm *CordonMgr) handleNode(obj interface{}) {
+	node, ok := obj.(*v1.Node)
+	if !ok {
+		return
+	}
+	requests, err := parseCordonRequests(node.Annotations[common.NPUCordonAnnotationKey])
+	if err != nil {
+		hwlog.RunLog.Errorf("invalid npu cordon annotation, requests are not changed, err: %v", err)
+		return
+	}
+	cm.mu.Lock()
+	if !cm.seeded {
+		cm.seedStatus(node.Annotations[common.NPUCordonStatusAnnotationKey])
+	}
+	changed := !cordonRequestsEqual(cm.requests, requests)
+	cm.requests = requests
+	cm.mu.Unlock()
+	if changed {
+		hwlog.RunLog.Infof("npu cordon requests changed: %v", node.Annotations[common.NPUCordonAnnotationKey])
+		common.TriggerUpdate(cordonUpdateReason)
+	}
+}
+
+// seedStatus loads the status written before restart, so the drained npu keeps cordoned and the time is kept
+func (cm *CordonMgr) seedStatus(value string) {
+	cm.seeded = true
+	if value == "" {
+		return
+	}
+	var statusList []CordonStatus
+	if err := json.Unmarshal([]byte(value), &statusList); err != nil {
+		hwlog.RunLog.Warnf("unmarshal npu cordon status failed, err: %v", err)
+		return
+	}
+	for _, status := range statusList {
+		cm.status[status.PhyID] = status
+	}
+	cm.lastStatus = value
+}
+
+func parseCordonRequests(value string) (map[int32]CordonRequest, error) {
+	requests := make(map[int32]CordonRequest)
+	if value == "" {
+		return requests, nil
+	}
+	var requestList []CordonRequest
+	if err := json.Unmarshal([]byte(value), &requestList); err != nil {
+		return nil, fmt.Errorf("unmarshal cordon requests failed, err: %v", err)
+	}
+	if len(requestList) > maxCordonRequests {
+		return nil, fmt.Errorf("number of cordon requests %d exceeds %d", len(requestList), maxCordonRequests)
+	}
+	for _, request := range requestList {
+		if request.PhyID < 0 {
+			return nil, fmt.Errorf("invalid phyID %d", request.PhyID)
+		}
+		if len(request.Operator) > maxCordonTextLen || len(request.Reason) > maxCordonTextLen {
+			return nil, fmt.Errorf("operator or reason of npu %d is longer than %d", request.PhyID,
+				maxCordonTextLen)
+		}
+		if _, ok := requests[request.PhyID]; ok {
+			hwlog.RunLog.Warnf("duplicated cordon request of npu %d, the last one is used", request.PhyID)
+		}
+		requests[request.PhyID] = request
+	}
+	return requests, nil
+}
+
+func cordonRequestsEqual(a, b map[int32]CordonRequest) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for phyID, request := range a {
+		if other, ok := b[phyID]; !ok || other != request {
+			return false
+		}
+	}
+	return true
+}
+
+// applyNPUCordon sets the cordoned npus unhealthy, the npus being drained keep healthy until the pods using them
+// finish, but they are left out of the available devices and the preferred allocation, so no new pod takes them
+// unless kubelet allocates without the preferred allocation, see CordonRequest.Drain
+func (tool *AscendTools) applyNPUCordon(groupDevice map[string][]*common.NpuDevice) {
+	var realUsed sets.String
+	if tool.client != nil {
+		realUsed = tool.getRealUsedDevices()
+	}
+	isUsed := func(device *common.NpuDevice) bool {
+		return device.PodUsed || realUsed.Has(device.DeviceName)
+	}
+	if status, changed := cordonMgr.apply(groupDevice, isUsed); changed {
+		cordonMgr.writeStatus(status)
+	}
+}
+
+// apply updates the cordon status by the devices, and returns the status when it is changed
+func (cm *CordonMgr) apply(groupDevice map[string][]*common.NpuDevice,
+	isUsed func(*common.NpuDevice) bool) (string, bool) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+	usedPhyIDs := sets.NewInt32()
+	physical := make(map[int32]*common.NpuDevice)
+	for _, devices := range groupDevice {
+		for _, device := range devices {
+			if isUsed(device) {
+				usedPhyIDs.Insert(device.PhyID)
+			}
+			if !common.IsVirtualDev(device.DeviceName) {
+				physical[device.PhyID] = device
+			}
+		}
+	}
+	newStatus := make(map[int32]CordonStatus, len(cm.requests))
+	for phyID, request := range cm.requests {
+		device, ok := physical[phyID]
+		if !ok {
+			hwlog.RunLog.Warnf("npu %d of cordon request is not found", phyID)
+			continue
+		}
+		old := cm.status[phyID]
+		state := common.NPUCordonStateCordoned
+		if request.Drain && old.State != common.NPUCordonStateCordoned && usedPhyIDs.Has(phyID) {
+			state = common.NPUCordonStateDraining
+		}
+		status := CordonStatus{CordonRequest: request, DeviceName: device.DeviceName, State: state, Since: old.Since}
+		if old.State != state || old.CordonRequest != request {
+			status.Since = time.Now().Unix()
+			hwlog.RunLog.Infof("npu %s is %s by %s, reason: %s", device.DeviceName, state, request.Operator,
+				request.Reason)
+		}
+		newStatus[phyID] = status
+	}
+	for phyID, old := range cm.status {
+		if _, ok := newStatus[phyID]; !ok {
+			hwlog.RunLog.Infof("npu %s is uncordoned, it was %s by %s", old.DeviceName, old.State, old.Operator)
+		}
+	}
+	cm.status = newStatus
+	setCordonedUnhealthy(groupDevice, newStatus)
+	statusStr := cordonStatusString(newStatus)
+	if statusStr == cm.lastStatus {
+		return statusStr, false
+	}
+	cm.lastStatus = statusStr
+	return statusStr, true
+}
+
+// IsNPUDraining whether the npu is being drained, it keeps healthy for the pod using it and is not preferred for
+// new pods
+func IsNPUDraining(phyID int32) bool {
+	cordonMgr.mu.Lock()
+	defer cordonMgr.mu.Unlock()
+	return cordonMgr.status[phyID].State == common.NPUCordonStateDraining
+}
+
+// drainingDevices names of the devices of the npus being drained, they are left out of the available devices
+func drainingDevices(devices []*common.NpuDevice) sets.String {
+	cordonMgr.mu.Lock()
+	defer cordonMgr.mu.Unlock()
+	draining := sets.NewString()
+	for _, device := range devices {
+		if cordonMgr.status[device.PhyID].State == common.NPUCordonStateDraining {
+			draining.Insert(device.DeviceName)
+		}
+	}
+	return draining
+}
+
+func setCordonedUnhealthy(groupDevice map[string][]*common.NpuDevice, status map[int32]CordonStatus) {
+	for _, devices := range groupDevice {
+		for _, device := range devices {
+			if status[device.PhyID].State == common.NPUCordonStateCordoned {
+				device.Health = v1beta1.Unhealthy
+			}
+		}
+	}
+}
+
+func cordonStatusString(status map[int32]CordonStatus) string {
+	statusList := make([]CordonStatus, 0, len(status))
+	for _, s := range status {
+		statusList = append(statusList, s)
+	}
+	sort.Slice(statusList, func(i, j int) bool {
+		return statusList[i].PhyID < statusList[j].PhyID
+	})
+	data, err := json.Marshal(statusList)
+	if err != nil {
+		hwlog.RunLog.Errorf("marshal npu cordon status failed, err: %v", err)
+		return emptyCordonStatus
+	}
+	return string(data)
+}
+
+func (cm *CordonMgr) writeStatus(status string) {
+	if cm.client == nil {
+		return
+	}
+	if err := cm.client.MergeNodeAnnotation(common.NPUCordonStatusAnnotationKey, status); err != nil {
+		hwlog.RunLog.Errorf("write npu cordon status to node annotation failed, err: %v", err)
+		// write again in the next cycle
+		cm.mu.Lock()
+		cm.lastStatus = ""
+		cm.mu.Unlock()
+	}
+}
@@ -0,0 +1,119 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package device test for npu cordon
+package device
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/smartystreets/goconvey/convey"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kubelet/pkg/apis/deviceplugin/v1beta1"
+
+	"Ascend-device-plugin/pkg/common"
+	"ascend-common/api"
+)
+
+func TestParseCordonRequests(t *testing.T) {
+	convey.Convey("test parseCordonRequests", t, func() {
+		requests, err := parseCordonRequests("")
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(requests), convey.ShouldEqual, 0)
+		requests, err = parseCordonRequests(`[{"phyID": 1, "drain": true, "operator": "admin", "reason": "ecc"}]`)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(requests[1], convey.ShouldResemble,
+			CordonRequest{PhyID: 1, Drain: true, Operator: "admin", Reason: "ecc"})
+		_, err = parseCordonRequests(`[{"phyID": -1}]`)
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = parseCordonRequests(`{"phyID": 1}`)
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+}
+
+func newCordonTestGroupDevice() map[string][]*common.NpuDevice {
+	return map[string][]*common.NpuDevice{
+		api.Ascend910: {
+			{DeviceName: "Ascend910-0", PhyID: 0, Health: v1beta1.Healthy},
+			{DeviceName: "Ascend910-1", PhyID: 1, Health: v1beta1.Healthy},
+		},
+	}
+}
+
+func TestCordonMgrApply(t *testing.T) {
+	convey.Convey("test CordonMgr apply", t, func() {
+		cm := newCordonMgr(nil)
+		cm.handleNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+			common.NPUCordonAnnotationKey: `[{"phyID": 0}, {"phyID": 1, "drain": true}]`}}})
+		used := true
+		isUsed := func(device *common.NpuDevice) bool { return used && device.PhyID == 1 }
+
+		convey.Convey("npu in use is draining, and cordoned after the pod finishes", func() {
+			groupDevice := newCordonTestGroupDevice()
+			_, changed := cm.apply(groupDevice, isUsed)
+			convey.So(changed, convey.ShouldBeTrue)
+			convey.So(groupDevice[api.Ascend910][0].Health, convey.ShouldEqual, v1beta1.Unhealthy)
+			convey.So(groupDevice[api.Ascend910][1].Health, convey.ShouldEqual, v1beta1.Healthy)
+			convey.So(cm.status[1].State, convey.ShouldEqual, common.NPUCordonStateDraining)
+
+			used = false
+			groupDevice = newCordonTestGroupDevice()
+			_, changed = cm.apply(groupDevice, isUsed)
+			convey.So(changed, convey.ShouldBeTrue)
+			convey.So(groupDevice[api.Ascend910][1].Health, convey.ShouldEqual, v1beta1.Unhealthy)
+
+			// a cordoned npu is not released by a new pod using it
+			used = true
+			_, changed = cm.apply(newCordonTestGroupDevice(), isUsed)
+			convey.So(changed, convey.ShouldBeFalse)
+			convey.So(cm.status[1].State, convey.ShouldEqual, common.NPUCordonStateCordoned)
+		})
+		convey.Convey("npu is uncordoned when the request is removed", func() {
+			cm.apply(newCordonTestGroupDevice(), isUsed)
+			cm.handleNode(&v1.Node{})
+			groupDevice := newCordonTestGroupDevice()
+			status, changed := cm.apply(groupDevice, isUsed)
+			convey.So(changed, convey.ShouldBeTrue)
+			convey.So(status, convey.ShouldEqual, emptyCordonStatus)
+			convey.So(groupDevice[api.Ascend910][0].Health, convey.ShouldEqual, v1beta1.Healthy)
+		})
+	})
+}
+
+func TestDrainingNPUNotAvailable(t *testing.T) {
+	convey.Convey("test npu being drained is left out of the available devices", t, func() {
+		cm := newCordonMgr(nil)
+		cm.handleNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+			common.NPUCordonAnnotationKey: `[{"phyID": 1, "drain": true}]`}}})
+		cm.apply(newCordonTestGroupDevice(), func(device *common.NpuDevice) bool { return device.PhyID == 1 })
+		tool := mockAscendTools()
+		patches := gomonkey.ApplyGlobalVar(&cordonMgr, cm).
+			ApplyPrivateMethod(reflect.TypeOf(&tool), "getRealUsedDevices",
+				func(_ *AscendTools) sets.String { return sets.String{} })
+		defer patches.Reset()
+		convey.So(IsNPUDraining(1), convey.ShouldBeTrue)
+		convey.So(IsNPUDraining(0), convey.ShouldBeFalse)
+
+		// the npu keeps healthy for the running pod, but a new pod can not take it
+		groupDevice := newCordonTestGroupDevice()
+		res := tool.getDevStatesDevSet(groupDevice, 0)
+		convey.So(groupDevice[api.Ascend910][1].Health, convey.ShouldEqual, v1beta1.Healthy)
+		convey.So(res.FreeHealthyDevice[api.Ascend910].Has("Ascend910-0"), convey.ShouldBeTrue)
+		convey.So(res.FreeHealthyDevice[api.Ascend910].Has("Ascend910-1"), convey.ShouldBeFalse)
+	})
+}
@@ -59,6 +59,9 @@ func NewNumaNodeManager(dmgr devmanager.DeviceInterface) *NumaNodeManager {
 
 // GetNumaNodesByPhyID returns cached numa nodes for the given phyID.
 func GetNumaNodesByPhyID(phyID int32) []int64 {
+	if numaMgr == nil {
+		return nil
+	}
 	return numaMgr.getNumaNodesByPhyID(phyID)
 }
 
@@ -114,11 +114,25 @@ func (cc *ContainerCache) RemoveContainer(containerID string) {
 	}
 }
 
+// ContainerCount returns the number of containers mounting the device
+func (cc *ContainerCache) ContainerCount(deviceID int) int {
+	cc.mutex.RLock()
+	defer cc.mutex.RUnlock()
+	return len(cc.deviceMap[deviceID])
+}
+
+// FindDuplicates finds duplicate mounts of the containers in cache
+func (cc *ContainerCache) FindDuplicates() []*types.DuplicateMountInfo {
+	cc.mutex.RLock()
+	defer cc.mutex.RUnlock()
+	return cc.findDuplicates()
+}
+
 // findDuplicates finds duplicate mounts
 func (cc *ContainerCache) findDuplicates() []*types.DuplicateMountInfo {
 	var duplicates []*types.DuplicateMountInfo
 	for deviceID, containerIDs := range cc.deviceMap {
-		hwlog.RunLog.Infof("checking device %d, containers: %d", deviceID, len(containerIDs))
+		hwlog.RunLog.Debugf("checking device %d, containers: %d", deviceID, len(containerIDs))
 		if len(containerIDs) == 1 {
 			continue
 		}
@@ -104,6 +104,16 @@ func TestStoreSingleAndFindDuplicates_WithDuplicates(t *testing.T) {
 	}
 }
 
+func TestFindDuplicates(t *testing.T) {
+	cache := NewContainerCache()
+	cache.StoreSingleAndFindDuplicates(&types.ContainerNPUInfo{ID: "container1", Devices: []int{0, 1}})
+	cache.StoreSingleAndFindDuplicates(&types.ContainerNPUInfo{ID: "container2", Devices: []int{0}})
+	duplicates := cache.FindDuplicates()
+	if len(duplicates) != 1 || len(duplicates[0].Containers) != 2 {
+		t.Errorf("expected device 0 mounted by 2 containers, got %v", duplicates)
+	}
+}
+
 func TestStoreSingleAndFindDuplicates_MultipleDevices(t *testing.T) {
 	cache := NewContainerCache()
 	info1 := &types.ContainerNPUInfo{ID: "container1", Devices: []int{0, 1}}
@@ -0,0 +1,175 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package duplicatedetector
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"Ascend-device-plugin/pkg/duplicatedetector/types"
+	"ascend-common/common-utils/hwlog"
+)
+
+// enforceState holds the actions already taken under the enforce policy, so they are not repeated by the
+// detections and the periodic recheck
+type enforceState struct {
+	enforcer types.Enforcer
+	dryRun   bool
+	evict    bool
+	// device ID -> container IDs of the events recorded
+	reported map[int]map[string]struct{}
+	// container IDs of the pods evicted
+	evicted map[string]struct{}
+	// device IDs marked fault
+	faultDevices map[int]struct{}
+	mutex        sync.Mutex
+}
+
+// newEnforceState returns nil under the log policy
+func newEnforceState(config *types.DetectorConfig) (*enforceState, error) {
+	switch config.Policy {
+	case "", types.PolicyLog:
+		return nil, nil
+	case types.PolicyEnforce:
+	default:
+		return nil, fmt.Errorf("duplicate mount policy %s is not supported", config.Policy)
+	}
+	if config.Enforcer == nil {
+		return nil, errors.New("enforcer is nil under the enforce policy")
+	}
+	return &enforceState{
+		enforcer:     config.Enforcer,
+		dryRun:       config.DryRun,
+		evict:        config.Evict,
+		reported:     make(map[int]map[string]struct{}),
+		evicted:      make(map[string]struct{}),
+		faultDevices: make(map[int]struct{}),
+	}, nil
+}
+
+// enforceDuplicate records events on the pods, marks the device fault and evicts the pods not allocated the device
+func (m *Manager) enforceDuplicate(dup *types.DuplicateMountInfo) {
+	es := m.enforce
+	if es == nil || dup == nil {
+		return
+	}
+	es.mutex.Lock()
+	defer es.mutex.Unlock()
+	if _, ok := es.faultDevices[dup.DeviceID]; !ok {
+		es.faultDevices[dup.DeviceID] = struct{}{}
+		es.do(fmt.Sprintf("mark device %d fault", dup.DeviceID), func() error {
+			es.enforcer.SetDeviceFault(dup.DeviceID, true)
+			return nil
+		})
+	}
+	message := fmt.Sprintf("NPU device /dev/davinci%d is mounted by %d containers", dup.DeviceID,
+		len(dup.Containers))
+	if es.reported[dup.DeviceID] == nil {
+		es.reported[dup.DeviceID] = make(map[string]struct{})
+	}
+	for _, c := range dup.Containers {
+		if c.PodName == "" {
+			continue
+		}
+		if _, ok := es.reported[dup.DeviceID][c.ID]; ok {
+			continue
+		}
+		// the event failed is recorded again by the periodic recheck, dry run logs it only once
+		if es.do(fmt.Sprintf("record event on pod %s/%s", c.PodNS, c.PodName), func() error {
+			return es.enforcer.RecordEvent(c, dup.DeviceID, message)
+		}) || es.dryRun {
+			es.reported[dup.DeviceID][c.ID] = struct{}{}
+		}
+	}
+	if es.evict {
+		es.evictUnallocated(dup)
+	}
+}
+
+// evictUnallocated evicts the pods not allocated the device by kubelet, only when the owner of the device is found
+func (es *enforceState) evictUnallocated(dup *types.DuplicateMountInfo) {
+	var unallocated []*types.ContainerNPUInfo
+	hasOwner := false
+	for _, c := range dup.Containers {
+		if c.PodName == "" {
+			hwlog.RunLog.Warnf("container %s is not managed by kubernetes, skip evicting it", c.Name)
+			continue
+		}
+		allocated, err := es.enforcer.IsAllocated(c, dup.DeviceID)
+		if err != nil {
+			hwlog.RunLog.Errorf("check allocation of pod %s/%s failed, skip evicting: %v", c.PodNS, c.PodName, err)
+			return
+		}
+		if allocated {
+			hasOwner = true
+			continue
+		}
+		unallocated = append(unallocated, c)
+	}
+	if !hasOwner {
+		hwlog.RunLog.Warnf("no pod is allocated device %d by kubelet, skip evicting", dup.DeviceID)
+		return
+	}
+	for _, c := range unallocated {
+		if _, ok := es.evicted[c.ID]; ok {
+			continue
+		}
+		// the eviction failed is retried by the periodic recheck
+		if es.do(fmt.Sprintf("evict pod %s/%s not allocated device %d", c.PodNS, c.PodName, dup.DeviceID),
+			func() error {
+				return es.enforcer.EvictPod(c)
+			}) {
+			es.evicted[c.ID] = struct{}{}
+		}
+	}
+}
+
+// do executes the action, returns whether it is executed successfully, it is never executed in dry run
+func (es *enforceState) do(action string, fn func() error) bool {
+	if es.dryRun {
+		hwlog.RunLog.Infof("[dry-run] duplicate mount enforcement would %s", action)
+		return false
+	}
+	if err := fn(); err != nil {
+		hwlog.RunLog.Errorf("duplicate mount enforcement failed to %s: %v", action, err)
+		return false
+	}
+	hwlog.RunLog.Infof("duplicate mount enforcement did %s", action)
+	return true
+}
+
+// releaseContainer forgets the removed container and clears the fault of the devices no longer mounted repeatedly
+func (m *Manager) releaseContainer(containerID string) {
+	es := m.enforce
+	if es == nil {
+		return
+	}
+	es.mutex.Lock()
+	defer es.mutex.Unlock()
+	delete(es.evicted, containerID)
+	for deviceID := range es.faultDevices {
+		delete(es.reported[deviceID], containerID)
+		if m.cache.ContainerCount(deviceID) > 1 {
+			continue
+		}
+		delete(es.faultDevices, deviceID)
+		delete(es.reported, deviceID)
+		es.do(fmt.Sprintf("clear fault of device %d", deviceID), func() error {
+			es.enforcer.SetDeviceFault(deviceID, false)
+			return nil
+		})
+	}
+}
@@ -0,0 +1,170 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package duplicatedetector
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"Ascend-device-plugin/pkg/duplicatedetector/cache"
+	"Ascend-device-plugin/pkg/duplicatedetector/types"
+)
+
+type mockEnforcer struct {
+	events    []string
+	faults    map[int]bool
+	allocated map[string]bool
+	evicted   []string
+	evictErr  error
+	eventErr  error
+}
+
+func (m *mockEnforcer) RecordEvent(container *types.ContainerNPUInfo, deviceID int, message string) error {
+	if m.eventErr != nil {
+		return m.eventErr
+	}
+	m.events = append(m.events, container.PodName)
+	return nil
+}
+
+func (m *mockEnforcer) SetDeviceFault(deviceID int, fault bool) {
+	m.faults[deviceID] = fault
+}
+
+func (m *mockEnforcer) IsAllocated(container *types.ContainerNPUInfo, deviceID int) (bool, error) {
+	return m.allocated[container.PodName], nil
+}
+
+func (m *mockEnforcer) EvictPod(container *types.ContainerNPUInfo) error {
+	if m.evictErr != nil {
+		return m.evictErr
+	}
+	m.evicted = append(m.evicted, container.PodName)
+	return nil
+}
+
+func newEnforceTestManager(t *testing.T, enforcer *mockEnforcer, dryRun bool) *Manager {
+	enforce, err := newEnforceState(&types.DetectorConfig{Policy: types.PolicyEnforce, Evict: true,
+		DryRun: dryRun, Enforcer: enforcer})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return &Manager{
+		client: &mockClient{containers: map[string]*types.ContainerNPUInfo{
+			"owner":    {ID: "owner", PodName: "pod-owner", PodNS: "default", Devices: []int{1}},
+			"intruder": {ID: "intruder", PodName: "pod-intruder", PodNS: "default", Devices: []int{1}},
+		}},
+		cache:   cache.NewContainerCache(),
+		enforce: enforce,
+	}
+}
+
+func TestNewEnforceState(t *testing.T) {
+	if es, err := newEnforceState(&types.DetectorConfig{}); err != nil || es != nil {
+		t.Errorf("expected no enforcement under the log policy")
+	}
+	if _, err := newEnforceState(&types.DetectorConfig{Policy: types.PolicyEnforce}); err == nil {
+		t.Errorf("expected error for nil enforcer")
+	}
+	if _, err := newEnforceState(&types.DetectorConfig{Policy: "kill"}); err == nil {
+		t.Errorf("expected error for unknown policy")
+	}
+}
+
+func TestManager_EnforceDuplicate(t *testing.T) {
+	enforcer := &mockEnforcer{faults: make(map[int]bool), allocated: map[string]bool{"pod-owner": true}}
+	manager := newEnforceTestManager(t, enforcer, false)
+	ctx := context.Background()
+	for _, id := range []string{"owner", "intruder"} {
+		if err := manager.HandleNewContainer(ctx, id, "k8s.io"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if !enforcer.faults[1] {
+		t.Errorf("device 1 should be marked fault")
+	}
+	if len(enforcer.events) != len(manager.client.(*mockClient).containers) {
+		t.Errorf("expected events on both pods, got %v", enforcer.events)
+	}
+	if len(enforcer.evicted) != 1 || enforcer.evicted[0] != "pod-intruder" {
+		t.Errorf("expected only the pod not allocated the device evicted, got %v", enforcer.evicted)
+	}
+
+	manager.HandleContainerRemoval("intruder")
+	if enforcer.faults[1] {
+		t.Errorf("fault of device 1 should be cleared")
+	}
+}
+
+func TestManager_EnforceDuplicateDryRun(t *testing.T) {
+	enforcer := &mockEnforcer{faults: make(map[int]bool), allocated: map[string]bool{"pod-owner": true}}
+	manager := newEnforceTestManager(t, enforcer, true)
+	ctx := context.Background()
+	for _, id := range []string{"owner", "intruder"} {
+		if err := manager.HandleNewContainer(ctx, id, "k8s.io"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(enforcer.faults) != 0 || len(enforcer.events) != 0 || len(enforcer.evicted) != 0 {
+		t.Errorf("no action should be executed in dry run")
+	}
+	if len(manager.enforce.evicted) != 0 {
+		t.Errorf("no pod should be recorded as evicted in dry run, got %v", manager.enforce.evicted)
+	}
+}
+
+func TestManager_EnforceDuplicateFailedRetried(t *testing.T) {
+	enforcer := &mockEnforcer{faults: make(map[int]bool), allocated: map[string]bool{"pod-owner": true},
+		evictErr: errors.New("evict failed"), eventErr: errors.New("record event failed")}
+	manager := newEnforceTestManager(t, enforcer, false)
+	for _, id := range []string{"owner", "intruder"} {
+		if err := manager.HandleNewContainer(context.Background(), id, "k8s.io"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(manager.enforce.evicted) != 0 || len(manager.enforce.reported[1]) != 0 {
+		t.Errorf("failed actions should not be recorded as done")
+	}
+
+	oldInterval := recheckInterval
+	recheckInterval = 10 * time.Millisecond
+	defer func() { recheckInterval = oldInterval }()
+	enforcer.evictErr, enforcer.eventErr = nil, nil
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	manager.recheckDuplicates(ctx)
+	if len(enforcer.evicted) != 1 || enforcer.evicted[0] != "pod-intruder" {
+		t.Errorf("expected the pod evicted by the recheck after eviction failed, got %v", enforcer.evicted)
+	}
+	if len(enforcer.events) != len(manager.client.(*mockClient).containers) {
+		t.Errorf("expected events recorded once on both pods by the recheck, got %v", enforcer.events)
+	}
+}
+
+func TestManager_EnforceDuplicateWithoutOwner(t *testing.T) {
+	enforcer := &mockEnforcer{faults: make(map[int]bool), allocated: map[string]bool{}}
+	manager := newEnforceTestManager(t, enforcer, false)
+	ctx := context.Background()
+	for _, id := range []string{"owner", "intruder"} {
+		if err := manager.HandleNewContainer(ctx, id, "k8s.io"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(enforcer.evicted) != 0 {
+		t.Errorf("no pod should be evicted when the owner is unknown, got %v", enforcer.evicted)
+	}
+}
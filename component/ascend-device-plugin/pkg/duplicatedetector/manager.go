@@ -33,6 +33,8 @@ import (
 var (
 	manager *Manager
 	once    sync.Once
+	// recheckInterval interval of enforcing the duplicate mounts in cache again, the failed actions are retried
+	recheckInterval = 30 * time.Second
 )
 
 // CheckDuplicateDevices checks for duplicate NPU devices and logs the results
@@ -56,6 +58,7 @@ type Manager struct {
 	client    containerruntime.Client
 	cache     *cache.ContainerCache
 	isRunning bool
+	enforce   *enforceState
 }
 
 // NewManager creates a new duplicate detection Manager
@@ -64,14 +67,20 @@ func NewManager(config *types.DetectorConfig) (*Manager, error) {
 		return nil, errors.New("config is nil")
 	}
 
+	enforce, err := newEnforceState(config)
+	if err != nil {
+		return nil, err
+	}
+
 	client, err := containerruntime.NewClient(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create container runtime client: %w", err)
 	}
 
 	return &Manager{
-		client: client,
-		cache:  cache.NewContainerCache(),
+		client:  client,
+		cache:   cache.NewContainerCache(),
+		enforce: enforce,
 	}, nil
 }
 
@@ -90,6 +99,9 @@ func (m *Manager) Start(ctx context.Context) {
 	}
 
 	go m.watchContainerEvents(ctx)
+	if m.enforce != nil && !m.enforce.dryRun {
+		go m.recheckDuplicates(ctx)
+	}
 
 	m.isRunning = true
 	hwlog.RunLog.Info("duplicate NPU device detection manager started successfully")
@@ -112,6 +124,23 @@ func (m *Manager) watchContainerEvents(ctx context.Context) {
 	})
 }
 
+// recheckDuplicates enforces the duplicate mounts in cache periodically, so that the events and evictions failed
+// are retried, the actions already taken are not repeated
+func (m *Manager) recheckDuplicates(ctx context.Context) {
+	ticker := time.NewTicker(recheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, dup := range m.cache.FindDuplicates() {
+				m.enforceDuplicate(dup)
+			}
+		}
+	}
+}
+
 func (m *Manager) scanAllContainers(ctx context.Context) error {
 	hwlog.RunLog.Info("initializing duplicate NPU device detector...")
 	result, err := m.client.ParseAllContainers(ctx)
@@ -120,7 +149,7 @@ func (m *Manager) scanAllContainers(ctx context.Context) error {
 	}
 	duplicates := m.cache.StoreAllAndFindDuplicates(result)
 	for _, dup := range duplicates {
-		m.logDuplicate(dup)
+		m.handleDuplicate(dup)
 	}
 
 	hwlog.RunLog.Infof("duplicate NPU device detector initialized. Found %d duplicate mount(s)", len(duplicates))
@@ -155,7 +184,7 @@ func (m *Manager) HandleNewContainer(ctx context.Context, containerID string, na
 	info.Namespace = namespace
 
 	for _, dup := range m.cache.StoreSingleAndFindDuplicates(info) {
-		m.logDuplicate(dup)
+		m.handleDuplicate(dup)
 	}
 
 	return nil
@@ -164,6 +193,13 @@ func (m *Manager) HandleNewContainer(ctx context.Context, containerID string, na
 // HandleContainerRemoval handles a container removal event
 func (m *Manager) HandleContainerRemoval(containerID string) {
 	m.cache.RemoveContainer(containerID)
+	m.releaseContainer(containerID)
+}
+
+// handleDuplicate logs a duplicate mount and enforces it under the enforce policy
+func (m *Manager) handleDuplicate(dup *types.DuplicateMountInfo) {
+	m.logDuplicate(dup)
+	m.enforceDuplicate(dup)
 }
 
 // logDuplicate logs a duplicate mount detection
@@ -42,6 +42,37 @@ type DetectorConfig struct {
 
 	// RuntimeType is the runtime type used by the containers (e.g., docker, containerd)
 	RuntimeType string
+
+	// Policy is the policy for handling duplicate mounts (e.g., log, enforce)
+	Policy string
+
+	// DryRun only logs the actions of the enforce policy without executing them
+	DryRun bool
+
+	// Evict evicts the pod whose container is not allocated the device by kubelet under the enforce policy
+	Evict bool
+
+	// Enforcer executes the actions of the enforce policy, required when Policy is enforce
+	Enforcer Enforcer
+}
+
+const (
+	// PolicyLog only logs the duplicate mounts
+	PolicyLog = "log"
+	// PolicyEnforce reports events, marks the device fault and optionally evicts the pod of the duplicate mounts
+	PolicyEnforce = "enforce"
+)
+
+// Enforcer executes the actions of the enforce policy on the duplicate mounts
+type Enforcer interface {
+	// RecordEvent records a warning event on the pod of the container
+	RecordEvent(container *ContainerNPUInfo, deviceID int, message string) error
+	// SetDeviceFault marks or clears the duplicate mount fault of the device
+	SetDeviceFault(deviceID int, fault bool)
+	// IsAllocated checks whether the device is allocated to the pod of the container by kubelet
+	IsAllocated(container *ContainerNPUInfo, deviceID int) (bool, error)
+	// EvictPod evicts the pod of the container
+	EvictPod(container *ContainerNPUInfo) error
 }
 
 // ContainerEventType represents the type of container event
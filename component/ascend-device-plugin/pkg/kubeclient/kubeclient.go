@@ -28,6 +28,7 @@ import (
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/types"
@@ -147,6 +148,19 @@ func (ki *ClientK8s) AddAnnotation(key, value string) error {
 	return err
 }
 
+// MergeNodeAnnotation sets the node annotation by merge patch, the key may contain '/'
+func (ki *ClientK8s) MergeNodeAnnotation(key, value string) error {
+	patchByte, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": map[string]string{key: value}},
+	})
+	if err != nil {
+		return fmt.Errorf("marshal node annotation patch failed, err: %v", err)
+	}
+	_, err = ki.Clientset.CoreV1().Nodes().Patch(context.TODO(), ki.NodeName, types.MergePatchType, patchByte,
+		metav1.PatchOptions{})
+	return err
+}
+
 // GetPod get pod by namespace and name
 func (ki *ClientK8s) GetPod(ctx context.Context, pod *v1.Pod) (*v1.Pod, error) {
 	return ki.getChannel().GetPod(ctx, pod)
@@ -360,6 +374,12 @@ func (ki *ClientK8s) CreateEvent(evt *v1.Event) (*v1.Event, error) {
 	return ki.Clientset.CoreV1().Events(evt.ObjectMeta.Namespace).Create(context.TODO(), evt, metav1.CreateOptions{})
 }
 
+// EvictPod evict pod by the eviction api, so the pod disruption budget is respected
+func (ki *ClientK8s) EvictPod(namespace, name string) error {
+	eviction := &policyv1.Eviction{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	return ki.Clientset.CoreV1().Pods(namespace).EvictV1(context.TODO(), eviction)
+}
+
 // GetNodeNameFromEnv get current node name from env
 func GetNodeNameFromEnv() (string, error) {
 	nodeName := os.Getenv(api.NodeNameEnv)
@@ -0,0 +1,284 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"ascend-common/common-utils/hwlog"
+	"ascend-common/common-utils/utils"
+)
+
+const (
+	// PluginTypeBuiltin plugin compiled into the device plugin, it is the default type
+	PluginTypeBuiltin = "builtin"
+	// PluginTypeExec executable called with the hook name as the argument and the device list as json on stdin
+	PluginTypeExec = "exec"
+	// PluginTypeGRPC grpc service listening on a unix socket, see GRPCServiceName for the methods
+	PluginTypeGRPC = "grpc"
+
+	// GRPCServiceName the service of the grpc plugins, each method takes a google.protobuf.Struct holding
+	// "hook", "devices" and "resetError", and returns a google.protobuf.Struct. The response of CustomReset
+	// holds "handled" and "error", the reset error is passed to the next plugin when "handled" is false
+	GRPCServiceName = "mindcluster.hotreset.v1.HotResetPlugin"
+
+	hookPreReset    = "preReset"
+	hookCustomReset = "customReset"
+	hookAfterReset  = "afterReset"
+
+	// ResetErrorEnv the env of the error of the reset passed to the exec plugins
+	ResetErrorEnv = "ASCEND_HOT_RESET_ERROR"
+	// ExecSkipExitCode exit code of the exec plugins which do not handle the hook, the reset error is passed to
+	// the next plugin in CustomReset
+	ExecSkipExitCode = 3
+
+	maxExecFileSize  = 100
+	maxExecOutputLen = 512
+)
+
+var grpcMethods = map[string]string{
+	hookPreReset:    "/" + GRPCServiceName + "/PreReset",
+	hookCustomReset: "/" + GRPCServiceName + "/CustomReset",
+	hookAfterReset:  "/" + GRPCServiceName + "/AfterReset",
+}
+
+// errHookSkipped the external plugin does not handle the hook
+var errHookSkipped = errors.New("hook skipped")
+
+// externalPlugin the hot reset plugin running out of the process
+type externalPlugin interface {
+	HotResetPlugin
+	// Config the config creating the plugin, the plugin is recreated when the config is changed
+	Config() PluginConfig
+	Close()
+}
+
+func isExternalPluginType(pluginType string) bool {
+	return pluginType == PluginTypeExec || pluginType == PluginTypeGRPC
+}
+
+func validateExternalConfig(cfg PluginConfig) error {
+	if !isExternalPluginType(cfg.Type) {
+		return fmt.Errorf("plugin type %s is not external", cfg.Type)
+	}
+	if !filepath.IsAbs(cfg.Path) {
+		return fmt.Errorf("path %s of plugin %s is not an absolute path", cfg.Path, cfg.PluginName)
+	}
+	return nil
+}
+
+func newExternalPlugin(cfg PluginConfig) (externalPlugin, error) {
+	if err := validateExternalConfig(cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Type == PluginTypeExec {
+		return &ExecPlugin{config: cfg}, nil
+	}
+	conn, err := grpc.NewClient("unix://"+cfg.Path, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("create grpc client of plugin %s failed: %w", cfg.PluginName, err)
+	}
+	return &GRPCPlugin{config: cfg, conn: conn}, nil
+}
+
+func resetErrString(resetErr error) string {
+	if resetErr == nil {
+		return ""
+	}
+	return resetErr.Error()
+}
+
+// customResetResult the error returned by CustomReset, the reset error is kept when the hook is skipped
+func customResetResult(name string, err, resetErr error) error {
+	if errors.Is(err, errHookSkipped) {
+		return resetErr
+	}
+	if err != nil {
+		return fmt.Errorf("plugin %s custom reset failed: %w", name, err)
+	}
+	return nil
+}
+
+// ExecPlugin the plugin calling an executable for each hook
+type ExecPlugin struct {
+	config PluginConfig
+}
+
+// Name the name of the plugin
+func (p *ExecPlugin) Name() string {
+	return p.config.PluginName
+}
+
+// Config the config creating the plugin
+func (p *ExecPlugin) Config() PluginConfig {
+	return p.config
+}
+
+// Close nothing to release for the exec plugin
+func (p *ExecPlugin) Close() {}
+
+// PreReset calls the executable with preReset
+func (p *ExecPlugin) PreReset(ctx context.Context, deviceList []ResetDevice) {
+	if err := p.run(ctx, hookPreReset, deviceList, nil); err != nil && !errors.Is(err, errHookSkipped) {
+		hwlog.RunLog.Warnf("exec plugin %s pre reset failed: %v", p.Name(), err)
+	}
+}
+
+// CustomReset calls the executable with customReset, the device is reset successfully when it exits with 0
+func (p *ExecPlugin) CustomReset(ctx context.Context, deviceList []ResetDevice, resetErr error) error {
+	return customResetResult(p.Name(), p.run(ctx, hookCustomReset, deviceList, resetErr), resetErr)
+}
+
+// AfterReset calls the executable with afterReset
+func (p *ExecPlugin) AfterReset(ctx context.Context, deviceList []ResetDevice, resetErr error) {
+	if err := p.run(ctx, hookAfterReset, deviceList, resetErr); err != nil && !errors.Is(err, errHookSkipped) {
+		hwlog.RunLog.Warnf("exec plugin %s after reset failed: %v", p.Name(), err)
+	}
+}
+
+func (p *ExecPlugin) run(ctx context.Context, hook string, deviceList []ResetDevice, resetErr error) error {
+	path, err := utils.RealFileChecker(p.config.Path, false, false, maxExecFileSize)
+	if err != nil {
+		return fmt.Errorf("check executable %s failed: %w", p.config.Path, err)
+	}
+	input, err := json.Marshal(deviceList)
+	if err != nil {
+		return fmt.Errorf("marshal device list failed: %w", err)
+	}
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, hook)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	cmd.Env = append(os.Environ(), ResetErrorEnv+"="+resetErrString(resetErr))
+	err = cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == ExecSkipExitCode {
+		return errHookSkipped
+	}
+	if err != nil {
+		return fmt.Errorf("%w, output: %s", err, trimOutput(output.String()))
+	}
+	hwlog.RunLog.Infof("exec plugin %s %s success", p.Name(), hook)
+	return nil
+}
+
+func trimOutput(output string) string {
+	output = strings.TrimSpace(output)
+	if len(output) > maxExecOutputLen {
+		return output[:maxExecOutputLen]
+	}
+	return output
+}
+
+// GRPCPlugin the plugin calling a grpc service listening on a unix socket for each hook
+type GRPCPlugin struct {
+	config PluginConfig
+	conn   *grpc.ClientConn
+}
+
+// Name the name of the plugin
+func (p *GRPCPlugin) Name() string {
+	return p.config.PluginName
+}
+
+// Config the config creating the plugin
+func (p *GRPCPlugin) Config() PluginConfig {
+	return p.config
+}
+
+// Close closes the connection to the service
+func (p *GRPCPlugin) Close() {
+	if err := p.conn.Close(); err != nil {
+		hwlog.RunLog.Warnf("close connection of grpc plugin %s failed: %v", p.Name(), err)
+	}
+}
+
+// PreReset calls the PreReset method of the service
+func (p *GRPCPlugin) PreReset(ctx context.Context, deviceList []ResetDevice) {
+	if _, err := p.invoke(ctx, hookPreReset, deviceList, nil); err != nil && !errors.Is(err, errHookSkipped) {
+		hwlog.RunLog.Warnf("grpc plugin %s pre reset failed: %v", p.Name(), err)
+	}
+}
+
+// CustomReset calls the CustomReset method of the service
+func (p *GRPCPlugin) CustomReset(ctx context.Context, deviceList []ResetDevice, resetErr error) error {
+	resp, err := p.invoke(ctx, hookCustomReset, deviceList, resetErr)
+	if err == nil && !resp.GetFields()["handled"].GetBoolValue() {
+		err = errHookSkipped
+	}
+	if err == nil {
+		if msg := resp.GetFields()["error"].GetStringValue(); msg != "" {
+			err = errors.New(msg)
+		}
+	}
+	return customResetResult(p.Name(), err, resetErr)
+}
+
+// AfterReset calls the AfterReset method of the service
+func (p *GRPCPlugin) AfterReset(ctx context.Context, deviceList []ResetDevice, resetErr error) {
+	if _, err := p.invoke(ctx, hookAfterReset, deviceList, resetErr); err != nil && !errors.Is(err, errHookSkipped) {
+		hwlog.RunLog.Warnf("grpc plugin %s after reset failed: %v", p.Name(), err)
+	}
+}
+
+func (p *GRPCPlugin) invoke(ctx context.Context, hook string, deviceList []ResetDevice,
+	resetErr error) (*structpb.Struct, error) {
+	req, err := newHookRequest(hook, deviceList, resetErr)
+	if err != nil {
+		return nil, err
+	}
+	resp := &structpb.Struct{}
+	err = p.conn.Invoke(ctx, grpcMethods[hook], req, resp)
+	if status.Code(err) == codes.Unimplemented {
+		return nil, errHookSkipped
+	}
+	if err != nil {
+		return nil, err
+	}
+	hwlog.RunLog.Infof("grpc plugin %s %s success", p.Name(), hook)
+	return resp, nil
+}
+
+// newHookRequest converts the device list to the struct by json, so the keys are the same as the exec plugins
+func newHookRequest(hook string, deviceList []ResetDevice, resetErr error) (*structpb.Struct, error) {
+	data, err := json.Marshal(deviceList)
+	if err != nil {
+		return nil, fmt.Errorf("marshal device list failed: %w", err)
+	}
+	var devices []interface{}
+	if err = json.Unmarshal(data, &devices); err != nil {
+		return nil, fmt.Errorf("unmarshal device list failed: %w", err)
+	}
+	return structpb.NewStruct(map[string]interface{}{
+		"hook":       hook,
+		"devices":    devices,
+		"resetError": resetErrString(resetErr),
+	})
+}
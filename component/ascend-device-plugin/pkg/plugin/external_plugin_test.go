@@ -0,0 +1,200 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	testExecPerm = 0700
+	// testExecScript saves the stdin and the reset error by the hook, customReset exits with the code in the
+	// file named exitcode, afterReset is skipped
+	testExecScript = `#!/bin/sh
+dir=$(dirname "$0")
+cat > "$dir/$1.json"
+echo "$ASCEND_HOT_RESET_ERROR" > "$dir/$1.err"
+case "$1" in
+customReset) exit "$(cat "$dir/exitcode")" ;;
+afterReset) exit 3 ;;
+esac
+`
+)
+
+var testResetDevices = []ResetDevice{{LogicID: 1, PhyID: 1, CardType: "Ascend910", IsFaultDev: true}}
+
+func writeTestExecPlugin(t *testing.T, exitCode string) string {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "plugin.sh")
+	if err := os.WriteFile(path, []byte(testExecScript), testExecPerm); err != nil {
+		t.Fatalf("write script failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "exitcode"), []byte(exitCode), testExecPerm); err != nil {
+		t.Fatalf("write exit code failed: %v", err)
+	}
+	return path
+}
+
+func TestExecPlugin(t *testing.T) {
+	path := writeTestExecPlugin(t, "0")
+	dir := filepath.Dir(path)
+	p, err := newExternalPlugin(PluginConfig{PluginName: "site", State: PluginStateOn, Type: PluginTypeExec,
+		Path: path})
+	if err != nil {
+		t.Fatalf("create exec plugin failed: %v", err)
+	}
+	convey.Convey("test exec plugin", t, func() {
+		convey.Convey("device list is passed by stdin", func() {
+			p.PreReset(context.Background(), testResetDevices)
+			data, err := os.ReadFile(filepath.Join(dir, hookPreReset+".json"))
+			convey.So(err, convey.ShouldBeNil)
+			var got []ResetDevice
+			convey.So(json.Unmarshal(data, &got), convey.ShouldBeNil)
+			convey.So(got, convey.ShouldResemble, testResetDevices)
+		})
+		convey.Convey("custom reset succeeds when exit with 0", func() {
+			convey.So(p.CustomReset(context.Background(), testResetDevices, testErr), convey.ShouldBeNil)
+			data, err := os.ReadFile(filepath.Join(dir, hookCustomReset+".err"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(string(data), convey.ShouldEqual, testErr.Error()+"\n")
+		})
+		convey.Convey("reset error is kept when the hook is skipped", func() {
+			convey.So(os.WriteFile(filepath.Join(dir, "exitcode"), []byte("3"), testExecPerm), convey.ShouldBeNil)
+			convey.So(p.CustomReset(context.Background(), testResetDevices, testErr), convey.ShouldEqual, testErr)
+		})
+		convey.Convey("custom reset fails when exit with other code", func() {
+			convey.So(os.WriteFile(filepath.Join(dir, "exitcode"), []byte("1"), testExecPerm), convey.ShouldBeNil)
+			err := p.CustomReset(context.Background(), testResetDevices, nil)
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(err.Error(), convey.ShouldContainSubstring, "exit status 1")
+		})
+		convey.Convey("executable not exist", func() {
+			missing := &ExecPlugin{config: PluginConfig{PluginName: "missing", Path: filepath.Join(dir, "none")}}
+			convey.So(missing.CustomReset(context.Background(), testResetDevices, nil), convey.ShouldNotBeNil)
+		})
+	})
+}
+
+// testResetService the grpc plugin service, CustomReset handles the reset when the reset error is empty
+type testResetService struct {
+	requests chan *structpb.Struct
+}
+
+func (s *testResetService) handle(_ interface{}, ctx context.Context, dec func(interface{}) error,
+	_ grpc.UnaryServerInterceptor) (interface{}, error) {
+	req := &structpb.Struct{}
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+	s.requests <- req
+	handled := req.GetFields()["resetError"].GetStringValue() == ""
+	return structpb.NewStruct(map[string]interface{}{"handled": handled, "error": ""})
+}
+
+func startTestResetService(t *testing.T, svc *testResetService) string {
+	socket := filepath.Join(t.TempDir(), "plugin.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("listen failed: %v", err)
+	}
+	server := grpc.NewServer()
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: GRPCServiceName,
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{
+			{MethodName: "PreReset", Handler: svc.handle},
+			{MethodName: "CustomReset", Handler: svc.handle},
+		},
+	}, svc)
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			t.Logf("serve stopped: %v", err)
+		}
+	}()
+	t.Cleanup(server.Stop)
+	return socket
+}
+
+func TestGRPCPlugin(t *testing.T) {
+	svc := &testResetService{requests: make(chan *structpb.Struct, 1)}
+	socket := startTestResetService(t, svc)
+	p, err := newExternalPlugin(PluginConfig{PluginName: "bmc", State: PluginStateOn, Type: PluginTypeGRPC,
+		Path: socket})
+	if err != nil {
+		t.Fatalf("create grpc plugin failed: %v", err)
+	}
+	defer p.Close()
+	convey.Convey("test grpc plugin", t, func() {
+		convey.Convey("device list is passed by the request", func() {
+			p.PreReset(context.Background(), testResetDevices)
+			req := <-svc.requests
+			convey.So(req.GetFields()["hook"].GetStringValue(), convey.ShouldEqual, hookPreReset)
+			devices := req.GetFields()["devices"].GetListValue().GetValues()
+			convey.So(len(devices), convey.ShouldEqual, 1)
+			convey.So(devices[0].GetStructValue().GetFields()["isFaultDev"].GetBoolValue(), convey.ShouldBeTrue)
+		})
+		convey.Convey("custom reset handled by the service", func() {
+			convey.So(p.CustomReset(context.Background(), testResetDevices, nil), convey.ShouldBeNil)
+			<-svc.requests
+			convey.So(p.CustomReset(context.Background(), testResetDevices, testErr), convey.ShouldEqual, testErr)
+			<-svc.requests
+		})
+		convey.Convey("method not implemented is skipped", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			_, err := p.(*GRPCPlugin).invoke(ctx, hookAfterReset, testResetDevices, nil)
+			convey.So(err, convey.ShouldEqual, errHookSkipped)
+		})
+	})
+}
+
+func TestPluginManager_ExternalPlugin(t *testing.T) {
+	path := writeTestExecPlugin(t, "0")
+	convey.Convey("test external plugin in hook chains", t, func() {
+		pm := NewPluginManager()
+		convey.So(pm.RegisterPlugin(&testPlugin{name: "builtin1"}), convey.ShouldBeNil)
+		pm.configMgr.configs = []PluginConfig{
+			{PluginName: "builtin1", State: PluginStateOn},
+			{PluginName: "site", State: PluginStateOn, Type: PluginTypeExec, Path: path},
+			{PluginName: "builtin1", State: PluginStateOn, Type: PluginTypeExec, Path: path},
+			{PluginName: "relative", State: PluginStateOn, Type: PluginTypeExec, Path: "plugin.sh"},
+			{PluginName: "unknown", State: PluginStateOn, Type: "dll", Path: path},
+		}
+		pm.BuildHookCache()
+		pre, custom, _ := pm.GetHookChains()
+		convey.So(len(pre), convey.ShouldEqual, 2)
+		convey.So(custom[1].Name(), convey.ShouldEqual, "site")
+		convey.So(pm.ExecuteCustomReset(context.Background(), testResetDevices, testErr), convey.ShouldBeNil)
+
+		site := pm.externalPlugins["site"]
+		pm.BuildHookCache()
+		convey.So(pm.externalPlugins["site"], convey.ShouldEqual, site)
+
+		pm.configMgr.configs = pm.configMgr.configs[:1]
+		pm.BuildHookCache()
+		convey.So(len(pm.externalPlugins), convey.ShouldEqual, 0)
+		pm.Stop()
+	})
+}
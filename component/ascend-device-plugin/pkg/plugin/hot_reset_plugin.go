@@ -21,13 +21,13 @@ import (
 )
 
 type ResetDevice struct {
-	LogicID    int32
-	CardID     int32
-	DeviceID   int32
-	PhyID      int32
-	CardType   string
-	IsFaultDev bool
-	TokensLeft int32
+	LogicID    int32  `json:"logicID"`
+	CardID     int32  `json:"cardID"`
+	DeviceID   int32  `json:"deviceID"`
+	PhyID      int32  `json:"phyID"`
+	CardType   string `json:"cardType"`
+	IsFaultDev bool   `json:"isFaultDev"`
+	TokensLeft int32  `json:"tokensLeft"`
 }
 
 type HookCaps struct {
@@ -32,6 +32,10 @@ import (
 type PluginConfig struct {
 	PluginName string `json:"pluginName"`
 	State      string `json:"state"`
+	// Type is builtin when it is empty, exec and grpc plugins run out of the process
+	Type string `json:"type,omitempty"`
+	// Path is the executable of exec plugins or the unix socket of grpc plugins
+	Path string `json:"path,omitempty"`
 }
 
 const (
@@ -209,6 +213,7 @@ func defaultPluginConfigs() []PluginConfig {
 type PluginManager struct {
 	mu               sync.RWMutex
 	Plugins          map[string]HotResetPlugin
+	externalPlugins  map[string]externalPlugin
 	configMgr        *PluginConfigMgr
 	preResetChain    []HotResetPlugin
 	customResetChain []HotResetPlugin
@@ -217,7 +222,8 @@ type PluginManager struct {
 
 func NewPluginManager() *PluginManager {
 	pm := &PluginManager{
-		Plugins: make(map[string]HotResetPlugin),
+		Plugins:         make(map[string]HotResetPlugin),
+		externalPlugins: make(map[string]externalPlugin),
 	}
 	pm.configMgr = NewPluginConfigMgr(pm.OnConfigChange)
 	return pm
@@ -263,21 +269,25 @@ func (pm *PluginManager) BuildHookCache() {
 
 func (pm *PluginManager) buildHookCacheLocked() {
 	var preChain, customChain, afterChain []HotResetPlugin
+	usedExternal := make(map[string]bool)
 	for _, cfg := range pm.configMgr.GetConfigs() {
 		if cfg.State != PluginStateOn {
 			hwlog.RunLog.Infof("plugin %s state is %s, skip", cfg.PluginName, cfg.State)
 			continue
 		}
-		p, ok := pm.Plugins[cfg.PluginName]
+		p, ok := pm.getPluginByConfigLocked(cfg)
 		if !ok {
-			hwlog.RunLog.Warnf("plugin %s not found, skip", cfg.PluginName)
 			continue
 		}
+		if isExternalPluginType(cfg.Type) {
+			usedExternal[cfg.PluginName] = true
+		}
 		preChain = append(preChain, p)
 		customChain = append(customChain, p)
 		afterChain = append(afterChain, p)
 		hwlog.RunLog.Infof("plugin %s hook built in cache", p.Name())
 	}
+	pm.closeExternalPluginsLocked(usedExternal)
 	pm.preResetChain = preChain
 	pm.customResetChain = customChain
 	pm.afterResetChain = afterChain
@@ -285,6 +295,53 @@ func (pm *PluginManager) buildHookCacheLocked() {
 		len(preChain), len(customChain), len(afterChain))
 }
 
+// getPluginByConfigLocked gets the builtin plugin or the external plugin of the config, the external plugin is
+// created again when its config is changed
+func (pm *PluginManager) getPluginByConfigLocked(cfg PluginConfig) (HotResetPlugin, bool) {
+	if cfg.Type == "" || cfg.Type == PluginTypeBuiltin {
+		p, ok := pm.Plugins[cfg.PluginName]
+		if !ok {
+			hwlog.RunLog.Warnf("plugin %s not found, skip", cfg.PluginName)
+		}
+		return p, ok
+	}
+	if !isExternalPluginType(cfg.Type) {
+		hwlog.RunLog.Warnf("plugin %s type %s is not supported, skip", cfg.PluginName, cfg.Type)
+		return nil, false
+	}
+	if _, ok := pm.Plugins[cfg.PluginName]; ok {
+		hwlog.RunLog.Warnf("external plugin %s conflicts with the builtin one, skip", cfg.PluginName)
+		return nil, false
+	}
+	if p, ok := pm.externalPlugins[cfg.PluginName]; ok {
+		if p.Config() == cfg {
+			return p, true
+		}
+		p.Close()
+		delete(pm.externalPlugins, cfg.PluginName)
+	}
+	p, err := newExternalPlugin(cfg)
+	if err != nil {
+		hwlog.RunLog.Warnf("create external plugin %s failed: %v, skip", cfg.PluginName, err)
+		return nil, false
+	}
+	pm.externalPlugins[cfg.PluginName] = p
+	hwlog.RunLog.Infof("external plugin %s created, type: %s, path: %s", cfg.PluginName, cfg.Type, cfg.Path)
+	return p, true
+}
+
+// closeExternalPluginsLocked closes the external plugins which are not used any more
+func (pm *PluginManager) closeExternalPluginsLocked(used map[string]bool) {
+	for name, p := range pm.externalPlugins {
+		if used[name] {
+			continue
+		}
+		p.Close()
+		delete(pm.externalPlugins, name)
+		hwlog.RunLog.Infof("external plugin %s closed", name)
+	}
+}
+
 func (pm *PluginManager) OnConfigChange() {
 	pm.BuildHookCache()
 }
@@ -372,6 +429,9 @@ func (pm *PluginManager) ExecuteAfterReset(ctx context.Context, deviceList []Res
 
 func (pm *PluginManager) Stop() {
 	pm.configMgr.Stop()
+	pm.mu.Lock()
+	pm.closeExternalPluginsLocked(nil)
+	pm.mu.Unlock()
 }
 
 func (pm *PluginManager) GetConfigMgr() *PluginConfigMgr {
@@ -0,0 +1,209 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package server holds the implementation of registration to kubelet, k8s device plugin interface and grpc service.
+package server
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kubelet/pkg/apis/deviceplugin/v1beta1"
+	cdiapi "tags.cncf.io/container-device-interface/pkg/cdi"
+
+	"Ascend-device-plugin/pkg/common"
+	"ascend-common/api"
+	"ascend-common/cdi"
+	"ascend-common/cdi/mount"
+	"ascend-common/common-utils/hwlog"
+)
+
+const (
+	cdiVendor = "huawei.com"
+	// cdiVirtualSuffix suffix of the class of virtual devices allocated by a physical resource, such as npu-core
+	cdiVirtualSuffix = "-vnpu"
+	// cdiMountListDir the mount lists of driver files, the same as the ones used by ascend-docker-runtime
+	cdiMountListDir = "/etc/ascend-docker-runtime.d"
+)
+
+var (
+	cdiCache     *cdiapi.Cache
+	cdiCacheErr  error
+	cdiCacheOnce sync.Once
+)
+
+// getCDICache the cdi cache writing the spec files to the cdi spec dir, auto refresh is not needed since the
+// device plugin only writes the spec files
+func getCDICache() (*cdiapi.Cache, error) {
+	cdiCacheOnce.Do(func() {
+		cdiCache, cdiCacheErr = cdiapi.NewCache(cdiapi.WithSpecDirs(common.ParamOption.CDISpecDir),
+			cdiapi.WithAutoRefresh(false))
+	})
+	return cdiCache, cdiCacheErr
+}
+
+// getCDIDevType the device type of the cdi library by the real card type
+func getCDIDevType() string {
+	switch common.ParamOption.RealCardType {
+	case api.Ascend910A, api.Ascend910B, api.Ascend910A3:
+		return cdi.Ascend910
+	default:
+		return common.ParamOption.RealCardType
+	}
+}
+
+func getCDIProductType() string {
+	if len(common.ParamOption.ProductTypes) == 0 {
+		return ""
+	}
+	return common.ParamOption.ProductTypes[0]
+}
+
+// cdiClass the class of the cdi devices is the resource name, so the device names of different resources are not
+// conflicted
+func (ps *PluginServer) cdiClass(virtual bool) string {
+	if virtual && !common.IsVirtualDev(ps.deviceType) {
+		return ps.deviceType + cdiVirtualSuffix
+	}
+	return ps.deviceType
+}
+
+func (ps *PluginServer) cdiKind(virtual bool) string {
+	return cdiVendor + "/" + ps.cdiClass(virtual)
+}
+
+// writeCDISpec writes the spec file holding the devices, the spec file is removed when there is no device
+func (ps *PluginServer) writeCDISpec(ids sets.Int, virtual bool) error {
+	cache, err := getCDICache()
+	if err != nil {
+		return fmt.Errorf("init cdi cache failed, err: %v", err)
+	}
+	name := cdiapi.GenerateSpecName(cdiVendor, ps.cdiClass(virtual))
+	if ids.Len() == 0 {
+		return cache.RemoveSpec(name)
+	}
+	spec, err := cdi.BuildSpec(cdi.BuildSpecConfig{
+		DeviceConfig: cdi.DeviceConfig{
+			DeviceIDs:   ids.List(),
+			DevType:     getCDIDevType(),
+			ProductType: getCDIProductType(),
+		},
+		UseVirtual: virtual,
+		Provider:   &mount.FileProvider{Dir: cdiMountListDir},
+		Kind:       ps.cdiKind(virtual),
+	})
+	if err != nil {
+		return fmt.Errorf("build cdi spec of %s failed, err: %v", ps.cdiKind(virtual), err)
+	}
+	if err = cache.WriteSpec(spec, name); err != nil {
+		return fmt.Errorf("write cdi spec %s failed, err: %v", name, err)
+	}
+	hwlog.RunLog.Infof("cdi spec %s is updated, devices: %v", name, ids.List())
+	return nil
+}
+
+// getCachedDeviceIDs the ids of the device nodes of the cached devices, which are the same as the ones mounted
+// in Allocate
+func (ps *PluginServer) getCachedDeviceIDs(virtual bool) (sets.Int, error) {
+	runtimeOptions := ""
+	if virtual {
+		runtimeOptions = common.VirtualDev
+	}
+	ps.cachedLock.RLock()
+	names := make([]string, 0, len(ps.cachedDevices))
+	allDevs := make([]common.NpuDevice, 0, len(ps.cachedDevices))
+	for _, dev := range ps.cachedDevices {
+		names = append(names, dev.DeviceName)
+		allDevs = append(allDevs, dev)
+	}
+	ps.cachedLock.RUnlock()
+	_, ids, err := common.GetDeviceListID(names, runtimeOptions)
+	if err != nil {
+		return nil, err
+	}
+	if !virtual {
+		ids = getFinalVisibleDevices(ids, common.NpuAllInfo{AllDevs: allDevs}, common.ParamOption.UseVolcanoType)
+	}
+	return sets.NewInt(ids...), nil
+}
+
+// syncCDISpec keeps the cdi spec file the same as the cached devices, devices of npu-core are created when allocated,
+// so the spec of them is written in Allocate
+func (ps *PluginServer) syncCDISpec() {
+	if !common.ParamOption.UseCDI || ps.deviceType == common.AiCoreResourceName {
+		return
+	}
+	virtual := common.IsVirtualDev(ps.deviceType)
+	ids, err := ps.getCachedDeviceIDs(virtual)
+	if err != nil {
+		hwlog.RunLog.Errorf("get device ids of %s for cdi spec failed, err: %v", ps.deviceType, err)
+		return
+	}
+	ps.cdiLock.Lock()
+	defer ps.cdiLock.Unlock()
+	if known, ok := ps.cdiSpecIDs[virtual]; ok && known.Equal(ids) {
+		return
+	}
+	if err = ps.writeCDISpec(ids, virtual); err != nil {
+		hwlog.RunLog.Errorf("sync cdi spec of %s failed, err: %v", ps.deviceType, err)
+		return
+	}
+	ps.setCDISpecIDs(ids, virtual)
+}
+
+func (ps *PluginServer) setCDISpecIDs(ids sets.Int, virtual bool) {
+	if ps.cdiSpecIDs == nil {
+		ps.cdiSpecIDs = make(map[bool]sets.Int)
+	}
+	ps.cdiSpecIDs[virtual] = ids
+}
+
+// setCDIDevices sets the cdi devices of the allocated devices to the response, the spec file is updated first
+// when the devices are not in it, such as the virtual devices created by the allocation
+func (ps *PluginServer) setCDIDevices(resp *v1beta1.ContainerAllocateResponse, devices []int) error {
+	if len(devices) == 0 {
+		return nil
+	}
+	virtual := ps.ascendRuntimeOptions == common.VirtualDev
+	ps.cdiLock.Lock()
+	known := ps.cdiSpecIDs[virtual]
+	if !known.HasAll(devices...) {
+		ids := sets.NewInt(devices...).Union(known)
+		err := ps.writeCDISpec(ids, virtual)
+		if err != nil && known.Len() > 0 {
+			// the virtual devices in the spec may be destroyed, only the allocated devices are kept
+			hwlog.RunLog.Warnf("update cdi spec of %s failed, err: %v, rewrite it by allocated devices",
+				ps.deviceType, err)
+			ids = sets.NewInt(devices...)
+			err = ps.writeCDISpec(ids, virtual)
+		}
+		if err != nil {
+			ps.cdiLock.Unlock()
+			return err
+		}
+		ps.setCDISpecIDs(ids, virtual)
+	}
+	ps.cdiLock.Unlock()
+	kind := ps.cdiKind(virtual)
+	names := make([]string, 0, len(devices))
+	for _, id := range devices {
+		name := fmt.Sprintf("%s=%d", kind, id)
+		names = append(names, name)
+		resp.CDIDevices = append(resp.CDIDevices, &v1beta1.CDIDevice{Name: name})
+	}
+	hwlog.RunLog.Infof("device-plugin will use cdi to mount, cdi devices: %s", strings.Join(names, ","))
+	return nil
+}
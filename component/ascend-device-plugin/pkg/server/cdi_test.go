@@ -0,0 +1,128 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package server test for cdi
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/smartystreets/goconvey/convey"
+	"k8s.io/kubelet/pkg/apis/deviceplugin/v1beta1"
+	cdispec "tags.cncf.io/container-device-interface/specs-go"
+
+	"Ascend-device-plugin/pkg/common"
+	"ascend-common/api"
+	"ascend-common/cdi"
+)
+
+// mockBuildSpec builds the spec without stat of the device nodes, and counts the calls
+func mockBuildSpec(calls *int) *gomonkey.Patches {
+	return gomonkey.ApplyFunc(cdi.BuildSpec, func(cfg cdi.BuildSpecConfig) (*cdispec.Spec, error) {
+		*calls++
+		spec := &cdispec.Spec{Version: "0.8.0", Kind: cfg.Kind}
+		for _, id := range cfg.DeviceIDs {
+			spec.Devices = append(spec.Devices, cdispec.Device{Name: strconv.Itoa(id),
+				ContainerEdits: cdispec.ContainerEdits{Env: []string{"ASCEND_VISIBLE_DEVICES=" + strconv.Itoa(id)}}})
+		}
+		return spec, nil
+	})
+}
+
+func setTestCDIOption(dir string) func() {
+	origin := common.ParamOption
+	common.ParamOption.UseCDI = true
+	common.ParamOption.CDISpecDir = dir
+	common.ParamOption.RealCardType = api.Ascend910B
+	cdiCacheOnce = sync.Once{}
+	return func() {
+		common.ParamOption = origin
+		cdiCacheOnce = sync.Once{}
+	}
+}
+
+func TestCDIKind(t *testing.T) {
+	convey.Convey("test cdiKind", t, func() {
+		ps := NewPluginServer(common.AiCoreResourceName, nil, nil, nil)
+		convey.So(ps.cdiKind(false), convey.ShouldEqual, "huawei.com/npu-core")
+		convey.So(ps.cdiKind(true), convey.ShouldEqual, "huawei.com/npu-core-vnpu")
+		ps = NewPluginServer(common.Ascend910vir2, nil, nil, nil)
+		convey.So(ps.cdiKind(true), convey.ShouldEqual, "huawei.com/Ascend910-2c")
+	})
+}
+
+func TestGetCDIDevType(t *testing.T) {
+	convey.Convey("test getCDIDevType", t, func() {
+		origin := common.ParamOption.RealCardType
+		defer func() { common.ParamOption.RealCardType = origin }()
+		common.ParamOption.RealCardType = api.Ascend910A3
+		convey.So(getCDIDevType(), convey.ShouldEqual, cdi.Ascend910)
+		common.ParamOption.RealCardType = api.Ascend310P
+		convey.So(getCDIDevType(), convey.ShouldEqual, cdi.Ascend310P)
+	})
+}
+
+func TestSyncCDISpec(t *testing.T) {
+	dir := t.TempDir()
+	defer setTestCDIOption(dir)()
+	calls := 0
+	patches := mockBuildSpec(&calls)
+	defer patches.Reset()
+	convey.Convey("test syncCDISpec", t, func() {
+		ps := NewPluginServer(api.Ascend910, devices[:2], nil, nil)
+		specFile := filepath.Join(dir, "huawei.com-Ascend910.yaml")
+		_, err := os.Stat(specFile)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(calls, convey.ShouldEqual, 1)
+		convey.So(ps.cdiSpecIDs[false].List(), convey.ShouldResemble, []int{0, 1})
+
+		ps.deepCopyDevice(devices[:2])
+		ps.syncCDISpec()
+		convey.So(calls, convey.ShouldEqual, 1)
+
+		ps.deepCopyDevice(nil)
+		ps.syncCDISpec()
+		_, err = os.Stat(specFile)
+		convey.So(os.IsNotExist(err), convey.ShouldBeTrue)
+	})
+}
+
+func TestSetCDIDevices(t *testing.T) {
+	dir := t.TempDir()
+	defer setTestCDIOption(dir)()
+	calls := 0
+	patches := mockBuildSpec(&calls)
+	defer patches.Reset()
+	convey.Convey("test setCDIDevices", t, func() {
+		ps := NewPluginServer(common.AiCoreResourceName, nil, nil, nil)
+		ps.ascendRuntimeOptions = common.VirtualDev
+		resp := &v1beta1.ContainerAllocateResponse{}
+		convey.So(ps.setCDIDevices(resp, []int{100, 101}), convey.ShouldBeNil)
+		convey.So(resp.CDIDevices, convey.ShouldResemble, []*v1beta1.CDIDevice{
+			{Name: "huawei.com/npu-core-vnpu=100"}, {Name: "huawei.com/npu-core-vnpu=101"}})
+		_, err := os.Stat(filepath.Join(dir, "huawei.com-npu-core-vnpu.yaml"))
+		convey.So(err, convey.ShouldBeNil)
+
+		resp = &v1beta1.ContainerAllocateResponse{}
+		convey.So(ps.setCDIDevices(resp, []int{101}), convey.ShouldBeNil)
+		convey.So(calls, convey.ShouldEqual, 1)
+		convey.So(ps.setCDIDevices(resp, []int{102}), convey.ShouldBeNil)
+		convey.So(ps.cdiSpecIDs[true].List(), convey.ShouldResemble, []int{100, 101, 102})
+	})
+}
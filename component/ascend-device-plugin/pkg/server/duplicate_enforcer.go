@@ -0,0 +1,130 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package server holds the implementation of registration to kubelet, k8s pod resource interface.
+package server
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"Ascend-device-plugin/pkg/common"
+	"Ascend-device-plugin/pkg/device"
+	"Ascend-device-plugin/pkg/duplicatedetector/types"
+	"Ascend-device-plugin/pkg/kubeclient"
+	"ascend-common/api"
+	"ascend-common/common-utils/hwlog"
+)
+
+// duplicateEnforcer executes the enforce policy of the duplicate detector
+type duplicateEnforcer struct {
+	client   *kubeclient.ClientK8s
+	prClient *PodResource
+}
+
+// NewDuplicateEnforcer returns the enforcer of the duplicate detector, nil when the kube client is unavailable
+func (hdm *HwDevManager) NewDuplicateEnforcer() types.Enforcer {
+	client := hdm.manager.GetKubeClient()
+	if client == nil {
+		hwlog.RunLog.Warn("kube client is nil, duplicate mount enforcement is not supported")
+		return nil
+	}
+	return &duplicateEnforcer{client: client, prClient: NewPodResource()}
+}
+
+// RecordEvent records a warning event on the pod of the container
+func (de *duplicateEnforcer) RecordEvent(container *types.ContainerNPUInfo, deviceID int, message string) error {
+	now := time.Now()
+	name := fmt.Sprintf("%s.%s.%d.%d", container.PodName, strings.ToLower(common.EventDuplicateNPUMount), deviceID,
+		now.UnixNano())
+	event := &v1.Event{
+		ObjectMeta: metav1.ObjectMeta{Namespace: container.PodNS, Name: name},
+		Type:       v1.EventTypeWarning,
+		Message:    fmt.Sprintf("%s, container %s of the pod is one of them", message, container.Name),
+		EventTime:  metav1.MicroTime{Time: now},
+		Reason:     common.EventDuplicateNPUMount, Action: common.EventDuplicateNPUMount,
+		Source: v1.EventSource{Component: common.Component, Host: de.client.NodeName},
+		InvolvedObject: v1.ObjectReference{
+			Kind: common.ResourceKindPod, Namespace: container.PodNS, Name: container.PodName,
+		},
+		ReportingController: common.Component, ReportingInstance: de.client.NodeName,
+	}
+	_, err := de.client.CreateEvent(event)
+	return err
+}
+
+// SetDeviceFault marks or clears the duplicate mount fault of the device in the device info configmap
+func (de *duplicateEnforcer) SetDeviceFault(deviceID int, fault bool) {
+	device.SetDuplicateMountFault(int32(deviceID), fault)
+}
+
+// IsAllocated checks whether the device is allocated to the pod of the container by the pod resources of kubelet
+func (de *duplicateEnforcer) IsAllocated(container *types.ContainerNPUInfo, deviceID int) (bool, error) {
+	podResources, err := de.prClient.GetPodResource()
+	if err != nil {
+		return false, err
+	}
+	podDevice, exist := podResources[container.PodNS+common.UnderLine+container.PodName]
+	if !exist {
+		return false, nil
+	}
+	deviceNames := podDevice.DeviceIds
+	if common.ParamOption.UseVolcanoType {
+		// the devices allocated by kubelet are replaced by the real devices under volcano
+		realDevices, err := de.getRealDevices(container)
+		if err != nil {
+			return false, err
+		}
+		deviceNames = realDevices
+	}
+	for _, deviceName := range deviceNames {
+		phyID, _, err := common.GetDeviceID(deviceName, "")
+		if err != nil {
+			hwlog.RunLog.Warnf("get physical id of device %s failed, %v", deviceName, err)
+			continue
+		}
+		if phyID == deviceID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (de *duplicateEnforcer) getRealDevices(container *types.ContainerNPUInfo) ([]string, error) {
+	for _, pod := range de.client.GetAllPodListCache() {
+		if pod.Namespace != container.PodNS || pod.Name != container.PodName {
+			continue
+		}
+		realDevice, exist := pod.Annotations[api.PodAnnotationAscendReal]
+		if !exist {
+			return nil, fmt.Errorf("annotation %s of pod %s/%s not found", api.PodAnnotationAscendReal,
+				container.PodNS, container.PodName)
+		}
+		return strings.Split(realDevice, common.CommaSepDev), nil
+	}
+	return nil, fmt.Errorf("pod %s/%s not found", container.PodNS, container.PodName)
+}
+
+// EvictPod evicts the pod of the container
+func (de *duplicateEnforcer) EvictPod(container *types.ContainerNPUInfo) error {
+	if container.PodName == "" {
+		return errors.New("container is not managed by kubernetes")
+	}
+	return de.client.EvictPod(container.PodNS, container.PodName)
+}
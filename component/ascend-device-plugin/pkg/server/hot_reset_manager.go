@@ -185,6 +185,11 @@ func (m *UnifiedHotResetManager) getResetRingDevices(faultDev *common.NpuDevice,
 }
 
 func (m *UnifiedHotResetManager) getRingSize(dev *common.NpuDevice, boardId uint32, deviceNum int) int {
+	return hccsRingSize(boardId, deviceNum)
+}
+
+// hccsRingSize the number of chips connected by hccs in a ring, chips of the same ring are reset together
+func hccsRingSize(boardId uint32, deviceNum int) int {
 	switch common.ParamOption.RealCardType {
 	case api.Ascend910A:
 		return common.Ascend910RingsNum
@@ -660,6 +660,7 @@ func (hdm *HwDevManager) ListenDevice(ctx context.Context) {
 		go hdm.SwitchDevManager.GetSwitchFaultCodeByInterval(ctx, time.Second*common.GetSwitchFaultCodeInterval)
 	}
 	hdm.loadFaultCodeAndDeviceInfoCm(ctx)
+	device.InitCordonMgr(ctx, hdm.manager.GetKubeClient())
 	go hdm.Serve(ctx)
 	if common.ParamOption.CheckCachedPods {
 		go hdm.manager.GetKubeClient().PodInformerInspector(ctx)
@@ -65,6 +65,7 @@ func (ps *PluginServer) Notify(devices []*common.NpuDevice) bool {
 	}
 	if ps.isRunning.Load() {
 		ps.deepCopyDevice(devices)
+		ps.syncCDISpec()
 		ps.reciChan <- struct{}{}
 		return true
 	}
@@ -271,6 +272,7 @@ func (ps *PluginServer) deepCopyDevice(cachedDevices []*common.NpuDevice) {
 			Health:     dev.Health,
 			PhyID:      dev.PhyID,
 			LogicID:    dev.LogicID,
+			CardID:     dev.CardID,
 			DevType:    dev.DevType,
 		})
 	}
@@ -1353,7 +1355,14 @@ func (ps *PluginServer) Allocate(ctx context.Context, requests *v1beta1.Allocate
 		finalVisibleDevices := getFinalVisibleDevices(ascendVisibleDevices, allNPUInfo, usePodAnnotation)
 		resp := new(v1beta1.ContainerAllocateResponse)
 		ps.mountShareDeviceConfig(resp, finalVisibleDevices, npuInfoConfigDir)
-		ps.setNPUDeviceMount(resp, finalVisibleDevices)
+		if common.ParamOption.UseCDI {
+			if err = ps.setCDIDevices(resp, finalVisibleDevices); err != nil {
+				hwlog.RunLog.Errorf("set cdi devices failed, err: %v", err)
+				return nil, err
+			}
+		} else {
+			ps.setNPUDeviceMount(resp, finalVisibleDevices)
+		}
 		ps.setHcclTopoFilePathEnv(resp, allNPUInfo)
 		ps.SetSlowNodeNoticeEnv(resp)
 		resps.ContainerResponses = append(resps.ContainerResponses, resp)
@@ -1390,16 +1399,32 @@ func (ps *PluginServer) SetSlowNodeNoticeEnv(resp *v1beta1.ContainerAllocateResp
 	hwlog.RunLog.Info("allocate step time env succeed")
 }
 
-// GetPreferredAllocation implement the kubelet device plugin interface
-func (ps *PluginServer) GetPreferredAllocation(context.Context, *v1beta1.PreferredAllocationRequest) (
+// GetPreferredAllocation implement the kubelet device plugin interface, the best-connected devices are returned
+// for each container when volcano is not used
+func (ps *PluginServer) GetPreferredAllocation(_ context.Context, req *v1beta1.PreferredAllocationRequest) (
 	*v1beta1.PreferredAllocationResponse, error) {
-	return nil, fmt.Errorf("not support")
+	if !ps.isPreferredAllocationSupported() || req == nil {
+		return nil, fmt.Errorf("not support")
+	}
+	resp := &v1beta1.PreferredAllocationResponse{}
+	for _, containerReq := range req.ContainerRequests {
+		deviceIDs, err := ps.preferredAllocation(containerReq)
+		if err != nil {
+			hwlog.RunLog.Errorf("get preferred allocation failed, err: %v", err)
+			return nil, err
+		}
+		hwlog.RunLog.Infof("preferred allocation of %s: %v, available: %v", ps.deviceType, deviceIDs,
+			containerReq.AvailableDeviceIDs)
+		resp.ContainerResponses = append(resp.ContainerResponses,
+			&v1beta1.ContainerPreferredAllocationResponse{DeviceIDs: deviceIDs})
+	}
+	return resp, nil
 }
 
 // GetDevicePluginOptions is Standard interface to kubelet.
 func (ps *PluginServer) GetDevicePluginOptions(ctx context.Context, e *v1beta1.Empty) (*v1beta1.DevicePluginOptions,
 	error) {
-	return &v1beta1.DevicePluginOptions{}, nil
+	return ps.devicePluginOptions(), nil
 }
 
 // PreStartContainer is Standard interface to kubelet with empty implement.
@@ -1426,5 +1451,6 @@ func NewPluginServer(deviceType string, devices []*common.NpuDevice, defaultDevs
 	}
 	ps.restartTimes.Store(0)
 	ps.deepCopyDevice(devices)
+	ps.syncCDISpec()
 	return ps
 }
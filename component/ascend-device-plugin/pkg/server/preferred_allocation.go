@@ -0,0 +1,258 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package server holds the implementation of registration to kubelet, k8s device plugin interface and grpc service.
+package server
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kubelet/pkg/apis/deviceplugin/v1beta1"
+
+	"Ascend-device-plugin/pkg/common"
+	"Ascend-device-plugin/pkg/device"
+	"Ascend-device-plugin/pkg/next/devicefactory/customname"
+	"ascend-common/common-utils/hwlog"
+)
+
+const noNumaNode = -1
+
+// topoDevice topology of a device which can be allocated
+type topoDevice struct {
+	id    string
+	phyID int32
+	ring  int32
+	card  int32
+	numa  int64
+	// preferred the device is healthy, not manually separated and not being drained
+	preferred bool
+	known     bool
+}
+
+// isPreferredAllocationSupported kubelet picks devices by the preferred allocation only when volcano is not used,
+// virtual devices have no topology
+func (ps *PluginServer) isPreferredAllocationSupported() bool {
+	return !common.ParamOption.UseVolcanoType && !common.IsVirtualDev(ps.deviceType)
+}
+
+// devicePluginOptions options of the device plugin, given to kubelet by registration and GetDevicePluginOptions
+func (ps *PluginServer) devicePluginOptions() *v1beta1.DevicePluginOptions {
+	return &v1beta1.DevicePluginOptions{GetPreferredAllocationAvailable: ps.isPreferredAllocationSupported()}
+}
+
+// preferredAllocation returns the best-connected devices of the request, the devices of the same hccs ring,
+// the same card and the same numa node are preferred in order
+func (ps *PluginServer) preferredAllocation(req *v1beta1.ContainerPreferredAllocationRequest) ([]string, error) {
+	size := int(req.AllocationSize)
+	available := sets.NewString(req.AvailableDeviceIDs...)
+	if size > available.Len() || len(req.MustIncludeDeviceIDs) > size {
+		return nil, fmt.Errorf("allocation size %d is invalid, available: %d, must include: %d", size,
+			available.Len(), len(req.MustIncludeDeviceIDs))
+	}
+	topo, cardSize := ps.getTopoDevices(req.AvailableDeviceIDs)
+	selector := newTopoSelector(topo, cardSize)
+	for _, id := range req.MustIncludeDeviceIDs {
+		if _, ok := selector.free[id]; !ok {
+			return nil, fmt.Errorf("device %s must be included is not available", id)
+		}
+		selector.selectDevice(id)
+	}
+	for len(selector.selected) < size {
+		id := selector.next(size - len(selector.selected))
+		if id == "" {
+			return nil, fmt.Errorf("only %d devices are available for allocation size %d",
+				len(selector.selected), size)
+		}
+		selector.selectDevice(id)
+	}
+	return selector.selected, nil
+}
+
+// getTopoDevices gets the topology of the available devices by the cached devices, and the number of chips of
+// each card in the cached devices. duplicated ids are only returned once
+func (ps *PluginServer) getTopoDevices(ids []string) ([]*topoDevice, map[int32]int) {
+	separated := sets.NewInt32(common.QueryManuallyFaultNPULogicIDsByHandleStatus(common.ManuallySeparateNpuAll)...)
+	ringSize := ps.getHccsRingSize()
+	ps.cachedLock.RLock()
+	known := make(map[string]common.NpuDevice, len(ps.cachedDevices))
+	cardSize := make(map[int32]int)
+	for _, dev := range ps.cachedDevices {
+		known[customname.ReplaceDevicePublicName(ps.deviceType, dev.DeviceName)] = dev
+		cardSize[dev.CardID]++
+	}
+	ps.cachedLock.RUnlock()
+	topo := make([]*topoDevice, 0, len(ids))
+	added := sets.NewString()
+	for _, id := range ids {
+		if added.Has(id) {
+			continue
+		}
+		added.Insert(id)
+		dev, ok := known[id]
+		if !ok {
+			hwlog.RunLog.Warnf("topology of device %s is unknown, it is allocated at last", id)
+			topo = append(topo, &topoDevice{id: id, ring: -1, card: -1, numa: noNumaNode})
+			continue
+		}
+		numa := int64(noNumaNode)
+		if nodes := device.GetNumaNodesByPhyID(dev.PhyID); len(nodes) > 0 {
+			numa = nodes[0]
+		}
+		ring := dev.PhyID / int32(ringSize)
+		if common.IsContainAtlas300IDuo() {
+			// chips of duo card are connected by the card
+			ring = dev.CardID
+		}
+		preferred := dev.Health == v1beta1.Healthy && !separated.Has(dev.LogicID) && !device.IsNPUDraining(dev.PhyID)
+		topo = append(topo, &topoDevice{id: id, phyID: dev.PhyID, ring: ring, card: dev.CardID, numa: numa,
+			preferred: preferred, known: true})
+	}
+	return topo, cardSize
+}
+
+func (ps *PluginServer) getHccsRingSize() int {
+	boardId, err := ps.manager.GetServerBoardId(common.FirstDevice)
+	if err != nil {
+		hwlog.RunLog.Warnf("get board id failed: %v, use default 0", err)
+		boardId = 0
+	}
+	ps.cachedLock.RLock()
+	deviceNum := len(ps.cachedDevices)
+	ps.cachedLock.RUnlock()
+	if ringSize := hccsRingSize(boardId, deviceNum); ringSize > 0 {
+		return ringSize
+	}
+	return 1
+}
+
+// topoSelector selects the devices one by one, each time the device best connected to the selected ones is picked
+type topoSelector struct {
+	free     map[string]*topoDevice
+	order    []*topoDevice
+	selected []string
+	rings    map[int32]int
+	cards    map[int32]int
+	numas    map[int64]int
+	// ringFree and cardFree number of free preferred devices of each ring and card
+	ringFree map[int32]int
+	cardFree map[int32]int
+	// cardSize number of all chips of each card, including the chips in use
+	cardSize map[int32]int
+}
+
+func newTopoSelector(topo []*topoDevice, cardSize map[int32]int) *topoSelector {
+	s := &topoSelector{
+		free:     make(map[string]*topoDevice, len(topo)),
+		order:    topo,
+		rings:    make(map[int32]int),
+		cards:    make(map[int32]int),
+		numas:    make(map[int64]int),
+		ringFree: make(map[int32]int),
+		cardFree: make(map[int32]int),
+		cardSize: cardSize,
+	}
+	for _, dev := range topo {
+		s.free[dev.id] = dev
+		if !dev.known || !dev.preferred {
+			continue
+		}
+		s.ringFree[dev.ring]++
+		s.cardFree[dev.card]++
+	}
+	return s
+}
+
+func (s *topoSelector) selectDevice(id string) {
+	dev, ok := s.free[id]
+	if !ok {
+		return
+	}
+	delete(s.free, id)
+	s.selected = append(s.selected, id)
+	if !dev.known {
+		return
+	}
+	s.rings[dev.ring]++
+	s.cards[dev.card]++
+	s.numas[dev.numa]++
+	if dev.preferred {
+		s.ringFree[dev.ring]--
+		s.cardFree[dev.card]--
+	}
+}
+
+// next returns the free device best connected to the selected ones, need is the number of devices still needed
+func (s *topoSelector) next(need int) string {
+	var best *topoDevice
+	for _, dev := range s.order {
+		if _, ok := s.free[dev.id]; !ok {
+			continue
+		}
+		if best == nil || s.better(dev, best, need) {
+			best = dev
+		}
+	}
+	if best == nil {
+		return ""
+	}
+	return best.id
+}
+
+// better compares the devices by health, hccs ring, card, numa node and physic id in order
+func (s *topoSelector) better(a, b *topoDevice, need int) bool {
+	if a.preferred != b.preferred {
+		return a.preferred
+	}
+	if a.known != b.known {
+		return a.known
+	}
+	if aRing, bRing := s.rings[a.ring] > 0, s.rings[b.ring] > 0; aRing != bRing {
+		return aRing
+	}
+	if a.ring != b.ring && s.rings[a.ring] == 0 {
+		if cmp := s.compareRing(a.ring, b.ring, need); cmp != 0 {
+			return cmp > 0
+		}
+	}
+	if aCard, bCard := s.cards[a.card] > 0, s.cards[b.card] > 0; aCard != bCard {
+		return aCard
+	}
+	// cards with all chips free are preferred, so the other cards are not split
+	if aWhole, bWhole := s.cardFree[a.card] == s.cardSize[a.card], s.cardFree[b.card] == s.cardSize[b.card]; aWhole !=
+		bWhole {
+		return aWhole
+	}
+	if aNuma, bNuma := s.numas[a.numa] > 0, s.numas[b.numa] > 0; aNuma != bNuma {
+		return aNuma
+	}
+	return a.phyID < b.phyID
+}
+
+// compareRing the smallest ring holding all the needed devices is preferred to reduce the fragments, otherwise the
+// largest ring is preferred. it returns a positive number when ring a is better, 0 when they are the same
+func (s *topoSelector) compareRing(a, b int32, need int) int {
+	aFree, bFree := s.ringFree[a], s.ringFree[b]
+	aFit, bFit := aFree >= need, bFree >= need
+	if aFit != bFit {
+		if aFit {
+			return 1
+		}
+		return -1
+	}
+	if aFit {
+		return bFree - aFree
+	}
+	return aFree - bFree
+}
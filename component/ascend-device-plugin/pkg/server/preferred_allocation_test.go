@@ -0,0 +1,170 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package server test for preferred allocation
+package server
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/smartystreets/goconvey/convey"
+	"k8s.io/kubelet/pkg/apis/deviceplugin/v1beta1"
+
+	"Ascend-device-plugin/pkg/common"
+	"Ascend-device-plugin/pkg/device"
+	"ascend-common/api"
+)
+
+const testTopoDeviceNum = 8
+
+func newTopoPluginServer() *PluginServer {
+	devs := make([]*common.NpuDevice, 0, testTopoDeviceNum)
+	for i := int32(0); i < testTopoDeviceNum; i++ {
+		devs = append(devs, &common.NpuDevice{DevType: api.Ascend910, DeviceName: fmt.Sprintf("%s-%d", api.Ascend910, i),
+			Health: v1beta1.Healthy, PhyID: i, LogicID: i, CardID: i})
+	}
+	return NewPluginServer(api.Ascend910, devs, nil, device.NewHwAscend910Manager())
+}
+
+func newPreferredRequest(size int32, must []string, available ...int) *v1beta1.PreferredAllocationRequest {
+	ids := make([]string, 0, len(available))
+	for _, i := range available {
+		ids = append(ids, fmt.Sprintf("%s-%d", api.Ascend910, i))
+	}
+	return &v1beta1.PreferredAllocationRequest{ContainerRequests: []*v1beta1.ContainerPreferredAllocationRequest{
+		{AvailableDeviceIDs: ids, MustIncludeDeviceIDs: must, AllocationSize: size}}}
+}
+
+// TestGetPreferredAllocationByTopo test the devices of the same hccs ring are preferred
+func TestGetPreferredAllocationByTopo(t *testing.T) {
+	patches := gomonkey.ApplyMethodReturn(&device.AscendTools{}, "GetServerBoardId", uint32(0), nil).
+		ApplyFuncReturn(common.QueryManuallyFaultNPULogicIDsByHandleStatus, []int32{})
+	defer patches.Reset()
+	originCardType, originVolcano := common.ParamOption.RealCardType, common.ParamOption.UseVolcanoType
+	common.ParamOption.RealCardType, common.ParamOption.UseVolcanoType = api.Ascend910A, false
+	defer func() {
+		common.ParamOption.RealCardType, common.ParamOption.UseVolcanoType = originCardType, originVolcano
+	}()
+	ps := newTopoPluginServer()
+	convey.Convey("test GetPreferredAllocation", t, func() {
+		convey.Convey("devices of the same ring are returned", func() {
+			resp, err := ps.GetPreferredAllocation(context.Background(), newPreferredRequest(4, nil, 7, 0, 4, 5, 1, 6, 2, 3))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(resp.ContainerResponses[0].DeviceIDs, convey.ShouldResemble,
+				[]string{"Ascend910-0", "Ascend910-1", "Ascend910-2", "Ascend910-3"})
+		})
+		convey.Convey("the smallest ring holding the request is preferred", func() {
+			resp, err := ps.GetPreferredAllocation(context.Background(), newPreferredRequest(2, nil, 0, 1, 2, 3, 4, 5))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(resp.ContainerResponses[0].DeviceIDs, convey.ShouldResemble, []string{"Ascend910-4", "Ascend910-5"})
+		})
+		convey.Convey("must include devices are kept and the ring of them is preferred", func() {
+			resp, err := ps.GetPreferredAllocation(context.Background(),
+				newPreferredRequest(2, []string{"Ascend910-6"}, 0, 1, 2, 3, 6, 7))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(resp.ContainerResponses[0].DeviceIDs, convey.ShouldResemble, []string{"Ascend910-6", "Ascend910-7"})
+		})
+		convey.Convey("invalid size returns error", func() {
+			_, err := ps.GetPreferredAllocation(context.Background(), newPreferredRequest(3, nil, 0, 1))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("duplicated available devices are counted once", func() {
+			_, err := ps.GetPreferredAllocation(context.Background(), newPreferredRequest(2, nil, 0, 0))
+			convey.So(err, convey.ShouldNotBeNil)
+			resp, err := ps.GetPreferredAllocation(context.Background(), newPreferredRequest(2, nil, 1, 1, 0))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(resp.ContainerResponses[0].DeviceIDs, convey.ShouldResemble, []string{"Ascend910-0", "Ascend910-1"})
+		})
+	})
+}
+
+// TestPreferredAllocationUnhealthy test the unhealthy and manually separated devices are allocated at last
+func TestPreferredAllocationUnhealthy(t *testing.T) {
+	patches := gomonkey.ApplyMethodReturn(&device.AscendTools{}, "GetServerBoardId", uint32(0), nil).
+		ApplyFuncReturn(common.QueryManuallyFaultNPULogicIDsByHandleStatus, []int32{1})
+	defer patches.Reset()
+	originCardType := common.ParamOption.RealCardType
+	common.ParamOption.RealCardType = api.Ascend910A
+	defer func() { common.ParamOption.RealCardType = originCardType }()
+	ps := newTopoPluginServer()
+	ps.cachedDevices[0].Health = v1beta1.Unhealthy
+	convey.Convey("test preferredAllocation with unhealthy devices", t, func() {
+		req := newPreferredRequest(4, nil, 0, 1, 2, 3, 4, 5, 6, 7)
+		ids, err := ps.preferredAllocation(req.ContainerRequests[0])
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(ids, convey.ShouldResemble, []string{"Ascend910-4", "Ascend910-5", "Ascend910-6", "Ascend910-7"})
+
+		req = newPreferredRequest(3, []string{"Ascend910-9"}, 0, 1, 2)
+		_, err = ps.preferredAllocation(req.ContainerRequests[0])
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+}
+
+// TestPreferredAllocationWholeCard test the cards with all chips free are preferred
+func TestPreferredAllocationWholeCard(t *testing.T) {
+	patches := gomonkey.ApplyMethodReturn(&device.AscendTools{}, "GetServerBoardId", uint32(0), nil).
+		ApplyFuncReturn(common.QueryManuallyFaultNPULogicIDsByHandleStatus, []int32{})
+	defer patches.Reset()
+	originCardType := common.ParamOption.RealCardType
+	common.ParamOption.RealCardType = api.Ascend910A
+	defer func() { common.ParamOption.RealCardType = originCardType }()
+	ps := newTopoPluginServer()
+	// two chips on each card
+	for i := range ps.cachedDevices {
+		ps.cachedDevices[i].CardID = ps.cachedDevices[i].PhyID / 2
+	}
+	convey.Convey("test preferredAllocation when a chip of the card is in use", t, func() {
+		// chip 0 is in use, card 0 is not whole any more
+		ids, err := ps.preferredAllocation(newPreferredRequest(1, nil, 1, 2, 3).ContainerRequests[0])
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(ids, convey.ShouldResemble, []string{"Ascend910-2"})
+	})
+}
+
+func TestIsPreferredAllocationSupported(t *testing.T) {
+	convey.Convey("test isPreferredAllocationSupported", t, func() {
+		origin := common.ParamOption.UseVolcanoType
+		defer func() { common.ParamOption.UseVolcanoType = origin }()
+		common.ParamOption.UseVolcanoType = true
+		ps := NewPluginServer(api.Ascend910, nil, nil, nil)
+		convey.So(ps.isPreferredAllocationSupported(), convey.ShouldBeFalse)
+		opts, err := ps.GetDevicePluginOptions(context.Background(), nil)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(opts.GetPreferredAllocationAvailable, convey.ShouldBeFalse)
+		common.ParamOption.UseVolcanoType = false
+		convey.So(ps.isPreferredAllocationSupported(), convey.ShouldBeTrue)
+		convey.So(NewPluginServer(common.Ascend910vir2, nil, nil, nil).isPreferredAllocationSupported(),
+			convey.ShouldBeFalse)
+	})
+}
+
+// TestPreferredAllocationDraining test the npu being drained is not allocated to a new pod
+func TestPreferredAllocationDraining(t *testing.T) {
+	patches := gomonkey.ApplyMethodReturn(&device.AscendTools{}, "GetServerBoardId", uint32(0), nil).
+		ApplyFuncReturn(common.QueryManuallyFaultNPULogicIDsByHandleStatus, []int32{}).
+		ApplyFunc(device.IsNPUDraining, func(phyID int32) bool { return phyID == 0 })
+	defer patches.Reset()
+	originCardType := common.ParamOption.RealCardType
+	common.ParamOption.RealCardType = api.Ascend910A
+	defer func() { common.ParamOption.RealCardType = originCardType }()
+	ps := newTopoPluginServer()
+	convey.Convey("test preferredAllocation of a new pod during a drain", t, func() {
+		ids, err := ps.preferredAllocation(newPreferredRequest(1, nil, 0, 1).ContainerRequests[0])
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(ids, convey.ShouldResemble, []string{"Ascend910-1"})
+	})
+}
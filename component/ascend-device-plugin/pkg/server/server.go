@@ -142,16 +142,22 @@ func (ps *PluginServer) register() error {
 	}()
 
 	client := v1beta1.NewRegistrationClient(conn)
+	if _, err = client.Register(context.Background(), ps.registerRequest()); err != nil {
+		return fmt.Errorf("register to kubelet fail: %v", err)
+	}
+	return nil
+}
+
+// registerRequest build the request registering to kubelet, kubelet before 1.25 reads the device plugin
+// options only from the request instead of GetDevicePluginOptions
+func (ps *PluginServer) registerRequest() *v1beta1.RegisterRequest {
 	resourceName := customname.ReplaceDevicePublicType(ps.deviceType, api.ResourceNamePrefix+ps.deviceType)
-	reqt := &v1beta1.RegisterRequest{
+	return &v1beta1.RegisterRequest{
 		Version:      v1beta1.Version,
 		Endpoint:     fmt.Sprintf("%s.sock", ps.deviceType),
 		ResourceName: resourceName,
+		Options:      ps.devicePluginOptions(),
 	}
-	if _, err = client.Register(context.Background(), reqt); err != nil {
-		return fmt.Errorf("register to kubelet fail: %v", err)
-	}
-	return nil
 }
 
 // need privilege
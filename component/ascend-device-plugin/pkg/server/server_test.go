@@ -277,3 +277,25 @@ func TestPluginServerStartPart5(t *testing.T) {
 		convey.So(err, convey.ShouldNotBeNil)
 	})
 }
+
+// TestPluginServerRegisterRequest Test PluginServer registerRequest()
+func TestPluginServerRegisterRequest(t *testing.T) {
+	convey.Convey("test registerRequest", t, func() {
+		origin := common.ParamOption.UseVolcanoType
+		defer func() { common.ParamOption.UseVolcanoType = origin }()
+		ps := NewPluginServer(api.Ascend910, nil, nil, nil)
+		convey.Convey("preferred allocation is supported, should be given by registration", func() {
+			common.ParamOption.UseVolcanoType = false
+			reqt := ps.registerRequest()
+			convey.So(reqt.Version, convey.ShouldEqual, v1beta1.Version)
+			convey.So(reqt.Endpoint, convey.ShouldEqual, api.Ascend910+".sock")
+			convey.So(reqt.ResourceName, convey.ShouldEqual, api.ResourceNamePrefix+api.Ascend910)
+			convey.So(reqt.Options, convey.ShouldNotBeNil)
+			convey.So(reqt.Options.GetPreferredAllocationAvailable, convey.ShouldBeTrue)
+		})
+		convey.Convey("volcano is used, preferred allocation should not be available", func() {
+			common.ParamOption.UseVolcanoType = true
+			convey.So(ps.registerRequest().Options.GetPreferredAllocationAvailable, convey.ShouldBeFalse)
+		})
+	})
+}
@@ -20,6 +20,7 @@ import (
 	"sync/atomic"
 
 	"google.golang.org/grpc"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/kubelet/pkg/apis/podresources/v1alpha1"
 
 	"Ascend-device-plugin/pkg/common"
@@ -54,6 +55,9 @@ type PluginServer struct {
 	restartTimes         atomic.Uint64
 	podLock              sync.Mutex
 	softShareJobs        sync.Map
+	cdiLock              sync.Mutex
+	// cdiSpecIDs device ids in the cdi spec files of the server, keyed by whether the devices are virtual
+	cdiSpecIDs map[bool]sets.Int
 }
 
 // PodDevice define device info in pod
@@ -65,6 +65,8 @@ const (
 	PodIP = "POD_IP"
 	// GrpcPort is the port of Grpc server
 	GrpcPort = ":8899"
+	// ClusterdServerName is the name in certificate of clusterd grpc server
+	ClusterdServerName = "clusterd-grpc-svc.mindx-dl.svc.cluster.local"
 	// MaxConfigMapNum the top number of config map size allowed created
 	MaxConfigMapNum = 20000
 	// RestartInterval is the interval judge that the pod is restarted or not, unit is milliseconds
@@ -27,9 +27,11 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 
 	"ascend-common/common-utils/hwlog"
+	"ascend-common/common-utils/tlsutil"
 	autils "ascend-common/common-utils/utils"
 	"ascend-faultdiag-online/pkg/core/model/enum"
 	"ascend-faultdiag-online/pkg/model"
@@ -47,6 +49,61 @@ const (
 
 var storage = utils.NewStorage[*model.JobSummary]()
 
+// Security is the tls and token config to connect clusterd
+type Security struct {
+	TLS tlsutil.Config
+	// TokenFile is the projected service account token file, sent instead of or together with client certificate
+	TokenFile string
+	// ServerName is the name in certificate of clusterd, default is constants.ClusterdServerName
+	ServerName string
+}
+
+var (
+	tlsReloader *tlsutil.Reloader
+	tokenCreds  *tlsutil.TokenFileCredentials
+	serverName  = constants.ClusterdServerName
+)
+
+// SetSecurity enable tls for the connection to clusterd, must be called before the first GetClient.
+// The tls files are reloaded when rotated until ctx done
+func SetSecurity(ctx context.Context, security Security) error {
+	if err := security.TLS.Validate(); err != nil {
+		return err
+	}
+	if !security.TLS.Enabled() {
+		if security.TokenFile != "" {
+			return errors.New("token can only be sent over tls, ca file should be set")
+		}
+		return nil
+	}
+	reloader, err := tlsutil.NewReloader(security.TLS)
+	if err != nil {
+		return err
+	}
+	go reloader.Watch(ctx, tlsutil.DefaultReloadInterval)
+	tlsReloader = reloader
+	if security.TokenFile != "" {
+		tokenCreds = tlsutil.NewTokenFileCredentials(security.TokenFile)
+	}
+	if security.ServerName != "" {
+		serverName = security.ServerName
+	}
+	return nil
+}
+
+func dialOptions() []grpc.DialOption {
+	if tlsReloader == nil {
+		return []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+	// clusterd is connected by ip, so the server name is required to verify its certificate
+	creds := credentials.NewTLS(tlsReloader.ClientConfig(serverName))
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if tokenCreds != nil {
+		opts = append(opts, grpc.WithPerRPCCredentials(tokenCreds))
+	}
+	return opts
+}
+
 type callback struct {
 	registerId string
 	jobName    string
@@ -95,7 +152,7 @@ func (c *Client) connect(host string) error {
 	serverAddr := host + constants.GrpcPort
 	c.conn, err = grpc.Dial(
 		serverAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		dialOptions()...,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to connect to grpc server: %v", err)
@@ -32,9 +32,11 @@ import (
 	"google.golang.org/grpc"
 
 	"ascend-common/common-utils/hwlog"
+	"ascend-common/common-utils/tlsutil"
 	"ascend-faultdiag-online/pkg/core/model/enum"
 	"ascend-faultdiag-online/pkg/model"
 	"ascend-faultdiag-online/pkg/utils"
+	"ascend-faultdiag-online/pkg/utils/constants"
 	"ascend-faultdiag-online/pkg/utils/grpc/job"
 	"ascend-faultdiag-online/pkg/utils/grpc/profiling"
 	"ascend-faultdiag-online/pkg/utils/grpc/pubfault"
@@ -437,3 +439,30 @@ func testSubFailed(c *Client) {
 	convey.So(c.callbacks.Len(), convey.ShouldEqual, 0)
 
 }
+
+func TestSetSecurity(t *testing.T) {
+	const testTokenFile = "/var/run/secrets/tokens/clusterd-token"
+	convey.Convey("Test SetSecurity", t, func() {
+		defer func() {
+			tlsReloader, tokenCreds, serverName = nil, nil, constants.ClusterdServerName
+		}()
+		convey.Convey("tls is not configured, should dial insecurely", func() {
+			convey.So(SetSecurity(context.Background(), Security{}), convey.ShouldBeNil)
+			convey.So(len(dialOptions()), convey.ShouldEqual, 1)
+			convey.So(SetSecurity(context.Background(), Security{TokenFile: testTokenFile}), convey.ShouldNotBeNil)
+			convey.So(SetSecurity(context.Background(), Security{TLS: tlsutil.Config{CertFile: "tls.crt"}}),
+				convey.ShouldNotBeNil)
+		})
+		convey.Convey("tls and token are configured, should dial with both", func() {
+			patches := gomonkey.ApplyFuncReturn(tlsutil.NewReloader, &tlsutil.Reloader{}, nil)
+			defer patches.Reset()
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			err := SetSecurity(ctx, Security{TLS: tlsutil.Config{CAFile: "ca.crt"}, TokenFile: testTokenFile,
+				ServerName: "clusterd"})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(len(dialOptions()), convey.ShouldEqual, 2)
+			convey.So(serverName, convey.ShouldEqual, "clusterd")
+		})
+	})
+}
@@ -0,0 +1,89 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package main recover-simulator drives the recover state machine of clusterd with a yaml scenario offline,
+// and prints state path graph and signals sent to agent
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"k8s.io/client-go/kubernetes/fake"
+
+	"ascend-common/common-utils/hwlog"
+	"ascend-common/common-utils/utils"
+	"clusterd/pkg/application/recover/simulator"
+	"clusterd/pkg/interface/kube"
+)
+
+const (
+	defaultLogLevel = 2
+	outputFileMode  = 0640
+)
+
+var (
+	scenarioFile string
+	outputFile   string
+	logLevel     int
+)
+
+func init() {
+	flag.StringVar(&scenarioFile, "scenario", "", "yaml scenario file of the simulation")
+	flag.StringVar(&outputFile, "output", "", "file to write json result, default stdout")
+	flag.IntVar(&logLevel, "logLevel", defaultLogLevel, "log level of clusterd, -1-debug, 0-info, "+
+		"1-warning, 2-error, 3-critical(default 2)")
+}
+
+func main() {
+	flag.Parse()
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	if scenarioFile == "" {
+		return fmt.Errorf("scenario file is not specified")
+	}
+	if err := hwlog.InitRunLogger(&hwlog.LogConfig{OnlyToStdout: true, LogLevel: logLevel},
+		context.Background()); err != nil {
+		return fmt.Errorf("init log failed, %v", err)
+	}
+	data, err := utils.LoadFile(scenarioFile)
+	if err != nil {
+		return fmt.Errorf("read scenario file failed, %v", err)
+	}
+	scenario, err := simulator.ParseScenario(data)
+	if err != nil {
+		return err
+	}
+	if err = kube.InitClientK8sByClientSet(fake.NewSimpleClientset()); err != nil {
+		return fmt.Errorf("init k8s client failed, %v", err)
+	}
+	result, err := simulator.Simulate(context.Background(), scenario)
+	if err != nil {
+		return fmt.Errorf("simulate failed, %v", err)
+	}
+	var output bytes.Buffer
+	encoder := json.NewEncoder(&output)
+	// keep "-->" of state path graph readable
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(result); err != nil {
+		return fmt.Errorf("marshal result failed, %v", err)
+	}
+	if outputFile == "" {
+		fmt.Print(output.String())
+	} else if err = os.WriteFile(outputFile, output.Bytes(), outputFileMode); err != nil {
+		return fmt.Errorf("write result failed, %v", err)
+	}
+	if result.TimedOut {
+		return fmt.Errorf("simulation did not settle in %d seconds", scenario.DurationSeconds)
+	}
+	return nil
+}
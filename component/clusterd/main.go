@@ -5,18 +5,22 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"flag"
 	"fmt"
+	"sync"
 	"syscall"
 	"time"
 
 	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
 
 	"ascend-common/common-utils/agreement"
 	"ascend-common/common-utils/healthz"
 	"ascend-common/common-utils/hwlog"
+	"ascend-common/common-utils/tlsutil"
 	"clusterd/pkg/application/conf"
 	"clusterd/pkg/application/faultmanager"
 	"clusterd/pkg/application/fdapi"
@@ -29,19 +33,27 @@ import (
 	"clusterd/pkg/application/schedulingexception"
 	"clusterd/pkg/application/statistics"
 	"clusterd/pkg/common/constant"
+	"clusterd/pkg/common/journal"
 	"clusterd/pkg/common/logs"
+	"clusterd/pkg/common/metrics"
+	"clusterd/pkg/common/notifier"
 	"clusterd/pkg/common/util"
 	"clusterd/pkg/domain/epranktable"
 	"clusterd/pkg/domain/job"
 	manualfault2 "clusterd/pkg/domain/manualfault"
 	sv "clusterd/pkg/interface/grpc"
+	"clusterd/pkg/interface/grpc/auth"
 	"clusterd/pkg/interface/kube"
+	"clusterd/pkg/interface/restapi"
 )
 
 const (
 	defaultLogFile        = "/var/log/mindx-dl/clusterd/clusterd.log"
 	grpcKeepAliveTimeOut  = 5
 	grpcKeepAliveInterval = 3
+	defaultLeaseDuration  = 15
+	defaultRenewDeadline  = 10
+	defaultRetryPeriod    = 2
 )
 
 var (
@@ -52,13 +64,28 @@ var (
 	BuildName  string
 	version    bool
 	server     *sv.ClusterInfoMgrServer
+	serverLock sync.Mutex
 	limiterMap = map[string]*rate.Limiter{
 		constant.RecoverGrpcProbe: rate.NewLimiter(rate.Every(time.Second/constant.MaxServeJobs),
 			constant.MaxServeJobs),
 		constant.BusinessGrpcReq: rate.NewLimiter(rate.Every(time.Second/constant.QpsLimit), constant.QpsLimit),
 	}
-	useProxy bool
-	hzFlags  = healthz.RegisterFlags()
+	useProxy      bool
+	hzFlags       = healthz.RegisterFlags()
+	leaderElect   bool
+	leaseName     string
+	leaseDuration int
+	renewDeadline int
+	retryPeriod   int
+	grpcTLS       tlsutil.Config
+	authPolicy    string
+	tokenAudience string
+	queryApi      bool
+	journalCfg    journal.Config
+	k8sEvents     bool
+	eventCfg      kube.EventConfig
+	notifierCfg   string
+	receiverCfg   string
 )
 
 func limitQPS(ctx context.Context, req interface{},
@@ -70,11 +97,19 @@ func limitQPS(ctx context.Context, req interface{},
 	if !limiter.Allow() {
 		hwlog.RunLog.Errorf("qps exceeded, method=%s, limit=%.1f, burst=%d, tokens=%.2f", info.FullMethod,
 			limiter.Limit(), limiter.Burst(), limiter.Tokens())
+		metrics.IncQpsLimitRejected(info.FullMethod)
 		return nil, fmt.Errorf("qps exceeded, method=%s", info.FullMethod)
 	}
 	return handler(ctx, req)
 }
 
+func countStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	metrics.IncSubscribeStream(info.FullMethod)
+	defer metrics.DecSubscribeStream(info.FullMethod)
+	return handler(srv, ss)
+}
+
 func startInformer(ctx context.Context) {
 	// starting informer requires after adding processing functions
 	addResourceFunc()
@@ -87,18 +122,56 @@ func startInformer(ctx context.Context) {
 	kube.InitPodGroupInformer()
 	go kube.InitACJobInformer()
 	go kube.InitVCJobInformer()
-	go pingmesh.TickerCheckSuperPodDevice(ctx)
 	// specific functions requires after informer
 	addFuncAfterInformer()
+}
 
+// startLeaderServices start the services which must be run by only one clusterd replica, that is all the loops
+// writing to the apiserver or acting on faults, followers only keep the informers and caches
+func startLeaderServices(ctx context.Context) {
+	if k8sEvents {
+		if err := kube.InitEventRecorder(ctx, eventCfg); err != nil {
+			hwlog.RunLog.Errorf("init kubernetes event recorder failed, error: %v", err)
+		}
+	}
+	if notifierCfg != "" {
+		if err := notifier.Init(ctx, notifierCfg); err != nil {
+			hwlog.RunLog.Errorf("init notifier failed, error: %v", err)
+		}
+	}
+	publicfault.ActivateReceiver(ctx)
+	// deal manually separate npu fault must before fault processor center
+	go manualfault.ProcessManuSep(ctx)
+	initGrpcServer(ctx)
+	fdapi.StartFdOL(ctx, grpcTLS)
+	faultmanager.GlobalFaultProcessCenter.Work(ctx)
+	go pingmesh.TickerCheckSuperPodDevice(ctx)
 	// generate global ranktable message handler
 	go epranktable.GetEpGlobalRankTableManager().ConsumerForQueue()
 	go kube.StartFaultJobReleaseInfoConsumer(ctx)
-
 	go jobv2.Handler(ctx)
 	go jobv2.Checker(ctx)
 	go resource.Report(ctx)
 	dealPubFault(ctx)
+	startStatisticOutput(ctx)
+	go job.RefreshFaultJobInfo(ctx)
+}
+
+func runLeaderElection(ctx context.Context, cancel context.CancelFunc) {
+	cfg, err := kube.NewLeaderElectionConfig(leaseName, leaseDuration, renewDeadline, retryPeriod)
+	if err != nil {
+		hwlog.RunLog.Errorf("init leader election config failed, error: %v", err)
+		cancel()
+		return
+	}
+	err = kube.RunLeaderElection(ctx, cfg, startLeaderServices, func() {
+		// leader services can not be restarted in process, exit and come back as a follower
+		cancel()
+	})
+	if err != nil {
+		hwlog.RunLog.Errorf("run leader election failed, error: %v", err)
+		cancel()
+	}
 }
 
 func dealPubFault(ctx context.Context) {
@@ -106,12 +179,11 @@ func dealPubFault(ctx context.Context) {
 	go publicfault.PubFaultNeedDelete.DealDelete(ctx)
 }
 
-func dealManuallySeparateNPUFault(ctx context.Context) {
+func initManuallySeparateNPUCache() {
 	// the cache initialization must be performed before the manual fault processing functions
 	manualfault2.InitFaultCmInfo()
 
 	manualfault.LoadManualCmInfo()
-	go manualfault.ProcessManuSep(ctx)
 }
 
 func addJobFunc() {
@@ -151,6 +223,27 @@ func main() {
 		fmt.Printf("logger init failed: %v\n", err)
 		return
 	}
+	if err := healthz.RegisterHandler(metrics.Path, metrics.Handler()); err != nil {
+		hwlog.RunLog.Errorf("failed to register metrics handler: %v", err)
+		return
+	}
+	if queryApi {
+		if err := healthz.RegisterHandler(restapi.Path, restapi.Handler()); err != nil {
+			hwlog.RunLog.Errorf("failed to register query api handler: %v", err)
+			return
+		}
+	}
+	if receiverCfg != "" {
+		handler, err := publicfault.NewReceiver(receiverCfg)
+		if err != nil {
+			hwlog.RunLog.Errorf("failed to init public fault receiver: %v", err)
+			return
+		}
+		if err = healthz.RegisterHandler(publicfault.ReceiverPath, handler); err != nil {
+			hwlog.RunLog.Errorf("failed to register public fault receiver handler: %v", err)
+			return
+		}
+	}
 	if err := hzFlags.Serve(ctx); err != nil {
 		hwlog.RunLog.Errorf("failed to start healthz server: %v", err)
 		return
@@ -159,34 +252,74 @@ func main() {
 		return
 	}
 	hwlog.RunLog.Infof("clusterd starting and the version is %s", BuildVersion)
+	if err := journal.Init(ctx, journalCfg); err != nil {
+		hwlog.RunLog.Errorf("init fault journal failed, error: %v", err)
+		return
+	}
 	if err := initK8sServer(); err != nil {
 		hwlog.RunLog.Errorf("init k8s servers failed, error: %v", err)
 		return
 	}
 	conf.TryLoadGlobalConfig()
 	go conf.WatchGlobalConfig(ctx)
-	// deal manually separate npu fault must before fault processor center
-	dealManuallySeparateNPUFault(ctx)
-	initGrpcServer(ctx)
-	fdapi.StartFdOL()
-	faultmanager.GlobalFaultProcessCenter.Work(ctx)
+	initManuallySeparateNPUCache()
+	// followers keep informers and caches warm, so that they can take over in seconds
 	startInformer(ctx)
-	initStatisticModule(ctx)
-	go job.RefreshFaultJobInfo(ctx)
+	initStatisticCache(ctx)
+	if leaderElect {
+		go runLeaderElection(ctx, cancel)
+	} else {
+		go kube.MarkStandaloneLeader(ctx)
+		startLeaderServices(ctx)
+	}
 	hwlog.RunLog.Info("clusterd starts to serve")
-	signalCatch(cancel)
+	signalCatch(ctx, cancel)
 }
 
-func initStatisticModule(ctx context.Context) {
+// initStatisticCache collect the statistics into cache, run by all replicas
+func initStatisticCache(ctx context.Context) {
 	go statistics.GlobalJobCollectMgr.JobCollector(ctx)
-	go statistics.GlobalJobOutputMgr.JobOutput(ctx)
-
 	// fault relation
-	go statistics.StatisticFault.UpdateFault(ctx)
 	statistics.StatisticFault.LoadFaultData()
+}
+
+// startStatisticOutput output the statistics to configmaps and check scheduling exception, run by the leader
+func startStatisticOutput(ctx context.Context) {
+	go statistics.GlobalJobOutputMgr.JobOutput(ctx)
+	go statistics.StatisticFault.UpdateFault(ctx)
 	schedulingexception.CheckSchedulingException(ctx, &schedulingexception.Config{})
 }
 
+// grpcSecurityOptions get the options of mutual tls and authorization, nil if tls is not configured
+func grpcSecurityOptions(ctx context.Context) ([]grpc.ServerOption, error) {
+	if !grpcTLS.Enabled() {
+		return nil, nil
+	}
+	reloader, err := tlsutil.NewReloader(grpcTLS)
+	if err != nil {
+		return nil, fmt.Errorf("load grpc tls files failed: %v", err)
+	}
+	go reloader.Watch(ctx, tlsutil.DefaultReloadInterval)
+	if authPolicy == "" {
+		hwlog.RunLog.Info("grpc mutual tls is enabled")
+		return []grpc.ServerOption{
+			grpc.Creds(credentials.NewTLS(reloader.ServerConfig(tls.RequireAndVerifyClientCert))),
+		}, nil
+	}
+	authorizer, err := auth.NewAuthorizer(authPolicy, tokenAudience)
+	if err != nil {
+		return nil, fmt.Errorf("load grpc authorization policy failed: %v", err)
+	}
+	go authorizer.Watch(ctx, tlsutil.DefaultReloadInterval)
+	hwlog.RunLog.Info("grpc mutual tls and authorization are enabled")
+	// clients without certificate can be authenticated by service account token, chained after qps limiter
+	return []grpc.ServerOption{
+		grpc.Creds(credentials.NewTLS(reloader.ServerConfig(tls.VerifyClientCertIfGiven))),
+		grpc.ChainUnaryInterceptor(authorizer.UnaryInterceptor),
+		grpc.ChainStreamInterceptor(authorizer.StreamInterceptor),
+	}, nil
+}
+
 func initGrpcServer(ctx context.Context) {
 	keepAlive := keepalive.ServerParameters{
 		Time:    time.Minute,
@@ -196,14 +329,23 @@ func initGrpcServer(ctx context.Context) {
 		MinTime:             grpcKeepAliveInterval * time.Second,
 		PermitWithoutStream: true,
 	}
-	server = sv.NewClusterInfoMgrServer([]grpc.ServerOption{
+	securityOpts, err := grpcSecurityOptions(ctx)
+	if err != nil {
+		hwlog.RunLog.Errorf("clusterd grpc server start failed, error: %v", err)
+		return
+	}
+	opts := []grpc.ServerOption{
 		grpc.MaxRecvMsgSize(constant.MaxGRPCRecvMsgSize),
 		grpc.MaxSendMsgSize(constant.MaxGRPCSendMsgSize),
 		grpc.MaxConcurrentStreams(constant.MaxGRPCConcurrentStreams),
-		grpc.UnaryInterceptor(limitQPS),
+		grpc.ChainUnaryInterceptor(limitQPS),
+		grpc.ChainStreamInterceptor(countStream),
 		grpc.KeepaliveParams(keepAlive),
 		grpc.KeepaliveEnforcementPolicy(keepAlivePolicy),
-	})
+	}
+	serverLock.Lock()
+	defer serverLock.Unlock()
+	server = sv.NewClusterInfoMgrServer(append(opts, securityOpts...))
 	if err := server.Start(ctx, useProxy); err != nil {
 		hwlog.RunLog.Errorf("clusterd grpc server start failed, error: %v", err)
 	}
@@ -239,13 +381,105 @@ func init() {
 	flag.IntVar(&hwLogConfig.MaxBackups, "maxBackups", hwlog.DefaultBackups,
 		"Maximum number of backup operator logs, range is (0, 180]")
 	flag.BoolVar(&useProxy, "useProxy", false, "use local grpc proxy")
+	flag.BoolVar(&leaderElect, "leaderElect", false,
+		"Enable leader election, only the lease holder runs fault processing and grpc services")
+	flag.StringVar(&leaseName, "leaseName", constant.DefaultLeaseName, "Name of lease used by leader election")
+	flag.IntVar(&leaseDuration, "leaseDuration", defaultLeaseDuration,
+		"Seconds followers wait before trying to acquire a lease which is not renewed(default 15)")
+	flag.IntVar(&renewDeadline, "renewDeadline", defaultRenewDeadline,
+		"Seconds the leader retries refreshing lease before giving up, must be less than leaseDuration(default 10)")
+	flag.IntVar(&retryPeriod, "retryPeriod", defaultRetryPeriod,
+		"Seconds between tries of acquiring or renewing lease, must be less than renewDeadline(default 2)")
+	flag.StringVar(&grpcTLS.CertFile, "grpcCertFile", "",
+		"Certificate file of grpc server, enable mutual tls together with grpcKeyFile and grpcCAFile")
+	flag.StringVar(&grpcTLS.KeyFile, "grpcKeyFile", "", "Private key file of grpc server")
+	flag.StringVar(&grpcTLS.CAFile, "grpcCAFile", "", "CA file to verify grpc client certificates")
+	flag.StringVar(&authPolicy, "grpcAuthPolicy", "",
+		"Authorization policy file of grpc services, requires mutual tls. Files are reloaded when rotated")
+	flag.StringVar(&tokenAudience, "grpcTokenAudience", auth.DefaultTokenAudience,
+		"Audience of service account tokens which clients send instead of certificate(default clusterd)")
+	flag.StringVar(&journalCfg.File, "faultJournalFile", journal.DefaultFile,
+		"Journal file of fault process decisions, queried by /api/v1/timeline, "+
+			"such as /var/log/mindx-dl/clusterd/fault_journal.log. Disabled when empty(default empty)")
+	flag.IntVar(&journalCfg.MaxSize, "faultJournalMaxSize", journal.DefaultMaxSize,
+		"Size in MB of fault journal file before rotated, range [1, 19](default 10)")
+	flag.IntVar(&journalCfg.MaxBackups, "faultJournalMaxBackups", journal.DefaultMaxBackups,
+		"Maximum number of rotated fault journal files, range [1, 180](default 30)")
+	flag.IntVar(&journalCfg.MaxAge, "faultJournalMaxAge", journal.DefaultMaxAge,
+		"Maximum days to keep rotated fault journal files, range [7, 700](default 7)")
+	flag.BoolVar(&queryApi, "queryApi", false,
+		"Serve read-only query api of faults, nodes, jobs and fault timeline under /api/v1/ on healthz server, "+
+			"requires enable-healthz")
+	flag.BoolVar(&k8sEvents, "k8sEvents", false,
+		"Record kubernetes events of fault and recover milestones on jobs, podgroups and nodes, "+
+			"and update FaultRecovery condition of AscendJob(default false)")
+	flag.Float64Var(&eventCfg.QPS, "eventQPS", kube.DefaultEventQPS,
+		"Rate of kubernetes events about one object, range (0, 1](default 0.2)")
+	flag.IntVar(&eventCfg.Burst, "eventBurst", kube.DefaultEventBurst,
+		"Burst of kubernetes events about one object, range [1, 50](default 25)")
+	flag.StringVar(&notifierCfg, "notifierConfig", "",
+		"Config file of notifier sinks, e.g. webhooks paged when job is rescheduled, npu is separated or pod group "+
+			"is stuck. Disabled when empty, reloaded when modified")
+	flag.StringVar(&receiverCfg, "pubFaultReceiverConfig", "",
+		"Config file of public fault receiver, which accepts alertmanager webhooks and public faults in json under "+
+			"/pubfault/v1/ on healthz server, requires enable-healthz. Disabled when empty, reloaded when modified")
 }
 
 func checkParameters() bool {
+	if err := grpcTLS.Validate(); err != nil {
+		hwlog.RunLog.Errorf("grpc tls parameters are invalid, error: %v", err)
+		return false
+	}
+	if grpcTLS.Enabled() && !grpcTLS.HasCertificate() {
+		hwlog.RunLog.Error("grpcCertFile and grpcKeyFile are required by grpc server when tls is enabled")
+		return false
+	}
+	if authPolicy != "" && !grpcTLS.Enabled() {
+		hwlog.RunLog.Error("grpcAuthPolicy requires mutual tls, grpcCertFile, grpcKeyFile and grpcCAFile should be set")
+		return false
+	}
+	if err := journalCfg.Validate(); err != nil {
+		hwlog.RunLog.Errorf("fault journal parameters are invalid, error: %v", err)
+		return false
+	}
+	if k8sEvents {
+		if err := eventCfg.Validate(); err != nil {
+			hwlog.RunLog.Errorf("kubernetes event parameters are invalid, error: %v", err)
+			return false
+		}
+	}
+	if queryApi && !hzFlags.EnableHealthz {
+		hwlog.RunLog.Error("queryApi is served on healthz server, enable-healthz should be set")
+		return false
+	}
+	if receiverCfg != "" && !hzFlags.EnableHealthz {
+		hwlog.RunLog.Error("pubFaultReceiverConfig is served on healthz server, enable-healthz should be set")
+		return false
+	}
+	if !leaderElect {
+		return true
+	}
+	if leaseName == "" {
+		hwlog.RunLog.Error("leaseName should not be empty")
+		return false
+	}
+	if retryPeriod <= 0 || renewDeadline <= retryPeriod || leaseDuration <= renewDeadline {
+		hwlog.RunLog.Errorf("leader election parameters should meet 0 < retryPeriod(%d) < renewDeadline(%d) "+
+			"< leaseDuration(%d)", retryPeriod, renewDeadline, leaseDuration)
+		return false
+	}
 	return true
 }
 
-func signalCatch(cancel context.CancelFunc) {
+func stopServer() {
+	serverLock.Lock()
+	defer serverLock.Unlock()
+	if server != nil {
+		server.Stop(false)
+	}
+}
+
+func signalCatch(ctx context.Context, cancel context.CancelFunc) {
 	osSignalChan := util.NewSignalWatcher(syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT, syscall.SIGKILL)
 	if osSignalChan == nil {
 		hwlog.RunLog.Error("create stop signal channel failed")
@@ -258,10 +492,11 @@ func signalCatch(cancel context.CancelFunc) {
 			return
 		}
 		hwlog.RunLog.Infof("receive system signal: %s, ClusterD shutting down", sig.String())
-		if server != nil {
-			server.Stop(false)
-		}
+		stopServer()
 		cancel()
+	case <-ctx.Done():
+		hwlog.RunLog.Info("context canceled, ClusterD shutting down")
+		stopServer()
 	}
 }
 
@@ -55,6 +55,7 @@ func loadGlobalConfig(cm *v1.ConfigMap) {
 		hwlog.RunLog.Errorf("cm <%s/%s> or its data is nil", api.ClusterNS, constant.ConfigCmName)
 		return
 	}
+	loadRecoverStrategyPolicy(cm)
 	data, ok := cm.Data[constant.ManuallySeparateNPUConfigKey]
 	if !ok {
 		hwlog.RunLog.Errorf("key %s is not found in cm <%s/%s>", constant.ManuallySeparateNPUConfigKey,
@@ -80,6 +81,32 @@ func loadGlobalConfig(cm *v1.ConfigMap) {
 	hwlog.RunLog.Info("load manually separate policy config success")
 }
 
+// loadRecoverStrategyPolicy the policy is optional, recover uses built-in strategy choice when it is not configured.
+// An invalid policy is ignored and the last valid one is kept
+func loadRecoverStrategyPolicy(cm *v1.ConfigMap) {
+	data, ok := cm.Data[constant.RecoverStrategyPolicyConfigKey]
+	if !ok {
+		if len(conf.GetRecoverStrategyPolicy().Rules) > 0 {
+			hwlog.RunLog.Infof("key %s is removed from cm <%s/%s>, use built-in recover strategy",
+				constant.RecoverStrategyPolicyConfigKey, api.ClusterNS, constant.ConfigCmName)
+		}
+		conf.SetRecoverStrategyPolicy(conf.RecoverStrategyPolicy{})
+		return
+	}
+	var policy conf.RecoverStrategyPolicy
+	if err := yaml.UnmarshalStrict([]byte(data), &policy); err != nil {
+		hwlog.RunLog.Errorf("unmarshal recover strategy policy config failed from cm <%s/%s>, error: %v",
+			api.ClusterNS, constant.ConfigCmName, err)
+		return
+	}
+	if err := conf.CheckRecoverStrategyPolicy(policy); err != nil {
+		hwlog.RunLog.Errorf("check recover strategy policy config failed, error: %v", err)
+		return
+	}
+	conf.SetRecoverStrategyPolicy(policy)
+	hwlog.RunLog.Infof("load recover strategy policy config success, rules count: %d", len(policy.Rules))
+}
+
 // TryLoadGlobalConfig try load global config from cm
 func TryLoadGlobalConfig() {
 	const retryTime = 3
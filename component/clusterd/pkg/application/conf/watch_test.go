@@ -178,6 +178,45 @@ func testNilWindow() {
 	convey.So(conf.GetReleaseDuration(), convey.ShouldEqual, 0)
 }
 
+const (
+	testRecoverPolicy = `
+rules:
+  - name: llm
+    namespaces: [team-a]
+    strategies: [recover, dump]
+    retry_budget: 2
+`
+	invalidRecoverPolicy = `
+rules:
+  - name: llm
+    strategies: [unknown]
+`
+)
+
+func TestLoadRecoverStrategyPolicy(t *testing.T) {
+	convey.Convey("test func loadRecoverStrategyPolicy", t, func() {
+		defer conf.SetRecoverStrategyPolicy(conf.RecoverStrategyPolicy{})
+		cm := getDemoCm()
+		cm.Data[constant.RecoverStrategyPolicyConfigKey] = testRecoverPolicy
+		loadGlobalConfig(cm)
+		policy := conf.GetRecoverStrategyPolicy()
+		convey.So(len(policy.Rules), convey.ShouldEqual, 1)
+		convey.So(policy.Rules[0].RetryBudget, convey.ShouldEqual, 2)
+
+		cm.Data[constant.RecoverStrategyPolicyConfigKey] = invalidRecoverPolicy
+		loadRecoverStrategyPolicy(cm)
+		convey.So(conf.GetRecoverStrategyPolicy().Rules[0].Strategies, convey.ShouldResemble,
+			[]string{constant.ProcessRecoverStrategyName, constant.ProcessDumpStrategyName})
+		cm.Data[constant.RecoverStrategyPolicyConfigKey] = "rules: [{name: llm, unknown_field: 1}]"
+		loadRecoverStrategyPolicy(cm)
+		convey.So(len(conf.GetRecoverStrategyPolicy().Rules), convey.ShouldEqual, 1)
+
+		delete(cm.Data, constant.RecoverStrategyPolicyConfigKey)
+		loadRecoverStrategyPolicy(cm)
+		convey.So(conf.GetRecoverStrategyPolicy().Rules, convey.ShouldBeNil)
+	})
+}
+
 func resetGlobalConfig() {
 	conf.SetManualSeparatePolicy(conf.ManuallySeparatePolicy{})
 }
@@ -9,6 +9,7 @@ import (
 
 	"ascend-common/common-utils/hwlog"
 	"clusterd/pkg/common/constant"
+	"clusterd/pkg/common/journal"
 	"clusterd/pkg/domain/faultdomain"
 	"clusterd/pkg/domain/faultdomain/cmmanager"
 )
@@ -19,6 +20,11 @@ type baseFaultCenter[T constant.ConfigMapInterface] struct {
 	mutex                sync.Mutex
 	cmManager            *cmmanager.FaultCenterCmManager[T]
 	centerType           int
+	// faults of last cycle, only kept when journal enabled
+	lastOriginal  faultSet
+	lastProcessed faultSet
+	// changes of each processor in last cycle, index is the same as processorList
+	lastChanges []changeSet
 }
 
 func newBaseFaultCenter[T constant.ConfigMapInterface](cmManager *cmmanager.FaultCenterCmManager[T], centerType int) baseFaultCenter[T] {
@@ -43,18 +49,41 @@ func (baseCenter *baseFaultCenter[T]) Process() {
 	} else {
 		processingCm = origCm
 	}
-	for _, processor := range baseCenter.processorList {
-		info := constant.OneConfigmapContent[T]{
-			AllConfigmap:    processingCm,
-			UpdateConfigmap: updateOriginalCm,
+	if !journal.Enabled() {
+		for _, processor := range baseCenter.processorList {
+			processingCm = baseCenter.processOne(processor, processingCm, updateOriginalCm)
 		}
-		cmType, ok := processor.Process(info).(constant.OneConfigmapContent[T])
-		if !ok {
-			continue
-		}
-		processingCm = cmType.AllConfigmap
+		baseCenter.setProcessedCm(processingCm)
+		return
+	}
+	original := collectFaults(processingCm)
+	current := original
+	if len(baseCenter.lastChanges) != len(baseCenter.processorList) {
+		baseCenter.lastChanges = make([]changeSet, len(baseCenter.processorList))
+	}
+	for i, processor := range baseCenter.processorList {
+		processingCm = baseCenter.processOne(processor, processingCm, updateOriginalCm)
+		processed := collectFaults(processingCm)
+		baseCenter.lastChanges[i] = diffProcessed(processorName(processor), baseCenter.lastChanges[i], current,
+			processed)
+		current = processed
 	}
 	baseCenter.setProcessedCm(processingCm)
+	diffCycle(centerNames[baseCenter.centerType], baseCenter.lastOriginal, original, baseCenter.lastProcessed, current)
+	baseCenter.lastOriginal, baseCenter.lastProcessed = original, current
+}
+
+func (baseCenter *baseFaultCenter[T]) processOne(processor constant.FaultProcessor, processingCm map[string]T,
+	updateOriginalCm []constant.InformerCmItem[T]) map[string]T {
+	info := constant.OneConfigmapContent[T]{
+		AllConfigmap:    processingCm,
+		UpdateConfigmap: updateOriginalCm,
+	}
+	cmType, ok := processor.Process(info).(constant.OneConfigmapContent[T])
+	if !ok {
+		return processingCm
+	}
+	return cmType.AllConfigmap
 }
 
 // NotifySubscriber notify subscriber
@@ -0,0 +1,171 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package cmprocess contain cm processor
+package cmprocess
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"clusterd/pkg/common/constant"
+	"clusterd/pkg/common/journal"
+)
+
+var centerNames = map[int]string{
+	constant.DeviceProcessType: "device-center",
+	constant.NodeProcessType:   "node-center",
+	constant.SwitchProcessType: "switch-center",
+}
+
+// journalFault one fault in configmap, key is node, device and fault code
+type journalFault struct {
+	node   string
+	device string
+	code   string
+	level  string
+}
+
+type faultSet map[string]journalFault
+
+func (f journalFault) key() string {
+	return f.node + "/" + f.device + "/" + f.code
+}
+
+func (s faultSet) add(fault journalFault) {
+	s[fault.key()] = fault
+}
+
+// nodeNameOfCm get node name from the key of processing configmaps, device cms are keyed by node name already
+func nodeNameOfCm(cmName string) string {
+	for _, prefix := range []string{constant.DeviceInfoPrefix, constant.NodeInfoPrefix, constant.SwitchInfoPrefix} {
+		if strings.HasPrefix(cmName, prefix) {
+			return strings.TrimPrefix(cmName, prefix)
+		}
+	}
+	return cmName
+}
+
+// collectFaults flatten faults of configmaps for journal
+func collectFaults[T constant.ConfigMapInterface](cms map[string]T) faultSet {
+	faults := make(faultSet)
+	for cmName, cm := range cms {
+		node := nodeNameOfCm(cmName)
+		switch info := any(cm).(type) {
+		case *constant.AdvanceDeviceFaultCm:
+			if info == nil {
+				continue
+			}
+			for _, deviceFaults := range info.FaultDeviceList {
+				for _, fault := range deviceFaults {
+					faults.add(journalFault{node: node, device: fault.NPUName, code: fault.FaultCode,
+						level: fault.FaultLevel})
+				}
+			}
+		case *constant.NodeInfo:
+			if info == nil {
+				continue
+			}
+			for _, dev := range info.FaultDevList {
+				if dev == nil {
+					continue
+				}
+				for _, code := range dev.FaultCode {
+					faults.add(journalFault{node: node, device: dev.DeviceType + "-" +
+						strconv.FormatInt(dev.DeviceId, 10), code: code, level: dev.FaultLevel})
+				}
+			}
+		case *constant.SwitchInfo:
+			if info == nil {
+				continue
+			}
+			for _, fault := range info.FaultInfo {
+				level := info.FaultLevel
+				if timeAndLevel, ok := info.FaultTimeAndLevelMap[fault.GetFaultTimeAndLevelKey()]; ok {
+					level = timeAndLevel.FaultLevel
+				}
+				faults.add(journalFault{node: node, device: "chip" + strconv.Itoa(int(fault.SwitchChipId)),
+					code: fault.AssembledFaultCode, level: level})
+			}
+		default:
+		}
+	}
+	return faults
+}
+
+func recordFault(stage, source string, fault journalFault, detail string) {
+	journal.Record(journal.Event{Stage: stage, Source: source, Node: fault.node, Device: fault.device,
+		FaultCode: fault.code, FaultLevel: fault.level, Detail: detail})
+}
+
+// processorName get package name of processor, e.g. retry of *retry.retryFaultProcessor
+func processorName(processor constant.FaultProcessor) string {
+	name := strings.TrimPrefix(fmt.Sprintf("%T", processor), "*")
+	if idx := strings.Index(name, "."); idx > 0 {
+		return name[:idx]
+	}
+	return name
+}
+
+// processedChange one fault filtered, added or changed level by a processor in a cycle
+type processedChange struct {
+	stage  string
+	fault  journalFault
+	detail string
+}
+
+// changeSet changes of a processor in a cycle, key is fault key
+type changeSet map[string]processedChange
+
+// diffProcessed get faults filtered, added or changed level by the processor, and record the changes which are
+// not made in last cycle, so that a fault kept filtered or escalated by the processor is recorded only once
+func diffProcessed(processor string, lastChanges changeSet, before, after faultSet) changeSet {
+	changes := make(changeSet)
+	for key, fault := range before {
+		newFault, ok := after[key]
+		if !ok {
+			changes[key] = processedChange{stage: journal.StageFiltered, fault: fault}
+			continue
+		}
+		if newFault.level != fault.level {
+			changes[key] = processedChange{stage: journal.StageEscalated, fault: newFault,
+				detail: "from " + fault.level}
+		}
+	}
+	for key, fault := range after {
+		if _, ok := before[key]; !ok {
+			changes[key] = processedChange{stage: journal.StageAdded, fault: fault}
+		}
+	}
+	for key, change := range changes {
+		if last, ok := lastChanges[key]; ok && last == change {
+			continue
+		}
+		recordFault(change.stage, processor, change.fault, change.detail)
+	}
+	return changes
+}
+
+func isSeparateLevel(level string) bool {
+	return level == constant.SeparateNPU || level == constant.ManuallySeparateNPU
+}
+
+// diffCycle record faults newly reported, separated or released in a process cycle of the center
+func diffCycle(center string, lastOriginal, original, lastProcessed, processed faultSet) {
+	for key, fault := range original {
+		if _, ok := lastOriginal[key]; !ok {
+			recordFault(journal.StageReceived, center, fault, "")
+		}
+	}
+	for key, fault := range processed {
+		last, ok := lastProcessed[key]
+		if isSeparateLevel(fault.level) && (!ok || !isSeparateLevel(last.level)) {
+			recordFault(journal.StageSeparated, center, fault, "")
+		}
+	}
+	for key, fault := range lastProcessed {
+		if _, ok := processed[key]; !ok {
+			recordFault(journal.StageReleased, center, fault, "")
+		}
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package cmprocess test for fault journal of fault centers
+package cmprocess
+
+import (
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/smartystreets/goconvey/convey"
+
+	"clusterd/pkg/application/faultmanager/cmprocess/retry"
+	"clusterd/pkg/common/constant"
+	"clusterd/pkg/common/journal"
+	"clusterd/pkg/domain/faultdomain/cmmanager"
+)
+
+const (
+	journalNode  = "node1"
+	journalNpu   = "Ascend910-0"
+	journalCode1 = "80C98009"
+	journalCode2 = "80E18005"
+)
+
+func recordedEvents() (*gomonkey.Patches, *[]journal.Event) {
+	events := &[]journal.Event{}
+	patches := gomonkey.ApplyFunc(journal.Record, func(event journal.Event) {
+		*events = append(*events, event)
+	})
+	return patches, events
+}
+
+func stagesOf(events []journal.Event) map[string]int {
+	stages := make(map[string]int)
+	for _, event := range events {
+		stages[event.Stage]++
+	}
+	return stages
+}
+
+func TestCollectFaults(t *testing.T) {
+	convey.Convey("test func 'collectFaults'", t, func() {
+		devices := collectFaults(map[string]*constant.AdvanceDeviceFaultCm{
+			journalNode: {FaultDeviceList: map[string][]constant.DeviceFault{journalNpu: {
+				{NPUName: journalNpu, FaultCode: journalCode1, FaultLevel: constant.RestartNPU},
+				{NPUName: journalNpu, FaultCode: journalCode2, FaultLevel: constant.SeparateNPU}}}},
+			"node2": nil,
+		})
+		convey.So(len(devices), convey.ShouldEqual, 2)
+		convey.So(devices[journalNode+"/"+journalNpu+"/"+journalCode1].level, convey.ShouldEqual,
+			constant.RestartNPU)
+		nodes := collectFaults(map[string]*constant.NodeInfo{
+			constant.NodeInfoPrefix + journalNode: {NodeInfoNoName: constant.NodeInfoNoName{
+				FaultDevList: []*constant.FaultDev{{DeviceType: "CPU", FaultCode: []string{journalCode1}}, nil}}},
+		})
+		convey.So(nodes[journalNode+"/CPU-0/"+journalCode1].node, convey.ShouldEqual, journalNode)
+		switches := collectFaults(map[string]*constant.SwitchInfo{
+			constant.SwitchInfoPrefix + journalNode: {SwitchFaultInfo: constant.SwitchFaultInfo{
+				FaultLevel: constant.NotHandleFault,
+				FaultInfo:  []constant.SimpleSwitchFaultInfo{{AssembledFaultCode: journalCode2}}}},
+		})
+		convey.So(switches[journalNode+"/chip0/"+journalCode2].level, convey.ShouldEqual, constant.NotHandleFault)
+	})
+}
+
+func TestProcessorName(t *testing.T) {
+	convey.Convey("test func 'processorName'", t, func() {
+		convey.So(processorName(retry.RetryProcessor), convey.ShouldEqual, "retry")
+	})
+}
+
+func TestDiffProcessed(t *testing.T) {
+	convey.Convey("test func 'diffProcessed'", t, func() {
+		patches, events := recordedEvents()
+		defer patches.Reset()
+		fault1 := journalFault{node: journalNode, device: journalNpu, code: journalCode1, level: constant.RestartNPU}
+		fault2 := journalFault{node: journalNode, device: journalNpu, code: journalCode2, level: constant.RestartNPU}
+		before := faultSet{fault1.key(): fault1, fault2.key(): fault2}
+		escalated := fault2
+		escalated.level = constant.SeparateNPU
+		added := journalFault{node: journalNode, device: "Ascend910-1", code: journalCode1}
+		after := faultSet{escalated.key(): escalated, added.key(): added}
+		changes := diffProcessed("retry", nil, before, after)
+		convey.So(stagesOf(*events), convey.ShouldResemble, map[string]int{journal.StageFiltered: 1,
+			journal.StageEscalated: 1, journal.StageAdded: 1})
+		*events = nil
+		diffProcessed("retry", changes, before, after)
+		convey.So(len(*events), convey.ShouldEqual, 0)
+	})
+}
+
+func TestDiffCycle(t *testing.T) {
+	convey.Convey("test func 'diffCycle'", t, func() {
+		patches, events := recordedEvents()
+		defer patches.Reset()
+		fault1 := journalFault{node: journalNode, device: journalNpu, code: journalCode1, level: constant.RestartNPU}
+		fault2 := journalFault{node: journalNode, device: journalNpu, code: journalCode2, level: constant.SeparateNPU}
+		original := faultSet{fault2.key(): fault2}
+		diffCycle("device-center", faultSet{}, original, faultSet{fault1.key(): fault1}, original)
+		convey.So(stagesOf(*events), convey.ShouldResemble, map[string]int{journal.StageReceived: 1,
+			journal.StageSeparated: 1, journal.StageReleased: 1})
+		*events = nil
+		diffCycle("device-center", original, original, original, original)
+		convey.So(len(*events), convey.ShouldEqual, 0)
+	})
+}
+
+func TestProcessWithJournal(t *testing.T) {
+	convey.Convey("fault center should keep faults of last cycle when journal enabled", t, func() {
+		patches, events := recordedEvents()
+		defer patches.Reset()
+		patches.ApplyFuncReturn(journal.Enabled, true)
+		baseCenter := newBaseFaultCenter(cmmanager.NodeCenterCmManager, constant.NodeProcessType)
+		baseCenter.addProcessors([]constant.FaultProcessor{&fakeProcessor{}})
+		baseCenter.Process()
+		convey.So(baseCenter.lastOriginal, convey.ShouldNotBeNil)
+		convey.So(baseCenter.lastProcessed, convey.ShouldNotBeNil)
+		convey.So(len(*events), convey.ShouldEqual, 0)
+	})
+}
+
+// addingProcessor always add the same fault, like a processor keeps escalating or adding a fault
+type addingProcessor struct{}
+
+func (p *addingProcessor) Process(info any) any {
+	content, ok := info.(constant.OneConfigmapContent[*constant.NodeInfo])
+	if !ok {
+		return info
+	}
+	content.AllConfigmap = map[string]*constant.NodeInfo{
+		constant.NodeInfoPrefix + journalNode: {NodeInfoNoName: constant.NodeInfoNoName{
+			FaultDevList: []*constant.FaultDev{{DeviceType: "CPU", FaultCode: []string{journalCode1},
+				FaultLevel: constant.RestartNPU}}}},
+	}
+	return content
+}
+
+func TestProcessUnchangedFaultsWithJournal(t *testing.T) {
+	convey.Convey("faults kept added by a processor should be journaled only once", t, func() {
+		patches, events := recordedEvents()
+		defer patches.Reset()
+		patches.ApplyFuncReturn(journal.Enabled, true)
+		baseCenter := newBaseFaultCenter(cmmanager.NodeCenterCmManager, constant.NodeProcessType)
+		baseCenter.addProcessors([]constant.FaultProcessor{&addingProcessor{}})
+		baseCenter.Process()
+		convey.So(stagesOf(*events)[journal.StageAdded], convey.ShouldEqual, 1)
+		*events = nil
+		baseCenter.Process()
+		convey.So(len(*events), convey.ShouldEqual, 0)
+	})
+}
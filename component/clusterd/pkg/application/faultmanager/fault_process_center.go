@@ -14,9 +14,16 @@ import (
 	"clusterd/pkg/application/faultmanager/jobprocess"
 	"clusterd/pkg/application/publicfault"
 	"clusterd/pkg/common/constant"
+	"clusterd/pkg/common/metrics"
 	"clusterd/pkg/domain/faultdomain/collector"
 )
 
+const (
+	deviceCenterName = "device"
+	nodeCenterName   = "node"
+	switchCenterName = "switch"
+)
+
 // GlobalFaultProcessCenter is a global instance of faultProcessCenter used for processing faults.
 var GlobalFaultProcessCenter *faultProcessCenter
 
@@ -32,6 +39,9 @@ type faultProcessCenter struct {
 }
 
 func (center *faultProcessCenter) Process() {
+	defer func(start time.Time) {
+		metrics.ObserveFaultProcessDuration(time.Since(start))
+	}(time.Now())
 	cmprocess.SwitchCenter.Process()
 	cmprocess.DeviceCenter.Process()
 	cmprocess.NodeCenter.Process()
@@ -49,6 +59,7 @@ func (center *faultProcessCenter) notifyFaultCenterProcess(whichToProcess int) {
 
 // Work faultProcessCenter work goroutine
 func (center *faultProcessCenter) Work(ctx context.Context) {
+	registerFaultCountMetrics()
 	go func() {
 		hwlog.RunLog.Info("faultProcessCenter start work!")
 		centerTicker := time.NewTicker(time.Second)
@@ -160,3 +171,29 @@ func RegisterForJobFaultRank(ch chan map[string]constant.JobFaultInfo, filterLev
 func FilterStressTestFault(jobID string, nodes []string, val bool) {
 	stresstest.StressTestProcessor.SetFilterAicFault(jobID, nodes, val)
 }
+
+func registerFaultCountMetrics() {
+	metrics.RegisterFaultCenterCount(deviceCenterName, func() int {
+		num := 0
+		for _, cm := range QueryDeviceInfoToReport() {
+			for _, faults := range cm.FaultDeviceList {
+				num += len(faults)
+			}
+		}
+		return num
+	})
+	metrics.RegisterFaultCenterCount(nodeCenterName, func() int {
+		num := 0
+		for _, info := range QueryNodeInfoToReport() {
+			num += len(info.FaultDevList)
+		}
+		return num
+	})
+	metrics.RegisterFaultCenterCount(switchCenterName, func() int {
+		num := 0
+		for _, info := range QuerySwitchInfoToReport() {
+			num += len(info.FaultInfo)
+		}
+		return num
+	})
+}
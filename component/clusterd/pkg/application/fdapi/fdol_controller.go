@@ -16,11 +16,14 @@
 package fdapi
 
 import (
+	gocontext "context"
 	"fmt"
 
 	"ascend-common/common-utils/hwlog"
+	"ascend-common/common-utils/tlsutil"
 	fdol "ascend-faultdiag-online"
 	"ascend-faultdiag-online/pkg/core/context"
+	fdgrpc "ascend-faultdiag-online/pkg/utils/grpc"
 )
 
 const (
@@ -31,9 +34,13 @@ const (
 	reloadApi     = "reload"
 )
 
-// StartFdOL for starting FdOL feature
-func StartFdOL() {
+// StartFdOL for starting FdOL feature, FdOL connects grpc server of clusterd with the tls files of the server
+func StartFdOL(ctx gocontext.Context, grpcTLS tlsutil.Config) {
 	hwlog.RunLog.Info("start fd-ol")
+	if err := fdgrpc.SetSecurity(ctx, fdgrpc.Security{TLS: grpcTLS}); err != nil {
+		hwlog.RunLog.Errorf("set grpc security of fd-ol failed: %v", err)
+		return
+	}
 	fdol.StartFDOnline(fdConfigPath, []string{"slowNode", "netFault"}, "cluster")
 }
 
@@ -17,6 +17,7 @@ package manualfault
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"k8s.io/api/core/v1"
@@ -27,6 +28,7 @@ import (
 	"clusterd/pkg/common/constant"
 	"clusterd/pkg/domain/conf"
 	"clusterd/pkg/domain/manualfault"
+	"clusterd/pkg/interface/kube"
 )
 
 // ProcessManuSep process manually separate npu info
@@ -70,6 +72,8 @@ func checkDiffAndDelete() {
 				nodeName, devId)
 			manualfault.Counter.ClearDevFaults(nodeName, devId)
 			manualfault.FaultCmInfo.DeleteSeparateDev(nodeName, devId)
+			kube.RecordNodeEvent(nodeName, v1.EventTypeNormal, manualfault.ReasonSeparationReleased,
+				fmt.Sprintf("%s is released, it is deleted from configmap %s", devId, constant.ManualDevInfoCmName))
 		}
 	}
 }
@@ -105,6 +109,8 @@ func doRelease(nodeName, dev string, devInfo []manualfault.DevCmInfo) {
 				nodeName, dev, cmInfo.FaultCode)
 			manualfault.Counter.ClearDevFault(nodeName, dev, cmInfo.FaultCode)
 			manualfault.FaultCmInfo.DeleteDevCode(nodeName, dev, cmInfo.FaultCode)
+			kube.RecordNodeEvent(nodeName, v1.EventTypeNormal, manualfault.ReasonSeparationReleased,
+				fmt.Sprintf("%s is released, fault code %s reaches release time", dev, cmInfo.FaultCode))
 			continue
 		}
 	}
@@ -0,0 +1,456 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package publicfault http receiver of public faults, accepts alertmanager webhook payloads and public fault info
+package publicfault
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"ascend-common/api"
+	"ascend-common/common-utils/hwlog"
+	"ascend-common/common-utils/utils"
+	"clusterd/pkg/common/constant"
+	"clusterd/pkg/common/logs"
+)
+
+const (
+	// ReceiverPath url path prefix of the public fault receiver
+	ReceiverPath = "/pubfault/v1/"
+	// ReceiverReloadInterval interval of checking whether receiver config file is modified
+	ReceiverReloadInterval = 10 * time.Second
+
+	alertmanagerResource = "alertmanager"
+	faultsResource       = "faults"
+	alertFiring          = "firing"
+	alertResolved        = "resolved"
+	defaultNodeNameLabel = "node"
+	descriptionKey       = "description"
+	summaryKey           = "summary"
+	bearerPrefix         = "Bearer "
+	receiverEvent        = "receive public fault by http"
+
+	maxReceiverConfigSize = 1024 * 1024
+	maxTokenFileSize      = 4096
+	maxRequestBodySize    = 4 * 1024 * 1024
+	maxRules              = 1000
+	maxFaultsPerInfo      = 100
+	maxDescriptionLength  = 512
+	pubFaultIdPrefix      = "alert-"
+)
+
+// AlertRule map the alerts matching all labels to a public fault
+type AlertRule struct {
+	Name string `yaml:"name"`
+	// Match labels and values of alert, all of them should be equal
+	Match     map[string]string `yaml:"match"`
+	FaultCode string            `yaml:"faultCode"`
+	FaultType string            `yaml:"faultType"`
+	// Resource of the public fault, default resource of config is used when empty
+	Resource string `yaml:"resource"`
+	// NodeNameLabel label of node name, default "node"
+	NodeNameLabel string `yaml:"nodeNameLabel"`
+	// NodeSNLabel label of node sn, used when node name label is absent
+	NodeSNLabel string `yaml:"nodeSNLabel"`
+	// DeviceIdsLabel label of comma separated device ids, DeviceIds are used when the label is absent
+	DeviceIdsLabel string  `yaml:"deviceIdsLabel"`
+	DeviceIds      []int32 `yaml:"deviceIds"`
+}
+
+// ReceiverConfig config of public fault receiver
+type ReceiverConfig struct {
+	// TokenFile file of bearer token which requests should carry
+	TokenFile string      `yaml:"tokenFile"`
+	Resource  string      `yaml:"resource"`
+	Rules     []AlertRule `yaml:"rules"`
+}
+
+// ParseReceiverConfig parse and check receiver config in yaml
+func ParseReceiverConfig(data []byte) (*ReceiverConfig, error) {
+	cfg := &ReceiverConfig{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("unmarshal receiver config failed: %v", err)
+	}
+	if cfg.TokenFile == "" {
+		return nil, errors.New("tokenFile is required")
+	}
+	if len(cfg.Rules) > maxRules {
+		return nil, fmt.Errorf("the number of rules exceeds %d", maxRules)
+	}
+	for i := range cfg.Rules {
+		rule := &cfg.Rules[i]
+		if len(rule.Match) == 0 {
+			return nil, fmt.Errorf("rule %d(%s) has no match label", i, rule.Name)
+		}
+		if rule.FaultCode == "" || rule.FaultType == "" {
+			return nil, fmt.Errorf("rule %d(%s) has no fault code or fault type", i, rule.Name)
+		}
+		if rule.Resource == "" && cfg.Resource == "" {
+			return nil, fmt.Errorf("rule %d(%s) has no resource", i, rule.Name)
+		}
+		if rule.DeviceIdsLabel == "" && len(rule.DeviceIds) == 0 {
+			return nil, fmt.Errorf("rule %d(%s) has no device ids", i, rule.Name)
+		}
+		if rule.NodeNameLabel == "" {
+			rule.NodeNameLabel = defaultNodeNameLabel
+		}
+		if rule.Resource == "" {
+			rule.Resource = cfg.Resource
+		}
+	}
+	return cfg, nil
+}
+
+func (r *AlertRule) match(labels map[string]string) bool {
+	for key, value := range r.Match {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *AlertRule) deviceIds(labels map[string]string) ([]int32, error) {
+	value, ok := labels[r.DeviceIdsLabel]
+	if r.DeviceIdsLabel == "" || !ok {
+		return r.DeviceIds, nil
+	}
+	var ids []int32
+	for _, item := range strings.Split(value, ",") {
+		id, err := strconv.ParseInt(strings.TrimSpace(item), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid device id %q in label %s", item, r.DeviceIdsLabel)
+		}
+		ids = append(ids, int32(id))
+	}
+	return ids, nil
+}
+
+// Alert one alert of alertmanager webhook payload
+type Alert struct {
+	Status      string            `json:"status"`
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	StartsAt    time.Time         `json:"startsAt"`
+	EndsAt      time.Time         `json:"endsAt"`
+	Fingerprint string            `json:"fingerprint"`
+}
+
+// AlertmanagerPayload webhook payload of alertmanager, version 4
+type AlertmanagerPayload struct {
+	Version  string  `json:"version"`
+	GroupKey string  `json:"groupKey"`
+	Status   string  `json:"status"`
+	Alerts   []Alert `json:"alerts"`
+}
+
+// ReceiveResult result of a receive request
+type ReceiveResult struct {
+	Accepted int      `json:"accepted"`
+	Skipped  int      `json:"skipped"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+// description of fault should be printable characters and spaces in one line
+func alertDescription(alert *Alert) string {
+	description := alert.Annotations[descriptionKey]
+	if description == "" {
+		description = alert.Annotations[summaryKey]
+	}
+	description = strings.Join(strings.Fields(description), " ")
+	if len(description) > maxDescriptionLength {
+		description = description[:maxDescriptionLength]
+	}
+	return description
+}
+
+// alertToFault convert alert to public fault, fault id is fingerprint of alert, so that resolved alert recovers
+// the fault of firing one
+func alertToFault(rule *AlertRule, alert *Alert) (api.Fault, error) {
+	fault := api.Fault{
+		FaultId:     alert.Fingerprint,
+		FaultType:   rule.FaultType,
+		FaultCode:   rule.FaultCode,
+		FaultTime:   alert.StartsAt.UnixMilli(),
+		Assertion:   constant.AssertionOccur,
+		Description: alertDescription(alert),
+	}
+	switch alert.Status {
+	case alertFiring:
+	case alertResolved:
+		fault.Assertion = constant.AssertionRecover
+		if !alert.EndsAt.IsZero() {
+			fault.FaultTime = alert.EndsAt.UnixMilli()
+		}
+	default:
+		return fault, fmt.Errorf("unsupported alert status %q", alert.Status)
+	}
+	deviceIds, err := rule.deviceIds(alert.Labels)
+	if err != nil {
+		return fault, err
+	}
+	influence := api.Influence{NodeName: alert.Labels[rule.NodeNameLabel], DeviceIds: deviceIds}
+	if influence.NodeName == "" && rule.NodeSNLabel != "" {
+		influence.NodeSN = alert.Labels[rule.NodeSNLabel]
+	}
+	if influence.NodeName == "" && influence.NodeSN == "" {
+		return fault, fmt.Errorf("alert has neither label %s nor node sn label", rule.NodeNameLabel)
+	}
+	fault.Influence = []api.Influence{influence}
+	return fault, nil
+}
+
+// alertsToPubFaults map alerts by rules and group faults by resource, at most 100 faults in one public fault info
+func alertsToPubFaults(cfg *ReceiverConfig, payload *AlertmanagerPayload, result *ReceiveResult) []*api.PubFaultInfo {
+	faults := make(map[string][]api.Fault)
+	var resources []string
+	for i := range payload.Alerts {
+		alert := &payload.Alerts[i]
+		var rule *AlertRule
+		for j := range cfg.Rules {
+			if cfg.Rules[j].match(alert.Labels) {
+				rule = &cfg.Rules[j]
+				break
+			}
+		}
+		if rule == nil {
+			result.Skipped++
+			continue
+		}
+		fault, err := alertToFault(rule, alert)
+		if err != nil {
+			result.Skipped++
+			result.Errors = append(result.Errors, fmt.Sprintf("alert %s: %v", alert.Fingerprint, err))
+			continue
+		}
+		if _, ok := faults[rule.Resource]; !ok {
+			resources = append(resources, rule.Resource)
+		}
+		faults[rule.Resource] = append(faults[rule.Resource], fault)
+	}
+	now := time.Now()
+	var infos []*api.PubFaultInfo
+	for _, resource := range resources {
+		for start := 0; start < len(faults[resource]); start += maxFaultsPerInfo {
+			end := min(start+maxFaultsPerInfo, len(faults[resource]))
+			infos = append(infos, &api.PubFaultInfo{
+				Id:        pubFaultIdPrefix + strconv.FormatInt(now.UnixNano(), 10) + "-" + strconv.Itoa(len(infos)),
+				TimeStamp: now.UnixMilli(),
+				Version:   validVersion,
+				Resource:  resource,
+				Faults:    faults[resource][start:end],
+			})
+		}
+	}
+	return infos
+}
+
+// receiver http handler of public faults, serves only when it is active on the leader
+type receiver struct {
+	configFile string
+	version    string
+	lock       sync.RWMutex
+	config     *ReceiverConfig
+	tokenHash  [sha256.Size]byte
+	active     atomic.Bool
+}
+
+var pubFaultReceiver *receiver
+
+// NewReceiver load receiver config file and create http handler serving under ReceiverPath:
+// POST alertmanager accepts alertmanager webhook payload, POST faults accepts json of public fault info
+func NewReceiver(configFile string) (http.Handler, error) {
+	r := &receiver{configFile: configFile}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	pubFaultReceiver = r
+	return r, nil
+}
+
+// ActivateReceiver start to accept public faults by http, receiver responds 503 before activated, so that only the
+// leader accepts them
+func ActivateReceiver(ctx context.Context) {
+	if pubFaultReceiver == nil {
+		return
+	}
+	pubFaultReceiver.active.Store(true)
+	go pubFaultReceiver.watch(ctx, ReceiverReloadInterval)
+	hwlog.RunLog.Info("public fault http receiver is activated")
+}
+
+func (r *receiver) load() error {
+	version := utils.MountedFileVersion(r.configFile)
+	data, err := utils.ReadMountedFile(r.configFile, maxReceiverConfigSize)
+	if err != nil {
+		return fmt.Errorf("read receiver config file failed: %v", err)
+	}
+	cfg, err := ParseReceiverConfig(data)
+	if err != nil {
+		return err
+	}
+	token, err := utils.ReadMountedFile(cfg.TokenFile, maxTokenFileSize)
+	if err != nil {
+		return fmt.Errorf("read token file failed: %v", err)
+	}
+	token = []byte(strings.TrimSpace(string(token)))
+	if len(token) == 0 {
+		return errors.New("token file is empty")
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.config, r.version, r.tokenHash = cfg, version, sha256.Sum256(token)
+	return nil
+}
+
+func (r *receiver) changed() bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return utils.MountedFileVersion(r.configFile) != r.version
+}
+
+// watch reload config file when it is modified until ctx done, the old config is kept if the new one is invalid
+func (r *receiver) watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			r.active.Store(false)
+			return
+		case <-ticker.C:
+			if !r.changed() {
+				continue
+			}
+			if err := r.load(); err != nil {
+				hwlog.RunLog.Errorf("reload public fault receiver config failed, keep using the old one, err: %v", err)
+				continue
+			}
+			hwlog.RunLog.Infof("public fault receiver config %s reloaded", r.configFile)
+		}
+	}
+}
+
+func (r *receiver) getConfig() *ReceiverConfig {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.config
+}
+
+// authorized compare hash of bearer token in constant time
+func (r *receiver) authorized(req *http.Request) bool {
+	auth := req.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, bearerPrefix) {
+		return false
+	}
+	hash := sha256.Sum256([]byte(strings.TrimPrefix(auth, bearerPrefix)))
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return subtle.ConstantTimeCompare(hash[:], r.tokenHash[:]) == 1
+}
+
+// ServeHTTP implement http.Handler
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if !r.active.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, ReceiveResult{Errors: []string{"receiver is not active"}})
+		return
+	}
+	if req.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, ReceiveResult{Errors: []string{"only POST method is supported"}})
+		return
+	}
+	if !r.authorized(req) {
+		writeJSON(w, http.StatusUnauthorized, ReceiveResult{Errors: []string{"unauthorized"}})
+		return
+	}
+	req.Body = http.MaxBytesReader(w, req.Body, maxRequestBodySize)
+	switch strings.Trim(strings.TrimPrefix(req.URL.Path, ReceiverPath), "/") {
+	case alertmanagerResource:
+		r.receiveAlerts(w, req)
+	case faultsResource:
+		receivePubFault(w, req)
+	default:
+		writeJSON(w, http.StatusNotFound, ReceiveResult{Errors: []string{"resource not found"}})
+	}
+}
+
+func (r *receiver) receiveAlerts(w http.ResponseWriter, req *http.Request) {
+	payload := &AlertmanagerPayload{}
+	if err := json.NewDecoder(req.Body).Decode(payload); err != nil {
+		writeJSON(w, http.StatusBadRequest, ReceiveResult{Errors: []string{"decode payload failed: " + err.Error()}})
+		return
+	}
+	result := ReceiveResult{}
+	status := http.StatusOK
+	for _, info := range alertsToPubFaults(r.getConfig(), payload, &result) {
+		code, err := collectPubFault(info)
+		if err != nil {
+			result.Skipped += len(info.Faults)
+			result.Errors = append(result.Errors, fmt.Sprintf("resource %s: %v", info.Resource, err))
+			// let alertmanager retry when limited
+			if code == http.StatusTooManyRequests {
+				status = code
+			}
+			continue
+		}
+		result.Accepted += len(info.Faults)
+	}
+	hwlog.RunLog.Infof("receive %d alerts from alertmanager, group key: %s, accepted: %d, skipped: %d",
+		len(payload.Alerts), payload.GroupKey, result.Accepted, result.Skipped)
+	writeJSON(w, status, result)
+}
+
+func receivePubFault(w http.ResponseWriter, req *http.Request) {
+	info := &api.PubFaultInfo{}
+	if err := json.NewDecoder(req.Body).Decode(info); err != nil {
+		writeJSON(w, http.StatusBadRequest, ReceiveResult{Errors: []string{"decode public fault failed: " +
+			err.Error()}})
+		return
+	}
+	code, err := collectPubFault(info)
+	if err != nil {
+		writeJSON(w, code, ReceiveResult{Skipped: len(info.Faults), Errors: []string{err.Error()}})
+		return
+	}
+	writeJSON(w, http.StatusOK, ReceiveResult{Accepted: len(info.Faults)})
+}
+
+// collectPubFault check and collect public fault the same as grpc service, returns http status code when failed
+func collectPubFault(info *api.PubFaultInfo) (int, error) {
+	logs.RecordLog(info.Resource, receiverEvent, constant.Start)
+	if err := PubFaultCollector(info); err != nil {
+		logs.RecordLog(info.Resource, receiverEvent, constant.Failed)
+		if err.Error() == "limiter work by resource failed" {
+			return http.StatusTooManyRequests, err
+		}
+		return http.StatusBadRequest, err
+	}
+	logs.RecordLog(info.Resource, receiverEvent, constant.Success)
+	return http.StatusOK, nil
+}
+
+func writeJSON(w http.ResponseWriter, code int, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		hwlog.RunLog.Errorf("marshal response failed, error: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if _, err = w.Write(data); err != nil {
+		hwlog.RunLog.Errorf("write response failed, error: %v", err)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package publicfault test for public fault http receiver
+package publicfault
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/smartystreets/goconvey/convey"
+
+	"ascend-common/api"
+	"clusterd/pkg/common/constant"
+)
+
+const (
+	testToken       = "test-token"
+	testFingerprint = "5f1c3b2a9d8e7f60"
+	testFileMode    = 0600
+	testRuleConfig  = "resource: resource1\nrules:\n- name: npu-hbm\n  match:\n    alertname: NPUHbmError\n" +
+		"  faultCode: \"000000001\"\n  faultType: NPU\n  deviceIdsLabel: npu\n  deviceIds: [0]\n"
+)
+
+func writeReceiverConfig(dir, content string) string {
+	tokenFile := filepath.Join(dir, "token")
+	convey.So(os.WriteFile(tokenFile, []byte(testToken+"\n"), testFileMode), convey.ShouldBeNil)
+	file := filepath.Join(dir, "receiver.yaml")
+	convey.So(os.WriteFile(file, []byte("tokenFile: "+tokenFile+"\n"+content), testFileMode), convey.ShouldBeNil)
+	return file
+}
+
+func TestParseReceiverConfig(t *testing.T) {
+	convey.Convey("test func 'ParseReceiverConfig'", t, func() {
+		convey.Convey("valid config, defaults should be set", func() {
+			cfg, err := ParseReceiverConfig([]byte("tokenFile: /t\n" + testRuleConfig))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(cfg.Rules[0].NodeNameLabel, convey.ShouldEqual, defaultNodeNameLabel)
+			convey.So(cfg.Rules[0].Resource, convey.ShouldEqual, testResource1)
+		})
+		convey.Convey("token file is missing, should return error", func() {
+			_, err := ParseReceiverConfig([]byte(testRuleConfig))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("rule without match, device ids or resource, should return error", func() {
+			_, err := ParseReceiverConfig([]byte("tokenFile: /t\nrules:\n- name: a\n  faultCode: \"000000001\"\n" +
+				"  faultType: NPU\n  resource: r\n  deviceIds: [0]\n"))
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = ParseReceiverConfig([]byte("tokenFile: /t\nrules:\n- name: a\n  match: {a: b}\n" +
+				"  faultCode: \"000000001\"\n  faultType: NPU\n  resource: r\n"))
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = ParseReceiverConfig([]byte("tokenFile: /t\nrules:\n- name: a\n  match: {a: b}\n" +
+				"  faultCode: \"000000001\"\n  faultType: NPU\n  deviceIds: [0]\n"))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("unknown field, should return error", func() {
+			_, err := ParseReceiverConfig([]byte("tokenFile: /t\nunknown: 1\n"))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestAlertsToPubFaults(t *testing.T) {
+	convey.Convey("test func 'alertsToPubFaults'", t, func() {
+		cfg, err := ParseReceiverConfig([]byte("tokenFile: /t\n" + testRuleConfig))
+		convey.So(err, convey.ShouldBeNil)
+		startsAt := time.UnixMilli(testTimeStamp)
+		payload := &AlertmanagerPayload{Alerts: []Alert{
+			{Status: alertFiring, Fingerprint: testFingerprint, StartsAt: startsAt,
+				Labels:      map[string]string{"alertname": "NPUHbmError", "node": testNodeName1, "npu": "1, 3"},
+				Annotations: map[string]string{summaryKey: "hbm\nerror"}},
+			{Status: alertResolved, Fingerprint: testFingerprint, StartsAt: startsAt, EndsAt: startsAt.Add(time.Minute),
+				Labels: map[string]string{"alertname": "NPUHbmError", "node": testNodeName1}},
+			{Status: alertFiring, Labels: map[string]string{"alertname": "Other"}},
+			{Status: alertFiring, Labels: map[string]string{"alertname": "NPUHbmError"}},
+		}}
+		result := &ReceiveResult{}
+		infos := alertsToPubFaults(cfg, payload, result)
+		convey.So(len(infos), convey.ShouldEqual, 1)
+		convey.So(result.Skipped, convey.ShouldEqual, 2)
+		convey.So(len(result.Errors), convey.ShouldEqual, 1)
+		faults := infos[0].Faults
+		convey.So(infos[0].Resource, convey.ShouldEqual, testResource1)
+		convey.So(len(faults), convey.ShouldEqual, 2)
+		convey.So(faults[0].Assertion, convey.ShouldEqual, constant.AssertionOccur)
+		convey.So(faults[0].FaultTime, convey.ShouldEqual, testTimeStamp)
+		convey.So(faults[0].Description, convey.ShouldEqual, "hbm error")
+		convey.So(faults[0].Influence[0].DeviceIds, convey.ShouldResemble, []int32{1, 3})
+		convey.So(faults[1].Assertion, convey.ShouldEqual, constant.AssertionRecover)
+		convey.So(faults[1].FaultTime, convey.ShouldEqual, startsAt.Add(time.Minute).UnixMilli())
+		convey.So(faults[1].Influence[0].DeviceIds, convey.ShouldResemble, []int32{0})
+	})
+}
+
+func postReceiver(handler http.Handler, path, token, body string) (int, ReceiveResult) {
+	req := httptest.NewRequest(http.MethodPost, ReceiverPath+path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", bearerPrefix+token)
+	}
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	result := ReceiveResult{}
+	convey.So(json.Unmarshal(recorder.Body.Bytes(), &result), convey.ShouldBeNil)
+	return recorder.Code, result
+}
+
+func TestReceiverServeHTTP(t *testing.T) {
+	convey.Convey("test method 'ServeHTTP' of receiver", t, func() {
+		var collected []*api.PubFaultInfo
+		var collectErr error
+		patches := gomonkey.ApplyFunc(PubFaultCollector, func(info *api.PubFaultInfo) error {
+			collected = append(collected, info)
+			return collectErr
+		})
+		defer patches.Reset()
+		handler, err := NewReceiver(writeReceiverConfig(t.TempDir(), testRuleConfig))
+		convey.So(err, convey.ShouldBeNil)
+		alerts := `{"alerts": [{"status": "firing", "fingerprint": "` + testFingerprint + `", ` +
+			`"startsAt": "2025-02-18T08:18:37Z", "labels": {"alertname": "NPUHbmError", "node": "node1"}}]}`
+		convey.Convey("receiver is not active, should respond 503", func() {
+			code, _ := postReceiver(handler, alertmanagerResource, testToken, alerts)
+			convey.So(code, convey.ShouldEqual, http.StatusServiceUnavailable)
+		})
+		handler.(*receiver).active.Store(true)
+		convey.Convey("token is wrong, should respond 401", func() {
+			code, _ := postReceiver(handler, alertmanagerResource, "wrong", alerts)
+			convey.So(code, convey.ShouldEqual, http.StatusUnauthorized)
+			convey.So(len(collected), convey.ShouldEqual, 0)
+		})
+		convey.Convey("alerts are collected as public faults", func() {
+			code, result := postReceiver(handler, alertmanagerResource, testToken, alerts)
+			convey.So(code, convey.ShouldEqual, http.StatusOK)
+			convey.So(result.Accepted, convey.ShouldEqual, 1)
+			convey.So(collected[0].Faults[0].FaultId, convey.ShouldEqual, testFingerprint)
+		})
+		convey.Convey("limited by resource, should respond 429", func() {
+			collectErr = errors.New("limiter work by resource failed")
+			code, result := postReceiver(handler, alertmanagerResource, testToken, alerts)
+			convey.So(code, convey.ShouldEqual, http.StatusTooManyRequests)
+			convey.So(result.Skipped, convey.ShouldEqual, 1)
+		})
+		convey.Convey("public fault info is collected, invalid one should respond 400", func() {
+			data, err := json.Marshal(faultInfo)
+			convey.So(err, convey.ShouldBeNil)
+			code, result := postReceiver(handler, faultsResource, testToken, string(data))
+			convey.So(code, convey.ShouldEqual, http.StatusOK)
+			convey.So(result.Accepted, convey.ShouldEqual, 1)
+			collectErr = testErr
+			code, _ = postReceiver(handler, faultsResource, testToken, string(data))
+			convey.So(code, convey.ShouldEqual, http.StatusBadRequest)
+			code, _ = postReceiver(handler, faultsResource, testToken, "{")
+			convey.So(code, convey.ShouldEqual, http.StatusBadRequest)
+		})
+	})
+}
@@ -0,0 +1,351 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package recover a series of service function
+package recover
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"ascend-common/api"
+	"ascend-common/common-utils/hwlog"
+	"clusterd/pkg/common/constant"
+	"clusterd/pkg/domain/common"
+	"clusterd/pkg/interface/grpc/recover"
+	"clusterd/pkg/interface/kube"
+)
+
+// checkpointFrozen is set when clusterd is going to exit, the controllers will be reset by closed streams
+// at that time, and the checkpoints must be kept for the next clusterd
+var checkpointFrozen atomic.Bool
+
+// recoverCheckpoint is the persisted snapshot of an EventController
+type recoverCheckpoint struct {
+	JobId                   string                            `json:"jobId"`
+	Uuid                    string                            `json:"uuid,omitempty"`
+	State                   string                            `json:"state"`
+	Path                    []string                          `json:"path,omitempty"`
+	PathGraph               string                            `json:"pathGraph,omitempty"`
+	HealthState             string                            `json:"healthState,omitempty"`
+	PlatStrategy            string                            `json:"platStrategy,omitempty"`
+	RestartFaultProcess     bool                              `json:"restartFaultProcess,omitempty"`
+	CacheNormalFault        []*pb.FaultRank                   `json:"cacheNormalFault,omitempty"`
+	CacheRetryFault         []*pb.FaultRank                   `json:"cacheRetryFault,omitempty"`
+	FaultPod                map[string]string                 `json:"faultPod,omitempty"`
+	OriginPod               map[string]string                 `json:"originPod,omitempty"`
+	PrePodForScale          map[string]string                 `json:"prePodForScale,omitempty"`
+	LatestStrategy          []string                          `json:"latestStrategy,omitempty"`
+	LatestRecoverResult     []*pb.RecoverStatusRequest        `json:"latestRecoverResult,omitempty"`
+	AgentReportStrategies   []string                          `json:"agentReportStrategies,omitempty"`
+	IsolateNodes            []string                          `json:"isolateNodes,omitempty"`
+	RecoverInPlacePodFaults map[string]*constant.PodFaultInfo `json:"recoverInPlacePodFaults,omitempty"`
+	UpdateTime              int64                             `json:"updateTime"`
+	// TimeoutSeconds timeout of waiting report in the state, the checkpoint expires after it
+	TimeoutSeconds int64 `json:"timeoutSeconds,omitempty"`
+}
+
+// checkpointWrite a write of checkpoint waiting to be done, the checkpoint is removed when point is nil
+type checkpointWrite struct {
+	point *recoverCheckpoint
+}
+
+// resumePoint describe how to drive a restored state machine back into the checkpoint state:
+// put the machine to src, then add event, so that the signal of the interrupted step is sent again
+type resumePoint struct {
+	src   string
+	event string
+}
+
+var resumePoints = map[string]resumePoint{
+	common.NotifyWaitFaultFlushingState:        {src: common.InitState, event: common.FaultOccurEvent},
+	common.NotifyStopTrainState:                {src: common.InitState, event: common.FaultOccurEvent},
+	common.WaitReportStopCompleteState:         {src: common.InitState, event: common.FaultOccurEvent},
+	common.NotifyDumpState:                     {src: common.InitState, event: common.FaultOccurEvent},
+	common.WaitFaultFlushFinishedState:         {src: common.WaitReportStopCompleteState, event: common.ReceiveReportEvent},
+	common.NotifyGlobalFaultState:              {src: common.WaitFaultFlushFinishedState, event: common.FaultFlushFinishedEvent},
+	common.WaitReportRecoverStrategyState:      {src: common.WaitFaultFlushFinishedState, event: common.FaultFlushFinishedEvent},
+	common.NotifyDecidedStrategyState:          {src: common.WaitReportRecoverStrategyState, event: common.ReceiveReportEvent},
+	common.WaitReportStepRetryStatusState:      {src: common.WaitReportRecoverStrategyState, event: common.ReceiveReportEvent},
+	common.WaitReportProcessRecoverStatusState: {src: common.WaitReportRecoverStrategyState, event: common.ReceiveReportEvent},
+	common.WaitReportDumpStatusState:           {src: common.WaitReportRecoverStrategyState, event: common.ReceiveReportEvent},
+	common.KillPodForUnrecoverableRetryState:   {src: common.WaitReportRecoverStrategyState, event: common.ReceiveReportEvent},
+	common.NotifyScaleInStrategyState:          {src: common.WaitReportRecoverStrategyState, event: common.ReceiveReportEvent},
+	common.WaitReportScaleInIsolateRanksState:  {src: common.WaitReportRecoverStrategyState, event: common.ReceiveReportEvent},
+	common.CheckReportScaleInIsolateRanksState: {src: common.WaitReportRecoverStrategyState, event: common.ReceiveReportEvent},
+	common.WaitReportScaleInStatusState:        {src: common.WaitReportRecoverStrategyState, event: common.ReceiveReportEvent},
+	common.CheckRecoverResultState:             {src: common.WaitReportStepRetryStatusState, event: common.ReceiveReportEvent},
+	common.ScaleInRunningState:                 {src: common.CheckRecoverResultState, event: common.ScaleInSuccessEvent},
+	common.WaitReportScaleOutStatusState:       {src: common.CheckRecoverResultState, event: common.ScaleInSuccessEvent},
+	common.ListenScheduleResultState:           {src: common.CheckRecoverResultState, event: common.CheckResultFinishEvent},
+	common.NotifyRestartAllProcessState:        {src: common.ListenScheduleResultState, event: common.ScheduleSuccessEvent},
+	common.WaitRestartAllProcessState:          {src: common.ListenScheduleResultState, event: common.ScheduleSuccessEvent},
+	common.FaultClearState:                     {src: common.WaitReportStopCompleteState, event: common.ReportTimeoutEvent},
+	common.FaultRetryState:                     {src: common.FaultClearState, event: common.ClearConfigMapFaultSuccessEvent},
+	common.NotifyKillJobState:                  {src: common.WaitReportStopCompleteState, event: common.ProcessNotReadyEvent},
+}
+
+// FreezeCheckpoint stop removing recover checkpoints, called before clusterd exit
+func FreezeCheckpoint() {
+	checkpointFrozen.Store(true)
+}
+
+func checkpointCMName(jobId string) string {
+	return constant.RecoverCheckpointPrefix + jobId
+}
+
+func jobIdFromCheckpointCMName(cmName string) string {
+	return strings.TrimPrefix(cmName, constant.RecoverCheckpointPrefix)
+}
+
+func kubeClientReady() bool {
+	client := kube.GetClientK8s()
+	return client != nil && client.ClientSet != nil
+}
+
+func deleteCheckpointCM(jobId string) {
+	if checkpointFrozen.Load() || !kubeClientReady() {
+		return
+	}
+	err := kube.DeleteConfigMap(checkpointCMName(jobId), api.ClusterNS)
+	if err != nil && !errors.IsNotFound(err) {
+		hwlog.RunLog.Errorf("jobId=%s, delete recover checkpoint failed, err: %v", jobId, err)
+		return
+	}
+	hwlog.RunLog.Infof("jobId=%s, recover checkpoint deleted", jobId)
+}
+
+// loadCheckpoints load all recover checkpoints saved by last clusterd, expired ones are deleted
+func loadCheckpoints() map[string]*recoverCheckpoint {
+	checkpoints := make(map[string]*recoverCheckpoint)
+	if !kubeClientReady() {
+		return checkpoints
+	}
+	cmList, err := kube.ListConfigMapsByLabel(api.ClusterNS, constant.CmRecoverCheckpoint+"="+constant.CmConsumerValue)
+	if err != nil {
+		hwlog.RunLog.Errorf("list recover checkpoints failed, err: %v", err)
+		return checkpoints
+	}
+	now := time.Now()
+	for _, cm := range cmList.Items {
+		jobId := jobIdFromCheckpointCMName(cm.Name)
+		point := &recoverCheckpoint{}
+		if err = json.Unmarshal([]byte(cm.Data[constant.RecoverCheckpointKey]), point); err != nil {
+			hwlog.RunLog.Errorf("jobId=%s, unmarshal recover checkpoint failed, err: %v", jobId, err)
+			deleteCheckpointCM(jobId)
+			continue
+		}
+		if point.UpdateTime < now.Add(-point.expiration()).UnixMilli() {
+			hwlog.RunLog.Warnf("jobId=%s, recover checkpoint in state %s is expired, discard it",
+				jobId, point.State)
+			deleteCheckpointCM(jobId)
+			continue
+		}
+		hwlog.RunLog.Infof("jobId=%s, load recover checkpoint, state=%s", jobId, point.State)
+		checkpoints[jobId] = point
+	}
+	return checkpoints
+}
+
+// expiration the checkpoint expires when the state is not left within the timeout of the state
+func (point *recoverCheckpoint) expiration() time.Duration {
+	if point.TimeoutSeconds > 0 {
+		return time.Duration(point.TimeoutSeconds) * time.Second
+	}
+	return reportTimeoutMinutes * time.Minute
+}
+
+func (ctl *EventController) snapshot(state string) *recoverCheckpoint {
+	timeout := ctl.reportTimeout(state)
+	ctl.lock.RLock()
+	defer ctl.lock.RUnlock()
+	point := &recoverCheckpoint{
+		JobId:                   ctl.jobInfo.JobId,
+		Uuid:                    ctl.uuid,
+		State:                   state,
+		Path:                    ctl.state.GetPath(),
+		PathGraph:               ctl.state.GetPathGraph(),
+		HealthState:             ctl.healthState,
+		PlatStrategy:            ctl.platStrategy,
+		RestartFaultProcess:     ctl.restartFaultProcess,
+		CacheNormalFault:        append([]*pb.FaultRank{}, ctl.cacheNormalFault...),
+		CacheRetryFault:         append([]*pb.FaultRank{}, ctl.cacheRetryFault...),
+		FaultPod:                make(map[string]string, len(ctl.faultPod)),
+		OriginPod:               make(map[string]string, len(ctl.originPod)),
+		PrePodForScale:          make(map[string]string, len(ctl.prePodForScale)),
+		LatestStrategy:          append([]string{}, ctl.latestStrategy...),
+		LatestRecoverResult:     append([]*pb.RecoverStatusRequest{}, ctl.latestRecoverResult...),
+		AgentReportStrategies:   append([]string{}, ctl.agentReportStrategies...),
+		IsolateNodes:            ctl.isolateNodes.List(),
+		RecoverInPlacePodFaults: make(map[string]*constant.PodFaultInfo, len(ctl.recoverInPlacePodFaults)),
+		UpdateTime:              time.Now().UnixMilli(),
+		TimeoutSeconds:          int64(timeout / time.Second),
+	}
+	for k, v := range ctl.faultPod {
+		point.FaultPod[k] = v
+	}
+	for k, v := range ctl.originPod {
+		point.OriginPod[k] = v
+	}
+	for k, v := range ctl.prePodForScale {
+		point.PrePodForScale[k] = v
+	}
+	for k, v := range ctl.recoverInPlacePodFaults {
+		point.RecoverInPlacePodFaults[k] = v
+	}
+	return point
+}
+
+// saveCheckpoint is the enter hook of state machine, persist controller each time a new state is entered.
+// the checkpoint is written in background, so a slow apiserver does not stall the recovery
+func (ctl *EventController) saveCheckpoint(state string) {
+	if _, ok := resumePoints[state]; !ok {
+		// init state or a state can not be resumed, nothing should be left for next clusterd
+		ctl.removeCheckpoint()
+		return
+	}
+	if !kubeClientReady() {
+		return
+	}
+	ctl.queueCheckpointWrite(&checkpointWrite{point: ctl.snapshot(state)})
+}
+
+func (ctl *EventController) removeCheckpoint() {
+	if checkpointFrozen.Load() {
+		return
+	}
+	ctl.checkpointLock.Lock()
+	saved := ctl.checkpointWriting || ctl.checkpointed.Load()
+	ctl.checkpointLock.Unlock()
+	if !saved {
+		return
+	}
+	ctl.queueCheckpointWrite(&checkpointWrite{})
+}
+
+// queueCheckpointWrite replaces the write waiting to be done, only the latest checkpoint is written
+func (ctl *EventController) queueCheckpointWrite(write *checkpointWrite) {
+	ctl.checkpointLock.Lock()
+	defer ctl.checkpointLock.Unlock()
+	ctl.pendingWrite = write
+	if ctl.checkpointWriting {
+		return
+	}
+	ctl.checkpointWriting = true
+	go ctl.flushCheckpoint()
+}
+
+func (ctl *EventController) flushCheckpoint() {
+	for {
+		ctl.checkpointLock.Lock()
+		write := ctl.pendingWrite
+		ctl.pendingWrite = nil
+		if write == nil {
+			ctl.checkpointWriting = false
+			ctl.checkpointLock.Unlock()
+			return
+		}
+		ctl.checkpointLock.Unlock()
+		if write.point == nil {
+			ctl.deleteCheckpoint()
+			continue
+		}
+		ctl.writeCheckpoint(write.point)
+	}
+}
+
+func (ctl *EventController) writeCheckpoint(point *recoverCheckpoint) {
+	if !kubeClientReady() {
+		return
+	}
+	data, err := json.Marshal(point)
+	if err != nil {
+		hwlog.RunLog.Errorf("jobId=%s, marshal recover checkpoint failed, err: %v", ctl.jobInfo.JobId, err)
+		return
+	}
+	label := map[string]string{constant.CmRecoverCheckpoint: constant.CmConsumerValue}
+	if err = kube.UpdateOrCreateConfigMap(checkpointCMName(ctl.jobInfo.JobId), api.ClusterNS,
+		map[string]string{constant.RecoverCheckpointKey: string(data)}, label); err != nil {
+		hwlog.RunLog.Errorf("jobId=%s, save recover checkpoint in state %s failed, err: %v",
+			ctl.jobInfo.JobId, point.State, err)
+		return
+	}
+	ctl.checkpointed.Store(true)
+}
+
+func (ctl *EventController) deleteCheckpoint() {
+	if checkpointFrozen.Load() || !ctl.checkpointed.CompareAndSwap(true, false) {
+		return
+	}
+	deleteCheckpointCM(ctl.jobInfo.JobId)
+}
+
+func (ctl *EventController) setPendingCheckpoint(point *recoverCheckpoint) {
+	ctl.lock.Lock()
+	defer ctl.lock.Unlock()
+	ctl.pendingCheckpoint = point
+}
+
+func (ctl *EventController) takePendingCheckpoint() *recoverCheckpoint {
+	ctl.lock.Lock()
+	defer ctl.lock.Unlock()
+	point := ctl.pendingCheckpoint
+	ctl.pendingCheckpoint = nil
+	return point
+}
+
+func (ctl *EventController) restore(point *recoverCheckpoint) {
+	ctl.lock.Lock()
+	defer ctl.lock.Unlock()
+	ctl.uuid = point.Uuid
+	ctl.healthState = point.HealthState
+	ctl.platStrategy = point.PlatStrategy
+	ctl.restartFaultProcess = point.RestartFaultProcess
+	ctl.cacheNormalFault = point.CacheNormalFault
+	ctl.cacheRetryFault = point.CacheRetryFault
+	ctl.latestStrategy = point.LatestStrategy
+	ctl.latestRecoverResult = point.LatestRecoverResult
+	ctl.agentReportStrategies = point.AgentReportStrategies
+	ctl.isolateNodes = sets.NewString(point.IsolateNodes...)
+	if point.FaultPod != nil {
+		ctl.faultPod = point.FaultPod
+	}
+	if len(point.OriginPod) != 0 {
+		ctl.originPod = point.OriginPod
+	}
+	if len(point.PrePodForScale) != 0 {
+		ctl.prePodForScale = point.PrePodForScale
+	}
+	if point.RecoverInPlacePodFaults != nil {
+		ctl.recoverInPlacePodFaults = point.RecoverInPlacePodFaults
+	}
+	ctl.matchStrategyRulesLocked()
+}
+
+// resumeFromCheckpoint drive controller back to the state saved by last clusterd,
+// it must be called after controller reset, when the agent subscribed again
+func (ctl *EventController) resumeFromCheckpoint() {
+	point := ctl.takePendingCheckpoint()
+	if point == nil {
+		return
+	}
+	resume, ok := resumePoints[point.State]
+	if !ok {
+		hwlog.RunLog.Warnf("jobId=%s, state %s in recover checkpoint can not be resumed, discard it",
+			ctl.jobInfo.JobId, point.State)
+		deleteCheckpointCM(ctl.jobInfo.JobId)
+		return
+	}
+	ctl.restore(point)
+	ctl.state.Restore(resume.src, point.Path,
+		fmt.Sprintf("%s(resume)-->%s", point.PathGraph, resume.src))
+	ctl.checkpointed.Store(true)
+	hwlog.RunLog.Infof("jobId=%s, resume from checkpoint state=%s, restart at state=%s with event=%s",
+		ctl.jobInfo.JobId, point.State, resume.src, resume.event)
+	ctl.addEvent(resume.event)
+}
@@ -0,0 +1,251 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package recover a series of checkpoint test function
+package recover
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/smartystreets/goconvey/convey"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"clusterd/pkg/common/constant"
+	"clusterd/pkg/domain/common"
+	"clusterd/pkg/domain/conf"
+	"clusterd/pkg/interface/grpc/recover"
+	"clusterd/pkg/interface/kube"
+)
+
+const (
+	checkpointJobId   = "checkpoint-job"
+	waitWriterRetries = 1000
+)
+
+func newCheckpointController() *EventController {
+	return NewEventController(common.JobBaseInfo{JobId: checkpointJobId}, keepAliveSeconds, context.Background())
+}
+
+// waitCheckpointWritten waits until the background writer of checkpoint exits
+func waitCheckpointWritten(ctl *EventController) {
+	for i := 0; i < waitWriterRetries; i++ {
+		ctl.checkpointLock.Lock()
+		writing := ctl.checkpointWriting
+		ctl.checkpointLock.Unlock()
+		if !writing {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSaveCheckpoint(t *testing.T) {
+	convey.Convey("Test saveCheckpoint", t, func() {
+		ctl := newCheckpointController()
+		ctl.cacheNormalFault = []*pb.FaultRank{{RankId: "1", FaultType: constant.NormalFaultType}}
+		ctl.latestStrategy = []string{constant.ProcessRetryStrategyName}
+		savedData := ""
+		deleteCount := 0
+		patches := gomonkey.ApplyFuncReturn(kubeClientReady, true).
+			ApplyFunc(kube.UpdateOrCreateConfigMap, func(_, _ string, data, _ map[string]string) error {
+				savedData = data[constant.RecoverCheckpointKey]
+				return nil
+			}).
+			ApplyFunc(kube.DeleteConfigMap, func(_, _ string) error {
+				deleteCount++
+				return nil
+			})
+		defer patches.Reset()
+		convey.Convey("resumable state should be saved", func() {
+			ctl.saveCheckpoint(common.WaitReportRecoverStrategyState)
+			waitCheckpointWritten(ctl)
+			point := &recoverCheckpoint{}
+			convey.So(json.Unmarshal([]byte(savedData), point), convey.ShouldBeNil)
+			convey.So(point.State, convey.ShouldEqual, common.WaitReportRecoverStrategyState)
+			convey.So(point.JobId, convey.ShouldEqual, checkpointJobId)
+			convey.So(len(point.CacheNormalFault), convey.ShouldEqual, 1)
+			convey.So(point.LatestStrategy, convey.ShouldResemble, ctl.latestStrategy)
+			convey.So(ctl.checkpointed.Load(), convey.ShouldBeTrue)
+		})
+		convey.Convey("entering init state should remove saved checkpoint once", func() {
+			ctl.saveCheckpoint(common.WaitReportRecoverStrategyState)
+			waitCheckpointWritten(ctl)
+			ctl.saveCheckpoint(common.InitState)
+			waitCheckpointWritten(ctl)
+			ctl.saveCheckpoint(common.InitState)
+			waitCheckpointWritten(ctl)
+			convey.So(deleteCount, convey.ShouldEqual, 1)
+			convey.So(ctl.checkpointed.Load(), convey.ShouldBeFalse)
+		})
+		convey.Convey("frozen checkpoint should not be removed", func() {
+			ctl.saveCheckpoint(common.WaitReportRecoverStrategyState)
+			waitCheckpointWritten(ctl)
+			FreezeCheckpoint()
+			defer checkpointFrozen.Store(false)
+			ctl.removeCheckpoint()
+			waitCheckpointWritten(ctl)
+			convey.So(deleteCount, convey.ShouldEqual, 0)
+		})
+		convey.Convey("save failed should not mark checkpointed", func() {
+			failPatch := gomonkey.ApplyFuncReturn(kube.UpdateOrCreateConfigMap, errors.New("update failed"))
+			defer failPatch.Reset()
+			ctl.saveCheckpoint(common.WaitReportRecoverStrategyState)
+			waitCheckpointWritten(ctl)
+			convey.So(ctl.checkpointed.Load(), convey.ShouldBeFalse)
+		})
+		convey.Convey("writes queued while writing should be coalesced", func() {
+			saveCount := 0
+			release := make(chan struct{})
+			blockPatch := gomonkey.ApplyFunc(kube.UpdateOrCreateConfigMap,
+				func(_, _ string, data, _ map[string]string) error {
+					if saveCount == 0 {
+						<-release
+					}
+					saveCount++
+					savedData = data[constant.RecoverCheckpointKey]
+					return nil
+				})
+			defer blockPatch.Reset()
+			ctl.saveCheckpoint(common.WaitReportRecoverStrategyState)
+			ctl.saveCheckpoint(common.WaitReportStepRetryStatusState)
+			ctl.saveCheckpoint(common.CheckRecoverResultState)
+			close(release)
+			waitCheckpointWritten(ctl)
+			point := &recoverCheckpoint{}
+			convey.So(json.Unmarshal([]byte(savedData), point), convey.ShouldBeNil)
+			convey.So(point.State, convey.ShouldEqual, common.CheckRecoverResultState)
+			convey.So(saveCount, convey.ShouldBeLessThanOrEqualTo, 2)
+		})
+	})
+}
+
+func newCheckpointCM(jobId string, point *recoverCheckpoint) v1.ConfigMap {
+	data := "invalid"
+	if point != nil {
+		bytes, err := json.Marshal(point)
+		if err == nil {
+			data = string(bytes)
+		}
+	}
+	return v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: checkpointCMName(jobId)},
+		Data:       map[string]string{constant.RecoverCheckpointKey: data},
+	}
+}
+
+func TestLoadCheckpoints(t *testing.T) {
+	convey.Convey("Test loadCheckpoints", t, func() {
+		const validJob, expiredJob, invalidJob, longTimeoutJob = "job1", "job2", "job3", "job4"
+		cmList := &v1.ConfigMapList{Items: []v1.ConfigMap{
+			newCheckpointCM(validJob, &recoverCheckpoint{JobId: validJob,
+				State: common.WaitReportStepRetryStatusState, UpdateTime: time.Now().UnixMilli()}),
+			newCheckpointCM(expiredJob, &recoverCheckpoint{JobId: expiredJob,
+				State:      common.WaitReportStepRetryStatusState,
+				UpdateTime: time.Now().Add(-(reportTimeoutMinutes + 1) * time.Minute).UnixMilli()}),
+			newCheckpointCM(invalidJob, nil),
+			newCheckpointCM(longTimeoutJob, &recoverCheckpoint{JobId: longTimeoutJob,
+				State: common.WaitReportStepRetryStatusState, TimeoutSeconds: conf.MaxStateTimeoutSeconds,
+				UpdateTime: time.Now().Add(-(reportTimeoutMinutes + 1) * time.Minute).UnixMilli()}),
+		}}
+		var deleted []string
+		patches := gomonkey.ApplyFuncReturn(kubeClientReady, true).
+			ApplyFuncReturn(kube.ListConfigMapsByLabel, cmList, nil).
+			ApplyFunc(kube.DeleteConfigMap, func(name, _ string) error {
+				deleted = append(deleted, name)
+				return nil
+			})
+		defer patches.Reset()
+		checkpoints := loadCheckpoints()
+		convey.So(len(checkpoints), convey.ShouldEqual, 2)
+		convey.So(checkpoints[validJob], convey.ShouldNotBeNil)
+		convey.So(checkpoints[longTimeoutJob], convey.ShouldNotBeNil)
+		convey.So(deleted, convey.ShouldResemble, []string{checkpointCMName(expiredJob), checkpointCMName(invalidJob)})
+
+		listPatch := gomonkey.ApplyFuncReturn(kube.ListConfigMapsByLabel, (*v1.ConfigMapList)(nil),
+			errors.New("list failed"))
+		defer listPatch.Reset()
+		convey.So(len(loadCheckpoints()), convey.ShouldEqual, 0)
+	})
+}
+
+func TestResumeFromCheckpoint(t *testing.T) {
+	convey.Convey("Test resumeFromCheckpoint", t, func() {
+		ctl := newCheckpointController()
+		deleteCount := 0
+		patches := gomonkey.ApplyFuncReturn(kubeClientReady, true).
+			ApplyFuncReturn(kube.UpdateOrCreateConfigMap, nil).
+			ApplyFunc(kube.DeleteConfigMap, func(_, _ string) error {
+				deleteCount++
+				return nil
+			})
+		defer patches.Reset()
+		convey.Convey("controller without checkpoint keep init state", func() {
+			ctl.resumeFromCheckpoint()
+			convey.So(ctl.state.GetState(), convey.ShouldEqual, common.InitState)
+			convey.So(len(ctl.events), convey.ShouldEqual, 0)
+		})
+		convey.Convey("resumable checkpoint should restore controller and add resume event", func() {
+			ctl.setPendingCheckpoint(&recoverCheckpoint{
+				JobId:            checkpointJobId,
+				Uuid:             "uuid",
+				State:            common.WaitReportProcessRecoverStatusState,
+				Path:             []string{common.InitState},
+				CacheNormalFault: []*pb.FaultRank{{RankId: "1", FaultType: constant.NormalFaultType}},
+				IsolateNodes:     []string{"node1"},
+				LatestStrategy:   []string{constant.ProcessRecoverStrategyName},
+			})
+			ctl.resumeFromCheckpoint()
+			convey.So(ctl.state.GetState(), convey.ShouldEqual, common.WaitReportRecoverStrategyState)
+			convey.So(<-ctl.events, convey.ShouldEqual, common.ReceiveReportEvent)
+			convey.So(ctl.uuid, convey.ShouldEqual, "uuid")
+			convey.So(len(ctl.cacheNormalFault), convey.ShouldEqual, 1)
+			convey.So(ctl.isolateNodes.Has("node1"), convey.ShouldBeTrue)
+			convey.So(ctl.checkpointed.Load(), convey.ShouldBeTrue)
+			convey.So(ctl.takePendingCheckpoint(), convey.ShouldBeNil)
+		})
+		convey.Convey("checkpoint can not be resumed should be discarded", func() {
+			ctl.setPendingCheckpoint(&recoverCheckpoint{JobId: checkpointJobId,
+				State: common.WaitSwitchNicFinishedState})
+			ctl.resumeFromCheckpoint()
+			convey.So(ctl.state.GetState(), convey.ShouldEqual, common.InitState)
+			convey.So(deleteCount, convey.ShouldEqual, 1)
+		})
+	})
+}
+
+func TestRegistryWithCheckpoint(t *testing.T) {
+	convey.Convey("Test registry and DeleteJob with checkpoint", t, func() {
+		s := &FaultRecoverService{
+			keepAliveInterval: keepAliveSeconds,
+			serviceCtx:        context.Background(),
+			eventCtl:          make(map[string]*EventController),
+			initJob:           make(map[string]common.JobBaseInfo),
+			currentFaults:     make(map[string]map[string]bool),
+			checkpoints: map[string]*recoverCheckpoint{
+				checkpointJobId: {JobId: checkpointJobId, State: common.CheckRecoverResultState},
+				"job2":          {JobId: "job2", State: common.CheckRecoverResultState},
+			},
+		}
+		deleteCount := 0
+		patches := gomonkey.ApplyFuncReturn(kubeClientReady, true).
+			ApplyFunc(kube.DeleteConfigMap, func(_, _ string) error {
+				deleteCount++
+				return nil
+			})
+		defer patches.Reset()
+		s.registry(common.JobBaseInfo{JobId: checkpointJobId})
+		ctl, ok := s.getController(checkpointJobId)
+		convey.So(ok, convey.ShouldBeTrue)
+		convey.So(ctl.pendingCheckpoint, convey.ShouldNotBeNil)
+		convey.So(len(s.checkpoints), convey.ShouldEqual, 1)
+
+		s.DeleteJob("job2")
+		convey.So(len(s.checkpoints), convey.ShouldEqual, 0)
+		convey.So(deleteCount, convey.ShouldEqual, 1)
+	})
+}
@@ -10,18 +10,23 @@ import (
 	"slices"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/clock"
 
 	"ascend-common/api"
 	"ascend-common/common-utils/hwlog"
 	"clusterd/pkg/application/faultmanager"
 	"clusterd/pkg/application/faultmanager/cmprocess/recoverinplace"
 	"clusterd/pkg/common/constant"
+	"clusterd/pkg/common/journal"
+	"clusterd/pkg/common/metrics"
 	"clusterd/pkg/common/util"
 	"clusterd/pkg/domain/common"
+	"clusterd/pkg/domain/conf"
 	"clusterd/pkg/domain/job"
 	"clusterd/pkg/domain/pod"
 	"clusterd/pkg/domain/podgroup"
@@ -39,6 +44,8 @@ const (
 	tpBlockStr16         = "16"
 	tpBlockStr32         = "32"
 	tpBlockStr64         = "64"
+	noneStrategyName     = "none"
+	journalSource        = "recover"
 )
 
 var (
@@ -110,6 +117,23 @@ type EventController struct {
 	newPodStatusMonitorChan     chan corev1.PodPhase
 	currentHotSwitchFaultPodId  string
 	currentHotSwitchBackupPodId string
+	pendingCheckpoint           *recoverCheckpoint
+	checkpointed                atomic.Bool
+	recoverStartTime            time.Time
+	strategyRules               []conf.RecoverStrategyRule
+	// pendingWrite the latest checkpoint write not done yet, checkpointWriting whether the writer is running
+	pendingWrite      *checkpointWrite
+	checkpointWriting bool
+	checkpointLock    sync.Mutex
+	// clock of waiting reports, nil means the real clock, offline tools use a fake clock to skip the waiting
+	clock clock.Clock
+}
+
+func (ctl *EventController) getClock() clock.Clock {
+	if ctl.clock == nil {
+		return clock.RealClock{}
+	}
+	return ctl.clock
 }
 
 func catchException() {
@@ -163,10 +187,105 @@ func NewEventController(jobInfo common.JobBaseInfo, keepAlive int, serviceCtx co
 	ctl.updatePodInfo()
 	var rules []common.TransRule = ctl.getBaseRules()
 	ctl.state = common.NewStateMachine(common.InitState, rules)
+	ctl.state.SetEnterHook(ctl.onStateEnter)
 	ctl.controllerContext, ctl.ctxCancelFunc = context.WithCancel(ctl.serviceContext)
 	return ctl
 }
 
+func (ctl *EventController) onStateEnter(state string) {
+	ctl.matchStrategyRules(state)
+	ctl.recordRecoverTime(state)
+	ctl.saveCheckpoint(state)
+}
+
+func (ctl *EventController) recordRecoverTime(state string) {
+	ctl.lock.Lock()
+	defer ctl.lock.Unlock()
+	switch state {
+	case common.NotifyWaitFaultFlushingState, common.NotifyPrepareHotSwitchState:
+		if ctl.recoverStartTime.IsZero() {
+			ctl.recoverStartTime = time.Now()
+		}
+	case common.InitState:
+		ctl.observeRecoverDuration()
+	default:
+	}
+}
+
+// observeRecoverDuration must be called with lock held
+func (ctl *EventController) observeRecoverDuration() {
+	if ctl.recoverStartTime.IsZero() {
+		return
+	}
+	strategy := noneStrategyName
+	if ctl.currentHotSwitchFaultPodId != "" {
+		strategy = constant.HotSwitchSignalType
+	} else if len(ctl.latestStrategy) > 0 {
+		strategy = ctl.latestStrategy[len(ctl.latestStrategy)-1]
+	}
+	metrics.ObserveRecoverDuration(strategy, time.Since(ctl.recoverStartTime))
+	ctl.recoverStartTime = time.Time{}
+}
+
+// recoverInfo get a snapshot of recover state for query
+func (ctl *EventController) recoverInfo() common.RecoverInfo {
+	ctl.lock.RLock()
+	defer ctl.lock.RUnlock()
+	info := common.RecoverInfo{
+		JobId:            ctl.jobInfo.JobId,
+		Uuid:             ctl.uuid,
+		State:            ctl.state.GetState(),
+		Path:             ctl.state.GetPath(),
+		PathGraph:        ctl.state.GetPathGraph(),
+		HealthState:      ctl.healthState,
+		PlatStrategy:     ctl.platStrategy,
+		LatestStrategy:   append([]string{}, ctl.latestStrategy...),
+		AgentStrategies:  append([]string{}, ctl.agentReportStrategies...),
+		ConfigStrategies: append([]string{}, ctl.jobInfo.MindXConfigStrategies...),
+		FaultPod:         make(map[string]string, len(ctl.faultPod)),
+		NormalFaults:     toRecoverFaults(ctl.cacheNormalFault),
+		RetryFaults:      toRecoverFaults(ctl.cacheRetryFault),
+		IsolateNodes:     ctl.isolateNodes.List(),
+	}
+	for k, v := range ctl.faultPod {
+		info.FaultPod[k] = v
+	}
+	if !ctl.recoverStartTime.IsZero() {
+		info.RecoverStartTime = ctl.recoverStartTime.UnixMilli()
+	}
+	return info
+}
+
+// recordJournal record a recover decision of the job to fault journal
+func (ctl *EventController) recordJournal(stage, detail string) {
+	journal.Record(journal.Event{Stage: stage, Source: journalSource, JobId: ctl.jobInfo.JobId, Detail: detail})
+}
+
+// strategyReason describe the inputs of choosing strategy, must be called with lock held
+func (ctl *EventController) strategyReason() string {
+	results := make([]string, 0, len(ctl.latestRecoverResult))
+	for _, result := range ctl.latestRecoverResult {
+		if result == nil || result.Status == nil {
+			continue
+		}
+		results = append(results, fmt.Sprintf("%s:%d", result.Strategy, result.Status.Code))
+	}
+	return fmt.Sprintf("configured=%v, reported=%v, previousResults=%v, normalFaults=%d, retryFaults=%d",
+		ctl.jobInfo.MindXConfigStrategies, ctl.agentReportStrategies, results, len(ctl.cacheNormalFault),
+		len(ctl.cacheRetryFault))
+}
+
+func toRecoverFaults(faults []*pb.FaultRank) []common.RecoverFault {
+	result := make([]common.RecoverFault, 0, len(faults))
+	for _, fault := range faults {
+		if fault == nil {
+			continue
+		}
+		result = append(result, common.RecoverFault{RankId: fault.RankId, FaultType: fault.FaultType})
+	}
+	return result
+}
+
 func (ctl *EventController) updatePodInfo() {
 	pods := pod.GetPodByJobId(ctl.jobInfo.JobId)
 	for _, pod := range pods {
@@ -233,10 +352,13 @@ func (ctl *EventController) reset(stop bool) {
 	if ctl.ctxCancelFunc != nil {
 		ctl.ctxCancelFunc()
 	}
+	ctl.removeCheckpoint()
+	ctl.observeRecoverDuration()
 	ctl.faultFlushing = false
 	ctl.restartFaultProcess = false
 	ctl.recoverInPlacePodFaults = make(map[string]*constant.PodFaultInfo)
 	ctl.uuid = ""
+	ctl.strategyRules = nil
 	ctl.latestStrategy = ctl.latestStrategy[:0]
 	ctl.faultPod = make(map[string]string)
 	ctl.updatePodInfo()
@@ -617,6 +739,7 @@ func (ctl *EventController) selectSendChannel(ctx context.Context, sendChan chan
 
 func (ctl *EventController) listenSendChannel(stream pb.Recover_SubscribeProcessManageSignalServer) {
 	ctl.reset(false)
+	ctl.resumeFromCheckpoint()
 	ctx, sendChan := ctl.getCtxAndSignalChan()
 	hwlog.RunLog.Infof("start listen a new send channel, jobId=%s", ctl.jobInfo.JobId)
 	exit := false
@@ -768,7 +891,7 @@ func (ctl *EventController) handleWaitReportStopComplete() (string, common.RespC
 			return common.ProcessNotReadyEvent, common.ClientError, nil
 		}
 		return common.ReceiveReportEvent, common.OK, nil
-	case <-time.After(time.Duration(reportTimeoutMinutes) * time.Minute):
+	case <-ctl.getClock().After(ctl.reportTimeout(common.WaitReportStopCompleteState)):
 		hwlog.RunLog.Errorf("wait report stop complete timeout, jobId=%s, uuid=%s", ctl.jobInfo.JobId, ctl.uuid)
 		return common.ReportTimeoutEvent, common.WaitReportTimeout, nil
 	}
@@ -1096,6 +1219,9 @@ func isNodeDeviceA5(nodeName string, clusterDevices []*api.SuperPodDevice) bool
 func (ctl *EventController) firstChooseStrategy() string {
 	hwlog.RunLog.Infof("first choose strategy, jobId=%s, configed: %v, reported: %v", ctl.jobInfo.JobId,
 		ctl.jobInfo.MindXConfigStrategies, ctl.agentReportStrategies)
+	if strategy, ok := ctl.choosePolicyStrategy(firstCandidates); ok {
+		return strategy
+	}
 	if ctl.supportRetryStrategy() && len(ctl.cacheNormalFault) <= 0 {
 		return constant.ProcessRetryStrategyName
 	}
@@ -1135,6 +1261,9 @@ func (ctl *EventController) canChooseScaleInStrategy() bool {
 }
 
 func (ctl *EventController) chooseForRetryFail() string {
+	if strategy, ok := ctl.choosePolicyStrategy(retryFailCandidates); ok {
+		return strategy
+	}
 	if ctl.supportRestartProcessStrategy() {
 		return constant.ProcessRecoverInPlaceStrategyName
 	}
@@ -1151,6 +1280,9 @@ func (ctl *EventController) chooseForRetryFail() string {
 }
 
 func (ctl *EventController) chooseForRecoverFail() string {
+	if strategy, ok := ctl.choosePolicyStrategy(recoverFailCandidates); ok {
+		return strategy
+	}
 	if ctl.supportDumpStrategy() {
 		return constant.ProcessDumpStrategyName
 	}
@@ -1241,6 +1373,11 @@ func (ctl *EventController) handleDecidedStrategyAfterChoose(signal *pb.ProcessM
 		return ctl.handleRestartFaultProcess(signal)
 	}
 	hwlog.RunLog.Infof("jobId=%s, choose strategy:%s", ctl.jobInfo.JobId, signal.ChangeStrategy)
+	ctl.lock.RLock()
+	reason := ctl.strategyReason()
+	ctl.lock.RUnlock()
+	ctl.recordJournal(journal.StageStrategy, fmt.Sprintf("strategy=%s, %s", signal.ChangeStrategy, reason))
+	ctl.recordStrategyEvent(signal.ChangeStrategy)
 	if signal.ChangeStrategy == constant.ScaleInStrategyName {
 		signal.ExtraParams = `{"scale-in-strategy": "DP"}`
 	} else if signal.ChangeStrategy == constant.ProcessRetryStrategyName {
@@ -1398,6 +1535,8 @@ func (ctl *EventController) removeAgentStrategy(strategy string) {
 }
 
 func (ctl *EventController) updateFixResult(strategy, value string) {
+	ctl.recordJournal(journal.StageResult, fmt.Sprintf("strategy=%s, result=%s", strategy, value))
+	ctl.recordFixResultEvent(strategy, value)
 	newRecoverStatusAnnotation := map[string]interface{}{
 		constant.ProcessRecoverStatusKey: value,
 	}
@@ -1425,7 +1564,7 @@ func (ctl *EventController) handleCheckRecoverResult() (string, common.RespCode,
 			return common.RecoverSuccessEvent, common.OK, nil
 		}
 		ctl.updateFixResult(result.Strategy, constant.RetryFailed)
-		if result.Code == common.RecoverableRetryError {
+		if result.Code == common.RecoverableRetryError && !ctl.retryBudgetUsedUp() {
 			return common.RecoverableRetryErrorEvent, common.RecoverableRetryError, nil
 		}
 		ctl.removeAgentStrategy(constant.ProcessRecoverStrategyName)
@@ -1581,6 +1720,8 @@ func (ctl *EventController) handleKillJob() (string, common.RespCode, error) {
 		FaultRanks:     nil,
 		ChangeStrategy: "",
 	}
+	ctl.recordJournal(journal.StageStrategy, "kill job")
+	ctl.recordKillJobEvent()
 	defer catchException()
 	select {
 	case sendChan <- signal:
@@ -1621,7 +1762,7 @@ func (ctl *EventController) handleWaitReportRecoverStrategy() (string, common.Re
 	case <-ctx.Done():
 		hwlog.RunLog.Warnf("controller context canceled, jobId=%s, uuid=%s", ctl.jobInfo.JobId, ctl.uuid)
 		return "", common.ControllerEventCancel, nil
-	case <-time.After(time.Duration(reportTimeoutMinutes) * time.Minute):
+	case <-ctl.getClock().After(ctl.reportTimeout(common.WaitReportRecoverStrategyState)):
 		hwlog.RunLog.Errorf("wait report recover strategy timeout, jobId=%s", ctl.jobInfo.JobId)
 		return common.ReportTimeoutEvent, common.WaitReportTimeout, nil
 	}
@@ -1743,7 +1884,7 @@ func (ctl *EventController) handleDecideRetryStrategy() (string, common.RespCode
 	case <-ctx.Done():
 		hwlog.RunLog.Warnf("controller context canceled, jobId=%s, uuid=%s", ctl.jobInfo.JobId, ctl.uuid)
 		return "", common.ControllerEventCancel, nil
-	case <-time.After(time.Duration(reportTimeoutMinutes) * time.Minute):
+	case <-ctl.getClock().After(ctl.reportTimeout(common.WaitReportStepRetryStatusState)):
 		hwlog.RunLog.Errorf("wait report recover status timeout, jobId=%s", ctl.jobInfo.JobId)
 		return common.ReportTimeoutEvent, common.WaitReportTimeout, nil
 	}
@@ -1757,7 +1898,7 @@ func (ctl *EventController) handleDecideRecoverStrategy() (string, common.RespCo
 		hwlog.RunLog.Errorf("jobId=%s, resultCh or scheduleCh is nil", ctl.jobInfo.JobId)
 		return "", common.OK, fmt.Errorf("jobId=%s, resultCh or scheduleCh is nil", ctl.jobInfo.JobId)
 	}
-	timer := time.NewTimer(time.Duration(reportTimeoutMinutes) * time.Minute)
+	timer := ctl.getClock().NewTimer(ctl.reportTimeout(common.WaitReportProcessRecoverStatusState))
 	defer timer.Stop()
 	for {
 		select {
@@ -1789,7 +1930,7 @@ func (ctl *EventController) handleDecideRecoverStrategy() (string, common.RespCo
 		case <-ctx.Done():
 			hwlog.RunLog.Warnf("controller context canceled, jobId=%s, uuid=%s", ctl.jobInfo.JobId, ctl.uuid)
 			return "", common.ControllerEventCancel, nil
-		case <-timer.C:
+		case <-timer.C():
 			hwlog.RunLog.Errorf("wait report recover status timeout, jobId=%s", ctl.jobInfo.JobId)
 			return common.ReportTimeoutEvent, common.WaitReportTimeout, nil
 		}
@@ -1798,7 +1939,7 @@ func (ctl *EventController) handleDecideRecoverStrategy() (string, common.RespCo
 
 func (ctl *EventController) handleDecideDumpStrategy() (string, common.RespCode, error) {
 	ctl.appendStrategy(constant.ProcessDumpStrategyName)
-	return ctl.waitReportStatus()
+	return ctl.waitReportStatus(common.WaitReportDumpStatusState)
 }
 
 func (ctl *EventController) handleDecideExitStrategy() (string, common.RespCode, error) {
@@ -1939,10 +2080,11 @@ func (ctl *EventController) handleNotifyScaleInStrategy() (string, common.RespCo
 
 func (ctl *EventController) handleWaitReportScaleInIsolateRanksStatus() (string, common.RespCode, error) {
 	ctl.appendStrategy(constant.ScaleInStrategyName)
-	return ctl.waitReportStatus()
+	return ctl.waitReportStatus(common.WaitReportScaleInIsolateRanksState)
 }
 
-func (ctl *EventController) waitReportStatus() (string, common.RespCode, error) {
+// waitReportStatus wait report of strategy result in the state
+func (ctl *EventController) waitReportStatus(state string) (string, common.RespCode, error) {
 	ctx, resultCh := ctl.getCtxAndResultChan()
 	if resultCh == nil {
 		hwlog.RunLog.Errorf("jobId=%s, resultCh is nil", ctl.jobInfo.JobId)
@@ -1960,14 +2102,14 @@ func (ctl *EventController) waitReportStatus() (string, common.RespCode, error)
 	case <-ctx.Done():
 		hwlog.RunLog.Warnf("controller context canceled, jobId=%s, uuid=%s", ctl.jobInfo.JobId, ctl.uuid)
 		return "", common.ControllerEventCancel, nil
-	case <-time.After(time.Duration(reportTimeoutMinutes) * time.Minute):
-		hwlog.RunLog.Errorf("%s timeout, jobId=%s", ctl.state.GetState(), ctl.jobInfo.JobId)
+	case <-ctl.getClock().After(ctl.reportTimeout(state)):
+		hwlog.RunLog.Errorf("%s timeout, jobId=%s", state, ctl.jobInfo.JobId)
 		return common.ReportTimeoutEvent, common.WaitReportTimeout, nil
 	}
 }
 
 func (ctl *EventController) handleWaitReportScaleInStatus() (string, common.RespCode, error) {
-	return ctl.waitReportStatus()
+	return ctl.waitReportStatus(common.WaitReportScaleInStatusState)
 }
 
 func (ctl *EventController) handleScaleInRunningState() (string, common.RespCode, error) {
@@ -2020,7 +2162,7 @@ func (ctl *EventController) waitScaleOut() {
 
 func (ctl *EventController) handleWaitReportScaleOutStatusState() (string, common.RespCode, error) {
 	ctl.appendStrategy(constant.ScaleOutStrategyName)
-	return ctl.waitReportStatus()
+	return ctl.waitReportStatus(common.WaitReportScaleOutStatusState)
 }
 
 func (ctl *EventController) whetherHasEnoughResource() bool {
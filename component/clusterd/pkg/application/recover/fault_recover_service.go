@@ -18,6 +18,8 @@ import (
 	"ascend-common/common-utils/hwlog"
 	"clusterd/pkg/application/faultmanager"
 	"clusterd/pkg/common/constant"
+	"clusterd/pkg/common/journal"
+	"clusterd/pkg/common/metrics"
 	"clusterd/pkg/common/util"
 	"clusterd/pkg/domain/common"
 	"clusterd/pkg/domain/faultdomain"
@@ -25,6 +27,7 @@ import (
 	"clusterd/pkg/domain/podgroup"
 	"clusterd/pkg/interface/grpc/recover"
 	"clusterd/pkg/interface/kube"
+	"clusterd/pkg/interface/restapi"
 )
 
 const (
@@ -47,6 +50,7 @@ type FaultRecoverService struct {
 	faultCh           chan map[string]constant.JobFaultInfo
 	podEventCh        chan *v1.Pod
 	currentFaults     map[string]map[string]bool
+	checkpoints       map[string]*recoverCheckpoint
 	pb.UnimplementedRecoverServer
 }
 
@@ -59,6 +63,7 @@ func NewFaultRecoverService(keepAlive int, ctx context.Context) *FaultRecoverSer
 	s.initJob = make(map[string]common.JobBaseInfo)
 	s.faultCh = make(chan map[string]constant.JobFaultInfo, 5)
 	s.currentFaults = make(map[string]map[string]bool)
+	s.checkpoints = loadCheckpoints()
 	newPodInfos = workqueue.NewDelayingQueue()
 
 	filterLevel := []string{constant.NotHandleFault, constant.PreSeparateNPU}
@@ -75,6 +80,8 @@ func NewFaultRecoverService(keepAlive int, ctx context.Context) *FaultRecoverSer
 	kube.AddPodFunc(constant.FaultRecover, func(oldPodInfo *v1.Pod, newPodInfo *v1.Pod, op string) {
 		s.updateOriginPodInfo(oldPodInfo, newPodInfo, op)
 	})
+	metrics.RegisterRecoverStateCount(s.countRecoverStates)
+	restapi.RegisterRecoverQuery(s.QueryRecoverInfo)
 	go s.startUpdateOriginPodInfo(s.serviceCtx)
 	go s.checkFaultFromFaultCenter()
 	go s.podStatusMonitor()
@@ -131,6 +138,28 @@ func (s *FaultRecoverService) getControllerByPod(podInfo *v1.Pod) *EventControll
 	return ctl
 }
 
+func (s *FaultRecoverService) countRecoverStates() map[string]int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	counts := make(map[string]int)
+	for _, ctl := range s.eventCtl {
+		if ctl == nil || ctl.state == nil {
+			continue
+		}
+		counts[ctl.state.GetState()]++
+	}
+	return counts
+}
+
+// QueryRecoverInfo get recover state of the job, false if the job has not registered recover service
+func (s *FaultRecoverService) QueryRecoverInfo(jobId string) (common.RecoverInfo, bool) {
+	ctl, exist := s.getController(jobId)
+	if !exist || ctl == nil || ctl.state == nil {
+		return common.RecoverInfo{}, false
+	}
+	return ctl.recoverInfo(), true
+}
+
 func (s *FaultRecoverService) getController(jobId string) (*EventController, bool) {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
@@ -178,6 +207,7 @@ func (s *FaultRecoverService) notifyFaultInfoForJob(faultInfo constant.JobFaultI
 	}
 	hwlog.RunLog.Infof("jobId=%s, new faults detected, enter additional processing, faultRanks=%v",
 		faultInfo.JobId, addedFaultRanks)
+	controller.recordFaultEvent(addedFaultRanks)
 	onlyRetryFault, supportRetry := s.getRetryStatus(addedFaults, controller)
 	removeGrpcFault, faultNodes := s.getFaultAndFaultNodes(addedFaultRanks, controller)
 	if !supportRetry || !onlyRetryFault && len(faultNodes) > 0 {
@@ -250,8 +280,11 @@ func (s *FaultRecoverService) getAdditionFault(jobId string,
 	var faultRanks []string
 	for faultKey := range newFaults {
 		if !currentFaultMap[faultKey] {
-			addedFaults[faultKey] = faultMap[faultKey]
-			faultRanks = append(faultRanks, faultMap[faultKey].RankId)
+			fault := faultMap[faultKey]
+			addedFaults[faultKey] = fault
+			faultRanks = append(faultRanks, fault.RankId)
+			journal.Record(journal.Event{Stage: journal.StageJobFault, Source: journalSource, JobId: jobId,
+				Detail: fmt.Sprintf("rank=%s, faultType=%s", fault.RankId, fault.FaultType)})
 		}
 	}
 	return addedFaults, faultRanks
@@ -614,6 +647,11 @@ func (s *FaultRecoverService) registry(jobInfo common.JobBaseInfo) {
 	controller, ok := s.eventCtl[jobInfo.JobId]
 	if !ok {
 		controller = NewEventController(jobInfo, s.keepAliveInterval, s.serviceCtx)
+		if point, exist := s.checkpoints[jobInfo.JobId]; exist {
+			// resume when agent subscribe again
+			controller.setPendingCheckpoint(point)
+			delete(s.checkpoints, jobInfo.JobId)
+		}
 		s.eventCtl[jobInfo.JobId] = controller
 	}
 }
@@ -810,6 +848,10 @@ func (s *FaultRecoverService) DeleteJob(jobId string) {
 		hwlog.RunLog.Infof("after delete serve jobs=%d, jobId=%s", len(s.eventCtl), jobId)
 		s.lock.Unlock()
 	}()
+	if _, exist := s.checkpoints[jobId]; exist {
+		delete(s.checkpoints, jobId)
+		deleteCheckpointCM(jobId)
+	}
 	if s.eventCtl == nil {
 		return
 	}
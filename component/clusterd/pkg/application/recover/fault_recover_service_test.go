@@ -1223,3 +1223,22 @@ func TestUpdateOriginPodInfo(t *testing.T) {
 		})
 	})
 }
+
+func TestQueryRecoverInfo(t *testing.T) {
+	convey.Convey("test method 'QueryRecoverInfo'", t, func() {
+		ctl := NewEventController(common.JobBaseInfo{JobId: fakeJobID}, keepAliveSecond, context.Background())
+		ctl.cacheNormalFault = []*pb.FaultRank{{RankId: testRankId1, FaultType: constant.NormalFaultType}, nil}
+		ctl.faultPod = map[string]string{testRankId1: testPodUid1}
+		ctl.latestStrategy = []string{constant.ProcessRetryStrategyName}
+		svr := &FaultRecoverService{eventCtl: map[string]*EventController{fakeJobID: ctl}}
+		info, ok := svr.QueryRecoverInfo(fakeJobID)
+		convey.So(ok, convey.ShouldBeTrue)
+		convey.So(info.State, convey.ShouldEqual, common.InitState)
+		convey.So(info.NormalFaults, convey.ShouldResemble,
+			[]common.RecoverFault{{RankId: testRankId1, FaultType: constant.NormalFaultType}})
+		convey.So(info.FaultPod[testRankId1], convey.ShouldEqual, testPodUid1)
+		convey.So(info.LatestStrategy, convey.ShouldResemble, []string{constant.ProcessRetryStrategyName})
+		_, ok = svr.QueryRecoverInfo(fakeJobID1)
+		convey.So(ok, convey.ShouldBeFalse)
+	})
+}
@@ -0,0 +1,108 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package recover a series of service function
+package recover
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"clusterd/pkg/common/constant"
+	"clusterd/pkg/common/notifier"
+	"clusterd/pkg/domain/job"
+	"clusterd/pkg/interface/kube"
+)
+
+// reasons of kubernetes events and job condition about recover milestones
+const (
+	reasonFaultDetected    = "FaultDetected"
+	reasonStrategyChosen   = "RecoverStrategyChosen"
+	reasonRecoverSucceeded = "RecoverSucceeded"
+	reasonRecoverFailed    = "RecoverFailed"
+	reasonProcessExited    = "ProcessExited"
+	reasonJobKilled        = "JobKilled"
+	reasonNodeIsolated     = "NodeIsolated"
+)
+
+// fixResultEvents event type, reason and condition status of recover results
+var fixResultEvents = map[string]struct {
+	eventType string
+	reason    string
+	condition corev1.ConditionStatus
+}{
+	constant.RetrySuccess:   {corev1.EventTypeNormal, reasonRecoverSucceeded, corev1.ConditionFalse},
+	constant.RecoverSuccess: {corev1.EventTypeNormal, reasonRecoverSucceeded, corev1.ConditionFalse},
+	// other strategies may be chosen after retry or recover failed, job is still recovering
+	constant.RetryFailed:   {corev1.EventTypeWarning, reasonRecoverFailed, corev1.ConditionTrue},
+	constant.RecoverFailed: {corev1.EventTypeWarning, reasonRecoverFailed, corev1.ConditionTrue},
+	constant.DumpSuccess:   {corev1.EventTypeWarning, reasonProcessExited, corev1.ConditionFalse},
+	constant.DumpFailed:    {corev1.EventTypeWarning, reasonProcessExited, corev1.ConditionFalse},
+	constant.ExitCompleted: {corev1.EventTypeWarning, reasonProcessExited, corev1.ConditionFalse},
+}
+
+// jobObject the job and pod group that events of controller are about, kind of job is got from job cache
+func (ctl *EventController) jobObject() kube.JobObject {
+	jobInfo, _ := job.GetJobCache(ctl.jobInfo.JobId)
+	return kube.JobObject{
+		Kind:      jobInfo.JobType,
+		Namespace: ctl.jobInfo.Namespace,
+		Name:      ctl.jobInfo.JobName,
+		UID:       ctl.jobInfo.JobId,
+		PgName:    ctl.jobInfo.PgName,
+	}
+}
+
+// recordEvent record a recover milestone as kubernetes event of the job and update the condition of job
+func (ctl *EventController) recordEvent(eventType, reason string, condition corev1.ConditionStatus, message string) {
+	object := ctl.jobObject()
+	kube.RecordJobEvent(object, eventType, reason, message)
+	kube.SetJobCondition(object, condition, reason, message)
+}
+
+func (ctl *EventController) recordFaultEvent(faultRanks []string) {
+	ctl.recordEvent(corev1.EventTypeWarning, reasonFaultDetected, corev1.ConditionTrue,
+		fmt.Sprintf("faults are detected on ranks %v", faultRanks))
+}
+
+func (ctl *EventController) recordStrategyEvent(strategy string) {
+	ctl.recordEvent(corev1.EventTypeNormal, reasonStrategyChosen, corev1.ConditionTrue,
+		fmt.Sprintf("recover strategy %s is chosen", strategy))
+	if strategy == constant.ProcessExitStrategyName {
+		ctl.notifyJobReschedule(reasonStrategyChosen, "processes exit and job is rescheduled")
+	}
+}
+
+func (ctl *EventController) recordFixResultEvent(strategy, value string) {
+	result, ok := fixResultEvents[value]
+	if !ok {
+		return
+	}
+	ctl.recordEvent(result.eventType, result.reason, result.condition,
+		fmt.Sprintf("result of recover strategy %s is %s", strategy, value))
+}
+
+func (ctl *EventController) recordKillJobEvent() {
+	ctl.recordEvent(corev1.EventTypeWarning, reasonJobKilled, corev1.ConditionFalse,
+		"job can not be recovered by process level strategies, kill job")
+	ctl.notifyJobReschedule(reasonJobKilled, "job can not be recovered by process level strategies, kill job")
+}
+
+// notifyJobReschedule notify that fault of job is escalated to job rescheduling
+func (ctl *EventController) notifyJobReschedule(reason, message string) {
+	notifier.Notify(notifier.Notification{
+		Kind:      notifier.KindJobReschedule,
+		Severity:  notifier.SeverityCritical,
+		Namespace: ctl.jobInfo.Namespace,
+		JobName:   ctl.jobInfo.JobName,
+		JobId:     ctl.jobInfo.JobId,
+		Reason:    reason,
+		Message:   message,
+	})
+}
+
+func (ctl *EventController) recordNodeIsolatedEvent(nodeName string) {
+	kube.RecordNodeEvent(nodeName, corev1.EventTypeWarning, reasonNodeIsolated,
+		fmt.Sprintf("node is isolated for stress test fault of job %s/%s", ctl.jobInfo.Namespace,
+			ctl.jobInfo.JobName))
+}
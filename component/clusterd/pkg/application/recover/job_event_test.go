@@ -0,0 +1,67 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package recover test for kubernetes events of recover milestones
+package recover
+
+import (
+	"context"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/smartystreets/goconvey/convey"
+	corev1 "k8s.io/api/core/v1"
+
+	"clusterd/pkg/common/constant"
+	"clusterd/pkg/domain/common"
+	"clusterd/pkg/domain/job"
+	"clusterd/pkg/interface/kube"
+)
+
+const eventTestJobId = "event-test-job"
+
+type recordedEvent struct {
+	object    kube.JobObject
+	eventType string
+	reason    string
+	condition corev1.ConditionStatus
+}
+
+// patchJobEvent capture job events and conditions of controller
+func patchJobEvent(events *[]recordedEvent) *gomonkey.Patches {
+	return gomonkey.ApplyFunc(kube.RecordJobEvent, func(object kube.JobObject, eventType, reason, _ string) {
+		*events = append(*events, recordedEvent{object: object, eventType: eventType, reason: reason})
+	}).ApplyFunc(kube.SetJobCondition, func(_ kube.JobObject, status corev1.ConditionStatus, _, _ string) {
+		(*events)[len(*events)-1].condition = status
+	})
+}
+
+func TestRecordFixResultEvent(t *testing.T) {
+	convey.Convey("test func 'recordFixResultEvent'", t, func() {
+		var events []recordedEvent
+		patches := patchJobEvent(&events)
+		defer patches.Reset()
+		job.SaveJobCache(eventTestJobId, constant.JobInfo{Key: eventTestJobId, JobType: "AscendJob"})
+		defer job.DeleteJobCache(eventTestJobId)
+		ctl := NewEventController(common.JobBaseInfo{JobId: eventTestJobId, JobName: "job", PgName: "pg",
+			Namespace: "default"}, keepAliveSeconds, context.Background())
+		convey.Convey("recover success, should record normal event and finish recovery condition", func() {
+			ctl.recordFixResultEvent(constant.ProcessRecoverStrategyName, constant.RecoverSuccess)
+			convey.So(len(events), convey.ShouldEqual, 1)
+			convey.So(events[0].object, convey.ShouldResemble, kube.JobObject{Kind: "AscendJob",
+				Namespace: "default", Name: "job", UID: eventTestJobId, PgName: "pg"})
+			convey.So(events[0].eventType, convey.ShouldEqual, corev1.EventTypeNormal)
+			convey.So(events[0].reason, convey.ShouldEqual, reasonRecoverSucceeded)
+			convey.So(events[0].condition, convey.ShouldEqual, corev1.ConditionFalse)
+		})
+		convey.Convey("retry failed, should record warning event and job is still recovering", func() {
+			ctl.recordFixResultEvent(constant.ProcessRetryStrategyName, constant.RetryFailed)
+			convey.So(len(events), convey.ShouldEqual, 1)
+			convey.So(events[0].eventType, convey.ShouldEqual, corev1.EventTypeWarning)
+			convey.So(events[0].condition, convey.ShouldEqual, corev1.ConditionTrue)
+		})
+		convey.Convey("unknown result, should not record event", func() {
+			ctl.recordFixResultEvent(constant.ScaleInStrategyName, "unknown")
+			convey.So(len(events), convey.ShouldEqual, 0)
+		})
+	})
+}
@@ -0,0 +1,98 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package recover a series of service function
+package recover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/utils/clock"
+
+	"clusterd/pkg/domain/common"
+	"clusterd/pkg/interface/grpc/recover"
+)
+
+// OfflineController recover controller of a job driven by offline tools such as recover simulator,
+// faults are given directly instead of by fault center, and reports of agent are given without grpc service
+type OfflineController struct {
+	ctl *EventController
+}
+
+// NewOfflineController return controller of the job, onStateEnter is called with path graph when a state is entered.
+// the controller waits reports by the clk, so that a fake clock can skip the timeout of waiting reports
+func NewOfflineController(jobInfo common.JobBaseInfo, keepAlive int, ctx context.Context, clk clock.Clock,
+	onStateEnter func(state, pathGraph string)) *OfflineController {
+	ctl := NewEventController(jobInfo, keepAlive, ctx)
+	ctl.clock = clk
+	ctl.state.SetEnterHook(func(state string) {
+		onStateEnter(state, ctl.state.GetPathGraph())
+		ctl.onStateEnter(state)
+	})
+	return &OfflineController{ctl: ctl}
+}
+
+// Subscribe send signals to the stream like SubscribeProcessManageSignal, return when the controller is reset
+func (oc *OfflineController) Subscribe(stream pb.Recover_SubscribeProcessManageSignalServer) {
+	oc.ctl.listenSendChannel(stream)
+}
+
+// Serving whether the controller has been subscribed
+func (oc *OfflineController) Serving() bool {
+	return oc.ctl.getCtlResetTime() != 0
+}
+
+// State current state of the controller
+func (oc *OfflineController) State() string {
+	return oc.ctl.state.GetState()
+}
+
+// FaultPod fault pods of the recovery, key is pod rank and value is pod uid
+func (oc *OfflineController) FaultPod() map[string]string {
+	return oc.ctl.GetFaultPod()
+}
+
+// InjectFault give faults to controller as the faults of fault center are given, empty health state keeps the current
+func (oc *OfflineController) InjectFault(faults []*pb.FaultRank, faultPod map[string]string, healthState string) {
+	oc.ctl.mergeFaultPod(faultPod)
+	oc.ctl.saveCacheFault(faults)
+	if healthState != "" {
+		oc.ctl.healthState = healthState
+	}
+	oc.ctl.addEvent(common.FaultOccurEvent)
+}
+
+// ReportStopComplete report stop complete like ReportStopComplete of grpc service, wait at most timeout
+func (oc *OfflineController) ReportStopComplete(request *pb.StopCompleteRequest, timeout time.Duration) error {
+	return offlineReport(oc.ctl.getCtxAndStopCompleteChan, request, timeout)
+}
+
+// ReportRecoverStrategy report strategies like ReportRecoverStrategy of grpc service, wait at most timeout
+func (oc *OfflineController) ReportRecoverStrategy(request *pb.RecoverStrategyRequest, timeout time.Duration) error {
+	return offlineReport(oc.ctl.getCtxAndReportRecoverStrategyChan, request, timeout)
+}
+
+// ReportRecoverStatus report recover result like ReportRecoverStatus of grpc service, wait at most timeout
+func (oc *OfflineController) ReportRecoverStatus(request *pb.RecoverStatusRequest, timeout time.Duration) error {
+	return offlineReport(oc.ctl.getCtxAndResultChan, request, timeout)
+}
+
+func offlineReport[T any](getChan func() (context.Context, chan T), request T, timeout time.Duration) (err error) {
+	// channels of controller may be closed by reset
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("channel closed, %v", r)
+		}
+	}()
+	ctx, ch := getChan()
+	select {
+	case ch <- request:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(timeout):
+		return errors.New("report timeout")
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package recover_test test for recover controller driven by the recover simulator
+package recover_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/smartystreets/goconvey/convey"
+	"k8s.io/client-go/kubernetes/fake"
+	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"clusterd/pkg/application/recover/simulator"
+	"clusterd/pkg/common/constant"
+	"clusterd/pkg/domain/common"
+	"clusterd/pkg/domain/conf"
+	"clusterd/pkg/domain/job"
+	"clusterd/pkg/interface/kube"
+)
+
+var simClientOnce sync.Once
+
+// initSimClient the simulation works on a fake client set
+func initSimClient(t *testing.T) {
+	simClientOnce.Do(func() {
+		if err := kube.InitClientK8sByClientSet(fake.NewSimpleClientset()); err != nil {
+			t.Logf("init k8s client failed, %v", err)
+		}
+	})
+}
+
+// patchPodGroupClient the simulation has no volcano client, patching podGroup retries for seconds
+func patchPodGroupClient() *gomonkey.Patches {
+	return gomonkey.ApplyFuncReturn(kube.RetryPatchPodGroupAnnotations, &v1beta1.PodGroup{}, nil).
+		ApplyFuncReturn(kube.RetryPatchPodGroupLabel, &v1beta1.PodGroup{}, nil)
+}
+
+func newSimScenario() simulator.Scenario {
+	strategies := []string{constant.ProcessRetryStrategyName, constant.ProcessRecoverStrategyName,
+		constant.ProcessDumpStrategyName, constant.ProcessExitStrategyName}
+	return simulator.Scenario{
+		Name:   "test",
+		Job:    simulator.Job{Name: "sim-job", Strategies: strategies, Pods: 2},
+		Agent:  simulator.Agent{Strategies: strategies},
+		Faults: []simulator.Fault{{Ranks: []string{"3"}, Type: simulator.FaultRetry}},
+	}
+}
+
+func signalTypes(result simulator.Result) []string {
+	types := make([]string, 0, len(result.Signals))
+	for _, signal := range result.Signals {
+		types = append(types, signal.SignalType)
+	}
+	return types
+}
+
+func TestOfflineControllerRecover(t *testing.T) {
+	initSimClient(t)
+	convey.Convey("test recover of offline controller driven by simulator", t, func() {
+		patches := patchPodGroupClient()
+		defer patches.Reset()
+		convey.Convey("agent step retries successfully, should recover by retry strategy", func() {
+			result, err := simulator.Simulate(context.Background(), newSimScenario())
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(result.TimedOut, convey.ShouldBeFalse)
+			convey.So(result.FinalState, convey.ShouldEqual, common.InitState)
+			convey.So(len(result.Paths), convey.ShouldEqual, 1)
+			convey.So(result.Paths[0], convey.ShouldContainSubstring, "("+common.RecoverSuccessEvent+")-->INIT")
+			convey.So(signalTypes(result), convey.ShouldResemble, []string{constant.StopTrainSignalType,
+				constant.GlobalFaultSignalType, constant.ChangeStrategySignalType})
+			convey.So(result.Signals[len(result.Signals)-1].ChangeStrategy, convey.ShouldEqual,
+				constant.ProcessRetryStrategyName)
+			convey.So(job.GetJobIsExists("default-sim-job-uid"), convey.ShouldBeFalse)
+		})
+		convey.Convey("agent does not report stop complete, should exit job after report timeout", func() {
+			scenario := newSimScenario()
+			scenario.Agent.StopTrain = simulator.ResultTimeout
+			scenario.Policy = &conf.RecoverStrategyPolicy{Rules: []conf.RecoverStrategyRule{{Name: "timeout",
+				Strategies: scenario.Job.Strategies, StateTimeoutSeconds: map[string]int{
+					common.WaitReportStopCompleteState: conf.MinStateTimeoutSeconds}}}}
+			result, err := simulator.Simulate(context.Background(), scenario)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(len(result.Paths), convey.ShouldEqual, 1)
+			convey.So(result.Paths[0], convey.ShouldContainSubstring,
+				common.WaitReportStopCompleteState+"("+common.ReportTimeoutEvent+")")
+			convey.So(result.FinalState, convey.ShouldEqual, common.InitState)
+			lastSignal := result.Signals[len(result.Signals)-1]
+			convey.So(lastSignal.ChangeStrategy, convey.ShouldEqual, constant.ProcessExitStrategyName)
+			// the timeout is skipped instead of waited
+			convey.So(lastSignal.ElapsedMs, convey.ShouldBeGreaterThanOrEqualTo,
+				conf.MinStateTimeoutSeconds*1000)
+		})
+	})
+}
@@ -610,6 +610,7 @@ func (ctl *EventController) handleStressTestFail() (string, common.RespCode, err
 		if err != nil {
 			hwlog.RunLog.Errorf("patch node:%s failed: %v", node, err)
 		}
+		ctl.recordNodeIsolatedEvent(node)
 	}
 	ctl.saveCacheFault(faultRank)
 	ctl.uuid = common.NewEventId(randomLen)
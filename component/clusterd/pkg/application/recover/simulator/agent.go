@@ -0,0 +1,144 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package simulator drives a recover controller with scripted agent behavior and fault injections offline
+package simulator
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"ascend-common/common-utils/hwlog"
+	"clusterd/pkg/common/constant"
+	"clusterd/pkg/domain/common"
+	"clusterd/pkg/domain/pod"
+	"clusterd/pkg/interface/grpc/recover"
+)
+
+// simStream signal stream subscribed by the simulated agent, it records signals and reacts as scripted
+type simStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	sim *simulation
+}
+
+// Send record the signal and react to it asynchronously like agent
+func (st *simStream) Send(signal *pb.ProcessManageSignal) error {
+	if signal == nil || signal.SignalType == constant.KeepAliveSignalType {
+		return nil
+	}
+	st.sim.recordSignal(signal)
+	go st.sim.react(signal)
+	return nil
+}
+
+// Context return context of the simulation
+func (st *simStream) Context() context.Context {
+	return st.ctx
+}
+
+// SetHeader not used by simulation
+func (st *simStream) SetHeader(metadata.MD) error {
+	return nil
+}
+
+// SendHeader not used by simulation
+func (st *simStream) SendHeader(metadata.MD) error {
+	return nil
+}
+
+// SetTrailer not used by simulation
+func (st *simStream) SetTrailer(metadata.MD) {
+}
+
+func (s *simulation) react(signal *pb.ProcessManageSignal) {
+	if s.scenario.Agent.DelayMs > 0 {
+		time.Sleep(time.Duration(s.scenario.Agent.DelayMs) * time.Millisecond)
+	}
+	switch signal.SignalType {
+	case constant.StopTrainSignalType:
+		s.reportStopComplete(signal)
+	case constant.GlobalFaultSignalType:
+		s.reportRecoverStrategy(signal)
+	case constant.ChangeStrategySignalType:
+		if signal.ChangeStrategy == constant.ProcessExitStrategyName {
+			return
+		}
+		if s.scenario.Agent.RescheduleFaultPods && slices.Contains(simReschedulings, signal.ChangeStrategy) {
+			s.rescheduleFaultPods()
+		}
+		s.reportStatus(signal.ChangeStrategy)
+	case constant.SaveAndExitSignalType:
+		s.reportStatus(constant.ProcessDumpStrategyName)
+	default:
+		return
+	}
+}
+
+func (s *simulation) reportStopComplete(signal *pb.ProcessManageSignal) {
+	code := common.OK
+	switch s.scenario.Agent.StopTrain {
+	case ResultTimeout:
+		return
+	case ResultNotReady:
+		code = common.ProcessNotReady
+	default:
+	}
+	request := &pb.StopCompleteRequest{JobId: s.jobInfo.JobId, Status: &pb.Status{Code: int32(code)},
+		FaultRanks: signal.FaultRanks}
+	if err := s.ctl.ReportStopComplete(request, simReportTimeout); err != nil {
+		hwlog.RunLog.Warnf("simulation %s report stop complete failed, %v", s.scenario.Name, err)
+		return
+	}
+	s.touch()
+}
+
+func (s *simulation) reportRecoverStrategy(signal *pb.ProcessManageSignal) {
+	request := &pb.RecoverStrategyRequest{JobId: s.jobInfo.JobId, FaultRanks: signal.FaultRanks,
+		Strategies: slices.Clone(s.scenario.Agent.Strategies)}
+	if err := s.ctl.ReportRecoverStrategy(request, simReportTimeout); err != nil {
+		hwlog.RunLog.Warnf("simulation %s report recover strategy failed, %v", s.scenario.Name, err)
+		return
+	}
+	s.touch()
+}
+
+func (s *simulation) reportStatus(strategy string) {
+	result := s.nextResult(strategy)
+	if result == ResultTimeout {
+		hwlog.RunLog.Infof("simulation %s does not report result of strategy %s", s.scenario.Name, strategy)
+		return
+	}
+	request := &pb.RecoverStatusRequest{JobId: s.jobInfo.JobId, Strategy: strategy,
+		Status: &pb.Status{Code: int32(simResultCodes[result]), Info: result}}
+	if err := s.ctl.ReportRecoverStatus(request, simReportTimeout); err != nil {
+		hwlog.RunLog.Warnf("simulation %s report result of strategy %s failed, %v", s.scenario.Name, strategy, err)
+		return
+	}
+	s.touch()
+}
+
+// rescheduleFaultPods replace the fault pods with new pods of the same rank as scheduler does
+func (s *simulation) rescheduleFaultPods() {
+	s.lock.Lock()
+	s.generation++
+	generation := s.generation
+	s.lock.Unlock()
+	for podRank := range s.ctl.FaultPod() {
+		oldPod := pod.GetPodByRankIndex(s.jobInfo.JobId, podRank)
+		rank, err := strconv.Atoi(podRank)
+		if oldPod.Name == "" || err != nil {
+			continue
+		}
+		newPod := s.newPod(rank, generation)
+		pod.DeletePod(&oldPod)
+		pod.SavePod(newPod)
+		hwlog.RunLog.Infof("simulation %s reschedule pod of rank %s, uid %s -> %s", s.scenario.Name, podRank,
+			oldPod.UID, newPod.UID)
+	}
+	s.touch()
+}
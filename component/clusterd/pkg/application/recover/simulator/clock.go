@@ -0,0 +1,66 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package simulator drives a recover controller with scripted agent behavior and fault injections offline
+package simulator
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+	testingclock "k8s.io/utils/clock/testing"
+)
+
+// simClock fake clock of the controller waiting reports, the simulation skips it to the nearest deadline when idle,
+// so that the timeout of waiting reports does not take wall time
+type simClock struct {
+	*testingclock.FakeClock
+	lock      sync.Mutex
+	deadlines []time.Time
+	elapsed   time.Duration
+}
+
+func newSimClock() *simClock {
+	return &simClock{FakeClock: testingclock.NewFakeClock(time.Now())}
+}
+
+// After record the deadline and wait on the fake clock
+func (c *simClock) After(d time.Duration) <-chan time.Time {
+	c.addDeadline(d)
+	return c.FakeClock.After(d)
+}
+
+// NewTimer record the deadline and create timer of the fake clock
+func (c *simClock) NewTimer(d time.Duration) clock.Timer {
+	c.addDeadline(d)
+	return c.FakeClock.NewTimer(d)
+}
+
+func (c *simClock) addDeadline(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.deadlines = append(c.deadlines, c.Now().Add(d))
+}
+
+// skip step the clock to the nearest deadline, return the duration skipped, 0 when there is no deadline
+func (c *simClock) skip() time.Duration {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.Now()
+	c.deadlines = slices.DeleteFunc(c.deadlines, func(deadline time.Time) bool { return !deadline.After(now) })
+	if len(c.deadlines) == 0 {
+		return 0
+	}
+	next := slices.MinFunc(c.deadlines, func(a, b time.Time) int { return a.Compare(b) })
+	c.SetTime(next)
+	c.elapsed += next.Sub(now)
+	return next.Sub(now)
+}
+
+// skipped total duration skipped
+func (c *simClock) skipped() time.Duration {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.elapsed
+}
@@ -0,0 +1,603 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package simulator drives a recover controller with scripted agent behavior and fault injections offline
+package simulator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"ascend-common/api"
+	"ascend-common/common-utils/hwlog"
+	"clusterd/pkg/application/recover"
+	"clusterd/pkg/common/constant"
+	"clusterd/pkg/domain/common"
+	"clusterd/pkg/domain/conf"
+	"clusterd/pkg/domain/job"
+	"clusterd/pkg/domain/pod"
+	"clusterd/pkg/domain/podgroup"
+	"clusterd/pkg/interface/grpc/recover"
+	"clusterd/pkg/interface/kube"
+)
+
+const (
+	// ResultSuccess agent reports strategy success
+	ResultSuccess = "success"
+	// ResultFail agent reports strategy failed
+	ResultFail = "fail"
+	// ResultRecoverable agent reports step retry failed with recoverable error
+	ResultRecoverable = "recoverable"
+	// ResultUnrecoverable agent reports step retry failed with unrecoverable error
+	ResultUnrecoverable = "unrecoverable"
+	// ResultTimeout agent does not report, controller waits until report timeout
+	ResultTimeout = "timeout"
+	// ResultNotReady agent reports process not ready when stopping train
+	ResultNotReady = "not_ready"
+	// FaultRetry fault can be fixed by step retry, such as uce fault
+	FaultRetry = "retry"
+	// FaultNormal fault can not be fixed by step retry
+	FaultNormal = "normal"
+
+	defaultSimDurationSeconds = 300
+	defaultSimDevicesPerPod   = 8
+	maxSimDurationSeconds     = 7200
+	maxSimPods                = 1024
+	maxSimFaults              = 100
+	simSettleTime             = 300 * time.Millisecond
+	simPollInterval           = 20 * time.Millisecond
+	simReconnectDelay         = 20 * time.Millisecond
+	simReportTimeout          = time.Second
+	simKeepAliveSeconds       = 10
+)
+
+var (
+	simFaultTypes  = map[string]string{FaultRetry: constant.UceFaultType, FaultNormal: constant.NormalFaultType}
+	simStopResults = []string{"", ResultSuccess, ResultTimeout, ResultNotReady}
+	simResultCodes = map[string]common.RespCode{ResultSuccess: common.OK, ResultFail: common.ClientError,
+		ResultRecoverable: common.RecoverableRetryError, ResultUnrecoverable: common.UnRecoverableRetryError}
+	simReschedulings = []string{constant.ProcessRecoverStrategyName, constant.ProcessRecoverInPlaceStrategyName}
+	// simLock the simulation uses global caches, so simulations can not run concurrently
+	simLock sync.Mutex
+)
+
+// Scenario scenario of recover simulation, scripted agent behavior and fault injections of a job
+type Scenario struct {
+	Name   string  `yaml:"name" json:"name"`
+	Job    Job     `yaml:"job" json:"job"`
+	Agent  Agent   `yaml:"agent" json:"agent"`
+	Faults []Fault `yaml:"faults" json:"faults"`
+	// Policy recover strategy policy used during the simulation, empty means no policy.
+	// timeouts of waiting report are configured by state_timeout_seconds of the rules, default 15 minutes
+	Policy *conf.RecoverStrategyPolicy `yaml:"policy" json:"policy,omitempty"`
+	// DurationSeconds maximum duration of the simulation, default 300
+	DurationSeconds int `yaml:"duration_seconds" json:"duration_seconds"`
+}
+
+// Job the simulated training job
+type Job struct {
+	Name      string `yaml:"name" json:"name"`
+	Namespace string `yaml:"namespace" json:"namespace"`
+	Framework string `yaml:"framework" json:"framework"`
+	// Strategies recover strategies configured on the job
+	Strategies    []string          `yaml:"strategies" json:"strategies"`
+	Pods          int               `yaml:"pods" json:"pods"`
+	DevicesPerPod int               `yaml:"devices_per_pod" json:"devices_per_pod"`
+	Labels        map[string]string `yaml:"labels" json:"labels"`
+	// ProcessRecoverEnable whether process recover is enabled on the job, dump is only chosen directly when enabled
+	ProcessRecoverEnable bool `yaml:"process_recover_enable" json:"process_recover_enable"`
+}
+
+// Agent scripted behavior of the training agent
+type Agent struct {
+	// Strategies strategies the agent reports it supports
+	Strategies []string `yaml:"strategies" json:"strategies"`
+	// StopTrain result of stopping train: success, timeout or not_ready, default success
+	StopTrain string `yaml:"stop_train" json:"stop_train"`
+	// Results results reported for each attempt of a strategy: success, fail, recoverable, unrecoverable or timeout.
+	// the last result is reused when attempts are more than results, default success
+	Results map[string][]string `yaml:"results" json:"results"`
+	// DelayMs delay of the agent before reporting
+	DelayMs int `yaml:"delay_ms" json:"delay_ms"`
+	// RescheduleFaultPods replace fault pods with new pods when recover strategy is notified
+	RescheduleFaultPods bool `yaml:"reschedule_fault_pods" json:"reschedule_fault_pods"`
+}
+
+// Fault a fault injected to the job
+type Fault struct {
+	// AfterMs the fault is injected after the time since the simulation starts
+	AfterMs int `yaml:"after_ms" json:"after_ms"`
+	// Ranks global rank ids of the fault
+	Ranks []string `yaml:"ranks" json:"ranks"`
+	// Type retry or normal
+	Type string `yaml:"type" json:"type"`
+}
+
+// Result result of recover simulation
+type Result struct {
+	Name string `json:"name"`
+	// Paths state path graph of each recovery in order
+	Paths []string `json:"paths"`
+	// Signals process manage signals sent to agent in order, keep-alive signals are not included
+	Signals    []Signal `json:"signals"`
+	FinalState string   `json:"final_state"`
+	// TimedOut the simulation did not settle in duration
+	TimedOut bool `json:"timed_out"`
+}
+
+// Signal a process manage signal sent to agent
+type Signal struct {
+	// ElapsedMs elapsed time since the simulation starts, including the time skipped while waiting reports
+	ElapsedMs      int64    `json:"elapsed_ms"`
+	SignalType     string   `json:"signal_type"`
+	Actions        []string `json:"actions,omitempty"`
+	ChangeStrategy string   `json:"change_strategy,omitempty"`
+	FaultRanks     []string `json:"fault_ranks,omitempty"`
+	NodeRankIds    []string `json:"node_rank_ids,omitempty"`
+	ExtraParams    string   `json:"extra_params,omitempty"`
+}
+
+// ParseScenario parse yaml scenario of recover simulation
+func ParseScenario(data []byte) (Scenario, error) {
+	var scenario Scenario
+	if err := yaml.UnmarshalStrict(data, &scenario); err != nil {
+		return Scenario{}, fmt.Errorf("parse scenario failed, %v", err)
+	}
+	if err := CheckScenario(&scenario); err != nil {
+		return Scenario{}, err
+	}
+	return scenario, nil
+}
+
+// CheckScenario check scenario of recover simulation and fill default values
+func CheckScenario(scenario *Scenario) error {
+	if scenario == nil {
+		return errors.New("scenario is nil")
+	}
+	if err := checkSimJob(&scenario.Job); err != nil {
+		return fmt.Errorf("job is invalid, %v", err)
+	}
+	if err := checkSimAgent(scenario.Agent); err != nil {
+		return fmt.Errorf("agent is invalid, %v", err)
+	}
+	if len(scenario.Faults) == 0 || len(scenario.Faults) > maxSimFaults {
+		return fmt.Errorf("faults count must be in [1, %d]", maxSimFaults)
+	}
+	totalRanks := scenario.Job.Pods * scenario.Job.DevicesPerPod
+	for i, fault := range scenario.Faults {
+		if err := checkSimFault(fault, totalRanks); err != nil {
+			return fmt.Errorf("fault %d is invalid, %v", i, err)
+		}
+	}
+	if scenario.Policy != nil {
+		if err := conf.CheckRecoverStrategyPolicy(*scenario.Policy); err != nil {
+			return fmt.Errorf("policy is invalid, %v", err)
+		}
+	}
+	if scenario.DurationSeconds == 0 {
+		scenario.DurationSeconds = defaultSimDurationSeconds
+	}
+	if scenario.DurationSeconds < 0 || scenario.DurationSeconds > maxSimDurationSeconds {
+		return fmt.Errorf("duration_seconds must be in [1, %d]", maxSimDurationSeconds)
+	}
+	return nil
+}
+
+func checkSimJob(simJob *Job) error {
+	if simJob.Name == "" {
+		return errors.New("name is empty")
+	}
+	if simJob.Namespace == "" {
+		simJob.Namespace = "default"
+	}
+	if simJob.Pods <= 0 || simJob.Pods > maxSimPods {
+		return fmt.Errorf("pods must be in [1, %d]", maxSimPods)
+	}
+	if simJob.DevicesPerPod == 0 {
+		simJob.DevicesPerPod = defaultSimDevicesPerPod
+	}
+	if simJob.DevicesPerPod < 0 {
+		return errors.New("devices_per_pod can not be negative")
+	}
+	return nil
+}
+
+func checkSimAgent(agent Agent) error {
+	if !slices.Contains(simStopResults, agent.StopTrain) {
+		return fmt.Errorf("stop_train %s should be one of %v", agent.StopTrain, simStopResults[1:])
+	}
+	for strategy, results := range agent.Results {
+		for _, result := range results {
+			if _, ok := simResultCodes[result]; !ok && result != ResultTimeout {
+				return fmt.Errorf("result %s of strategy %s is not supported", result, strategy)
+			}
+		}
+	}
+	if agent.DelayMs < 0 {
+		return errors.New("delay_ms can not be negative")
+	}
+	return nil
+}
+
+func checkSimFault(fault Fault, totalRanks int) error {
+	if _, ok := simFaultTypes[fault.Type]; !ok {
+		return fmt.Errorf("type %s should be %s or %s", fault.Type, FaultRetry, FaultNormal)
+	}
+	if len(fault.Ranks) == 0 {
+		return errors.New("ranks is empty")
+	}
+	for _, rank := range fault.Ranks {
+		rankId, err := strconv.Atoi(rank)
+		if err != nil || rankId < 0 || rankId >= totalRanks {
+			return fmt.Errorf("rank %s should be in [0, %d)", rank, totalRanks)
+		}
+	}
+	if fault.AfterMs < 0 {
+		return errors.New("after_ms can not be negative")
+	}
+	return nil
+}
+
+// Simulate drive a recover controller with the scenario and return the state paths and signals.
+// k8s client should be initialized with a fake client set, job, pod and podGroup caches and recover strategy
+// policy are replaced during the simulation, so it should not run in a working clusterd
+func Simulate(ctx context.Context, scenario Scenario) (Result, error) {
+	client := kube.GetClientK8s()
+	if client == nil || client.ClientSet == nil {
+		return Result{}, errors.New("k8s client is not initialized")
+	}
+	if err := CheckScenario(&scenario); err != nil {
+		return Result{}, err
+	}
+	simLock.Lock()
+	defer simLock.Unlock()
+	sim := newSimulation(scenario)
+	restore, err := sim.setup(client.ClientSet)
+	defer restore()
+	if err != nil {
+		return Result{}, err
+	}
+	simCtx, cancel := context.WithTimeout(ctx, time.Duration(scenario.DurationSeconds)*time.Second)
+	defer cancel()
+	sim.run(simCtx)
+	cancel()
+	return sim.result(), nil
+}
+
+type simulation struct {
+	scenario    Scenario
+	jobInfo     common.JobBaseInfo
+	ctl         *recover.OfflineController
+	clock       *simClock
+	start       time.Time
+	lock        sync.Mutex
+	signals     []Signal
+	paths       []string
+	lastPath    string
+	lastActive  time.Time
+	attempts    map[string]int
+	generation  int
+	timedOut    bool
+	finalState  string
+	serveFinish chan struct{}
+}
+
+func newSimulation(scenario Scenario) *simulation {
+	jobName := scenario.Job.Name
+	return &simulation{
+		scenario: scenario,
+		jobInfo: common.JobBaseInfo{
+			JobId:     scenario.Job.Namespace + "-" + jobName + "-uid",
+			JobName:   jobName,
+			PgName:    "podgroup-" + jobName,
+			Namespace: scenario.Job.Namespace,
+			Framework: scenario.Job.Framework,
+			RecoverConfig: common.RecoverConfig{
+				ProcessRecoverEnable:  scenario.Job.ProcessRecoverEnable,
+				MindXConfigStrategies: scenario.Job.Strategies,
+			},
+		},
+		clock:       newSimClock(),
+		attempts:    make(map[string]int),
+		serveFinish: make(chan struct{}),
+	}
+}
+
+// setup save job, podGroup and pods to caches and create reset configmap, return the function to clean them
+func (s *simulation) setup(client kubernetes.Interface) (func(), error) {
+	oldPolicy := conf.GetRecoverStrategyPolicy()
+	if s.scenario.Policy != nil {
+		conf.SetRecoverStrategyPolicy(*s.scenario.Policy)
+	} else {
+		conf.SetRecoverStrategyPolicy(conf.RecoverStrategyPolicy{})
+	}
+	pg := s.podGroup()
+	podgroup.SavePodGroup(pg)
+	job.SaveJobCache(s.jobInfo.JobId, constant.JobInfo{
+		Key:       s.jobInfo.JobId,
+		Name:      s.jobInfo.JobName,
+		NameSpace: s.jobInfo.Namespace,
+		PgName:    s.jobInfo.PgName,
+		Framework: s.jobInfo.Framework,
+		Replicas:  s.scenario.Job.Pods,
+		Status:    "running",
+	})
+	for podRank := 0; podRank < s.scenario.Job.Pods; podRank++ {
+		pod.SavePod(s.newPod(podRank, 0))
+	}
+	restore := func() {
+		for _, podInfo := range pod.GetPodByJobId(s.jobInfo.JobId) {
+			pod.DeletePod(&podInfo)
+		}
+		job.DeleteJobCache(s.jobInfo.JobId)
+		podgroup.DeletePodGroup(pg)
+		conf.SetRecoverStrategyPolicy(oldPolicy)
+		if err := client.CoreV1().ConfigMaps(s.jobInfo.Namespace).Delete(context.Background(),
+			constant.ResetInfoCMNamePrefix+s.jobInfo.JobName, metav1.DeleteOptions{}); err != nil {
+			hwlog.RunLog.Warnf("delete reset configmap failed, err: %v", err)
+		}
+	}
+	resetCM := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constant.ResetInfoCMNamePrefix + s.jobInfo.JobName,
+			Namespace: s.jobInfo.Namespace},
+		Data: map[string]string{constant.ResetInfoCMDataKey: "{}"},
+	}
+	if _, err := client.CoreV1().ConfigMaps(s.jobInfo.Namespace).Create(context.Background(), resetCM,
+		metav1.CreateOptions{}); err != nil {
+		return restore, fmt.Errorf("create reset configmap failed, %v", err)
+	}
+	return restore, nil
+}
+
+func (s *simulation) ownerReference() metav1.OwnerReference {
+	isController := true
+	return metav1.OwnerReference{
+		APIVersion: "mindxdl.gitee.com/v1",
+		Kind:       "AscendJob",
+		Name:       s.jobInfo.JobName,
+		UID:        types.UID(s.jobInfo.JobId),
+		Controller: &isController,
+	}
+}
+
+func (s *simulation) podGroup() *v1beta1.PodGroup {
+	return &v1beta1.PodGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            s.jobInfo.PgName,
+			Namespace:       s.jobInfo.Namespace,
+			Labels:          s.scenario.Job.Labels,
+			OwnerReferences: []metav1.OwnerReference{s.ownerReference()},
+		},
+		Status: v1beta1.PodGroupStatus{Phase: v1beta1.PodGroupRunning},
+	}
+}
+
+func (s *simulation) newPod(podRank, generation int) *v1.Pod {
+	devicesPerPod := s.scenario.Job.DevicesPerPod
+	podDevice := constant.PodDevice{PodName: fmt.Sprintf("%s-%d", s.jobInfo.JobName, podRank)}
+	var realCards []string
+	for i := 0; i < devicesPerPod; i++ {
+		podDevice.Devices = append(podDevice.Devices, constant.Device{
+			DeviceID: strconv.Itoa(i),
+			RankID:   strconv.Itoa(podRank*devicesPerPod + i),
+		})
+		realCards = append(realCards, fmt.Sprintf("Ascend910-%d", i))
+	}
+	deviceInfo, err := json.Marshal(podDevice)
+	if err != nil {
+		hwlog.RunLog.Errorf("marshal pod device failed, err: %v", err)
+	}
+	realCard, err := json.Marshal(realCards)
+	if err != nil {
+		hwlog.RunLog.Errorf("marshal real card failed, err: %v", err)
+	}
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podDevice.PodName,
+			Namespace: s.jobInfo.Namespace,
+			UID:       types.UID(fmt.Sprintf("%s-pod-%d-%d", s.jobInfo.JobId, podRank, generation)),
+			Annotations: map[string]string{
+				api.PodRankIndexAnno:        strconv.Itoa(podRank),
+				api.Pod910DeviceAnno:        string(deviceInfo),
+				api.PodAnnotationAscendReal: string(realCard),
+			},
+			OwnerReferences: []metav1.OwnerReference{s.ownerReference()},
+		},
+		Spec:   v1.PodSpec{NodeName: fmt.Sprintf("node-%d", podRank)},
+		Status: v1.PodStatus{Phase: v1.PodRunning},
+	}
+}
+
+func (s *simulation) run(ctx context.Context) {
+	s.start = time.Now()
+	s.touch()
+	s.ctl = recover.NewOfflineController(s.jobInfo, simKeepAliveSeconds, ctx, s.clock, s.recordState)
+	go s.serve(ctx)
+	if s.waitServing(ctx) {
+		s.injectFaults(ctx)
+		s.waitSettled(ctx)
+	}
+	s.finish()
+}
+
+// serve subscribe signals like agent, agent subscribes again after the controller resets when a recovery finishes
+func (s *simulation) serve(ctx context.Context) {
+	defer close(s.serveFinish)
+	stream := &simStream{ctx: ctx, sim: s}
+	for ctx.Err() == nil {
+		s.ctl.Subscribe(stream)
+		select {
+		case <-ctx.Done():
+		case <-time.After(simReconnectDelay):
+		}
+	}
+}
+
+func (s *simulation) waitServing(ctx context.Context) bool {
+	for !s.ctl.Serving() {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(simPollInterval):
+		}
+	}
+	return true
+}
+
+func (s *simulation) injectFaults(ctx context.Context) {
+	faults := slices.Clone(s.scenario.Faults)
+	slices.SortStableFunc(faults, func(a, b Fault) int { return a.AfterMs - b.AfterMs })
+	for _, fault := range faults {
+		wait := time.Until(s.start.Add(time.Duration(fault.AfterMs) * time.Millisecond))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+		s.injectFault(fault)
+	}
+}
+
+// injectFault give fault to controller as recover service does with faults from fault center
+func (s *simulation) injectFault(fault Fault) {
+	s.touch()
+	faultType := simFaultTypes[fault.Type]
+	faults := make([]*pb.FaultRank, 0, len(fault.Ranks))
+	faultPod := make(map[string]string)
+	for _, rank := range fault.Ranks {
+		faults = append(faults, &pb.FaultRank{RankId: rank, FaultType: faultType})
+		rankId, err := strconv.Atoi(rank)
+		if err != nil {
+			continue
+		}
+		podRank := strconv.Itoa(rankId / s.scenario.Job.DevicesPerPod)
+		faultPod[podRank] = string(pod.GetPodByRankIndex(s.jobInfo.JobId, podRank).UID)
+	}
+	hwlog.RunLog.Infof("simulation %s inject %s fault, ranks=%v", s.scenario.Name, fault.Type, fault.Ranks)
+	healthState := ""
+	if fault.Type == FaultNormal {
+		healthState = constant.UnHealthyState
+	}
+	s.ctl.InjectFault(faults, faultPod, healthState)
+}
+
+// waitSettled wait until the controller stays in init state without activity after all faults are injected,
+// the controller waiting reports without activity skips to the timeout
+func (s *simulation) waitSettled(ctx context.Context) {
+	idleTime := simSettleTime + time.Duration(s.scenario.Agent.DelayMs)*time.Millisecond
+	for {
+		select {
+		case <-ctx.Done():
+			s.timedOut = true
+			return
+		case <-time.After(simPollInterval):
+		}
+		if time.Since(s.lastActiveTime()) <= idleTime {
+			continue
+		}
+		if s.ctl.State() == common.InitState {
+			return
+		}
+		if skipped := s.clock.skip(); skipped > 0 {
+			hwlog.RunLog.Infof("simulation %s skip %v of waiting reports", s.scenario.Name, skipped)
+			s.touch()
+		}
+	}
+}
+
+func (s *simulation) finish() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.finalState = s.ctl.State()
+	if s.lastPath != "" {
+		s.paths = append(s.paths, s.lastPath)
+		s.lastPath = ""
+	}
+}
+
+// result must be called after the simulation context is done
+func (s *simulation) result() Result {
+	// wait for subscription exits, so that no signal is recorded after result
+	<-s.serveFinish
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return Result{
+		Name:       s.scenario.Name,
+		Paths:      slices.Clone(s.paths),
+		Signals:    slices.Clone(s.signals),
+		FinalState: s.finalState,
+		TimedOut:   s.timedOut,
+	}
+}
+
+func (s *simulation) touch() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.lastActive = time.Now()
+}
+
+func (s *simulation) lastActiveTime() time.Time {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.lastActive
+}
+
+// recordState record path graph of recovery, a recovery ends when the machine enters init state or
+// the machine is reset without entering init state
+func (s *simulation) recordState(state, pathGraph string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.lastActive = time.Now()
+	if s.lastPath != "" && !strings.HasPrefix(pathGraph, s.lastPath) {
+		s.paths = append(s.paths, s.lastPath)
+	}
+	s.lastPath = pathGraph
+	if state == common.InitState {
+		s.paths = append(s.paths, pathGraph)
+		s.lastPath = ""
+	}
+}
+
+func (s *simulation) recordSignal(signal *pb.ProcessManageSignal) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.lastActive = time.Now()
+	s.signals = append(s.signals, Signal{
+		ElapsedMs:      (time.Since(s.start) + s.clock.skipped()).Milliseconds(),
+		SignalType:     signal.SignalType,
+		Actions:        slices.Clone(signal.Actions),
+		ChangeStrategy: signal.ChangeStrategy,
+		FaultRanks:     common.Faults2Ranks(signal.FaultRanks),
+		NodeRankIds:    slices.Clone(signal.NodeRankIds),
+		ExtraParams:    signal.ExtraParams,
+	})
+}
+
+// nextResult get the scripted result of this attempt of the strategy
+func (s *simulation) nextResult(strategy string) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	results := s.scenario.Agent.Results[strategy]
+	attempt := s.attempts[strategy]
+	s.attempts[strategy]++
+	if len(results) == 0 {
+		return ResultSuccess
+	}
+	if attempt >= len(results) {
+		return results[len(results)-1]
+	}
+	return results[attempt]
+}
@@ -0,0 +1,139 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package simulator test for recover simulation
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/smartystreets/goconvey/convey"
+
+	"ascend-common/common-utils/hwlog"
+	"clusterd/pkg/common/constant"
+	"clusterd/pkg/domain/common"
+	"clusterd/pkg/domain/conf"
+)
+
+const (
+	simScenarioPath = "../../../../testdata/resource/recover_simulator_test.yaml"
+	simTestPods     = 2
+	simTestDevices  = 8
+)
+
+func TestMain(m *testing.M) {
+	if err := hwlog.InitRunLogger(&hwlog.LogConfig{OnlyToStdout: true}, context.Background()); err != nil {
+		return
+	}
+	code := m.Run()
+	fmt.Printf("exit_code = %v\n", code)
+}
+
+func newTestSimScenario() Scenario {
+	strategies := []string{constant.ProcessRetryStrategyName, constant.ProcessRecoverStrategyName,
+		constant.ProcessDumpStrategyName, constant.ProcessExitStrategyName}
+	return Scenario{
+		Name:   "test",
+		Job:    Job{Name: "sim-job", Strategies: strategies, Pods: simTestPods},
+		Agent:  Agent{Strategies: strategies},
+		Faults: []Fault{{Ranks: []string{"3"}, Type: FaultRetry}},
+	}
+}
+
+func TestParseSimScenario(t *testing.T) {
+	convey.Convey("test func 'ParseScenario'", t, func() {
+		convey.Convey("scenario file is valid, should fill default values", func() {
+			data, err := os.ReadFile(simScenarioPath)
+			convey.So(err, convey.ShouldBeNil)
+			scenario, err := ParseScenario(data)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(scenario.Name, convey.ShouldEqual, "retry-success")
+			convey.So(scenario.Job.DevicesPerPod, convey.ShouldEqual, simTestDevices)
+			convey.So(scenario.Faults[0].Type, convey.ShouldEqual, FaultRetry)
+		})
+		convey.Convey("unknown field, should return error", func() {
+			_, err := ParseScenario([]byte("name: a\nunknown: b\n"))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestCheckSimScenario(t *testing.T) {
+	convey.Convey("test func 'CheckScenario'", t, func() {
+		convey.Convey("valid scenario, should fill namespace, devices and duration", func() {
+			scenario := newTestSimScenario()
+			convey.So(CheckScenario(&scenario), convey.ShouldBeNil)
+			convey.So(scenario.Job.Namespace, convey.ShouldEqual, "default")
+			convey.So(scenario.Job.DevicesPerPod, convey.ShouldEqual, defaultSimDevicesPerPod)
+			convey.So(scenario.DurationSeconds, convey.ShouldEqual, defaultSimDurationSeconds)
+		})
+		convey.Convey("rank out of job, should return error", func() {
+			scenario := newTestSimScenario()
+			scenario.Faults[0].Ranks = []string{"16"}
+			convey.So(CheckScenario(&scenario), convey.ShouldNotBeNil)
+		})
+		convey.Convey("unsupported fault type, should return error", func() {
+			scenario := newTestSimScenario()
+			scenario.Faults[0].Type = "unknown"
+			convey.So(CheckScenario(&scenario), convey.ShouldNotBeNil)
+		})
+		convey.Convey("unsupported agent result, should return error", func() {
+			scenario := newTestSimScenario()
+			scenario.Agent.Results = map[string][]string{constant.ProcessRetryStrategyName: {"unknown"}}
+			convey.So(CheckScenario(&scenario), convey.ShouldNotBeNil)
+		})
+		convey.Convey("invalid policy, should return error", func() {
+			scenario := newTestSimScenario()
+			scenario.Policy = &conf.RecoverStrategyPolicy{Rules: []conf.RecoverStrategyRule{{Name: "a"}}}
+			convey.So(CheckScenario(&scenario), convey.ShouldNotBeNil)
+		})
+		convey.Convey("no fault, should return error", func() {
+			scenario := newTestSimScenario()
+			scenario.Faults = nil
+			convey.So(CheckScenario(&scenario), convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestSimulationRecordState(t *testing.T) {
+	convey.Convey("test func 'recordState', path is recorded when machine enters init or is reset", t, func() {
+		sim := newSimulation(newTestSimScenario())
+		sim.recordState(common.NotifyStopTrainState, "INIT(faultOccur)-->A")
+		sim.recordState(common.NotifyStopTrainState, "INIT(faultOccur)-->B")
+		sim.recordState(common.InitState, "INIT(faultOccur)-->B(finish)-->INIT")
+		convey.So(sim.paths, convey.ShouldResemble, []string{"INIT(faultOccur)-->A",
+			"INIT(faultOccur)-->B(finish)-->INIT"})
+		convey.So(sim.lastPath, convey.ShouldBeEmpty)
+	})
+}
+
+func TestSimulationNextResult(t *testing.T) {
+	convey.Convey("test func 'nextResult', the last result is reused", t, func() {
+		scenario := newTestSimScenario()
+		scenario.Agent.Results = map[string][]string{constant.ProcessRetryStrategyName: {ResultRecoverable,
+			ResultFail}}
+		sim := newSimulation(scenario)
+		convey.So(sim.nextResult(constant.ProcessRetryStrategyName), convey.ShouldEqual, ResultRecoverable)
+		convey.So(sim.nextResult(constant.ProcessRetryStrategyName), convey.ShouldEqual, ResultFail)
+		convey.So(sim.nextResult(constant.ProcessRetryStrategyName), convey.ShouldEqual, ResultFail)
+		convey.So(sim.nextResult(constant.ProcessDumpStrategyName), convey.ShouldEqual, ResultSuccess)
+	})
+}
+
+func TestSimClockSkip(t *testing.T) {
+	convey.Convey("test func 'skip', clock is stepped to the nearest deadline", t, func() {
+		clk := newSimClock()
+		convey.So(clk.skip(), convey.ShouldEqual, 0)
+		timeout := clk.After(time.Minute)
+		timer := clk.NewTimer(time.Second)
+		convey.So(clk.skip(), convey.ShouldEqual, time.Second)
+		<-timer.C()
+		convey.So(clk.skip(), convey.ShouldEqual, time.Minute-time.Second)
+		<-timeout
+		convey.So(clk.skip(), convey.ShouldEqual, 0)
+		convey.So(clk.skipped(), convey.ShouldEqual, time.Minute)
+	})
+}
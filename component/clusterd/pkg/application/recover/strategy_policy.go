@@ -0,0 +1,149 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package recover a series of service function
+package recover
+
+import (
+	"slices"
+	"time"
+
+	"ascend-common/common-utils/hwlog"
+	"clusterd/pkg/common/constant"
+	"clusterd/pkg/domain/common"
+	"clusterd/pkg/domain/conf"
+	"clusterd/pkg/domain/podgroup"
+)
+
+var (
+	firstCandidates = []string{constant.ProcessRetryStrategyName, constant.ProcessRecoverInPlaceStrategyName,
+		constant.ProcessRecoverStrategyName, constant.ElasticTrainingStrategyName, constant.ProcessDumpStrategyName,
+		constant.ProcessExitStrategyName}
+	retryFailCandidates = []string{constant.ProcessRecoverInPlaceStrategyName, constant.ProcessRecoverStrategyName,
+		constant.ElasticTrainingStrategyName, constant.ProcessDumpStrategyName, constant.ProcessExitStrategyName}
+	recoverFailCandidates = []string{constant.ProcessDumpStrategyName, constant.ProcessExitStrategyName}
+)
+
+// matchStrategyRules get the policy rules of the job when a recovery begins, so that a reloaded policy only takes
+// effect on new recoveries
+func (ctl *EventController) matchStrategyRules(state string) {
+	if state != common.NotifyWaitFaultFlushingState {
+		return
+	}
+	ctl.lock.Lock()
+	defer ctl.lock.Unlock()
+	ctl.matchStrategyRulesLocked()
+}
+
+// matchStrategyRulesLocked must be called with lock held
+func (ctl *EventController) matchStrategyRulesLocked() {
+	pg := podgroup.GetPodGroup(ctl.jobInfo.JobId)
+	ctl.strategyRules = conf.GetRecoverStrategyPolicy().MatchJob(ctl.jobInfo.Namespace, pg.Labels)
+	if len(ctl.strategyRules) > 0 {
+		hwlog.RunLog.Infof("jobId=%s matches recover strategy rules: %v", ctl.jobInfo.JobId,
+			ruleNames(ctl.strategyRules))
+	}
+}
+
+func ruleNames(rules []conf.RecoverStrategyRule) []string {
+	names := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		names = append(names, rule.Name)
+	}
+	return names
+}
+
+// getStrategyRule get the rule matching fault type of current recovery, return false if there is no matching rule
+func (ctl *EventController) getStrategyRule() (conf.RecoverStrategyRule, bool) {
+	ctl.lock.RLock()
+	defer ctl.lock.RUnlock()
+	faultType := conf.NormalFaultType
+	if len(ctl.cacheNormalFault) == 0 && len(ctl.cacheRetryFault) > 0 {
+		faultType = conf.RetryFaultType
+	}
+	for _, rule := range ctl.strategyRules {
+		if rule.MatchFaultType(faultType) {
+			return rule, true
+		}
+	}
+	return conf.RecoverStrategyRule{}, false
+}
+
+// choosePolicyStrategy choose the first strategy of the policy rule which is in candidates and supported by job,
+// the fallback of rule is chosen when retry budget is used up or no strategy can be chosen.
+// return false if no rule matches the recovery, then the built-in choice is used
+func (ctl *EventController) choosePolicyStrategy(candidates []string) (string, bool) {
+	rule, ok := ctl.getStrategyRule()
+	if !ok {
+		return "", false
+	}
+	if ctl.budgetUsedUp(rule) {
+		hwlog.RunLog.Warnf("jobId=%s used up retry budget %d of rule %s, fallback", ctl.jobInfo.JobId,
+			rule.RetryBudget, rule.Name)
+		return ctl.policyFallback(rule), true
+	}
+	for _, strategy := range rule.Strategies {
+		if !slices.Contains(candidates, strategy) {
+			continue
+		}
+		if chosen, supported := ctl.policyStrategySupported(strategy); supported {
+			hwlog.RunLog.Infof("jobId=%s choose strategy %s by rule %s", ctl.jobInfo.JobId, chosen, rule.Name)
+			return chosen, true
+		}
+	}
+	hwlog.RunLog.Warnf("jobId=%s has no strategy of rule %s to choose from %v, fallback", ctl.jobInfo.JobId,
+		rule.Name, candidates)
+	return ctl.policyFallback(rule), true
+}
+
+func (ctl *EventController) policyFallback(rule conf.RecoverStrategyRule) string {
+	if rule.GetFallback() == constant.ProcessDumpStrategyName && ctl.supportDumpStrategy() {
+		return constant.ProcessDumpStrategyName
+	}
+	return constant.ProcessExitStrategyName
+}
+
+// policyStrategySupported check strategy of policy with the same conditions as built-in choice,
+// return the strategy name to notify
+func (ctl *EventController) policyStrategySupported(strategy string) (string, bool) {
+	switch strategy {
+	case constant.ProcessRetryStrategyName:
+		return strategy, ctl.supportRetryStrategy() && len(ctl.cacheNormalFault) <= 0
+	case constant.ProcessRecoverInPlaceStrategyName:
+		return strategy, ctl.supportRestartProcessStrategy()
+	case constant.ProcessRecoverStrategyName:
+		return strategy, ctl.supportRecoverStrategy()
+	case constant.ElasticTrainingStrategyName:
+		return constant.ScaleInStrategyName, ctl.canChooseScaleInStrategy()
+	case constant.ProcessDumpStrategyName:
+		return strategy, ctl.supportDumpStrategy()
+	case constant.ProcessExitStrategyName:
+		return strategy, true
+	default:
+		return strategy, false
+	}
+}
+
+// retryBudgetUsedUp check whether recover attempts reach retry budget of the policy rule
+func (ctl *EventController) retryBudgetUsedUp() bool {
+	rule, ok := ctl.getStrategyRule()
+	return ok && ctl.budgetUsedUp(rule)
+}
+
+func (ctl *EventController) budgetUsedUp(rule conf.RecoverStrategyRule) bool {
+	if rule.RetryBudget == 0 {
+		return false
+	}
+	ctl.lock.RLock()
+	defer ctl.lock.RUnlock()
+	return len(ctl.latestRecoverResult) >= rule.RetryBudget
+}
+
+// reportTimeout get timeout of waiting report in the state, default 15 minutes
+func (ctl *EventController) reportTimeout(state string) time.Duration {
+	if rule, ok := ctl.getStrategyRule(); ok {
+		if seconds, ok := rule.StateTimeoutSeconds[state]; ok {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return time.Duration(reportTimeoutMinutes) * time.Minute
+}
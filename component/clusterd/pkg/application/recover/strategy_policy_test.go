@@ -0,0 +1,106 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package recover test for recover strategy policy
+package recover
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/smartystreets/goconvey/convey"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
+
+	"clusterd/pkg/common/constant"
+	"clusterd/pkg/domain/common"
+	"clusterd/pkg/domain/conf"
+	"clusterd/pkg/domain/podgroup"
+	pb "clusterd/pkg/interface/grpc/recover"
+)
+
+const (
+	policyTimeoutSeconds = 120
+	policyRetryBudget    = 2
+)
+
+func newPolicyController(rules ...conf.RecoverStrategyRule) *EventController {
+	ctl := NewEventController(newJobInfoWithStrategy([]string{constant.ProcessRetryStrategyName,
+		constant.ProcessRecoverStrategyName, constant.ProcessDumpStrategyName}), keepAliveSeconds,
+		context.Background())
+	ctl.agentReportStrategies = []string{constant.ProcessRetryStrategyName, constant.ProcessRecoverStrategyName,
+		constant.ProcessDumpStrategyName}
+	ctl.strategyRules = rules
+	return ctl
+}
+
+func TestMatchStrategyRules(t *testing.T) {
+	convey.Convey("test func 'matchStrategyRules'", t, func() {
+		defer conf.SetRecoverStrategyPolicy(conf.RecoverStrategyPolicy{})
+		conf.SetRecoverStrategyPolicy(conf.RecoverStrategyPolicy{Rules: []conf.RecoverStrategyRule{
+			{Name: "llm", JobLabels: map[string]string{"app": "llm"}, Strategies: []string{"exit"}},
+			{Name: "other", Namespaces: []string{"other"}, Strategies: []string{"exit"}}}})
+		patches := gomonkey.ApplyFuncReturn(podgroup.GetPodGroup, v1beta1.PodGroup{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "llm"}}}).
+			ApplyFuncReturn(common.RetryWriteResetCM, &v1.ConfigMap{Data: make(map[string]string)}, nil)
+		defer patches.Reset()
+		ctl := newPolicyController()
+		ctl.matchStrategyRules(common.WaitReportDumpStatusState)
+		convey.So(ctl.strategyRules, convey.ShouldBeNil)
+		ctl.matchStrategyRules(common.NotifyWaitFaultFlushingState)
+		convey.So(ruleNames(ctl.strategyRules), convey.ShouldResemble, []string{"llm"})
+		ctl.reset(true)
+		convey.So(ctl.strategyRules, convey.ShouldBeNil)
+	})
+}
+
+func TestChoosePolicyStrategy(t *testing.T) {
+	convey.Convey("test func 'choosePolicyStrategy'", t, func() {
+		convey.Convey("built-in choice is used when no rule matches", func() {
+			ctl := newPolicyController(conf.RecoverStrategyRule{Name: "retry-only",
+				FaultTypes: []string{conf.RetryFaultType}, Strategies: []string{constant.ProcessExitStrategyName}})
+			ctl.cacheNormalFault = []*pb.FaultRank{{RankId: "0"}}
+			_, ok := ctl.choosePolicyStrategy(firstCandidates)
+			convey.So(ok, convey.ShouldBeFalse)
+		})
+		convey.Convey("strategies are chosen in order of rule", func() {
+			ctl := newPolicyController(conf.RecoverStrategyRule{Name: "llm", Strategies: []string{
+				constant.ProcessRecoverInPlaceStrategyName, constant.ProcessDumpStrategyName,
+				constant.ProcessRecoverStrategyName}})
+			ctl.cacheRetryFault = []*pb.FaultRank{{RankId: "0"}}
+			convey.So(ctl.firstChooseStrategy(), convey.ShouldEqual, constant.ProcessDumpStrategyName)
+			convey.So(ctl.chooseForRecoverFail(), convey.ShouldEqual, constant.ProcessDumpStrategyName)
+		})
+		convey.Convey("fallback is chosen when no strategy can be chosen or retry budget is used up", func() {
+			ctl := newPolicyController(conf.RecoverStrategyRule{Name: "llm", RetryBudget: policyRetryBudget,
+				Fallback: constant.ProcessDumpStrategyName, Strategies: []string{constant.ProcessRetryStrategyName,
+					constant.ProcessRecoverStrategyName}})
+			ctl.cacheRetryFault = []*pb.FaultRank{{RankId: "0"}}
+			convey.So(ctl.firstChooseStrategy(), convey.ShouldEqual, constant.ProcessRetryStrategyName)
+			convey.So(ctl.chooseForRetryFail(), convey.ShouldEqual, constant.ProcessRecoverStrategyName)
+			convey.So(ctl.chooseForRecoverFail(), convey.ShouldEqual, constant.ProcessDumpStrategyName)
+			ctl.latestRecoverResult = []*pb.RecoverStatusRequest{{Strategy: constant.ProcessRetryStrategyName},
+				{Strategy: constant.ProcessRetryStrategyName}}
+			convey.So(ctl.retryBudgetUsedUp(), convey.ShouldBeTrue)
+			convey.So(ctl.chooseForRetryFail(), convey.ShouldEqual, constant.ProcessDumpStrategyName)
+			ctl.agentReportStrategies = nil
+			convey.So(ctl.chooseForRetryFail(), convey.ShouldEqual, constant.ProcessExitStrategyName)
+		})
+	})
+}
+
+func TestReportTimeout(t *testing.T) {
+	convey.Convey("test func 'reportTimeout'", t, func() {
+		ctl := newPolicyController()
+		convey.So(ctl.reportTimeout(common.WaitReportDumpStatusState), convey.ShouldEqual,
+			reportTimeoutMinutes*time.Minute)
+		ctl.strategyRules = []conf.RecoverStrategyRule{{Name: "llm", Strategies: []string{"exit"},
+			StateTimeoutSeconds: map[string]int{common.WaitReportDumpStatusState: policyTimeoutSeconds}}}
+		convey.So(ctl.reportTimeout(common.WaitReportDumpStatusState), convey.ShouldEqual,
+			policyTimeoutSeconds*time.Second)
+		convey.So(ctl.reportTimeout(common.WaitReportStopCompleteState), convey.ShouldEqual,
+			reportTimeoutMinutes*time.Minute)
+	})
+}
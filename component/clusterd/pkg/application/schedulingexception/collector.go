@@ -32,6 +32,7 @@ import (
 	batchv1 "ascend-common/api/ascend-operator/apis/batch/v1"
 	"ascend-common/common-utils/hwlog"
 	"clusterd/pkg/common/constant"
+	"clusterd/pkg/common/notifier"
 	"clusterd/pkg/common/util"
 	"clusterd/pkg/domain/job"
 	"clusterd/pkg/domain/pod"
@@ -128,10 +129,18 @@ func (c *Collector) checkJobs() {
 
 		hwlog.RunLog.Infof("pgname: %v, cond: %v, pre cond: %v", jobInfo.PgName, cond, c.jobExceptions[jobKey].Condition)
 		key := jobInfo.Name + "." + jobKey
-		if cond.Equal(c.jobExceptions[jobKey].Condition) {
-			exceptionJobs[key] = c.jobExceptions[jobKey]
+		exception := c.jobExceptions[jobKey]
+		if !cond.Equal(exception.Condition) {
+			exception.notified = false
+		} else {
+			exceptionJobs[key] = exception
+			// notify only when the job enters the exception or the exception changes
+			if !exception.notified {
+				notifyException(jobKey, jobInfo, cond)
+				exception.notified = true
+			}
 		}
-		c.jobExceptions[jobKey].Condition = *cond
+		exception.Condition = *cond
 	}
 
 	allMetaObjs := c.processJobs(exceptionJobs, allJobs)
@@ -150,6 +159,19 @@ func (c *Collector) checkJobs() {
 	hwlog.RunLog.Infof("updated scheduling exception configmap with %d jobs", len(exceptionJobs))
 }
 
+// notifyException notify that pod group of job is stuck
+func notifyException(jobKey string, jobInfo constant.JobInfo, cond *conditionDetail) {
+	notifier.Notify(notifier.Notification{
+		Kind:      notifier.KindSchedulingException,
+		Severity:  notifier.SeverityWarning,
+		Namespace: jobInfo.NameSpace,
+		JobName:   jobInfo.Name,
+		JobId:     jobKey,
+		Reason:    cond.Reason,
+		Message:   fmt.Sprintf("pod group %s is %s: %s", jobInfo.PgName, cond.Status, cond.Message),
+	})
+}
+
 func getPgFromCache(namespace, name string) *v1beta1.PodGroup {
 	obj, err := kube.GetObject(kube.PodGroupGVK(), fmt.Sprintf("%s/%s", namespace, name))
 	if err != nil {
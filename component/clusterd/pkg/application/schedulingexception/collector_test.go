@@ -21,6 +21,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/agiledragon/gomonkey/v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"volcano.sh/apis/pkg/apis/batch/v1alpha1"
@@ -28,6 +29,10 @@ import (
 
 	"ascend-common/common-utils/hwlog"
 	"clusterd/pkg/common/constant"
+	"clusterd/pkg/common/notifier"
+	"clusterd/pkg/domain/job"
+	"clusterd/pkg/domain/pod"
+	"clusterd/pkg/interface/kube"
 )
 
 func init() {
@@ -378,6 +383,38 @@ func TestCollector_CleanupJobs(t *testing.T) {
 	}
 }
 
+func TestCollector_CheckJobsNotifyOnTransition(t *testing.T) {
+	pendingPod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "ns"},
+		Status: corev1.PodStatus{Phase: corev1.PodPending}}
+	failedPod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "ns"},
+		Status: corev1.PodStatus{Phase: corev1.PodFailed}}
+	pods := map[string]corev1.Pod{"pod1": pendingPod}
+	notified := 0
+	patches := gomonkey.ApplyFuncReturn(job.GetAllJobCache,
+		map[string]constant.JobInfo{"job1": {Name: "job1", NameSpace: "ns", PgName: "pg1"}}).
+		ApplyFuncReturn(getPgFromCache, nil).
+		ApplyFunc(pod.GetPodByJobId, func(string) map[string]corev1.Pod { return pods }).
+		ApplyFuncReturn(kube.ListObjects, nil).
+		ApplyFuncReturn(updateConfigMap, nil).
+		ApplyFunc(notifier.Notify, func(notifier.Notification) { notified++ })
+	defer patches.Reset()
+	c := &Collector{jobExceptions: map[string]*jobExceptionInfo{}}
+	const cycles = 3
+	for i := 0; i < cycles; i++ {
+		c.checkJobs()
+	}
+	if notified != 1 {
+		t.Errorf("expected 1 notification of the same exception, got %d", notified)
+	}
+	pods = map[string]corev1.Pod{"pod1": failedPod}
+	for i := 0; i < cycles; i++ {
+		c.checkJobs()
+	}
+	if notified != 2 {
+		t.Errorf("expected 2 notifications after the exception changes, got %d", notified)
+	}
+}
+
 type processPodGroupInqueueTestCase struct {
 	name     string
 	pg       *v1beta1.PodGroup
@@ -20,6 +20,8 @@ type jobExceptionInfo struct {
 	JobType   string          `json:"jobType"`
 	NameSpace string          `json:"nameSpace"`
 	Condition conditionDetail `json:"conditions"`
+	// notified the exception of current condition has been notified
+	notified bool
 }
 
 type conditionDetail struct {
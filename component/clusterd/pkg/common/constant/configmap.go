@@ -26,11 +26,17 @@ const (
 	ClusterNodeInfo = "cluster-info-node-cm"
 	// ClusterSwitchInfo the name of cluster switchinfo 1520 info config map
 	ClusterSwitchInfo = "cluster-info-switch-"
+	// RecoverCheckpointPrefix is prefix of recover state machine checkpoint config map name, suffix is job id
+	RecoverCheckpointPrefix = "recover-checkpoint-"
+	// RecoverCheckpointKey configmap recover-checkpoint-<jobId> key of checkpoint data
+	RecoverCheckpointKey = "Checkpoint"
 )
 
 const (
 	// CmStatisticFault cm label for fault statistic
 	CmStatisticFault = "mc-statistic-fault"
+	// CmRecoverCheckpoint cm label for recover state machine checkpoint
+	CmRecoverCheckpoint = "mc-recover-checkpoint"
 	// CmConsumer who uses these configmap
 	CmConsumer = "mx-consumer-volcano"
 	// CmConsumerValue the value only for true
@@ -445,6 +445,8 @@ const (
 	ManualDevInfoCmName = "clusterd-manual-info-cm"
 	// ManuallySeparateNPUConfigKey the key of manually separate npu config in cm
 	ManuallySeparateNPUConfigKey = "manually_separate_policy.conf"
+	// RecoverStrategyPolicyConfigKey the key of recover strategy policy config in cm
+	RecoverStrategyPolicyConfigKey = "recover_strategy_policy.conf"
 	// HoursToMilliseconds hours to milliseconds
 	HoursToMilliseconds = 60 * 60 * 1000
 	// SecondsToMilliseconds seconds to milliseconds
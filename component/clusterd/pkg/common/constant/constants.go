@@ -35,6 +35,15 @@ const (
 	// GrpcPort is the grpc port
 	GrpcPort = ":8899"
 
+	// PodNameEnv env of clusterd pod name, used as identity of leader election
+	PodNameEnv = "POD_NAME"
+	// PodNamespaceEnv env of clusterd pod namespace, the lease is created in it
+	PodNamespaceEnv = "POD_NAMESPACE"
+	// DefaultLeaseName default lease name of clusterd leader election
+	DefaultLeaseName = "clusterd-leader"
+	// LeaderLabelKey pod label marks whether clusterd replica holds the lease, grpc service selects leader by it
+	LeaderLabelKey = "clusterd-leader"
+
 	// DefaultNamespace represents the default value of namespace
 	DefaultNamespace = "default"
 	// TestName represents the default value of name
@@ -0,0 +1,315 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package journal fault journal of clusterd, record how faults move through the fault centers and the recover
+// state machine as json lines in rotating files, and reconstruct the timeline of a node, device or job from them
+package journal
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"ascend-common/common-utils/hwlog"
+)
+
+// stages of fault events
+const (
+	// StageReceived fault is reported to fault center
+	StageReceived = "received"
+	// StageFiltered fault is removed by a fault processor
+	StageFiltered = "filtered"
+	// StageAdded fault is added by a fault processor
+	StageAdded = "added"
+	// StageEscalated fault level is changed by a fault processor
+	StageEscalated = "escalated"
+	// StageSeparated fault is reported with a separate level after processing
+	StageSeparated = "separated"
+	// StageReleased fault disappears from the processed result
+	StageReleased = "released"
+	// StageJobFault fault is dispatched to a job registered recover service
+	StageJobFault = "job-fault"
+	// StageStrategy recover strategy is chosen for a job
+	StageStrategy = "strategy"
+	// StageResult recover result of a job is reported
+	StageResult = "result"
+)
+
+const (
+	// DefaultFile journal is disabled by default, set a path such as /var/log/mindx-dl/clusterd/fault_journal.log
+	// to enable it
+	DefaultFile = ""
+	// DefaultMaxSize default size in MB of a journal file before rotated
+	DefaultMaxSize = 10
+	// DefaultMaxBackups default number of rotated journal files to keep
+	DefaultMaxBackups = 30
+	// DefaultMaxAge default days to keep rotated journal files
+	DefaultMaxAge = 7
+
+	eventChanLength = 4096
+	maxLineLength   = 64 * 1024
+	// maxQueryEvents at most the latest 10000 matched events are returned by one query
+	maxQueryEvents = 10000
+	// maxQueryWindow at most 7 days of events before until are scanned by one query
+	maxQueryWindow = 7 * 24 * time.Hour
+	// limits of rotated files are the same as hwlog
+	maxFileSize = 19
+	maxBackups  = 180
+	minAge      = 7
+	maxAge      = 700
+)
+
+// Event one fault related decision
+type Event struct {
+	Time       int64  `json:"time"`
+	Stage      string `json:"stage"`
+	Source     string `json:"source"`
+	Node       string `json:"node,omitempty"`
+	Device     string `json:"device,omitempty"`
+	FaultCode  string `json:"faultCode,omitempty"`
+	FaultLevel string `json:"faultLevel,omitempty"`
+	JobId      string `json:"jobId,omitempty"`
+	Detail     string `json:"detail,omitempty"`
+}
+
+// Config of journal files, journal is disabled when File is empty
+type Config struct {
+	File       string
+	MaxSize    int
+	MaxBackups int
+	MaxAge     int
+}
+
+// Filter of journal query, empty field matches all, time is in milliseconds
+type Filter struct {
+	Node      string
+	Device    string
+	JobId     string
+	FaultCode string
+	Since     int64
+	Until     int64
+}
+
+type writer struct {
+	file   string
+	logs   *hwlog.Logs
+	events chan Event
+}
+
+var (
+	current *writer
+	lock    sync.RWMutex
+)
+
+// Validate check the journal config
+func (c Config) Validate() error {
+	if c.File == "" {
+		return nil
+	}
+	if !filepath.IsAbs(c.File) {
+		return fmt.Errorf("journal file %s should be an absolute path", c.File)
+	}
+	if c.MaxSize < 1 || c.MaxSize > maxFileSize {
+		return fmt.Errorf("journal max size %d should be in [1, %d] MB", c.MaxSize, maxFileSize)
+	}
+	if c.MaxBackups < 1 || c.MaxBackups > maxBackups {
+		return fmt.Errorf("journal max backups %d should be in [1, %d]", c.MaxBackups, maxBackups)
+	}
+	if c.MaxAge < minAge || c.MaxAge > maxAge {
+		return fmt.Errorf("journal max age %d should be in [%d, %d] days", c.MaxAge, minAge, maxAge)
+	}
+	return nil
+}
+
+// Init start writing journal until ctx done, events are written asynchronously
+func Init(ctx context.Context, cfg Config) error {
+	if cfg.File == "" {
+		return nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	w := &writer{
+		file: cfg.File,
+		logs: &hwlog.Logs{FileName: cfg.File, Capacity: cfg.MaxSize, SaveVolume: cfg.MaxBackups,
+			SaveTime: cfg.MaxAge},
+		events: make(chan Event, eventChanLength),
+	}
+	lock.Lock()
+	current = w
+	lock.Unlock()
+	go w.run(ctx)
+	hwlog.RunLog.Infof("fault journal is written to %s", cfg.File)
+	return nil
+}
+
+func getWriter() *writer {
+	lock.RLock()
+	defer lock.RUnlock()
+	return current
+}
+
+// Enabled return whether journal is written, callers can skip building events when disabled
+func Enabled() bool {
+	return getWriter() != nil
+}
+
+// Record append an event to journal without blocking, the event is dropped if the journal is busy
+func Record(event Event) {
+	w := getWriter()
+	if w == nil {
+		return
+	}
+	if event.Time == 0 {
+		event.Time = time.Now().UnixMilli()
+	}
+	select {
+	case w.events <- event:
+	default:
+		hwlog.RunLog.WarnfWithLimit("journal", "full", "fault journal is busy, event dropped: %+v", event)
+	}
+}
+
+func (w *writer) run(ctx context.Context) {
+	defer func() {
+		if err := w.logs.Close(); err != nil {
+			hwlog.RunLog.Warnf("close fault journal failed, err: %v", err)
+		}
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-w.events:
+			w.write(event)
+		}
+	}
+}
+
+func (w *writer) write(event Event) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		hwlog.RunLog.Errorf("marshal fault journal event failed, err: %v", err)
+		return
+	}
+	if _, err = w.logs.Write(append(data, '\n')); err != nil {
+		hwlog.RunLog.ErrorfWithLimit("journal", "write", "write fault journal failed, err: %v", err)
+		return
+	}
+	hwlog.ResetErrCnt("journal", "write")
+}
+
+func (f Filter) match(event Event) bool {
+	return (f.Node == "" || f.Node == event.Node) && (f.Device == "" || f.Device == event.Device) &&
+		(f.JobId == "" || f.JobId == event.JobId) &&
+		(f.FaultCode == "" || strings.EqualFold(f.FaultCode, event.FaultCode)) &&
+		(f.Since == 0 || event.Time >= f.Since) && (f.Until == 0 || event.Time <= f.Until)
+}
+
+// journalFiles return the current journal file, then rotated journal files from new to old
+func journalFiles(file string) ([]string, error) {
+	ext := filepath.Ext(file)
+	prefix := strings.TrimSuffix(file, ext) + "-"
+	backups, err := filepath.Glob(prefix + "*" + ext)
+	if err != nil {
+		return nil, err
+	}
+	// rotated files are suffixed by time, sorting by name is sorting by time
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+	return append([]string{file}, backups...), nil
+}
+
+// limitWindow restrict the time range of filter to at most 7 days, ending now when until is not set
+func (f Filter) limitWindow(now time.Time) Filter {
+	if f.Until == 0 {
+		f.Until = now.UnixMilli()
+	}
+	if earliest := f.Until - maxQueryWindow.Milliseconds(); f.Since < earliest {
+		f.Since = earliest
+	}
+	return f
+}
+
+// Query get the latest matched events ordered by time, at most 10000 events in 7 days before until are returned.
+// files are read from new to old, older files are not read once enough events are found
+func Query(filter Filter) ([]Event, error) {
+	w := getWriter()
+	if w == nil {
+		return nil, errors.New("fault journal is not enabled")
+	}
+	files, err := journalFiles(w.file)
+	if err != nil {
+		return nil, err
+	}
+	filter = filter.limitWindow(time.Now())
+	var events []Event
+	for _, file := range files {
+		fileEvents, done, err := readFile(file, filter)
+		if err != nil {
+			return nil, err
+		}
+		events = append(fileEvents, events...)
+		if done || len(events) >= maxQueryEvents {
+			break
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time < events[j].Time
+	})
+	if len(events) > maxQueryEvents {
+		events = events[len(events)-maxQueryEvents:]
+	}
+	return events, nil
+}
+
+// readFile get the latest matched events in file, done is true when the file is last written before since,
+// so neither the file nor older files hold any event in the time range
+func readFile(file string, filter Filter) ([]Event, bool, error) {
+	info, err := os.Lstat(file)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, false, fmt.Errorf("journal file %s is not a regular file", file)
+	}
+	if info.ModTime().UnixMilli() < filter.Since {
+		return nil, true, nil
+	}
+	events, err := scanFile(file, filter)
+	return events, false, err
+}
+
+func scanFile(file string, filter Filter) ([]Event, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var events []Event
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, maxLineLength), maxLineLength)
+	for scanner.Scan() {
+		var event Event
+		if err = json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue
+		}
+		if !filter.match(event) {
+			continue
+		}
+		events = append(events, event)
+		if len(events) > maxQueryEvents {
+			events = events[1:]
+		}
+	}
+	return events, scanner.Err()
+}
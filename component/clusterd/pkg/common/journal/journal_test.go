@@ -0,0 +1,129 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package journal test for fault journal
+package journal
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/smartystreets/goconvey/convey"
+
+	"ascend-common/common-utils/hwlog"
+)
+
+const (
+	testNode  = "node1"
+	testJobId = "job-uid"
+	fileMode  = 0600
+	waitTime  = 100 * time.Millisecond
+
+	backupTimeFormat = "2006-01-02T15-04-05.000"
+)
+
+func init() {
+	if err := hwlog.InitRunLogger(&hwlog.LogConfig{OnlyToStdout: true}, context.Background()); err != nil {
+		fmt.Printf("init hwlog failed, %v\n", err)
+	}
+}
+
+func testConfig(dir string) Config {
+	return Config{File: filepath.Join(dir, "fault_journal.log"), MaxSize: DefaultMaxSize,
+		MaxBackups: DefaultMaxBackups, MaxAge: DefaultMaxAge}
+}
+
+func TestValidate(t *testing.T) {
+	convey.Convey("test method 'Validate'", t, func() {
+		convey.So(Config{}.Validate(), convey.ShouldBeNil)
+		cfg := testConfig(t.TempDir())
+		convey.So(cfg.Validate(), convey.ShouldBeNil)
+		invalid := cfg
+		invalid.File = "journal.log"
+		convey.So(invalid.Validate(), convey.ShouldNotBeNil)
+		invalid = cfg
+		invalid.MaxSize = maxFileSize + 1
+		convey.So(invalid.Validate(), convey.ShouldNotBeNil)
+		invalid = cfg
+		invalid.MaxBackups = 0
+		convey.So(invalid.Validate(), convey.ShouldNotBeNil)
+		invalid = cfg
+		invalid.MaxAge = minAge - 1
+		convey.So(invalid.Validate(), convey.ShouldNotBeNil)
+	})
+}
+
+func TestRecordAndQuery(t *testing.T) {
+	convey.Convey("test func 'Record' and 'Query'", t, func() {
+		defer func() {
+			current = nil
+		}()
+		Record(Event{Stage: StageReceived})
+		convey.So(Enabled(), convey.ShouldBeFalse)
+		_, err := Query(Filter{Node: testNode})
+		convey.So(err, convey.ShouldNotBeNil)
+
+		dir := t.TempDir()
+		cfg := testConfig(dir)
+		backupTime := time.Now().Add(-time.Hour)
+		backup := filepath.Join(dir, "fault_journal-"+backupTime.Format(backupTimeFormat)+".log")
+		old := fmt.Sprintf(`{"time":%d,"stage":"received","source":"device-center","node":"node1",`+
+			`"faultCode":"80C98009"}`, backupTime.UnixMilli()) + "\ninvalid line\n"
+		convey.So(os.WriteFile(backup, []byte(old), fileMode), convey.ShouldBeNil)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		convey.So(Init(ctx, cfg), convey.ShouldBeNil)
+		convey.So(Enabled(), convey.ShouldBeTrue)
+		Record(Event{Stage: StageFiltered, Source: "retry", Node: testNode, FaultCode: "80c98009"})
+		Record(Event{Stage: StageStrategy, Source: "recover", JobId: testJobId})
+		time.Sleep(waitTime)
+
+		events, err := Query(Filter{Node: testNode})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(events), convey.ShouldEqual, 2)
+		convey.So(events[0].Stage, convey.ShouldEqual, StageReceived)
+		convey.So(events[1].Stage, convey.ShouldEqual, StageFiltered)
+		convey.So(events[1].Time, convey.ShouldBeGreaterThan, 1)
+		events, err = Query(Filter{Node: testNode, FaultCode: "80C98009", Since: backupTime.UnixMilli() + 1})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(events), convey.ShouldEqual, 1)
+		events, err = Query(Filter{JobId: testJobId})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(events), convey.ShouldEqual, 1)
+		convey.So(events[0].Source, convey.ShouldEqual, "recover")
+	})
+}
+
+func TestQueryLimit(t *testing.T) {
+	convey.Convey("test func 'Query' with limited time window", t, func() {
+		defer func() {
+			current = nil
+		}()
+		dir := t.TempDir()
+		cfg := testConfig(dir)
+		writeBackup := func(eventTime, modTime time.Time) {
+			backup := filepath.Join(dir, "fault_journal-"+modTime.Format(backupTimeFormat)+".log")
+			line := fmt.Sprintf(`{"time":%d,"stage":"received","node":"node1"}`+"\n", eventTime.UnixMilli())
+			convey.So(os.WriteFile(backup, []byte(line), fileMode), convey.ShouldBeNil)
+			convey.So(os.Chtimes(backup, modTime, modTime), convey.ShouldBeNil)
+		}
+		now := time.Now()
+		// event out of the window is not returned, file written before since is not read
+		writeBackup(now.Add(-maxQueryWindow-time.Hour), now.Add(-time.Hour))
+		writeBackup(now.Add(-time.Minute), now.Add(-maxQueryWindow-time.Hour))
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		convey.So(Init(ctx, cfg), convey.ShouldBeNil)
+		events, err := Query(Filter{Node: testNode})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(events), convey.ShouldEqual, 0)
+
+		filter := Filter{Since: 1, Until: now.UnixMilli()}.limitWindow(now)
+		convey.So(filter.Since, convey.ShouldEqual, now.Add(-maxQueryWindow).UnixMilli())
+		filter = Filter{}.limitWindow(now)
+		convey.So(filter.Until, convey.ShouldEqual, now.UnixMilli())
+	})
+}
@@ -0,0 +1,152 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package metrics prometheus metrics of clusterd
+package metrics
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"ascend-common/common-utils/hwlog"
+)
+
+const (
+	namespace = "clusterd"
+	// Path url path of metrics
+	Path = "/metrics"
+
+	centerLabel   = "center"
+	stateLabel    = "state"
+	strategyLabel = "strategy"
+	methodLabel   = "method"
+	serviceLabel  = "service"
+)
+
+var (
+	registry = prometheus.NewRegistry()
+
+	faultProcessDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "fault_process_duration_seconds",
+		Help:      "Duration of one fault process center cycle",
+		Buckets:   []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 2, 5},
+	})
+	recoverDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "recover_duration_seconds",
+		Help:      "Duration from fault occurred to recover state machine back to init, by the last chosen strategy",
+		Buckets:   []float64{1, 5, 10, 30, 60, 120, 300, 600, 900, 1800},
+	}, []string{strategyLabel})
+	qpsLimitRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_qps_limit_rejected_total",
+		Help:      "Number of grpc requests rejected by qps limiter",
+	}, []string{methodLabel})
+	subscribeStreams = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "grpc_subscribe_streams",
+		Help:      "Number of active grpc streams of each service",
+	}, []string{serviceLabel})
+	recoverStates = &recoverStateCollector{
+		desc: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "recover_jobs"),
+			"Number of jobs in each recover state", []string{stateLabel}, nil),
+	}
+)
+
+// recoverStateCollector collect job number of each recover state when scraped
+type recoverStateCollector struct {
+	desc      *prometheus.Desc
+	lock      sync.RWMutex
+	countFunc func() map[string]int
+}
+
+// Describe implement prometheus.Collector
+func (c *recoverStateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect implement prometheus.Collector
+func (c *recoverStateCollector) Collect(ch chan<- prometheus.Metric) {
+	c.lock.RLock()
+	countFunc := c.countFunc
+	c.lock.RUnlock()
+	if countFunc == nil {
+		return
+	}
+	for state, num := range countFunc() {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(num), state)
+	}
+}
+
+func init() {
+	registry.MustRegister(collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		faultProcessDuration, recoverDuration, qpsLimitRejected, subscribeStreams, recoverStates)
+}
+
+// Handler return http handler of metrics
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// ObserveFaultProcessDuration record duration of one fault process cycle
+func ObserveFaultProcessDuration(duration time.Duration) {
+	faultProcessDuration.Observe(duration.Seconds())
+}
+
+// ObserveRecoverDuration record duration of one recovery
+func ObserveRecoverDuration(strategy string, duration time.Duration) {
+	recoverDuration.WithLabelValues(strategy).Observe(duration.Seconds())
+}
+
+// IncQpsLimitRejected count a grpc request rejected by qps limiter
+func IncQpsLimitRejected(method string) {
+	qpsLimitRejected.WithLabelValues(method).Inc()
+}
+
+// IncSubscribeStream count a new grpc stream of the method
+func IncSubscribeStream(fullMethod string) {
+	subscribeStreams.WithLabelValues(serviceName(fullMethod)).Inc()
+}
+
+// DecSubscribeStream count a closed grpc stream of the method
+func DecSubscribeStream(fullMethod string) {
+	subscribeStreams.WithLabelValues(serviceName(fullMethod)).Dec()
+}
+
+// serviceName get service from grpc full method, format is /package.service/method
+func serviceName(fullMethod string) string {
+	service := strings.TrimPrefix(fullMethod, "/")
+	if idx := strings.LastIndex(service, "/"); idx >= 0 {
+		service = service[:idx]
+	}
+	return service
+}
+
+// RegisterFaultCenterCount register the function to count faults of fault center, called when scraped
+func RegisterFaultCenterCount(center string, countFunc func() int) {
+	err := registry.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   namespace,
+		Name:        "fault_center_faults",
+		Help:        "Number of faults in each fault process center",
+		ConstLabels: prometheus.Labels{centerLabel: center},
+	}, func() float64 {
+		return float64(countFunc())
+	}))
+	if err != nil {
+		hwlog.RunLog.Errorf("register fault count metric of center %s failed, err: %v", center, err)
+	}
+}
+
+// RegisterRecoverStateCount register the function to count jobs of each recover state, called when scraped
+func RegisterRecoverStateCount(countFunc func() map[string]int) {
+	recoverStates.lock.Lock()
+	defer recoverStates.lock.Unlock()
+	recoverStates.countFunc = countFunc
+}
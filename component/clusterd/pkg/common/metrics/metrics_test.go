@@ -0,0 +1,66 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package metrics test for metrics
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/smartystreets/goconvey/convey"
+
+	"ascend-common/common-utils/hwlog"
+)
+
+const testMethod = "/pb.Recover/SubscribeProcessManageSignal"
+
+func init() {
+	if err := hwlog.InitRunLogger(&hwlog.LogConfig{OnlyToStdout: true}, context.Background()); err != nil {
+		return
+	}
+}
+
+func scrape() string {
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, Path, nil))
+	body, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		return ""
+	}
+	return string(body)
+}
+
+func TestServiceName(t *testing.T) {
+	convey.Convey("test func 'serviceName'", t, func() {
+		convey.So(serviceName(testMethod), convey.ShouldEqual, "pb.Recover")
+		convey.So(serviceName("invalid"), convey.ShouldEqual, "invalid")
+	})
+}
+
+func TestHandler(t *testing.T) {
+	convey.Convey("test metrics handler", t, func() {
+		ObserveFaultProcessDuration(time.Millisecond)
+		ObserveRecoverDuration("retry", time.Minute)
+		IncQpsLimitRejected(testMethod)
+		IncSubscribeStream(testMethod)
+		IncSubscribeStream(testMethod)
+		DecSubscribeStream(testMethod)
+		RegisterFaultCenterCount("device", func() int { return 1 })
+		RegisterRecoverStateCount(func() map[string]int {
+			return map[string]int{"InitState": 2}
+		})
+		defer RegisterRecoverStateCount(nil)
+		body := scrape()
+		convey.So(body, convey.ShouldContainSubstring, "clusterd_fault_process_duration_seconds_count 1")
+		convey.So(body, convey.ShouldContainSubstring, `clusterd_recover_duration_seconds_count{strategy="retry"} 1`)
+		convey.So(body, convey.ShouldContainSubstring,
+			`clusterd_grpc_qps_limit_rejected_total{method="`+testMethod+`"} 1`)
+		convey.So(body, convey.ShouldContainSubstring, `clusterd_grpc_subscribe_streams{service="pb.Recover"} 1`)
+		convey.So(body, convey.ShouldContainSubstring, `clusterd_fault_center_faults{center="device"} 1`)
+		convey.So(body, convey.ShouldContainSubstring, `clusterd_recover_jobs{state="InitState"} 2`)
+	})
+}
@@ -0,0 +1,395 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package notifier send notifications of fault and recover events to external sinks such as webhooks, so that
+// on-call team can be paged when a job is rescheduled, an npu is separated or a pod group is stuck
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"ascend-common/common-utils/hwlog"
+	"ascend-common/common-utils/utils"
+)
+
+// severities of notifications, in ascending order
+const (
+	// SeverityInfo notification for information
+	SeverityInfo = "info"
+	// SeverityWarning notification which may need attention
+	SeverityWarning = "warning"
+	// SeverityCritical notification which needs to be handled at once
+	SeverityCritical = "critical"
+)
+
+// kinds of notifications
+const (
+	// KindJobReschedule fault of job can not be recovered in process and job is rescheduled
+	KindJobReschedule = "job-reschedule"
+	// KindNPUSeparated npu is manually separated for frequent faults
+	KindNPUSeparated = "npu-separated"
+	// KindSchedulingException pod group of job is stuck in scheduling
+	KindSchedulingException = "scheduling-exception"
+)
+
+const (
+	// DefaultReloadInterval interval of checking whether config file is modified
+	DefaultReloadInterval = 10 * time.Second
+	maxConfigFileSize     = 1024 * 1024
+	maxSinks              = 32
+	maxDedupSeconds       = 86400
+	queueLength           = 1024
+)
+
+var severityOrder = map[string]int{SeverityInfo: 0, SeverityWarning: 1, SeverityCritical: 2}
+
+// Notification one event sent to sinks
+type Notification struct {
+	Time      int64  `json:"time"`
+	Kind      string `json:"kind"`
+	Severity  string `json:"severity"`
+	Namespace string `json:"namespace,omitempty"`
+	JobName   string `json:"jobName,omitempty"`
+	JobId     string `json:"jobId,omitempty"`
+	Node      string `json:"node,omitempty"`
+	Device    string `json:"device,omitempty"`
+	FaultCode string `json:"faultCode,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+// dedupKey notifications with the same key are duplicated, time and message are not compared
+func (n *Notification) dedupKey() string {
+	return n.Kind + "/" + n.Namespace + "/" + n.JobId + "/" + n.Node + "/" + n.Device + "/" + n.FaultCode + "/" +
+		n.Reason
+}
+
+// Filter select notifications sent to a sink, empty list matches all
+type Filter struct {
+	MinSeverity string   `yaml:"minSeverity"`
+	Kinds       []string `yaml:"kinds"`
+	Namespaces  []string `yaml:"namespaces"`
+	FaultCodes  []string `yaml:"faultCodes"`
+}
+
+func matchList(list []string, value string) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Match check whether the notification passes the filter, namespace and fault code filters only apply to
+// notifications carrying them
+func (f *Filter) Match(n *Notification) bool {
+	if f.MinSeverity != "" && severityOrder[n.Severity] < severityOrder[f.MinSeverity] {
+		return false
+	}
+	if !matchList(f.Kinds, n.Kind) {
+		return false
+	}
+	if n.Namespace != "" && !matchList(f.Namespaces, n.Namespace) {
+		return false
+	}
+	return n.FaultCode == "" || matchList(f.FaultCodes, n.FaultCode)
+}
+
+func (f *Filter) check() error {
+	if _, ok := severityOrder[f.MinSeverity]; f.MinSeverity != "" && !ok {
+		return fmt.Errorf("unsupported severity %s", f.MinSeverity)
+	}
+	return nil
+}
+
+// SinkConfig config of one sink, the config of sink type is in the field with the same name
+type SinkConfig struct {
+	Name string `yaml:"name"`
+	Type string `yaml:"type"`
+	// DedupSeconds duplicated notifications in the window are dropped, 0 means no de-duplication
+	DedupSeconds int            `yaml:"dedupSeconds"`
+	Filter       Filter         `yaml:"filter"`
+	Webhook      *WebhookConfig `yaml:"webhook"`
+}
+
+// Config config of notifier
+type Config struct {
+	Sinks []SinkConfig `yaml:"sinks"`
+}
+
+// ParseConfig parse and check config in yaml
+func ParseConfig(data []byte) (*Config, error) {
+	cfg := &Config{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("unmarshal notifier config failed: %v", err)
+	}
+	if len(cfg.Sinks) > maxSinks {
+		return nil, fmt.Errorf("the number of sinks exceeds %d", maxSinks)
+	}
+	names := make(map[string]bool, len(cfg.Sinks))
+	for i, sink := range cfg.Sinks {
+		if sink.Name == "" || names[sink.Name] {
+			return nil, fmt.Errorf("name of sink %d is empty or duplicated", i)
+		}
+		names[sink.Name] = true
+		if _, ok := getSinkFactory(sink.Type); !ok {
+			return nil, fmt.Errorf("type %s of sink %s is not supported", sink.Type, sink.Name)
+		}
+		if sink.DedupSeconds < 0 || sink.DedupSeconds > maxDedupSeconds {
+			return nil, fmt.Errorf("dedupSeconds of sink %s should be in range [0, %d]", sink.Name, maxDedupSeconds)
+		}
+		if err := sink.Filter.check(); err != nil {
+			return nil, fmt.Errorf("filter of sink %s is invalid: %v", sink.Name, err)
+		}
+	}
+	return cfg, nil
+}
+
+// Sink send notifications to a destination
+type Sink interface {
+	// Send send the notification, returns error when failed after retries
+	Send(ctx context.Context, n *Notification) error
+}
+
+// SinkFactory create a sink from config, the config is checked by the factory
+type SinkFactory func(cfg SinkConfig) (Sink, error)
+
+var (
+	factories    = map[string]SinkFactory{}
+	factoryMutex sync.RWMutex
+)
+
+// RegisterSinkType register a type of sink, the sinks of the type can be configured after registered
+func RegisterSinkType(sinkType string, factory SinkFactory) {
+	factoryMutex.Lock()
+	defer factoryMutex.Unlock()
+	factories[sinkType] = factory
+}
+
+func getSinkFactory(sinkType string) (SinkFactory, bool) {
+	factoryMutex.RLock()
+	defer factoryMutex.RUnlock()
+	factory, ok := factories[sinkType]
+	return factory, ok
+}
+
+// sinkWorker send notifications to one sink in order, so that a slow sink does not block others
+type sinkWorker struct {
+	name   string
+	sink   Sink
+	filter Filter
+	dedup  time.Duration
+	queue  chan *Notification
+	sent   map[string]time.Time
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+func (w *sinkWorker) duplicated(n *Notification, now time.Time) bool {
+	if w.dedup <= 0 {
+		return false
+	}
+	for key, sentTime := range w.sent {
+		if now.Sub(sentTime) >= w.dedup {
+			delete(w.sent, key)
+		}
+	}
+	key := n.dedupKey()
+	if _, ok := w.sent[key]; ok {
+		return true
+	}
+	w.sent[key] = now
+	return false
+}
+
+func (w *sinkWorker) run(ctx context.Context) {
+	defer close(w.done)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.stop:
+			return
+		case n := <-w.queue:
+			if w.duplicated(n, time.Now()) {
+				hwlog.RunLog.Debugf("notification %s is duplicated in sink %s, dropped", n.dedupKey(), w.name)
+				continue
+			}
+			if err := w.sink.Send(ctx, n); err != nil {
+				hwlog.RunLog.Errorf("send notification %s to sink %s failed, error: %v", n.Kind, w.name, err)
+			}
+		}
+	}
+}
+
+// Notifier dispatch notifications to sinks
+type Notifier struct {
+	configFile string
+	version    string
+	lock       sync.RWMutex
+	workers    []*sinkWorker
+}
+
+var (
+	notifier     *Notifier
+	notifierLock sync.RWMutex
+)
+
+// Init load config file and start sinks, config file is reloaded when modified until ctx done
+func Init(ctx context.Context, configFile string) error {
+	n := &Notifier{configFile: configFile}
+	if err := n.load(ctx); err != nil {
+		return err
+	}
+	notifierLock.Lock()
+	notifier = n
+	notifierLock.Unlock()
+	go n.watch(ctx, DefaultReloadInterval)
+	return nil
+}
+
+// Notify send the notification to sinks asynchronously, do nothing when notifier is not initialized
+func Notify(n Notification) {
+	notifierLock.RLock()
+	current := notifier
+	notifierLock.RUnlock()
+	if current == nil {
+		return
+	}
+	if n.Time == 0 {
+		n.Time = time.Now().UnixMilli()
+	}
+	current.dispatch(&n)
+}
+
+func (n *Notifier) dispatch(notification *Notification) {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	for _, worker := range n.workers {
+		if !worker.filter.Match(notification) {
+			continue
+		}
+		select {
+		case worker.queue <- notification:
+		default:
+			hwlog.RunLog.Warnf("notification queue of sink %s is full, drop notification %s", worker.name,
+				notification.Kind)
+		}
+	}
+}
+
+func newWorkers(cfg *Config) ([]*sinkWorker, error) {
+	workers := make([]*sinkWorker, 0, len(cfg.Sinks))
+	for _, sinkCfg := range cfg.Sinks {
+		factory, ok := getSinkFactory(sinkCfg.Type)
+		if !ok {
+			return nil, fmt.Errorf("type %s of sink %s is not supported", sinkCfg.Type, sinkCfg.Name)
+		}
+		sink, err := factory(sinkCfg)
+		if err != nil {
+			return nil, fmt.Errorf("create sink %s failed: %v", sinkCfg.Name, err)
+		}
+		workers = append(workers, &sinkWorker{
+			name:   sinkCfg.Name,
+			sink:   sink,
+			filter: sinkCfg.Filter,
+			dedup:  time.Duration(sinkCfg.DedupSeconds) * time.Second,
+			queue:  make(chan *Notification, queueLength),
+			sent:   make(map[string]time.Time),
+			stop:   make(chan struct{}),
+			done:   make(chan struct{}),
+		})
+	}
+	return workers, nil
+}
+
+// load read config file and replace the sinks, the old sinks are kept when the new config is invalid
+func (n *Notifier) load(ctx context.Context) error {
+	version := utils.MountedFileVersion(n.configFile)
+	data, err := utils.ReadMountedFile(n.configFile, maxConfigFileSize)
+	if err != nil {
+		return fmt.Errorf("read notifier config file failed: %v", err)
+	}
+	cfg, err := ParseConfig(data)
+	if err != nil {
+		return err
+	}
+	workers, err := newWorkers(cfg)
+	if err != nil {
+		return err
+	}
+	n.lock.Lock()
+	oldWorkers := n.workers
+	takeOverQueues(oldWorkers, workers)
+	n.workers, n.version = workers, version
+	n.lock.Unlock()
+	// the old workers stop before the new ones start, so that the notifications queued are sent in order
+	for _, worker := range oldWorkers {
+		close(worker.stop)
+		<-worker.done
+	}
+	for _, worker := range workers {
+		go worker.run(ctx)
+	}
+	return nil
+}
+
+// takeOverQueues the new worker takes over the queue and sent records of the old worker of the same sink, so that
+// the notifications queued are not lost when reloaded, the notifications of the removed sinks are dropped
+func takeOverQueues(oldWorkers, newWorkers []*sinkWorker) {
+	taken := make(map[string]bool, len(newWorkers))
+	for _, newWorker := range newWorkers {
+		for _, oldWorker := range oldWorkers {
+			if oldWorker.name == newWorker.name {
+				newWorker.queue, newWorker.sent = oldWorker.queue, oldWorker.sent
+				taken[oldWorker.name] = true
+				break
+			}
+		}
+	}
+	for _, oldWorker := range oldWorkers {
+		if !taken[oldWorker.name] && len(oldWorker.queue) > 0 {
+			hwlog.RunLog.Warnf("sink %s is removed, drop %d notifications queued", oldWorker.name,
+				len(oldWorker.queue))
+		}
+	}
+}
+
+func (n *Notifier) changed() bool {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+	return utils.MountedFileVersion(n.configFile) != n.version
+}
+
+func (n *Notifier) watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			notifierLock.Lock()
+			if notifier == n {
+				notifier = nil
+			}
+			notifierLock.Unlock()
+			return
+		case <-ticker.C:
+			if !n.changed() {
+				continue
+			}
+			if err := n.load(ctx); err != nil {
+				hwlog.RunLog.Errorf("reload notifier config failed, keep using the old one, err: %v", err)
+				continue
+			}
+			hwlog.RunLog.Infof("notifier config %s reloaded", n.configFile)
+		}
+	}
+}
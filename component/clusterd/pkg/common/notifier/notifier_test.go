@@ -0,0 +1,244 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package notifier test for notifier
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/smartystreets/goconvey/convey"
+
+	"ascend-common/common-utils/hwlog"
+)
+
+const (
+	fileMode     = 0600
+	waitTime     = 3 * time.Second
+	pollInterval = 20 * time.Millisecond
+	dedupSeconds = 60
+)
+
+func init() {
+	if err := hwlog.InitRunLogger(&hwlog.LogConfig{OnlyToStdout: true}, context.Background()); err != nil {
+		fmt.Printf("init hwlog failed, %v\n", err)
+	}
+}
+
+// receiver records request bodies and headers received by test webhook server
+type receiver struct {
+	lock    sync.Mutex
+	bodies  []string
+	headers []http.Header
+	status  []int
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.bodies = append(r.bodies, string(body))
+	r.headers = append(r.headers, req.Header.Clone())
+	status := http.StatusOK
+	if len(r.status) > 0 {
+		status, r.status = r.status[0], r.status[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func (r *receiver) received() []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]string{}, r.bodies...)
+}
+
+func (r *receiver) waitReceived(count int) []string {
+	deadline := time.Now().Add(waitTime)
+	for time.Now().Before(deadline) && len(r.received()) < count {
+		time.Sleep(pollInterval)
+	}
+	return r.received()
+}
+
+func writeConfig(dir, content string) string {
+	file := filepath.Join(dir, "notifier.yaml")
+	convey.So(os.WriteFile(file, []byte(content), fileMode), convey.ShouldBeNil)
+	return file
+}
+
+func TestParseConfig(t *testing.T) {
+	convey.Convey("test func 'ParseConfig'", t, func() {
+		convey.Convey("valid config, should be parsed", func() {
+			cfg, err := ParseConfig([]byte("sinks:\n- name: oncall\n  type: webhook\n  dedupSeconds: 300\n" +
+				"  filter:\n    minSeverity: critical\n  webhook:\n    url: https://alert.example.com/hook\n"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(cfg.Sinks[0].Filter.MinSeverity, convey.ShouldEqual, SeverityCritical)
+			convey.So(cfg.Sinks[0].Webhook.URL, convey.ShouldEqual, "https://alert.example.com/hook")
+		})
+		convey.Convey("unknown field, should return error", func() {
+			_, err := ParseConfig([]byte("sinks:\n- name: a\n  type: webhook\n  unknown: 1\n"))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("unsupported sink type, should return error", func() {
+			_, err := ParseConfig([]byte("sinks:\n- name: a\n  type: email\n"))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("duplicated sink name, should return error", func() {
+			_, err := ParseConfig([]byte("sinks:\n- name: a\n  type: webhook\n- name: a\n  type: webhook\n"))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("invalid severity or dedup window, should return error", func() {
+			_, err := ParseConfig([]byte("sinks:\n- name: a\n  type: webhook\n  filter:\n    minSeverity: high\n"))
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = ParseConfig([]byte("sinks:\n- name: a\n  type: webhook\n  dedupSeconds: -1\n"))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestFilterMatch(t *testing.T) {
+	convey.Convey("test method 'Match' of filter", t, func() {
+		filter := Filter{MinSeverity: SeverityWarning, Kinds: []string{KindNPUSeparated, KindJobReschedule},
+			Namespaces: []string{"team-a"}, FaultCodes: []string{"80E01801"}}
+		convey.So(filter.Match(&Notification{Kind: KindJobReschedule, Severity: SeverityCritical,
+			Namespace: "team-a"}), convey.ShouldBeTrue)
+		convey.So(filter.Match(&Notification{Kind: KindJobReschedule, Severity: SeverityInfo,
+			Namespace: "team-a"}), convey.ShouldBeFalse)
+		convey.So(filter.Match(&Notification{Kind: KindSchedulingException, Severity: SeverityWarning,
+			Namespace: "team-a"}), convey.ShouldBeFalse)
+		convey.So(filter.Match(&Notification{Kind: KindJobReschedule, Severity: SeverityCritical,
+			Namespace: "team-b"}), convey.ShouldBeFalse)
+		// node level notification has no namespace, only fault code is filtered
+		convey.So(filter.Match(&Notification{Kind: KindNPUSeparated, Severity: SeverityWarning,
+			FaultCode: "80E01801"}), convey.ShouldBeTrue)
+		convey.So(filter.Match(&Notification{Kind: KindNPUSeparated, Severity: SeverityWarning,
+			FaultCode: "80C98000"}), convey.ShouldBeFalse)
+	})
+}
+
+func TestDuplicated(t *testing.T) {
+	convey.Convey("test method 'duplicated' of sink worker", t, func() {
+		worker := &sinkWorker{dedup: dedupSeconds * time.Second, sent: map[string]time.Time{}}
+		n := &Notification{Kind: KindNPUSeparated, Node: "node1", Device: "npu-0", FaultCode: "80E01801"}
+		now := time.Now()
+		convey.So(worker.duplicated(n, now), convey.ShouldBeFalse)
+		convey.So(worker.duplicated(&Notification{Kind: KindNPUSeparated, Node: "node1", Device: "npu-0",
+			FaultCode: "80E01801", Message: "other"}, now.Add(time.Second)), convey.ShouldBeTrue)
+		convey.So(worker.duplicated(&Notification{Kind: KindNPUSeparated, Node: "node1", Device: "npu-1",
+			FaultCode: "80E01801"}, now.Add(time.Second)), convey.ShouldBeFalse)
+		convey.So(worker.duplicated(n, now.Add(dedupSeconds*time.Second)), convey.ShouldBeFalse)
+		worker.dedup = 0
+		convey.So(worker.duplicated(n, now), convey.ShouldBeFalse)
+	})
+}
+
+func TestNotify(t *testing.T) {
+	convey.Convey("test func 'Init' and 'Notify'", t, func() {
+		recv := &receiver{}
+		server := httptest.NewServer(recv)
+		defer server.Close()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		convey.Convey("notifier is not initialized, should do nothing", func() {
+			Notify(Notification{Kind: KindJobReschedule})
+			convey.So(len(recv.received()), convey.ShouldEqual, 0)
+		})
+		convey.Convey("config file does not exist, should return error", func() {
+			convey.So(Init(ctx, filepath.Join(t.TempDir(), "none.yaml")), convey.ShouldNotBeNil)
+		})
+		convey.Convey("notifications are filtered and de-duplicated", func() {
+			file := writeConfig(t.TempDir(), fmt.Sprintf("sinks:\n- name: oncall\n  type: webhook\n"+
+				"  dedupSeconds: %d\n  filter:\n    minSeverity: warning\n  webhook:\n    url: %s\n",
+				dedupSeconds, server.URL))
+			convey.So(Init(ctx, file), convey.ShouldBeNil)
+			Notify(Notification{Kind: KindJobReschedule, Severity: SeverityCritical, JobId: "job1"})
+			Notify(Notification{Kind: KindJobReschedule, Severity: SeverityCritical, JobId: "job1"})
+			Notify(Notification{Kind: KindJobReschedule, Severity: SeverityInfo, JobId: "job2"})
+			Notify(Notification{Kind: KindSchedulingException, Severity: SeverityWarning, JobId: "job3"})
+			bodies := recv.waitReceived(2)
+			time.Sleep(pollInterval * 5)
+			convey.So(len(recv.received()), convey.ShouldEqual, 2)
+			var n Notification
+			convey.So(json.Unmarshal([]byte(bodies[0]), &n), convey.ShouldBeNil)
+			convey.So(n.JobId, convey.ShouldEqual, "job1")
+			convey.So(n.Time, convey.ShouldBeGreaterThan, 0)
+		})
+	})
+}
+
+func TestReload(t *testing.T) {
+	convey.Convey("test method 'load', invalid config should keep old sinks", t, func() {
+		recv := &receiver{}
+		server := httptest.NewServer(recv)
+		defer server.Close()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		dir := t.TempDir()
+		file := writeConfig(dir, fmt.Sprintf("sinks:\n- name: a\n  type: webhook\n  webhook:\n    url: %s\n",
+			server.URL))
+		n := &Notifier{configFile: file}
+		convey.So(n.load(ctx), convey.ShouldBeNil)
+		convey.So(n.changed(), convey.ShouldBeFalse)
+		writeConfig(dir, "sinks:\n- name: a\n  type: unknown\n")
+		convey.So(n.load(ctx), convey.ShouldNotBeNil)
+		convey.So(len(n.workers), convey.ShouldEqual, 1)
+		writeConfig(dir, fmt.Sprintf("sinks:\n- name: a\n  type: webhook\n  webhook:\n    url: %s\n"+
+			"- name: b\n  type: webhook\n  webhook:\n    url: %s\n", server.URL, server.URL))
+		convey.So(n.load(ctx), convey.ShouldBeNil)
+		convey.So(len(n.workers), convey.ShouldEqual, 2)
+		n.dispatch(&Notification{Kind: KindNPUSeparated, Severity: SeverityWarning})
+		convey.So(len(recv.waitReceived(2)), convey.ShouldEqual, 2)
+	})
+}
+
+func TestReloadKeepQueued(t *testing.T) {
+	convey.Convey("test method 'load', notifications queued before reload should be sent", t, func() {
+		recv := &receiver{}
+		gate := make(chan struct{})
+		var gateOnce sync.Once
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			// the first request blocks until reloading, so that the others are queued in the old worker
+			gateOnce.Do(func() { <-gate })
+			recv.ServeHTTP(w, req)
+		}))
+		defer server.Close()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		dir := t.TempDir()
+		file := writeConfig(dir, fmt.Sprintf("sinks:\n- name: a\n  type: webhook\n  webhook:\n    url: %s\n",
+			server.URL))
+		n := &Notifier{configFile: file}
+		convey.So(n.load(ctx), convey.ShouldBeNil)
+		const queued = 3
+		for i := 0; i < queued; i++ {
+			n.dispatch(&Notification{Kind: KindJobReschedule, Severity: SeverityCritical,
+				JobId: fmt.Sprintf("job%d", i)})
+		}
+		writeConfig(dir, fmt.Sprintf("sinks:\n- name: a\n  type: webhook\n  webhook:\n    url: %s\n"+
+			"- name: b\n  type: webhook\n  webhook:\n    url: %s\n", server.URL, server.URL))
+		loaded := make(chan error, 1)
+		go func() { loaded <- n.load(ctx) }()
+		close(gate)
+		convey.So(<-loaded, convey.ShouldBeNil)
+		bodies := recv.waitReceived(queued)
+		convey.So(len(bodies), convey.ShouldEqual, queued)
+		for i, body := range bodies {
+			var notification Notification
+			convey.So(json.Unmarshal([]byte(body), &notification), convey.ShouldBeNil)
+			convey.So(notification.JobId, convey.ShouldEqual, fmt.Sprintf("job%d", i))
+		}
+	})
+}
@@ -0,0 +1,261 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package notifier send notifications of fault and recover events to external sinks
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"ascend-common/common-utils/hwlog"
+	"ascend-common/common-utils/utils"
+)
+
+const (
+	// WebhookSinkType type of generic http webhook sink
+	WebhookSinkType = "webhook"
+	// SignatureHeader header of hmac-sha256 signature of "<timestamp>.<body>", in format "sha256=<hex>"
+	SignatureHeader = "X-Clusterd-Signature"
+	// TimestampHeader header of unix seconds when the request is signed
+	TimestampHeader = "X-Clusterd-Timestamp"
+
+	defaultWebhookTimeout    = 5
+	maxWebhookTimeout        = 60
+	defaultWebhookRetries    = 3
+	maxWebhookRetries        = 10
+	defaultRetryIntervalMs   = 500
+	maxRetryInterval         = 30 * time.Second
+	maxSecretFileSize        = 4096
+	maxCAFileSize            = 1024 * 1024
+	maxResponseBodyToRead    = 4096
+	maxTemplateLength        = 64 * 1024
+	contentTypeJSON          = "application/json"
+	retryIntervalMultiplier  = 2
+	minClientErrorStatusCode = 400
+	maxClientErrorStatusCode = 499
+)
+
+// WebhookConfig config of http webhook sink
+type WebhookConfig struct {
+	URL     string            `yaml:"url"`
+	Headers map[string]string `yaml:"headers"`
+	// BodyTemplate text/template of request body executed with Notification, json of notification by default.
+	// Function "json" escapes a value as json, e.g. {"text": {{json .Message}}}
+	BodyTemplate string `yaml:"bodyTemplate"`
+	// TimeoutSeconds timeout of each request, default 5
+	TimeoutSeconds int `yaml:"timeoutSeconds"`
+	// MaxRetries retries when request failed or server responds 429 and 5xx, 0 means no retry, default 3
+	MaxRetries *int `yaml:"maxRetries"`
+	// RetryIntervalMs interval before the first retry, doubled for each retry up to 30 seconds, default 500
+	RetryIntervalMs int `yaml:"retryIntervalMs"`
+	// SecretFile file of hmac key, requests are signed when set
+	SecretFile string `yaml:"secretFile"`
+	// CAFile file of CA certificates to verify https server, system CAs are used when empty
+	CAFile string `yaml:"caFile"`
+}
+
+type webhookSink struct {
+	name          string
+	url           string
+	headers       map[string]string
+	body          *template.Template
+	client        *http.Client
+	maxRetries    int
+	retryInterval time.Duration
+	secret        []byte
+}
+
+func init() {
+	RegisterSinkType(WebhookSinkType, newWebhookSink)
+}
+
+func templateJSON(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	return string(data), err
+}
+
+func (c *WebhookConfig) setDefaults() {
+	if c.TimeoutSeconds == 0 {
+		c.TimeoutSeconds = defaultWebhookTimeout
+	}
+	if c.MaxRetries == nil {
+		retries := defaultWebhookRetries
+		c.MaxRetries = &retries
+	}
+	if c.RetryIntervalMs == 0 {
+		c.RetryIntervalMs = defaultRetryIntervalMs
+	}
+}
+
+func (c *WebhookConfig) check() error {
+	target, err := url.Parse(c.URL)
+	if err != nil || (target.Scheme != "https" && target.Scheme != "http") || target.Host == "" {
+		return fmt.Errorf("url should be a http or https url")
+	}
+	if c.TimeoutSeconds < 0 || c.TimeoutSeconds > maxWebhookTimeout {
+		return fmt.Errorf("timeoutSeconds should be in range [1, %d]", maxWebhookTimeout)
+	}
+	if *c.MaxRetries < 0 || *c.MaxRetries > maxWebhookRetries {
+		return fmt.Errorf("maxRetries should be in range [0, %d]", maxWebhookRetries)
+	}
+	if c.RetryIntervalMs < 0 || time.Duration(c.RetryIntervalMs)*time.Millisecond > maxRetryInterval {
+		return fmt.Errorf("retryIntervalMs should be in range [1, %d]", maxRetryInterval.Milliseconds())
+	}
+	if len(c.BodyTemplate) > maxTemplateLength {
+		return fmt.Errorf("length of bodyTemplate exceeds %d", maxTemplateLength)
+	}
+	return nil
+}
+
+func (c *WebhookConfig) httpClient() (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	if c.CAFile != "" {
+		caData, err := utils.ReadMountedFile(c.CAFile, maxCAFileSize)
+		if err != nil {
+			return nil, fmt.Errorf("read ca file failed: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caData) {
+			return nil, errors.New("no certificate is found in ca file")
+		}
+		transport.TLSClientConfig.RootCAs = pool
+	}
+	return &http.Client{Transport: transport, Timeout: time.Duration(c.TimeoutSeconds) * time.Second}, nil
+}
+
+func newWebhookSink(cfg SinkConfig) (Sink, error) {
+	if cfg.Webhook == nil {
+		return nil, errors.New("webhook config is required")
+	}
+	webhook := *cfg.Webhook
+	webhook.setDefaults()
+	if err := webhook.check(); err != nil {
+		return nil, err
+	}
+	sink := &webhookSink{
+		name:          cfg.Name,
+		url:           webhook.URL,
+		headers:       webhook.Headers,
+		maxRetries:    *webhook.MaxRetries,
+		retryInterval: time.Duration(webhook.RetryIntervalMs) * time.Millisecond,
+	}
+	if strings.HasPrefix(webhook.URL, "http://") {
+		hwlog.RunLog.Warnf("webhook of sink %s is not https, notifications are sent in plain text", cfg.Name)
+	}
+	if webhook.BodyTemplate != "" {
+		body, err := template.New(cfg.Name).Funcs(template.FuncMap{"json": templateJSON}).
+			Option("missingkey=error").Parse(webhook.BodyTemplate)
+		if err != nil {
+			return nil, fmt.Errorf("parse bodyTemplate failed: %v", err)
+		}
+		sink.body = body
+	}
+	if webhook.SecretFile != "" {
+		secret, err := utils.ReadMountedFile(webhook.SecretFile, maxSecretFileSize)
+		if err != nil {
+			return nil, fmt.Errorf("read secret file failed: %v", err)
+		}
+		sink.secret = bytes.TrimSpace(secret)
+		if len(sink.secret) == 0 {
+			return nil, errors.New("secret file is empty")
+		}
+	}
+	client, err := webhook.httpClient()
+	if err != nil {
+		return nil, err
+	}
+	sink.client = client
+	return sink, nil
+}
+
+func (s *webhookSink) render(n *Notification) ([]byte, error) {
+	if s.body == nil {
+		return json.Marshal(n)
+	}
+	var buf bytes.Buffer
+	if err := s.body.Execute(&buf, n); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// sign hmac-sha256 of "<timestamp>.<body>", receivers should reject requests with old timestamp against replay
+func sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send post the notification, retry with exponential backoff when failed, client errors except 429 are not retried
+func (s *webhookSink) Send(ctx context.Context, n *Notification) error {
+	body, err := s.render(n)
+	if err != nil {
+		return fmt.Errorf("render body failed: %v", err)
+	}
+	interval := s.retryInterval
+	for attempt := 0; ; attempt++ {
+		retryable, err := s.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if !retryable || attempt >= s.maxRetries {
+			return err
+		}
+		hwlog.RunLog.Warnf("post notification to sink %s failed, retry %d after %v, error: %v", s.name,
+			attempt+1, interval, err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+		interval = min(interval*retryIntervalMultiplier, maxRetryInterval)
+	}
+}
+
+// post send the body once, returns whether the error is retryable
+func (s *webhookSink) post(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", contentTypeJSON)
+	for key, value := range s.headers {
+		req.Header.Set(key, value)
+	}
+	if len(s.secret) > 0 {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(TimestampHeader, timestamp)
+		req.Header.Set(SignatureHeader, sign(s.secret, timestamp, body))
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	// drain limited body so that the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBodyToRead))
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return false, nil
+	}
+	retryable := resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode < minClientErrorStatusCode || resp.StatusCode > maxClientErrorStatusCode
+	return retryable, fmt.Errorf("server responds status %d", resp.StatusCode)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package notifier test for webhook sink
+package notifier
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+const testRetryIntervalMs = 10
+
+func newTestWebhook(webhook WebhookConfig) *webhookSink {
+	if webhook.RetryIntervalMs == 0 {
+		webhook.RetryIntervalMs = testRetryIntervalMs
+	}
+	sink, err := newWebhookSink(SinkConfig{Name: "test", Type: WebhookSinkType, Webhook: &webhook})
+	convey.So(err, convey.ShouldBeNil)
+	return sink.(*webhookSink)
+}
+
+func TestNewWebhookSink(t *testing.T) {
+	convey.Convey("test func 'newWebhookSink'", t, func() {
+		convey.Convey("webhook config is missing, should return error", func() {
+			_, err := newWebhookSink(SinkConfig{Name: "test", Type: WebhookSinkType})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("invalid url or retries, should return error", func() {
+			_, err := newWebhookSink(SinkConfig{Name: "test", Webhook: &WebhookConfig{URL: "ftp://a"}})
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = newWebhookSink(SinkConfig{Name: "test", Webhook: &WebhookConfig{URL: "https://a",
+				MaxRetries: intPtr(maxWebhookRetries + 1)}})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("invalid template, should return error", func() {
+			_, err := newWebhookSink(SinkConfig{Name: "test", Webhook: &WebhookConfig{URL: "https://a",
+				BodyTemplate: "{{.Kind"}})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("secret file is empty, should return error", func() {
+			secretFile := filepath.Join(t.TempDir(), "secret")
+			convey.So(os.WriteFile(secretFile, []byte("\n"), fileMode), convey.ShouldBeNil)
+			_, err := newWebhookSink(SinkConfig{Name: "test", Webhook: &WebhookConfig{URL: "https://a",
+				SecretFile: secretFile}})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestWebhookSend(t *testing.T) {
+	convey.Convey("test method 'Send' of webhook sink", t, func() {
+		recv := &receiver{}
+		server := httptest.NewServer(recv)
+		defer server.Close()
+		n := &Notification{Kind: KindJobReschedule, Severity: SeverityCritical, Message: `say "hi"`}
+		convey.Convey("body is rendered by template and signed", func() {
+			secretFile := filepath.Join(t.TempDir(), "secret")
+			convey.So(os.WriteFile(secretFile, []byte("key\n"), fileMode), convey.ShouldBeNil)
+			sink := newTestWebhook(WebhookConfig{URL: server.URL, SecretFile: secretFile,
+				Headers:      map[string]string{"Authorization": "Bearer token"},
+				BodyTemplate: `{"text": {{json .Message}}, "level": "{{.Severity}}"}`})
+			convey.So(sink.Send(context.Background(), n), convey.ShouldBeNil)
+			convey.So(recv.received(), convey.ShouldResemble, []string{`{"text": "say \"hi\"", "level": "critical"}`})
+			header := recv.headers[0]
+			convey.So(header.Get("Authorization"), convey.ShouldEqual, "Bearer token")
+			convey.So(header.Get(SignatureHeader), convey.ShouldEqual,
+				sign([]byte("key"), header.Get(TimestampHeader), []byte(recv.bodies[0])))
+		})
+		convey.Convey("server error, should retry until success", func() {
+			recv.status = []int{http.StatusInternalServerError, http.StatusTooManyRequests}
+			sink := newTestWebhook(WebhookConfig{URL: server.URL})
+			convey.So(sink.Send(context.Background(), n), convey.ShouldBeNil)
+			convey.So(len(recv.received()), convey.ShouldEqual, 3)
+		})
+		convey.Convey("retries are used up, should return error", func() {
+			recv.status = []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway}
+			sink := newTestWebhook(WebhookConfig{URL: server.URL, MaxRetries: intPtr(2)})
+			convey.So(sink.Send(context.Background(), n), convey.ShouldNotBeNil)
+			convey.So(len(recv.received()), convey.ShouldEqual, 3)
+		})
+		convey.Convey("max retries is 0, should not retry", func() {
+			recv.status = []int{http.StatusBadGateway}
+			sink := newTestWebhook(WebhookConfig{URL: server.URL, MaxRetries: intPtr(0)})
+			convey.So(sink.Send(context.Background(), n), convey.ShouldNotBeNil)
+			convey.So(len(recv.received()), convey.ShouldEqual, 1)
+		})
+		convey.Convey("client error, should not retry", func() {
+			recv.status = []int{http.StatusBadRequest}
+			sink := newTestWebhook(WebhookConfig{URL: server.URL})
+			convey.So(sink.Send(context.Background(), n), convey.ShouldNotBeNil)
+			convey.So(len(recv.received()), convey.ShouldEqual, 1)
+		})
+	})
+}
+
+func intPtr(value int) *int {
+	return &value
+}
@@ -35,6 +35,7 @@ type StateMachine struct {
 	path      []string
 	pathGraph string // src(event)-->dst
 	lock      sync.RWMutex
+	onEnter   func(state string)
 }
 
 // NewStateMachine return a new state machine
@@ -61,6 +62,36 @@ func (m *StateMachine) GetState() string {
 	return m.state
 }
 
+// SetEnterHook set the function called each time a rule matched and the machine entered its dst state,
+// the hook is called before the rule handler
+func (m *StateMachine) SetEnterHook(hook func(state string)) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.onEnter = hook
+}
+
+// GetPath return states the machine has passed through since last reset
+func (m *StateMachine) GetPath() []string {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return append([]string{}, m.path...)
+}
+
+// Restore put state machine to the given state with history path, used to resume a persisted machine
+func (m *StateMachine) Restore(state string, path []string, pathGraph string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.state = state
+	m.path = append([]string{}, path...)
+	m.pathGraph = pathGraph
+}
+
+func (m *StateMachine) getEnterHook() func(state string) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.onEnter
+}
+
 // Reset reset state machine
 func (m *StateMachine) Reset() {
 	m.lock.Lock()
@@ -124,5 +155,8 @@ func (m *StateMachine) Trigger(event string, args ...interface{}) (string, RespC
 		return "", OrderMix, errors.New("rule match error, change order may mixed")
 	}
 	m.changeState(rule.Dst)
+	if hook := m.getEnterHook(); hook != nil {
+		hook(rule.Dst)
+	}
 	return rule.Handler()
 }
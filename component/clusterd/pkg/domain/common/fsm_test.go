@@ -98,3 +98,35 @@ func TestTrigger(t *testing.T) {
 		})
 	})
 }
+
+func TestEnterHook(t *testing.T) {
+	convey.Convey("Test TestEnterHook", t, func() {
+		sm := NewStateMachine(InitState, getFakeRules())
+		var entered []string
+		sm.SetEnterHook(func(state string) {
+			entered = append(entered, state)
+		})
+		convey.Convey("hook called with dst state when rule match", func() {
+			_, _, err := sm.Trigger("event1")
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(entered, convey.ShouldResemble, []string{"state1"})
+		})
+		convey.Convey("hook not called when rule not match", func() {
+			_, _, err := sm.Trigger("event2")
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(len(entered), convey.ShouldEqual, 0)
+		})
+	})
+}
+
+func TestRestore(t *testing.T) {
+	convey.Convey("Test TestRestore", t, func() {
+		sm := NewStateMachine(InitState, getFakeRules())
+		graph := fmt.Sprintf("%s(%s)-->%s", InitState, "event1", "state1")
+		sm.Restore("state1", []string{InitState, "state1"}, graph)
+		convey.So(sm.GetState(), convey.ShouldEqual, "state1")
+		convey.So(sm.GetPath(), convey.ShouldResemble, []string{InitState, "state1"})
+		convey.So(sm.GetPathGraph(), convey.ShouldEqual, graph)
+		convey.So(sm.RuleCheck(sm.GetState(), "event2"), convey.ShouldBeTrue)
+	})
+}
@@ -68,3 +68,28 @@ type RecoverResult struct {
 
 // StressTestParam node -> rank -> ops
 type StressTestParam map[string]map[string][]int64
+
+// RecoverFault fault rank cached by recover controller
+type RecoverFault struct {
+	RankId    string `json:"rankId"`
+	FaultType string `json:"faultType"`
+}
+
+// RecoverInfo recover state of a job, snapshot of its event controller
+type RecoverInfo struct {
+	JobId            string            `json:"jobId"`
+	Uuid             string            `json:"uuid,omitempty"`
+	State            string            `json:"state"`
+	Path             []string          `json:"path,omitempty"`
+	PathGraph        string            `json:"pathGraph,omitempty"`
+	HealthState      string            `json:"healthState,omitempty"`
+	PlatStrategy     string            `json:"platStrategy,omitempty"`
+	LatestStrategy   []string          `json:"latestStrategy,omitempty"`
+	AgentStrategies  []string          `json:"agentStrategies,omitempty"`
+	ConfigStrategies []string          `json:"configStrategies,omitempty"`
+	FaultPod         map[string]string `json:"faultPod,omitempty"`
+	NormalFaults     []RecoverFault    `json:"normalFaults,omitempty"`
+	RetryFaults      []RecoverFault    `json:"retryFaults,omitempty"`
+	IsolateNodes     []string          `json:"isolateNodes,omitempty"`
+	RecoverStartTime int64             `json:"recoverStartTime,omitempty"`
+}
@@ -0,0 +1,185 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package conf global config base func
+package conf
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"clusterd/pkg/common/constant"
+	"clusterd/pkg/domain/common"
+)
+
+const (
+	// RetryFaultType fault type of recovery which only has faults can be fixed by step retry, such as uce fault
+	RetryFaultType = "retry"
+	// NormalFaultType fault type of recovery which has other faults
+	NormalFaultType = "normal"
+	// MaxRecoverRules maximum rules of recover strategy policy
+	MaxRecoverRules = 100
+	// MaxRetryBudget maximum recover attempts of one recovery
+	MaxRetryBudget = 20
+	// MinStateTimeoutSeconds minimum timeout of waiting report in a state
+	MinStateTimeoutSeconds = 30
+	// MaxStateTimeoutSeconds maximum timeout of waiting report in a state
+	MaxStateTimeoutSeconds = 7200
+)
+
+var (
+	policyStrategies = []string{constant.ProcessRetryStrategyName, constant.ProcessRecoverInPlaceStrategyName,
+		constant.ProcessRecoverStrategyName, constant.ElasticTrainingStrategyName, constant.ProcessDumpStrategyName,
+		constant.ProcessExitStrategyName}
+	fallbackStrategies = []string{constant.ProcessDumpStrategyName, constant.ProcessExitStrategyName}
+	policyFaultTypes   = []string{RetryFaultType, NormalFaultType}
+	// timeoutStates the states waiting report of training which timeout can be configured
+	timeoutStates = []string{common.WaitReportStopCompleteState, common.WaitReportRecoverStrategyState,
+		common.WaitReportStepRetryStatusState, common.WaitReportProcessRecoverStatusState,
+		common.WaitReportDumpStatusState, common.WaitReportScaleInIsolateRanksState,
+		common.WaitReportScaleInStatusState, common.WaitReportScaleOutStatusState}
+
+	recoverPolicy     RecoverStrategyPolicy
+	recoverPolicyLock sync.RWMutex
+)
+
+// RecoverStrategyPolicy recover strategy policy config, the first rule matching the job and fault type is used.
+// Recover controller takes the built-in strategy choice when no rule matches
+type RecoverStrategyPolicy struct {
+	Rules []RecoverStrategyRule `yaml:"rules"`
+}
+
+// RecoverStrategyRule strategies to try for the jobs and faults matching the rule
+type RecoverStrategyRule struct {
+	Name string `yaml:"name"`
+	// Namespaces namespaces of job, empty means all namespaces
+	Namespaces []string `yaml:"namespaces"`
+	// JobLabels labels must be all on the podgroup of job
+	JobLabels map[string]string `yaml:"job_labels"`
+	// FaultTypes retry or normal, empty means all fault types
+	FaultTypes []string `yaml:"fault_types"`
+	// Strategies ordered strategies to try, a strategy is skipped if the job does not support it
+	Strategies []string `yaml:"strategies"`
+	// RetryBudget maximum recover attempts of one recovery, 0 means no limit
+	RetryBudget int `yaml:"retry_budget"`
+	// Fallback dump or exit, taken when no strategy can be tried, the job is rescheduled after it. default exit
+	Fallback string `yaml:"fallback"`
+	// StateTimeoutSeconds timeout of waiting report in the states, default 15 minutes
+	StateTimeoutSeconds map[string]int `yaml:"state_timeout_seconds"`
+}
+
+// SetRecoverStrategyPolicy set recover strategy policy config, it only takes effect on new recoveries
+func SetRecoverStrategyPolicy(policy RecoverStrategyPolicy) {
+	recoverPolicyLock.Lock()
+	defer recoverPolicyLock.Unlock()
+	recoverPolicy = policy
+}
+
+// GetRecoverStrategyPolicy get recover strategy policy config, the result should not be modified
+func GetRecoverStrategyPolicy() RecoverStrategyPolicy {
+	recoverPolicyLock.RLock()
+	defer recoverPolicyLock.RUnlock()
+	return recoverPolicy
+}
+
+// MatchJob get the rules matching namespace and labels of job, in order of the policy
+func (p RecoverStrategyPolicy) MatchJob(namespace string, labels map[string]string) []RecoverStrategyRule {
+	var rules []RecoverStrategyRule
+	for _, rule := range p.Rules {
+		if len(rule.Namespaces) > 0 && !slices.Contains(rule.Namespaces, namespace) {
+			continue
+		}
+		matched := true
+		for key, value := range rule.JobLabels {
+			if labels[key] != value {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// MatchFaultType check whether the rule matches fault type of recovery
+func (r RecoverStrategyRule) MatchFaultType(faultType string) bool {
+	return len(r.FaultTypes) == 0 || slices.Contains(r.FaultTypes, faultType)
+}
+
+// GetFallback get fallback strategy of the rule
+func (r RecoverStrategyRule) GetFallback() string {
+	if r.Fallback == "" {
+		return constant.ProcessExitStrategyName
+	}
+	return r.Fallback
+}
+
+// CheckRecoverStrategyPolicy check recover strategy policy config
+func CheckRecoverStrategyPolicy(policy RecoverStrategyPolicy) error {
+	if len(policy.Rules) > MaxRecoverRules {
+		return fmt.Errorf("rules count %d exceeds %d", len(policy.Rules), MaxRecoverRules)
+	}
+	names := make(map[string]struct{}, len(policy.Rules))
+	for i, rule := range policy.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("name of rule %d is empty", i)
+		}
+		if _, ok := names[rule.Name]; ok {
+			return fmt.Errorf("rule name %s is duplicated", rule.Name)
+		}
+		names[rule.Name] = struct{}{}
+		if err := checkRecoverStrategyRule(rule); err != nil {
+			return fmt.Errorf("rule %s is invalid, %v", rule.Name, err)
+		}
+	}
+	return nil
+}
+
+func checkRecoverStrategyRule(rule RecoverStrategyRule) error {
+	for _, faultType := range rule.FaultTypes {
+		if !slices.Contains(policyFaultTypes, faultType) {
+			return fmt.Errorf("fault type %s should be one of %v", faultType, policyFaultTypes)
+		}
+	}
+	if len(rule.Strategies) == 0 {
+		return fmt.Errorf("strategies is empty")
+	}
+	for i, strategy := range rule.Strategies {
+		if !slices.Contains(policyStrategies, strategy) {
+			return fmt.Errorf("strategy %s should be one of %v", strategy, policyStrategies)
+		}
+		if slices.Contains(rule.Strategies[:i], strategy) {
+			return fmt.Errorf("strategy %s is duplicated", strategy)
+		}
+	}
+	if rule.RetryBudget < 0 || rule.RetryBudget > MaxRetryBudget {
+		return fmt.Errorf("retry_budget must be in [0, %d]", MaxRetryBudget)
+	}
+	if rule.Fallback != "" && !slices.Contains(fallbackStrategies, rule.Fallback) {
+		return fmt.Errorf("fallback %s should be one of %v", rule.Fallback, fallbackStrategies)
+	}
+	for state, timeout := range rule.StateTimeoutSeconds {
+		if !slices.Contains(timeoutStates, state) {
+			return fmt.Errorf("timeout of state %s can not be configured, should be one of %v", state, timeoutStates)
+		}
+		if timeout < MinStateTimeoutSeconds || timeout > MaxStateTimeoutSeconds {
+			return fmt.Errorf("timeout of state %s must be in [%d, %d]", state, MinStateTimeoutSeconds,
+				MaxStateTimeoutSeconds)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package conf test for recover strategy policy
+package conf
+
+import (
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+
+	"clusterd/pkg/common/constant"
+	"clusterd/pkg/domain/common"
+)
+
+const (
+	testNamespace   = "team-a"
+	testRetryBudget = 3
+	testTimeout     = 600
+)
+
+func validRecoverRule() RecoverStrategyRule {
+	return RecoverStrategyRule{
+		Name:                "llm",
+		Namespaces:          []string{testNamespace},
+		JobLabels:           map[string]string{"app": "llm"},
+		FaultTypes:          []string{RetryFaultType},
+		Strategies:          []string{constant.ProcessRetryStrategyName, constant.ProcessRecoverStrategyName},
+		RetryBudget:         testRetryBudget,
+		Fallback:            constant.ProcessDumpStrategyName,
+		StateTimeoutSeconds: map[string]int{common.WaitReportProcessRecoverStatusState: testTimeout},
+	}
+}
+
+func TestCheckRecoverStrategyPolicy(t *testing.T) {
+	convey.Convey("test func 'CheckRecoverStrategyPolicy'", t, func() {
+		convey.So(CheckRecoverStrategyPolicy(RecoverStrategyPolicy{}), convey.ShouldBeNil)
+		convey.So(CheckRecoverStrategyPolicy(RecoverStrategyPolicy{Rules: []RecoverStrategyRule{validRecoverRule()}}),
+			convey.ShouldBeNil)
+		invalidCases := []func(rule *RecoverStrategyRule){
+			func(rule *RecoverStrategyRule) { rule.Name = "" },
+			func(rule *RecoverStrategyRule) { rule.FaultTypes = []string{"uce"} },
+			func(rule *RecoverStrategyRule) { rule.Strategies = nil },
+			func(rule *RecoverStrategyRule) { rule.Strategies = []string{constant.ScaleInStrategyName} },
+			func(rule *RecoverStrategyRule) {
+				rule.Strategies = []string{constant.ProcessDumpStrategyName, constant.ProcessDumpStrategyName}
+			},
+			func(rule *RecoverStrategyRule) { rule.RetryBudget = MaxRetryBudget + 1 },
+			func(rule *RecoverStrategyRule) { rule.Fallback = constant.ProcessRecoverStrategyName },
+			func(rule *RecoverStrategyRule) {
+				rule.StateTimeoutSeconds = map[string]int{common.InitState: testTimeout}
+			},
+			func(rule *RecoverStrategyRule) {
+				rule.StateTimeoutSeconds = map[string]int{common.WaitReportDumpStatusState: MinStateTimeoutSeconds - 1}
+			},
+		}
+		for _, modify := range invalidCases {
+			rule := validRecoverRule()
+			modify(&rule)
+			convey.So(CheckRecoverStrategyPolicy(RecoverStrategyPolicy{Rules: []RecoverStrategyRule{rule}}),
+				convey.ShouldNotBeNil)
+		}
+		convey.So(CheckRecoverStrategyPolicy(RecoverStrategyPolicy{Rules: []RecoverStrategyRule{validRecoverRule(),
+			validRecoverRule()}}), convey.ShouldNotBeNil)
+	})
+}
+
+func TestMatchJob(t *testing.T) {
+	convey.Convey("test method 'MatchJob' and 'MatchFaultType'", t, func() {
+		defaultRule := RecoverStrategyRule{Name: "default", Strategies: []string{constant.ProcessExitStrategyName}}
+		policy := RecoverStrategyPolicy{Rules: []RecoverStrategyRule{validRecoverRule(), defaultRule}}
+		rules := policy.MatchJob(testNamespace, map[string]string{"app": "llm", "team": "a"})
+		convey.So(len(rules), convey.ShouldEqual, 2)
+		convey.So(rules[0].MatchFaultType(RetryFaultType), convey.ShouldBeTrue)
+		convey.So(rules[0].MatchFaultType(NormalFaultType), convey.ShouldBeFalse)
+		convey.So(rules[1].MatchFaultType(NormalFaultType), convey.ShouldBeTrue)
+		rules = policy.MatchJob(testNamespace, map[string]string{"app": "cv"})
+		convey.So(len(rules), convey.ShouldEqual, 1)
+		convey.So(rules[0].GetFallback(), convey.ShouldEqual, constant.ProcessExitStrategyName)
+		convey.So(len(policy.MatchJob("team-b", nil)), convey.ShouldEqual, 1)
+	})
+}
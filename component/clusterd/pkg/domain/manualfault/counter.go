@@ -16,12 +16,24 @@
 package manualfault
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
+	"k8s.io/api/core/v1"
+
 	"ascend-common/common-utils/hwlog"
 	"clusterd/pkg/common/constant"
+	"clusterd/pkg/common/notifier"
 	"clusterd/pkg/domain/conf"
+	"clusterd/pkg/interface/kube"
+)
+
+const (
+	// ReasonManuallySeparated reason of node event when npu is manually separated for frequent faults
+	ReasonManuallySeparated = "NPUManuallySeparated"
+	// ReasonSeparationReleased reason of node event when manually separated npu is released
+	ReasonSeparationReleased = "NPUSeparationReleased"
 )
 
 // Counter tn instance of FaultCounter
@@ -127,6 +139,18 @@ func (c *FaultCounter) dealFrequencyFault(fault FaultInfo) {
 		fault.NodeName, fault.DevName, fault.FaultCode)
 	c.clearDevFault(fault.NodeName, fault.DevName, fault.FaultCode)
 	FaultCmInfo.AddSeparateDev(fault)
+	kube.RecordNodeEvent(fault.NodeName, v1.EventTypeWarning, ReasonManuallySeparated,
+		fmt.Sprintf("%s is manually separated, fault code %s reaches frequency threshold", fault.DevName,
+			fault.FaultCode))
+	notifier.Notify(notifier.Notification{
+		Kind:      notifier.KindNPUSeparated,
+		Severity:  notifier.SeverityWarning,
+		Node:      fault.NodeName,
+		Device:    fault.DevName,
+		FaultCode: fault.FaultCode,
+		Reason:    ReasonManuallySeparated,
+		Message:   "fault code reaches frequency threshold",
+	})
 }
 
 func logCounterFault(fault FaultInfo, times []int64) {
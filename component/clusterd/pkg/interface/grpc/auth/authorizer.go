@@ -0,0 +1,294 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package auth authentication and authorization of clusterd grpc services
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"ascend-common/common-utils/hwlog"
+	"ascend-common/common-utils/utils"
+	"clusterd/pkg/domain/job"
+	"clusterd/pkg/interface/kube"
+)
+
+const (
+	// DefaultTokenAudience default audience of service account token sent to clusterd
+	DefaultTokenAudience = "clusterd"
+	authorizationKey     = "authorization"
+	bearerPrefix         = "Bearer "
+	tokenCacheTTL        = time.Minute
+	maxTokenCacheSize    = 1000
+	maxPolicyFileSize    = 1024 * 1024
+	jobNsNameParts       = 2
+)
+
+type tokenEntry struct {
+	username string
+	expire   time.Time
+}
+
+// Authorizer authenticate the clients by certificate or service account token, authorize them by policy file,
+// the policy file is reloaded when modified
+type Authorizer struct {
+	policyFile string
+	audiences  []string
+	lock       sync.RWMutex
+	policy     *Policy
+	version    string
+	tokenLock  sync.Mutex
+	tokens     map[string]tokenEntry
+}
+
+// NewAuthorizer load policy file, tokens are reviewed with the audience
+func NewAuthorizer(policyFile, audience string) (*Authorizer, error) {
+	a := &Authorizer{
+		policyFile: policyFile,
+		audiences:  []string{audience},
+		tokens:     make(map[string]tokenEntry),
+	}
+	if err := a.load(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *Authorizer) load() error {
+	version := utils.MountedFileVersion(a.policyFile)
+	data, err := utils.ReadMountedFile(a.policyFile, maxPolicyFileSize)
+	if err != nil {
+		return fmt.Errorf("read policy file failed: %v", err)
+	}
+	policy, err := ParsePolicy(data)
+	if err != nil {
+		return err
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.policy = policy
+	a.version = version
+	return nil
+}
+
+func (a *Authorizer) getPolicy() *Policy {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	return a.policy
+}
+
+func (a *Authorizer) changed() bool {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	return utils.MountedFileVersion(a.policyFile) != a.version
+}
+
+// Watch reload policy file when it is modified until ctx done, the old policy is kept if the new one is invalid
+func (a *Authorizer) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !a.changed() {
+				continue
+			}
+			if err := a.load(); err != nil {
+				hwlog.RunLog.Errorf("reload grpc authorization policy failed, keep using the old one, err: %v", err)
+				continue
+			}
+			hwlog.RunLog.Infof("grpc authorization policy %s reloaded", a.policyFile)
+		}
+	}
+}
+
+// certIdentities get common name, dns names and uris of the verified client certificate
+func certIdentities(ctx context.Context) []string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.AuthInfo == nil {
+		return nil
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	leaf := tlsInfo.State.VerifiedChains[0][0]
+	var identities []string
+	if leaf.Subject.CommonName != "" {
+		identities = append(identities, leaf.Subject.CommonName)
+	}
+	identities = append(identities, leaf.DNSNames...)
+	for _, uri := range leaf.URIs {
+		identities = append(identities, uri.String())
+	}
+	return identities
+}
+
+func bearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, value := range md.Get(authorizationKey) {
+		if strings.HasPrefix(value, bearerPrefix) {
+			return strings.TrimSpace(strings.TrimPrefix(value, bearerPrefix))
+		}
+	}
+	return ""
+}
+
+// tokenIdentity get username of service account token, the reviewed tokens are cached for a while
+func (a *Authorizer) tokenIdentity(token string) (string, error) {
+	sum := sha256.Sum256([]byte(token))
+	key := hex.EncodeToString(sum[:])
+	now := time.Now()
+	a.tokenLock.Lock()
+	entry, ok := a.tokens[key]
+	a.tokenLock.Unlock()
+	if ok && now.Before(entry.expire) {
+		return entry.username, nil
+	}
+	result, err := kube.ReviewToken(token, a.audiences)
+	if err != nil {
+		return "", fmt.Errorf("review token failed: %v", err)
+	}
+	if !result.Authenticated {
+		return "", fmt.Errorf("token is not authenticated: %s", result.Error)
+	}
+	a.tokenLock.Lock()
+	defer a.tokenLock.Unlock()
+	if len(a.tokens) >= maxTokenCacheSize {
+		for k, v := range a.tokens {
+			if now.After(v.expire) {
+				delete(a.tokens, k)
+			}
+		}
+	}
+	if len(a.tokens) < maxTokenCacheSize {
+		a.tokens[key] = tokenEntry{username: result.User.Username, expire: now.Add(tokenCacheTTL)}
+	}
+	return result.User.Username, nil
+}
+
+// identities authenticate the client, by certificate first and then by bearer token
+func (a *Authorizer) identities(ctx context.Context) ([]string, error) {
+	identities := certIdentities(ctx)
+	if token := bearerToken(ctx); token != "" {
+		username, err := a.tokenIdentity(token)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, username)
+	}
+	if len(identities) == 0 {
+		return nil, errors.New("neither client certificate nor bearer token is provided")
+	}
+	return identities, nil
+}
+
+type jobIdGetter interface {
+	GetJobId() string
+}
+
+type jobIDGetter interface {
+	GetJobID() string
+}
+
+type jobNsNameGetter interface {
+	GetJobNsName() string
+}
+
+// jobNamespace get namespace of the job referred by request, return false if request does not refer to a job.
+// Namespace is empty if the job is unknown
+func jobNamespace(req interface{}) (bool, string) {
+	var jobId string
+	switch r := req.(type) {
+	case jobIdGetter:
+		jobId = r.GetJobId()
+	case jobIDGetter:
+		jobId = r.GetJobID()
+	case jobNsNameGetter:
+		parts := strings.SplitN(r.GetJobNsName(), "/", jobNsNameParts)
+		return true, parts[0]
+	default:
+		return false, ""
+	}
+	jobInfo, ok := job.GetJobCache(jobId)
+	if !ok {
+		return true, ""
+	}
+	return true, jobInfo.NameSpace
+}
+
+func (a *Authorizer) authorize(identities []string, fullMethod string, req interface{}) error {
+	jobRef, namespace := jobNamespace(req)
+	if err := a.getPolicy().Authorize(identities, fullMethod, jobRef, namespace); err != nil {
+		hwlog.RunLog.Warnf("grpc request denied, err: %v", err)
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return nil
+}
+
+func (a *Authorizer) authenticate(ctx context.Context, fullMethod string) ([]string, error) {
+	identities, err := a.identities(ctx)
+	if err != nil {
+		hwlog.RunLog.Warnf("grpc request of %s is not authenticated, err: %v", fullMethod, err)
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return identities, nil
+}
+
+// UnaryInterceptor authorize unary requests
+func (a *Authorizer) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	identities, err := a.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	if err = a.authorize(identities, info.FullMethod, req); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// authorizedStream authorize the request of server stream when it is received
+type authorizedStream struct {
+	grpc.ServerStream
+	authorizer *Authorizer
+	fullMethod string
+	identities []string
+}
+
+// RecvMsg receive request then authorize it
+func (s *authorizedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.authorizer.authorize(s.identities, s.fullMethod, m)
+}
+
+// StreamInterceptor authorize stream requests
+func (a *Authorizer) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	identities, err := a.authenticate(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authorizedStream{ServerStream: ss, authorizer: a, fullMethod: info.FullMethod,
+		identities: identities})
+}
@@ -0,0 +1,207 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package auth test for authorizer
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	authv1 "k8s.io/api/authentication/v1"
+
+	"clusterd/pkg/common/constant"
+	"clusterd/pkg/domain/job"
+	"clusterd/pkg/interface/grpc/profiling"
+	"clusterd/pkg/interface/grpc/pubfault"
+	"clusterd/pkg/interface/grpc/recover"
+	"clusterd/pkg/interface/kube"
+)
+
+const (
+	testJobId  = "job-uid"
+	testToken  = "test-token"
+	policyMode = 0600
+	waitTime   = 50 * time.Millisecond
+)
+
+func newTestAuthorizer(t *testing.T) (*Authorizer, string) {
+	policyFile := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(policyFile, []byte(testPolicy), policyMode); err != nil {
+		t.Fatalf("write policy failed: %v", err)
+	}
+	a, err := NewAuthorizer(policyFile, DefaultTokenAudience)
+	if err != nil {
+		t.Fatalf("new authorizer failed: %v", err)
+	}
+	return a, policyFile
+}
+
+func certContext(commonName string) context.Context {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: commonName}}
+	state := tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+}
+
+func tokenContext(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(authorizationKey, bearerPrefix+token))
+}
+
+func TestJobNamespace(t *testing.T) {
+	convey.Convey("test func 'jobNamespace'", t, func() {
+		patches := gomonkey.ApplyFunc(job.GetJobCache, func(jobKey string) (constant.JobInfo, bool) {
+			return constant.JobInfo{NameSpace: teamA}, jobKey == testJobId
+		})
+		defer patches.Reset()
+		jobRef, ns := jobNamespace(&pubfault.PublicFaultRequest{})
+		convey.So(jobRef, convey.ShouldBeFalse)
+		jobRef, ns = jobNamespace(&pb.ClientInfo{JobId: testJobId})
+		convey.So(jobRef, convey.ShouldBeTrue)
+		convey.So(ns, convey.ShouldEqual, teamA)
+		jobRef, ns = jobNamespace(&pb.SwitchNicRequest{JobID: "unknown"})
+		convey.So(jobRef, convey.ShouldBeTrue)
+		convey.So(ns, convey.ShouldBeEmpty)
+		jobRef, ns = jobNamespace(&profiling.DataTypeReq{JobNsName: "team-b/job"})
+		convey.So(jobRef, convey.ShouldBeTrue)
+		convey.So(ns, convey.ShouldEqual, "team-b")
+	})
+}
+
+func TestIdentities(t *testing.T) {
+	convey.Convey("test method 'identities'", t, func() {
+		a, _ := newTestAuthorizer(t)
+		reviewCount := 0
+		patches := gomonkey.ApplyFunc(kube.ReviewToken, func(token string, _ []string) (*authv1.TokenReviewStatus,
+			error) {
+			reviewCount++
+			if token != testToken {
+				return &authv1.TokenReviewStatus{Error: "invalid token"}, nil
+			}
+			return &authv1.TokenReviewStatus{Authenticated: true, User: authv1.UserInfo{Username: teamAUser}}, nil
+		})
+		defer patches.Reset()
+		convey.Convey("client certificate should be identity", func() {
+			identities, err := a.identities(certContext("noded"))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(identities, convey.ShouldResemble, []string{"noded"})
+		})
+		convey.Convey("reviewed token should be cached", func() {
+			identities, err := a.identities(tokenContext(testToken))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(identities, convey.ShouldResemble, []string{teamAUser})
+			_, err = a.identities(tokenContext(testToken))
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(reviewCount, convey.ShouldEqual, 1)
+		})
+		convey.Convey("invalid token or no credential should fail", func() {
+			_, err := a.identities(tokenContext("invalid"))
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = a.identities(context.Background())
+			convey.So(err, convey.ShouldNotBeNil)
+			failPatch := gomonkey.ApplyFuncReturn(kube.ReviewToken, (*authv1.TokenReviewStatus)(nil),
+				errors.New("review failed"))
+			defer failPatch.Reset()
+			_, err = a.identities(tokenContext("another"))
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	convey.Convey("test method 'UnaryInterceptor'", t, func() {
+		a, _ := newTestAuthorizer(t)
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "ok", nil
+		}
+		info := &grpc.UnaryServerInfo{FullMethod: sendPublicFault}
+		resp, err := a.UnaryInterceptor(certContext("noded"), &pubfault.PublicFaultRequest{}, info, handler)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(resp, convey.ShouldEqual, "ok")
+		_, err = a.UnaryInterceptor(context.Background(), &pubfault.PublicFaultRequest{}, info, handler)
+		convey.So(status.Code(err), convey.ShouldEqual, codes.Unauthenticated)
+		info.FullMethod = stressTest
+		_, err = a.UnaryInterceptor(certContext("noded"), &pb.StressTestParam{JobID: testJobId}, info, handler)
+		convey.So(status.Code(err), convey.ShouldEqual, codes.PermissionDenied)
+	})
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	req *pb.ClientInfo
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeServerStream) RecvMsg(m interface{}) error {
+	info, ok := m.(*pb.ClientInfo)
+	if !ok {
+		return errors.New("unexpected message")
+	}
+	info.JobId = s.req.JobId
+	return nil
+}
+
+func TestStreamInterceptor(t *testing.T) {
+	convey.Convey("test method 'StreamInterceptor'", t, func() {
+		a, _ := newTestAuthorizer(t)
+		patches := gomonkey.ApplyFuncReturn(job.GetJobCache, constant.JobInfo{NameSpace: teamA}, true).
+			ApplyFuncReturn(kube.ReviewToken, &authv1.TokenReviewStatus{Authenticated: true,
+				User: authv1.UserInfo{Username: teamAUser}}, nil)
+		defer patches.Reset()
+		handler := func(srv interface{}, stream grpc.ServerStream) error {
+			return stream.RecvMsg(&pb.ClientInfo{})
+		}
+		info := &grpc.StreamServerInfo{FullMethod: "/Recover/SubscribeProcessManageSignal"}
+		stream := &fakeServerStream{ctx: tokenContext(testToken), req: &pb.ClientInfo{JobId: testJobId}}
+		convey.So(a.StreamInterceptor(nil, stream, info, handler), convey.ShouldBeNil)
+		otherNsPatch := gomonkey.ApplyFuncReturn(job.GetJobCache, constant.JobInfo{NameSpace: "team-b"}, true)
+		defer otherNsPatch.Reset()
+		err := a.StreamInterceptor(nil, stream, info, handler)
+		convey.So(status.Code(err), convey.ShouldEqual, codes.PermissionDenied)
+		stream.ctx = context.Background()
+		err = a.StreamInterceptor(nil, stream, info, handler)
+		convey.So(status.Code(err), convey.ShouldEqual, codes.Unauthenticated)
+	})
+}
+
+func TestWatch(t *testing.T) {
+	convey.Convey("test method 'Watch'", t, func() {
+		a, policyFile := newTestAuthorizer(t)
+		convey.So(a.changed(), convey.ShouldBeFalse)
+		runWatch := func() {
+			ctx, cancel := context.WithTimeout(context.Background(), waitTime)
+			defer cancel()
+			a.Watch(ctx, time.Millisecond)
+		}
+		convey.Convey("invalid policy should keep the old one", func() {
+			convey.So(os.WriteFile(policyFile, []byte("rules: invalid"), policyMode), convey.ShouldBeNil)
+			runWatch()
+			convey.So(len(a.getPolicy().Rules), convey.ShouldEqual, 3)
+		})
+		convey.Convey("modified policy should be reloaded", func() {
+			time.Sleep(time.Millisecond)
+			newPolicy := "rules:\n  - identities: [noded]\n    methods: ['*']\n"
+			convey.So(os.WriteFile(policyFile, []byte(newPolicy), policyMode), convey.ShouldBeNil)
+			runWatch()
+			convey.So(len(a.getPolicy().Rules), convey.ShouldEqual, 1)
+			convey.So(a.changed(), convey.ShouldBeFalse)
+		})
+	})
+}
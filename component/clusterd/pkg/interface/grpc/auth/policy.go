@@ -0,0 +1,138 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package auth authentication and authorization of clusterd grpc services
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	wildcard = "*"
+	// maxRules the max number of rules in policy
+	maxRules = 1000
+)
+
+// Rule grant the clients matching any of the identities to call the methods, on the jobs of the namespaces.
+//
+// Identity is the common name, dns name or uri of client certificate, or the username of service account token,
+// such as "system:serviceaccount:mindx-dl:noded". Ending with "*" matches the prefix.
+//
+// Method is a service name such as "Recover", or a full method such as "/Recover/StressTest", "*" matches all.
+//
+// Namespaces restrict the jobs of requests carrying job id or job name, "*" matches all. A rule without
+// namespaces only allows the requests which do not refer to a job, such as SendPublicFault
+type Rule struct {
+	Name       string   `yaml:"name"`
+	Identities []string `yaml:"identities"`
+	Methods    []string `yaml:"methods"`
+	Namespaces []string `yaml:"namespaces"`
+}
+
+// Policy authorization policy of grpc services, a request is allowed if any rule allows it
+type Policy struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// ParsePolicy parse and check policy in yaml
+func ParsePolicy(data []byte) (*Policy, error) {
+	policy := &Policy{}
+	if err := yaml.UnmarshalStrict(data, policy); err != nil {
+		return nil, fmt.Errorf("unmarshal policy failed: %v", err)
+	}
+	if err := policy.check(); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (p *Policy) check() error {
+	if len(p.Rules) > maxRules {
+		return fmt.Errorf("the number of rules exceeds %d", maxRules)
+	}
+	for i, rule := range p.Rules {
+		if len(rule.Identities) == 0 {
+			return fmt.Errorf("rule %d(%s) has no identity", i, rule.Name)
+		}
+		if len(rule.Methods) == 0 {
+			return fmt.Errorf("rule %d(%s) has no method", i, rule.Name)
+		}
+		for _, identity := range rule.Identities {
+			if identity == "" || identity == wildcard {
+				return fmt.Errorf("rule %d(%s) has empty or match-all identity", i, rule.Name)
+			}
+		}
+	}
+	return nil
+}
+
+func matchPattern(pattern, value string) bool {
+	if strings.HasSuffix(pattern, wildcard) {
+		return strings.HasPrefix(value, strings.TrimSuffix(pattern, wildcard))
+	}
+	return pattern == value
+}
+
+func (r *Rule) matchIdentity(identities []string) bool {
+	for _, pattern := range r.Identities {
+		for _, identity := range identities {
+			if matchPattern(pattern, identity) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchMethod full method format is /service/method
+func (r *Rule) matchMethod(fullMethod string) bool {
+	service := strings.TrimPrefix(fullMethod, "/")
+	if idx := strings.LastIndex(service, "/"); idx >= 0 {
+		service = service[:idx]
+	}
+	for _, method := range r.Methods {
+		if method == wildcard || method == service || method == fullMethod {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) matchNamespace(namespace string) bool {
+	for _, ns := range r.Namespaces {
+		if ns == wildcard || ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// Authorize check whether the identities can call the method. jobRef is false if request does not refer to a job,
+// otherwise namespace is the namespace of the job, which is empty when the job is unknown
+func (p *Policy) Authorize(identities []string, fullMethod string, jobRef bool, namespace string) error {
+	if p == nil {
+		return errors.New("authorization policy is nil")
+	}
+	if len(identities) == 0 {
+		return errors.New("client is not authenticated")
+	}
+	methodAllowed := false
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if !rule.matchIdentity(identities) || !rule.matchMethod(fullMethod) {
+			continue
+		}
+		methodAllowed = true
+		if !jobRef || rule.matchNamespace(namespace) {
+			return nil
+		}
+	}
+	if !methodAllowed {
+		return fmt.Errorf("%v is not allowed to call %s", identities, fullMethod)
+	}
+	return fmt.Errorf("%v is not allowed to call %s on jobs of namespace <%s>", identities, fullMethod, namespace)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package auth test for authorization policy
+package auth
+
+import (
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+const (
+	testPolicy = `
+rules:
+  - name: noded
+    identities: ["noded"]
+    methods: ["PubFault"]
+  - name: team-a
+    identities: ["system:serviceaccount:team-a:*"]
+    methods: ["Recover", "/job.Job/Register"]
+    namespaces: ["team-a"]
+  - name: operator
+    identities: ["spiffe://cluster.local/ns/mindx-dl/sa/ascend-operator"]
+    methods: ["*"]
+    namespaces: ["*"]
+`
+	sendPublicFault = "/PubFault/SendPublicFault"
+	stressTest      = "/Recover/StressTest"
+	teamAUser       = "system:serviceaccount:team-a:default"
+	teamA           = "team-a"
+)
+
+func TestParsePolicy(t *testing.T) {
+	convey.Convey("test func 'ParsePolicy'", t, func() {
+		policy, err := ParsePolicy([]byte(testPolicy))
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(policy.Rules), convey.ShouldEqual, 3)
+		_, err = ParsePolicy([]byte("rules:\n  - identities: [a]\n"))
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = ParsePolicy([]byte("rules:\n  - identities: ['*']\n    methods: ['*']\n"))
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = ParsePolicy([]byte("unknown: true\n"))
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+}
+
+func TestAuthorize(t *testing.T) {
+	convey.Convey("test method 'Authorize'", t, func() {
+		policy, err := ParsePolicy([]byte(testPolicy))
+		convey.So(err, convey.ShouldBeNil)
+		convey.Convey("unauthenticated client should be denied", func() {
+			convey.So(policy.Authorize(nil, sendPublicFault, false, ""), convey.ShouldNotBeNil)
+		})
+		convey.Convey("request without job should only check method", func() {
+			convey.So(policy.Authorize([]string{"noded"}, sendPublicFault, false, ""), convey.ShouldBeNil)
+			convey.So(policy.Authorize([]string{"noded"}, stressTest, false, ""), convey.ShouldNotBeNil)
+		})
+		convey.Convey("rule without namespaces should deny requests on job", func() {
+			convey.So(policy.Authorize([]string{"noded"}, sendPublicFault, true, teamA), convey.ShouldNotBeNil)
+		})
+		convey.Convey("request on job should check namespace", func() {
+			convey.So(policy.Authorize([]string{teamAUser}, stressTest, true, teamA), convey.ShouldBeNil)
+			convey.So(policy.Authorize([]string{teamAUser}, "/job.Job/Register", true, teamA), convey.ShouldBeNil)
+			convey.So(policy.Authorize([]string{teamAUser}, stressTest, true, "team-b"), convey.ShouldNotBeNil)
+			convey.So(policy.Authorize([]string{teamAUser}, stressTest, true, ""), convey.ShouldNotBeNil)
+			convey.So(policy.Authorize([]string{teamAUser}, "/job.Job/SubscribeJobSummarySignal", true, teamA),
+				convey.ShouldNotBeNil)
+		})
+		convey.Convey("match-all rule should allow any method on any job", func() {
+			identities := []string{"ascend-operator", "spiffe://cluster.local/ns/mindx-dl/sa/ascend-operator"}
+			convey.So(policy.Authorize(identities, stressTest, true, ""), convey.ShouldBeNil)
+		})
+	})
+}
@@ -125,6 +125,8 @@ func (server *ClusterInfoMgrServer) Stop(grace bool) {
 	if server.grpcServer == nil {
 		return
 	}
+	// streams closed by stop will reset recover controllers, keep their checkpoints for next clusterd
+	recover.FreezeCheckpoint()
 	if grace {
 		server.grpcServer.GracefulStop()
 	} else {
@@ -0,0 +1,237 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package kube a series of kube function
+package kube
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+
+	"ascend-common/common-utils/hwlog"
+)
+
+const (
+	eventComponent = "clusterd"
+	// DefaultEventQPS default rate of events about one object, one event every 5 seconds
+	DefaultEventQPS = 0.2
+	// DefaultEventBurst default burst of events about one object
+	DefaultEventBurst = 25
+	// similar events about one object, only differ in message, are aggregated after 10 events in 10 minutes
+	eventAggregateMaxEvents   = 10
+	eventAggregateIntervalSec = 600
+	conditionChanLength       = 1024
+	conditionUpdateTimeout    = 10 * time.Second
+)
+
+const (
+	// FaultRecoveryCondition type of AscendJob condition about fault recovery, True while job is recovering
+	FaultRecoveryCondition commonv1.JobConditionType = "FaultRecovery"
+)
+
+// EventConfig config of kubernetes events recorded by clusterd
+type EventConfig struct {
+	// QPS rate of events about one object, events beyond burst are dropped
+	QPS float64
+	// Burst of events about one object
+	Burst int
+}
+
+// Validate check the config of events
+func (c EventConfig) Validate() error {
+	if c.QPS <= 0 || c.QPS > 1 {
+		return fmt.Errorf("event qps %v should be in range (0, 1]", c.QPS)
+	}
+	if c.Burst <= 0 || c.Burst > DefaultEventBurst*2 {
+		return fmt.Errorf("event burst %d should be in range [1, %d]", c.Burst, DefaultEventBurst*2)
+	}
+	return nil
+}
+
+// JobObject identify a job and its pod group that events are about
+type JobObject struct {
+	// Kind of job, AscendJob or volcano Job
+	Kind      string
+	Namespace string
+	Name      string
+	UID       string
+	PgName    string
+}
+
+type jobCondition struct {
+	job       JobObject
+	condition commonv1.JobCondition
+}
+
+var (
+	eventRecorder     record.EventRecorder
+	eventRecorderLock sync.RWMutex
+	conditionChan     chan jobCondition
+)
+
+// InitEventRecorder start to record events of clusterd to kubernetes, events of the same object are rate limited
+// and similar events are aggregated, so that a fault storm does not flood api server
+func InitEventRecorder(ctx context.Context, cfg EventConfig) error {
+	if k8sClient == nil || k8sClient.ClientSet == nil {
+		return fmt.Errorf("k8s client is nil")
+	}
+	broadcaster := record.NewBroadcasterWithCorrelatorOptions(record.CorrelatorOptions{
+		QPS:                  float32(cfg.QPS),
+		BurstSize:            cfg.Burst,
+		MaxEvents:            eventAggregateMaxEvents,
+		MaxIntervalInSeconds: eventAggregateIntervalSec,
+	})
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{
+		Interface: k8sClient.ClientSet.CoreV1().Events(""),
+	})
+	recorder := broadcaster.NewRecorder(nil, v1.EventSource{Component: eventComponent})
+	conditions := make(chan jobCondition, conditionChanLength)
+	eventRecorderLock.Lock()
+	eventRecorder = recorder
+	conditionChan = conditions
+	eventRecorderLock.Unlock()
+	go updateJobConditions(ctx, conditions)
+	go func() {
+		<-ctx.Done()
+		eventRecorderLock.Lock()
+		eventRecorder = nil
+		conditionChan = nil
+		eventRecorderLock.Unlock()
+		broadcaster.Shutdown()
+	}()
+	hwlog.RunLog.Infof("kubernetes event recorder is started, qps=%v, burst=%d", cfg.QPS, cfg.Burst)
+	return nil
+}
+
+func getEventRecorder() record.EventRecorder {
+	eventRecorderLock.RLock()
+	defer eventRecorderLock.RUnlock()
+	return eventRecorder
+}
+
+// jobReference reference of job, kind of job is the kind of pod group owner
+func jobReference(job JobObject) *v1.ObjectReference {
+	ref := &v1.ObjectReference{Kind: job.Kind, Namespace: job.Namespace, Name: job.Name, UID: types.UID(job.UID)}
+	switch job.Kind {
+	case AcJobGVK().Kind:
+		ref.APIVersion = AcJobGVK().GroupVersion().String()
+	case VcJobGVK().Kind:
+		ref.APIVersion = VcJobGVK().GroupVersion().String()
+	default:
+	}
+	return ref
+}
+
+// RecordJobEvent record an event on the job and its pod group, do nothing when recorder is not started
+func RecordJobEvent(job JobObject, eventType, reason, message string) {
+	recorder := getEventRecorder()
+	if recorder == nil {
+		return
+	}
+	if job.Kind != "" && job.Name != "" {
+		recorder.Event(jobReference(job), eventType, reason, message)
+	}
+	if job.PgName != "" {
+		recorder.Event(&v1.ObjectReference{Kind: PodGroupGVK().Kind, APIVersion: PodGroupGVK().GroupVersion().String(),
+			Namespace: job.Namespace, Name: job.PgName}, eventType, reason, message)
+	}
+}
+
+// RecordNodeEvent record an event on the node, do nothing when recorder is not started
+func RecordNodeEvent(nodeName, eventType, reason, message string) {
+	recorder := getEventRecorder()
+	if recorder == nil || nodeName == "" {
+		return
+	}
+	// node events are reported in default namespace with uid of node name, the same as kubelet
+	recorder.Event(&v1.ObjectReference{Kind: "Node", Name: nodeName, UID: types.UID(nodeName)},
+		eventType, reason, message)
+}
+
+// SetJobCondition update the condition of AscendJob status asynchronously, volcano jobs are skipped
+func SetJobCondition(job JobObject, status v1.ConditionStatus, reason, message string) {
+	if job.Kind != AcJobGVK().Kind || job.Name == "" {
+		return
+	}
+	eventRecorderLock.RLock()
+	defer eventRecorderLock.RUnlock()
+	if conditionChan == nil {
+		return
+	}
+	now := metav1.Now()
+	condition := jobCondition{job: job, condition: commonv1.JobCondition{Type: FaultRecoveryCondition,
+		Status: status, Reason: reason, Message: message, LastUpdateTime: now, LastTransitionTime: now}}
+	select {
+	case conditionChan <- condition:
+	default:
+		hwlog.RunLog.Warnf("condition queue is full, drop condition %s=%s of job %s/%s", FaultRecoveryCondition,
+			status, job.Namespace, job.Name)
+	}
+}
+
+func updateJobConditions(ctx context.Context, conditions <-chan jobCondition) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case item := <-conditions:
+			if err := updateAscendJobCondition(item.job, item.condition); err != nil {
+				hwlog.RunLog.Warnf("update condition %s of job %s/%s failed, error: %v", item.condition.Type,
+					item.job.Namespace, item.job.Name, err)
+			}
+		}
+	}
+}
+
+// updateAscendJobCondition set the condition to the status of AscendJob, retry when conflicted
+func updateAscendJobCondition(job JobObject, condition commonv1.JobCondition) error {
+	if operatorClient == nil || operatorClient.ClientSet == nil {
+		return fmt.Errorf("operator client is nil")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), conditionUpdateTimeout)
+	defer cancel()
+	jobs := operatorClient.ClientSet.BatchV1().Jobs(job.Namespace)
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		acJob, err := jobs.Get(ctx, job.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if job.UID != "" && string(acJob.UID) != job.UID {
+			return fmt.Errorf("uid of job is %s, not %s", acJob.UID, job.UID)
+		}
+		if !setCondition(&acJob.Status, condition) {
+			return nil
+		}
+		_, err = jobs.UpdateStatus(ctx, acJob, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// setCondition set the condition into status, transition time is kept when status is not changed.
+// return false when the condition is the same as the existing one
+func setCondition(status *commonv1.JobStatus, condition commonv1.JobCondition) bool {
+	for i, cond := range status.Conditions {
+		if cond.Type != condition.Type {
+			continue
+		}
+		if cond.Status == condition.Status && cond.Reason == condition.Reason && cond.Message == condition.Message {
+			return false
+		}
+		if cond.Status == condition.Status {
+			condition.LastTransitionTime = cond.LastTransitionTime
+		}
+		status.Conditions[i] = condition
+		return true
+	}
+	status.Conditions = append(status.Conditions, condition)
+	return true
+}
@@ -0,0 +1,150 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package kube test for kubernetes events
+package kube
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	commonv1 "github.com/kubeflow/common/pkg/apis/common/v1"
+	"github.com/smartystreets/goconvey/convey"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const (
+	testEventNode    = "node-1"
+	testEventJob     = "job-1"
+	testEventPg      = "pg-1"
+	testEventUID     = "uid-1"
+	testEventWait    = 3 * time.Second
+	testEventPoll    = 50 * time.Millisecond
+	testEventSettled = 300 * time.Millisecond
+)
+
+// startTestRecorder start recorder with a new fake client, the old client is restored when context is canceled
+func startTestRecorder(cfg EventConfig) (*fake.Clientset, context.CancelFunc) {
+	oldClient := k8sClient
+	client := fake.NewSimpleClientset()
+	k8sClient = &K8sClient{ClientSet: client}
+	ctx, cancel := context.WithCancel(context.Background())
+	convey.So(InitEventRecorder(ctx, cfg), convey.ShouldBeNil)
+	return client, func() {
+		cancel()
+		k8sClient = oldClient
+	}
+}
+
+// waitEvents wait until events are sent to fake client and no more events come
+func waitEvents(client *fake.Clientset, namespace string, least int) []v1.Event {
+	deadline := time.Now().Add(testEventWait)
+	for time.Now().Before(deadline) {
+		events, err := client.CoreV1().Events(namespace).List(context.Background(), metav1.ListOptions{})
+		if err == nil && len(events.Items) >= least {
+			time.Sleep(testEventSettled)
+			events, err = client.CoreV1().Events(namespace).List(context.Background(), metav1.ListOptions{})
+			convey.So(err, convey.ShouldBeNil)
+			return events.Items
+		}
+		time.Sleep(testEventPoll)
+	}
+	return nil
+}
+
+func TestEventConfigValidate(t *testing.T) {
+	convey.Convey("test func 'Validate' of event config", t, func() {
+		convey.So(EventConfig{QPS: DefaultEventQPS, Burst: DefaultEventBurst}.Validate(), convey.ShouldBeNil)
+		convey.So(EventConfig{QPS: 0, Burst: DefaultEventBurst}.Validate(), convey.ShouldNotBeNil)
+		convey.So(EventConfig{QPS: DefaultEventQPS, Burst: 0}.Validate(), convey.ShouldNotBeNil)
+	})
+}
+
+func TestRecordEvent(t *testing.T) {
+	convey.Convey("test func 'RecordJobEvent' and 'RecordNodeEvent'", t, func() {
+		convey.Convey("recorder is not started, should do nothing", func() {
+			RecordNodeEvent(testEventNode, v1.EventTypeWarning, "test", "test")
+			convey.So(getEventRecorder(), convey.ShouldBeNil)
+		})
+		convey.Convey("job event is recorded on job and pod group", func() {
+			client, stop := startTestRecorder(EventConfig{QPS: DefaultEventQPS, Burst: DefaultEventBurst})
+			defer stop()
+			job := JobObject{Kind: AcJobGVK().Kind, Namespace: "default", Name: testEventJob, UID: testEventUID,
+				PgName: testEventPg}
+			RecordJobEvent(job, v1.EventTypeWarning, "FaultDetected", "faults are detected")
+			events := waitEvents(client, "default", 2)
+			convey.So(len(events), convey.ShouldEqual, 2)
+			kinds := map[string]string{}
+			for _, event := range events {
+				kinds[event.InvolvedObject.Kind] = event.InvolvedObject.Name
+				convey.So(event.Source.Component, convey.ShouldEqual, eventComponent)
+			}
+			convey.So(kinds, convey.ShouldResemble, map[string]string{AcJobGVK().Kind: testEventJob,
+				PodGroupGVK().Kind: testEventPg})
+		})
+		convey.Convey("events of one node exceed burst, should be dropped", func() {
+			client, stop := startTestRecorder(EventConfig{QPS: DefaultEventQPS, Burst: 1})
+			defer stop()
+			RecordNodeEvent(testEventNode, v1.EventTypeWarning, "NPUManuallySeparated", "npu-0 is separated")
+			RecordNodeEvent(testEventNode, v1.EventTypeWarning, "NPUManuallySeparated", "npu-1 is separated")
+			RecordNodeEvent(testEventNode, v1.EventTypeWarning, "NPUManuallySeparated", "npu-2 is separated")
+			events := waitEvents(client, "default", 1)
+			convey.So(len(events), convey.ShouldEqual, 1)
+			convey.So(events[0].InvolvedObject.Name, convey.ShouldEqual, testEventNode)
+		})
+	})
+}
+
+func TestSetJobCondition(t *testing.T) {
+	convey.Convey("test func 'SetJobCondition'", t, func() {
+		convey.Convey("volcano job, should be skipped", func() {
+			_, stop := startTestRecorder(EventConfig{QPS: DefaultEventQPS, Burst: DefaultEventBurst})
+			defer stop()
+			SetJobCondition(JobObject{Kind: VcJobGVK().Kind, Name: testEventJob}, v1.ConditionTrue, "a", "b")
+			convey.So(len(conditionChan), convey.ShouldEqual, 0)
+		})
+		convey.Convey("operator client is nil, update should return error", func() {
+			err := updateAscendJobCondition(JobObject{Kind: AcJobGVK().Kind, Name: testEventJob},
+				commonv1.JobCondition{Type: FaultRecoveryCondition})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestSetCondition(t *testing.T) {
+	convey.Convey("test func 'setCondition'", t, func() {
+		oldTime := metav1.NewTime(time.Unix(1, 0))
+		newTime := metav1.Now()
+		status := &commonv1.JobStatus{Conditions: []commonv1.JobCondition{{Type: commonv1.JobRunning,
+			Status: v1.ConditionTrue}}}
+		condition := commonv1.JobCondition{Type: FaultRecoveryCondition, Status: v1.ConditionTrue,
+			Reason: "FaultDetected", LastTransitionTime: oldTime, LastUpdateTime: oldTime}
+		convey.Convey("new condition, should be appended", func() {
+			convey.So(setCondition(status, condition), convey.ShouldBeTrue)
+			convey.So(len(status.Conditions), convey.ShouldEqual, 2)
+		})
+		convey.Convey("the same condition, should not be changed", func() {
+			setCondition(status, condition)
+			same := condition
+			same.LastUpdateTime = newTime
+			convey.So(setCondition(status, same), convey.ShouldBeFalse)
+		})
+		convey.Convey("reason changed with same status, transition time should be kept", func() {
+			setCondition(status, condition)
+			changed := condition
+			changed.Reason, changed.LastTransitionTime = "RecoverStrategyChosen", newTime
+			convey.So(setCondition(status, changed), convey.ShouldBeTrue)
+			convey.So(status.Conditions[1].Reason, convey.ShouldEqual, "RecoverStrategyChosen")
+			convey.So(status.Conditions[1].LastTransitionTime, convey.ShouldResemble, oldTime)
+		})
+		convey.Convey("status changed, transition time should be updated", func() {
+			setCondition(status, condition)
+			changed := condition
+			changed.Status, changed.LastTransitionTime = v1.ConditionFalse, newTime
+			convey.So(setCondition(status, changed), convey.ShouldBeTrue)
+			convey.So(status.Conditions[1].LastTransitionTime, convey.ShouldResemble, newTime)
+		})
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	authv1 "k8s.io/api/authentication/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -113,6 +114,29 @@ func DeleteConfigMap(cmName, cmNamespace string) error {
 	return k8sClient.ClientSet.CoreV1().ConfigMaps(cmNamespace).Delete(context.TODO(), cmName, metav1.DeleteOptions{})
 }
 
+// ListConfigMapsByLabel list configMaps in namespace which match the label selector
+func ListConfigMapsByLabel(cmNamespace, labelSelector string) (*v1.ConfigMapList, error) {
+	if k8sClient == nil || k8sClient.ClientSet == nil {
+		return nil, fmt.Errorf("k8s client is nil")
+	}
+	return k8sClient.ClientSet.CoreV1().ConfigMaps(cmNamespace).List(context.TODO(),
+		metav1.ListOptions{LabelSelector: labelSelector})
+}
+
+// ReviewToken ask apiserver to authenticate the service account token for the audiences
+func ReviewToken(token string, audiences []string) (*authv1.TokenReviewStatus, error) {
+	if k8sClient == nil || k8sClient.ClientSet == nil {
+		return nil, fmt.Errorf("k8s client is nil")
+	}
+	review := &authv1.TokenReview{Spec: authv1.TokenReviewSpec{Token: token, Audiences: audiences}}
+	result, err := k8sClient.ClientSet.AuthenticationV1().TokenReviews().Create(context.TODO(), review,
+		metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return &result.Status, nil
+}
+
 // RetryPatchPodLabels retry patch pod labels
 func RetryPatchPodLabels(podName, podNamespace string, retryTimes int, labels map[string]string) error {
 	_, err := PatchPodLabel(podName, podNamespace, labels)
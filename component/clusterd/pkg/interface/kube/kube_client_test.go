@@ -13,9 +13,13 @@ import (
 
 	"github.com/agiledragon/gomonkey/v2"
 	"github.com/smartystreets/goconvey/convey"
+	authv1 "k8s.io/api/authentication/v1"
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 
 	"ascend-common/api"
 	"ascend-common/common-utils/hwlog"
@@ -202,6 +206,52 @@ func TestDeleteCM(t *testing.T) {
 	})
 }
 
+func TestListCMByLabel(t *testing.T) {
+	const testLabelKey = "test-label"
+	convey.Convey("test func 'ListConfigMapsByLabel' success, only labeled cm returned", t, func() {
+		createCM(t)
+		err := CreateOrUpdateConfigMap(testName, testNS, nil, map[string]string{testLabelKey: testValue1})
+		convey.So(err, convey.ShouldBeNil)
+		defer func() {
+			DeleteConfigMap(testCMName, testNS)
+			DeleteConfigMap(testName, testNS)
+		}()
+		cmList, err := ListConfigMapsByLabel(testNS, testLabelKey+"="+testValue1)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(cmList.Items), convey.ShouldEqual, 1)
+		convey.So(cmList.Items[0].Name, convey.ShouldEqual, testName)
+	})
+}
+
+func TestReviewToken(t *testing.T) {
+	const testUser = "system:serviceaccount:mindx-dl:noded"
+	convey.Convey("test func 'ReviewToken'", t, func() {
+		fakeClient, ok := testK8sClient.ClientSet.(*fake.Clientset)
+		convey.So(ok, convey.ShouldBeTrue)
+		fakeClient.PrependReactor("create", "tokenreviews", func(action k8stesting.Action) (bool,
+			runtime.Object, error) {
+			createAction, ok := action.(k8stesting.CreateAction)
+			if !ok {
+				return true, nil, testErr
+			}
+			review, ok := createAction.GetObject().(*authv1.TokenReview)
+			if !ok || review.Spec.Token != testValue1 {
+				return true, &authv1.TokenReview{}, nil
+			}
+			review.Status = authv1.TokenReviewStatus{Authenticated: true,
+				User: authv1.UserInfo{Username: testUser}}
+			return true, review, nil
+		})
+		status, err := ReviewToken(testValue1, []string{testName})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(status.Authenticated, convey.ShouldBeTrue)
+		convey.So(status.User.Username, convey.ShouldEqual, testUser)
+		status, err = ReviewToken(testValue2, []string{testName})
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(status.Authenticated, convey.ShouldBeFalse)
+	})
+}
+
 func TestCreateOrUpdateCM(t *testing.T) {
 	convey.Convey("test func 'CreateOrUpdateConfigMap' success. cm does not exist, create success", t, func() {
 		DeleteConfigMap(testCMName, testNS)
@@ -4,6 +4,8 @@
 package kube
 
 import (
+	"errors"
+
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 
@@ -33,6 +35,19 @@ func GetClientK8s() *K8sClient {
 	return k8sClient
 }
 
+// InitClientK8sByClientSet init k8s client with the client set built by caller, such as the fake client set of
+// offline tools. a working k8s client can not be replaced
+func InitClientK8sByClientSet(clientSet kubernetes.Interface) error {
+	if clientSet == nil {
+		return errors.New("client set is nil")
+	}
+	if k8sClient != nil && k8sClient.ClientSet != nil {
+		return errors.New("k8s client is already initialized")
+	}
+	k8sClient = &K8sClient{ClientSet: clientSet}
+	return nil
+}
+
 // newClientK8s create k8s client
 func newClientK8s() (*K8sClient, error) {
 	clientCfg, err := clientcmd.BuildConfigFromFlags("", "")
@@ -8,6 +8,7 @@ import (
 
 	"github.com/agiledragon/gomonkey/v2"
 	"github.com/smartystreets/goconvey/convey"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 func TestInitClientK8s(t *testing.T) {
@@ -20,3 +21,24 @@ func TestInitClientK8s(t *testing.T) {
 		convey.So(err, convey.ShouldBeNil)
 	})
 }
+
+func TestInitClientK8sByClientSet(t *testing.T) {
+	convey.Convey("test func 'InitClientK8sByClientSet'", t, func() {
+		convey.Convey("client set is nil, should return error", func() {
+			convey.So(InitClientK8sByClientSet(nil), convey.ShouldNotBeNil)
+		})
+		convey.Convey("k8s client is initialized, should not be replaced", func() {
+			oldClient := k8sClient
+			convey.So(InitClientK8sByClientSet(fake.NewSimpleClientset()), convey.ShouldNotBeNil)
+			convey.So(k8sClient, convey.ShouldEqual, oldClient)
+		})
+		convey.Convey("k8s client is not initialized, should init with client set", func() {
+			oldClient := k8sClient
+			defer func() { k8sClient = oldClient }()
+			k8sClient = nil
+			clientSet := fake.NewSimpleClientset()
+			convey.So(InitClientK8sByClientSet(clientSet), convey.ShouldBeNil)
+			convey.So(k8sClient.ClientSet, convey.ShouldEqual, clientSet)
+		})
+	})
+}
@@ -0,0 +1,149 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package kube a series of kube function
+package kube
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+
+	"ascend-common/common-utils/hwlog"
+	"clusterd/pkg/common/constant"
+)
+
+// leaderMarkInterval interval of marking the pod as leader again after the leader label failed to be patched
+var leaderMarkInterval = 10 * time.Second
+
+// LeaderElectionConfig config of clusterd leader election
+type LeaderElectionConfig struct {
+	LeaseName     string
+	Namespace     string
+	Identity      string
+	LeaseDuration time.Duration
+	RenewDeadline time.Duration
+	RetryPeriod   time.Duration
+}
+
+// NewLeaderElectionConfig build leader election config of current pod, namespace and identity come from env
+func NewLeaderElectionConfig(leaseName string, leaseDuration, renewDeadline, retryPeriod int) (
+	*LeaderElectionConfig, error) {
+	podName := os.Getenv(constant.PodNameEnv)
+	namespace := os.Getenv(constant.PodNamespaceEnv)
+	if podName == "" || namespace == "" {
+		return nil, fmt.Errorf("env %s and %s are required by leader election",
+			constant.PodNameEnv, constant.PodNamespaceEnv)
+	}
+	return &LeaderElectionConfig{
+		LeaseName:     leaseName,
+		Namespace:     namespace,
+		Identity:      podName,
+		LeaseDuration: time.Duration(leaseDuration) * time.Second,
+		RenewDeadline: time.Duration(renewDeadline) * time.Second,
+		RetryPeriod:   time.Duration(retryPeriod) * time.Second,
+	}, nil
+}
+
+// RunLeaderElection block until ctx done or leadership lost. onStarted is called in a new goroutine when
+// current pod become leader, onStopped is called when leadership lost
+func RunLeaderElection(ctx context.Context, cfg *LeaderElectionConfig,
+	onStarted func(ctx context.Context), onStopped func()) error {
+	if k8sClient == nil || k8sClient.ClientSet == nil {
+		return errors.New("k8s client is nil")
+	}
+	if cfg == nil {
+		return errors.New("leader election config is nil")
+	}
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      cfg.LeaseName,
+			Namespace: cfg.Namespace,
+		},
+		Client:     k8sClient.ClientSet.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{Identity: cfg.Identity},
+	}
+	elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		LeaseDuration:   cfg.LeaseDuration,
+		RenewDeadline:   cfg.RenewDeadline,
+		RetryPeriod:     cfg.RetryPeriod,
+		ReleaseOnCancel: true,
+		Name:            cfg.LeaseName,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(leaderCtx context.Context) {
+				hwlog.RunLog.Infof("%s become leader of lease %s/%s", cfg.Identity, cfg.Namespace, cfg.LeaseName)
+				markLeaderUntilDone(leaderCtx, cfg.Identity, cfg.Namespace)
+				onStarted(leaderCtx)
+			},
+			OnStoppedLeading: func() {
+				hwlog.RunLog.Warnf("%s lost leadership of lease %s/%s", cfg.Identity, cfg.Namespace, cfg.LeaseName)
+				MarkLeaderPod(cfg.Identity, cfg.Namespace, false)
+				onStopped()
+			},
+			OnNewLeader: func(identity string) {
+				hwlog.RunLog.Infof("current leader of lease %s/%s is %s", cfg.Namespace, cfg.LeaseName, identity)
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("create leader elector failed: %v", err)
+	}
+	// a restarted container may still carry the leader label of its previous run
+	MarkLeaderPod(cfg.Identity, cfg.Namespace, false)
+	elector.Run(ctx)
+	return nil
+}
+
+// MarkStandaloneLeader mark current pod as leader when leader election is disabled, so that the grpc service of
+// clusterd selecting the leader label still reaches the only replica
+func MarkStandaloneLeader(ctx context.Context) {
+	podName := os.Getenv(constant.PodNameEnv)
+	namespace := os.Getenv(constant.PodNamespaceEnv)
+	if podName == "" || namespace == "" {
+		hwlog.RunLog.Warnf("env %s or %s is empty, leader label of pod is not marked, grpc service selecting "+
+			"the leader label can not reach clusterd", constant.PodNameEnv, constant.PodNamespaceEnv)
+		return
+	}
+	markLeaderUntilDone(ctx, podName, namespace)
+}
+
+// markLeaderUntilDone mark the pod as leader, and keep retrying in background until succeeded or ctx done when
+// failed, because the grpc service of clusterd has no endpoint before the leader label is patched
+func markLeaderUntilDone(ctx context.Context, podName, namespace string) {
+	if MarkLeaderPod(podName, namespace, true) == nil {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(leaderMarkInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if MarkLeaderPod(podName, namespace, true) == nil {
+					hwlog.RunLog.Infof("pod %s/%s is marked as leader", namespace, podName)
+					return
+				}
+			}
+		}
+	}()
+}
+
+// MarkLeaderPod patch leader label of pod, the grpc service of clusterd select the pod with label value true
+func MarkLeaderPod(podName, namespace string, isLeader bool) error {
+	labels := map[string]string{constant.LeaderLabelKey: strconv.FormatBool(isLeader)}
+	err := RetryPatchPodLabels(podName, namespace, constant.RetryTime, labels)
+	if err != nil {
+		hwlog.RunLog.Errorf("patch leader label of pod %s/%s to %v failed, err: %v",
+			namespace, podName, isLeader, err)
+	}
+	return err
+}
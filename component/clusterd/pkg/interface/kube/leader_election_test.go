@@ -0,0 +1,140 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package kube test for leader election
+package kube
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/smartystreets/goconvey/convey"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"clusterd/pkg/common/constant"
+)
+
+const (
+	testLeaseName     = "test-lease"
+	testLeaderPodName = "clusterd-0"
+	testLeaseDuration = 15
+	testRenewDeadline = 10
+	testRetryPeriod   = 2
+)
+
+func TestNewLeaderElectionConfig(t *testing.T) {
+	convey.Convey("test func 'NewLeaderElectionConfig'", t, func() {
+		convey.Convey("env of pod name is missing, should return error", func() {
+			t.Setenv(constant.PodNameEnv, "")
+			t.Setenv(constant.PodNamespaceEnv, testNS)
+			_, err := NewLeaderElectionConfig(testLeaseName, testLeaseDuration, testRenewDeadline, testRetryPeriod)
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("env is set, should return config of current pod", func() {
+			t.Setenv(constant.PodNameEnv, testLeaderPodName)
+			t.Setenv(constant.PodNamespaceEnv, testNS)
+			cfg, err := NewLeaderElectionConfig(testLeaseName, testLeaseDuration, testRenewDeadline, testRetryPeriod)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(cfg.Identity, convey.ShouldEqual, testLeaderPodName)
+			convey.So(cfg.Namespace, convey.ShouldEqual, testNS)
+			convey.So(cfg.LeaseDuration, convey.ShouldEqual, testLeaseDuration*time.Second)
+		})
+	})
+}
+
+func getLeaderLabel(t *testing.T) string {
+	pod, err := testK8sClient.ClientSet.CoreV1().Pods(testNS).Get(context.TODO(), testLeaderPodName,
+		metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get pod failed, err: %v", err)
+	}
+	return pod.Labels[constant.LeaderLabelKey]
+}
+
+func TestRunLeaderElection(t *testing.T) {
+	convey.Convey("test func 'RunLeaderElection'", t, func() {
+		convey.Convey("config is nil, should return error", func() {
+			err := RunLeaderElection(context.Background(), nil, func(context.Context) {}, func() {})
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+		convey.Convey("only one candidate, should become leader and mark pod", func() {
+			_, err := testK8sClient.ClientSet.CoreV1().Pods(testNS).Create(context.TODO(),
+				&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: testLeaderPodName, Namespace: testNS}},
+				metav1.CreateOptions{})
+			convey.So(err, convey.ShouldBeNil)
+			defer testK8sClient.ClientSet.CoreV1().Pods(testNS).Delete(context.TODO(), testLeaderPodName,
+				metav1.DeleteOptions{})
+			cfg := &LeaderElectionConfig{LeaseName: testLeaseName, Namespace: testNS, Identity: testLeaderPodName,
+				LeaseDuration: 2 * time.Second, RenewDeadline: time.Second, RetryPeriod: 100 * time.Millisecond}
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			leaderLabel, stopped := "", false
+			err = RunLeaderElection(ctx, cfg, func(context.Context) {
+				leaderLabel = getLeaderLabel(t)
+				cancel()
+			}, func() {
+				stopped = true
+			})
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(leaderLabel, convey.ShouldEqual, "true")
+			convey.So(stopped, convey.ShouldBeTrue)
+			convey.So(getLeaderLabel(t), convey.ShouldEqual, "false")
+		})
+	})
+}
+
+func TestMarkStandaloneLeader(t *testing.T) {
+	convey.Convey("test func 'MarkStandaloneLeader'", t, func() {
+		_, err := testK8sClient.ClientSet.CoreV1().Pods(testNS).Create(context.TODO(),
+			&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: testLeaderPodName, Namespace: testNS}},
+			metav1.CreateOptions{})
+		convey.So(err, convey.ShouldBeNil)
+		defer testK8sClient.ClientSet.CoreV1().Pods(testNS).Delete(context.TODO(), testLeaderPodName,
+			metav1.DeleteOptions{})
+		convey.Convey("env of pod is missing, should not mark pod", func() {
+			t.Setenv(constant.PodNameEnv, "")
+			t.Setenv(constant.PodNamespaceEnv, testNS)
+			MarkStandaloneLeader(context.Background())
+			convey.So(getLeaderLabel(t), convey.ShouldEqual, "")
+		})
+		convey.Convey("env is set, should mark pod as leader", func() {
+			t.Setenv(constant.PodNameEnv, testLeaderPodName)
+			t.Setenv(constant.PodNamespaceEnv, testNS)
+			MarkStandaloneLeader(context.Background())
+			convey.So(getLeaderLabel(t), convey.ShouldEqual, "true")
+		})
+	})
+}
+
+func TestMarkLeaderUntilDone(t *testing.T) {
+	convey.Convey("test func 'markLeaderUntilDone'", t, func() {
+		oldInterval := leaderMarkInterval
+		leaderMarkInterval = 10 * time.Millisecond
+		defer func() { leaderMarkInterval = oldInterval }()
+		const failedTimes = 2
+		var called int32
+		patches := gomonkey.ApplyFunc(MarkLeaderPod, func(string, string, bool) error {
+			if atomic.AddInt32(&called, 1) <= failedTimes {
+				return errors.New("patch failed")
+			}
+			return nil
+		})
+		defer patches.Reset()
+		convey.Convey("patch failed, should retry until succeeded", func() {
+			markLeaderUntilDone(context.Background(), testLeaderPodName, testNS)
+			time.Sleep(100 * time.Millisecond)
+			convey.So(atomic.LoadInt32(&called), convey.ShouldEqual, failedTimes+1)
+		})
+		convey.Convey("ctx is done, should stop retrying", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			markLeaderUntilDone(ctx, testLeaderPodName, testNS)
+			time.Sleep(50 * time.Millisecond)
+			convey.So(atomic.LoadInt32(&called), convey.ShouldEqual, 1)
+		})
+	})
+}
@@ -0,0 +1,277 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package restapi read-only http api to query fault and job state of clusterd
+package restapi
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"clusterd/pkg/application/faultmanager"
+	"clusterd/pkg/common/constant"
+)
+
+const (
+	deviceFaultType = "device"
+	nodeFaultType   = "node"
+	switchFaultType = "switch"
+
+	nodeParam       = "node"
+	typeParam       = "type"
+	faultCodeParam  = "faultCode"
+	faultLevelParam = "faultLevel"
+	faultyParam     = "faulty"
+)
+
+// faultItem one fault of a node, flattened from the processed configmaps of fault centers
+type faultItem struct {
+	Node       string `json:"node"`
+	Type       string `json:"type"`
+	Device     string `json:"device"`
+	FaultCode  string `json:"faultCode"`
+	FaultLevel string `json:"faultLevel"`
+	FaultTime  int64  `json:"faultTime,omitempty"`
+}
+
+// nodeSummary fault summary of a node
+type nodeSummary struct {
+	Name             string `json:"name"`
+	DeviceFaultNum   int    `json:"deviceFaultNum"`
+	NodeFaultNum     int    `json:"nodeFaultNum"`
+	SwitchFaultNum   int    `json:"switchFaultNum"`
+	AvailableDevices int    `json:"availableDevices"`
+	NodeStatus       string `json:"nodeStatus,omitempty"`
+	SwitchStatus     string `json:"switchStatus,omitempty"`
+}
+
+// nodeDetail all processed fault info of a node
+type nodeDetail struct {
+	Name   string                         `json:"name"`
+	Device *constant.AdvanceDeviceFaultCm `json:"device,omitempty"`
+	Node   *constant.NodeInfo             `json:"node,omitempty"`
+	Switch *constant.SwitchInfo           `json:"switch,omitempty"`
+}
+
+type faultFilter struct {
+	node       string
+	faultType  string
+	faultCode  string
+	faultLevel string
+}
+
+func (f faultFilter) match(item faultItem) bool {
+	return (f.node == "" || f.node == item.Node) && (f.faultType == "" || f.faultType == item.Type) &&
+		(f.faultCode == "" || strings.EqualFold(f.faultCode, item.FaultCode)) &&
+		(f.faultLevel == "" || f.faultLevel == item.FaultLevel)
+}
+
+// clusterFaults processed info of all nodes, keyed by node name
+type clusterFaults struct {
+	devices  map[string]*constant.AdvanceDeviceFaultCm
+	nodes    map[string]*constant.NodeInfo
+	switches map[string]*constant.SwitchInfo
+}
+
+func queryClusterFaults() clusterFaults {
+	faults := clusterFaults{
+		devices:  make(map[string]*constant.AdvanceDeviceFaultCm),
+		nodes:    make(map[string]*constant.NodeInfo),
+		switches: make(map[string]*constant.SwitchInfo),
+	}
+	for cmName, info := range faultmanager.QueryDeviceInfoToReport() {
+		if info != nil {
+			faults.devices[strings.TrimPrefix(cmName, constant.DeviceInfoPrefix)] = info
+		}
+	}
+	for cmName, info := range faultmanager.QueryNodeInfoToReport() {
+		if info != nil {
+			faults.nodes[strings.TrimPrefix(cmName, constant.NodeInfoPrefix)] = info
+		}
+	}
+	for cmName, info := range faultmanager.QuerySwitchInfoToReport() {
+		if info != nil {
+			faults.switches[strings.TrimPrefix(cmName, constant.SwitchInfoPrefix)] = info
+		}
+	}
+	return faults
+}
+
+func (c clusterFaults) nodeNames() []string {
+	names := make(map[string]struct{}, len(c.devices))
+	for name := range c.devices {
+		names[name] = struct{}{}
+	}
+	for name := range c.nodes {
+		names[name] = struct{}{}
+	}
+	for name := range c.switches {
+		names[name] = struct{}{}
+	}
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func deviceFaults(nodeName string, info *constant.AdvanceDeviceFaultCm) []faultItem {
+	var items []faultItem
+	for _, faults := range info.FaultDeviceList {
+		for _, fault := range faults {
+			item := faultItem{Node: nodeName, Type: deviceFaultType, Device: fault.NPUName,
+				FaultCode: fault.FaultCode, FaultLevel: fault.FaultLevel}
+			if timeAndLevel, ok := fault.FaultTimeAndLevelMap[fault.FaultCode]; ok {
+				item.FaultTime = timeAndLevel.FaultTime
+			}
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func nodeFaults(nodeName string, info *constant.NodeInfo) []faultItem {
+	var items []faultItem
+	for _, dev := range info.FaultDevList {
+		if dev == nil {
+			continue
+		}
+		for _, code := range dev.FaultCode {
+			items = append(items, faultItem{Node: nodeName, Type: nodeFaultType,
+				Device: dev.DeviceType + "-" + strconv.FormatInt(dev.DeviceId, 10), FaultCode: code,
+				FaultLevel: dev.FaultLevel, FaultTime: info.UpdateTime})
+		}
+	}
+	return items
+}
+
+func switchFaults(nodeName string, info *constant.SwitchInfo) []faultItem {
+	var items []faultItem
+	for _, fault := range info.FaultInfo {
+		item := faultItem{Node: nodeName, Type: switchFaultType,
+			Device:    "chip" + strconv.Itoa(int(fault.SwitchChipId)) + "-port" + strconv.Itoa(int(fault.SwitchPortId)),
+			FaultCode: fault.AssembledFaultCode, FaultLevel: info.FaultLevel, FaultTime: fault.AlarmRaisedTime}
+		if timeAndLevel, ok := info.FaultTimeAndLevelMap[fault.GetFaultTimeAndLevelKey()]; ok {
+			item.FaultLevel = timeAndLevel.FaultLevel
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func (c clusterFaults) faultsOfNode(nodeName string) []faultItem {
+	var items []faultItem
+	if info, ok := c.devices[nodeName]; ok {
+		items = append(items, deviceFaults(nodeName, info)...)
+	}
+	if info, ok := c.nodes[nodeName]; ok {
+		items = append(items, nodeFaults(nodeName, info)...)
+	}
+	if info, ok := c.switches[nodeName]; ok {
+		items = append(items, switchFaults(nodeName, info)...)
+	}
+	return items
+}
+
+func sortFaults(items []faultItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Node != items[j].Node {
+			return items[i].Node < items[j].Node
+		}
+		if items[i].Type != items[j].Type {
+			return items[i].Type < items[j].Type
+		}
+		if items[i].Device != items[j].Device {
+			return items[i].Device < items[j].Device
+		}
+		return items[i].FaultCode < items[j].FaultCode
+	})
+}
+
+// listFaults list faults of all nodes, filtered by node, type, faultCode and faultLevel
+func listFaults(w http.ResponseWriter, r *http.Request) {
+	p, err := parsePage(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	query := r.URL.Query()
+	filter := faultFilter{node: query.Get(nodeParam), faultType: query.Get(typeParam),
+		faultCode: query.Get(faultCodeParam), faultLevel: query.Get(faultLevelParam)}
+	if filter.faultType != "" && filter.faultType != deviceFaultType && filter.faultType != nodeFaultType &&
+		filter.faultType != switchFaultType {
+		writeError(w, http.StatusBadRequest, "invalid type: "+filter.faultType)
+		return
+	}
+	faults := queryClusterFaults()
+	items := make([]faultItem, 0)
+	for _, name := range faults.nodeNames() {
+		if filter.node != "" && filter.node != name {
+			continue
+		}
+		for _, item := range faults.faultsOfNode(name) {
+			if filter.match(item) {
+				items = append(items, item)
+			}
+		}
+	}
+	sortFaults(items)
+	writePage(w, p, items)
+}
+
+func (c clusterFaults) summary(nodeName string) nodeSummary {
+	summary := nodeSummary{Name: nodeName}
+	if info, ok := c.devices[nodeName]; ok {
+		summary.DeviceFaultNum = len(deviceFaults(nodeName, info))
+		summary.AvailableDevices = len(info.AvailableDeviceList)
+	}
+	if info, ok := c.nodes[nodeName]; ok {
+		summary.NodeFaultNum = len(nodeFaults(nodeName, info))
+		summary.NodeStatus = info.NodeStatus
+	}
+	if info, ok := c.switches[nodeName]; ok {
+		summary.SwitchFaultNum = len(switchFaults(nodeName, info))
+		summary.SwitchStatus = info.NodeStatus
+	}
+	return summary
+}
+
+// listNodes list fault summary of nodes, only faulty nodes are listed when query faulty=true
+func listNodes(w http.ResponseWriter, r *http.Request) {
+	p, err := parsePage(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	onlyFaulty := false
+	if value := r.URL.Query().Get(faultyParam); value != "" {
+		if onlyFaulty, err = strconv.ParseBool(value); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid faulty: "+value)
+			return
+		}
+	}
+	faults := queryClusterFaults()
+	items := make([]nodeSummary, 0)
+	for _, name := range faults.nodeNames() {
+		summary := faults.summary(name)
+		if onlyFaulty && summary.DeviceFaultNum+summary.NodeFaultNum+summary.SwitchFaultNum == 0 {
+			continue
+		}
+		items = append(items, summary)
+	}
+	writePage(w, p, items)
+}
+
+// getNode get processed device, node and switch info of a node
+func getNode(w http.ResponseWriter, nodeName string) {
+	faults := queryClusterFaults()
+	detail := nodeDetail{Name: nodeName, Device: faults.devices[nodeName], Node: faults.nodes[nodeName],
+		Switch: faults.switches[nodeName]}
+	if detail.Device == nil && detail.Node == nil && detail.Switch == nil {
+		writeError(w, http.StatusNotFound, "node not found: "+nodeName)
+		return
+	}
+	writeJSON(w, http.StatusOK, detail)
+}
@@ -0,0 +1,94 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package restapi test for fault api
+package restapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/smartystreets/goconvey/convey"
+
+	"clusterd/pkg/application/faultmanager"
+	"clusterd/pkg/common/constant"
+)
+
+const (
+	node1       = "node1"
+	node2       = "node2"
+	npuFault    = "80C98009"
+	switchFault = "[0x00f1ff09,155913,cpu,na]"
+)
+
+func patchClusterFaults() *gomonkey.Patches {
+	devices := map[string]*constant.AdvanceDeviceFaultCm{
+		constant.DeviceInfoPrefix + node1: {
+			FaultDeviceList: map[string][]constant.DeviceFault{"Ascend910-0": {{NPUName: "Ascend910-0",
+				FaultCode: npuFault, FaultLevel: constant.SeparateNPU,
+				FaultTimeAndLevelMap: map[string]constant.FaultTimeAndLevel{npuFault: {FaultTime: 1}}}}},
+			AvailableDeviceList: []string{"Ascend910-1"},
+		},
+		constant.DeviceInfoPrefix + node2: {AvailableDeviceList: []string{"Ascend910-0"}},
+	}
+	nodes := map[string]*constant.NodeInfo{
+		constant.NodeInfoPrefix + node1: {NodeInfoNoName: constant.NodeInfoNoName{NodeStatus: constant.UnHealthyState,
+			FaultDevList: []*constant.FaultDev{{DeviceType: "CPU", FaultCode: []string{"00000001"},
+				FaultLevel: constant.PreSeparateFault}}}, UpdateTime: 2},
+	}
+	switches := map[string]*constant.SwitchInfo{
+		constant.SwitchInfoPrefix + node2: {SwitchFaultInfo: constant.SwitchFaultInfo{FaultLevel: constant.NotHandleFault,
+			FaultInfo: []constant.SimpleSwitchFaultInfo{{AssembledFaultCode: switchFault, AlarmRaisedTime: 3}}}},
+	}
+	return gomonkey.ApplyFuncReturn(faultmanager.QueryDeviceInfoToReport, devices).
+		ApplyFuncReturn(faultmanager.QueryNodeInfoToReport, nodes).
+		ApplyFuncReturn(faultmanager.QuerySwitchInfoToReport, switches)
+}
+
+func TestListFaults(t *testing.T) {
+	convey.Convey("test func 'listFaults'", t, func() {
+		patches := patchClusterFaults()
+		defer patches.Reset()
+		var items []faultItem
+		result := decodeList(serve(http.MethodGet, Path+faultsResource), &items)
+		convey.So(result.Total, convey.ShouldEqual, 3)
+		convey.So(items[0], convey.ShouldResemble, faultItem{Node: node1, Type: deviceFaultType,
+			Device: "Ascend910-0", FaultCode: npuFault, FaultLevel: constant.SeparateNPU, FaultTime: 1})
+		convey.So(items[1].Type, convey.ShouldEqual, nodeFaultType)
+		convey.So(items[2].FaultCode, convey.ShouldEqual, switchFault)
+		decodeList(serve(http.MethodGet, Path+"faults?node=node1&type=node"), &items)
+		convey.So(len(items), convey.ShouldEqual, 1)
+		convey.So(items[0].FaultTime, convey.ShouldEqual, 2)
+		decodeList(serve(http.MethodGet, Path+"faults?faultCode=80c98009"), &items)
+		convey.So(len(items), convey.ShouldEqual, 1)
+		result = decodeList(serve(http.MethodGet, Path+"faults?faultLevel=NotHandleFault&limit=1&offset=1"), &items)
+		convey.So(result.Total, convey.ShouldEqual, 1)
+		convey.So(len(items), convey.ShouldEqual, 0)
+		convey.So(serve(http.MethodGet, Path+"faults?type=cpu").Code, convey.ShouldEqual, http.StatusBadRequest)
+	})
+}
+
+func TestListNodes(t *testing.T) {
+	convey.Convey("test func 'listNodes'", t, func() {
+		patches := patchClusterFaults()
+		defer patches.Reset()
+		var items []nodeSummary
+		result := decodeList(serve(http.MethodGet, Path+nodesResource), &items)
+		convey.So(result.Total, convey.ShouldEqual, 2)
+		convey.So(items[0], convey.ShouldResemble, nodeSummary{Name: node1, DeviceFaultNum: 1, NodeFaultNum: 1,
+			AvailableDevices: 1, NodeStatus: constant.UnHealthyState})
+		convey.So(items[1].SwitchFaultNum, convey.ShouldEqual, 1)
+		convey.So(serve(http.MethodGet, Path+"nodes?faulty=a").Code, convey.ShouldEqual, http.StatusBadRequest)
+	})
+}
+
+func TestGetNode(t *testing.T) {
+	convey.Convey("test func 'getNode'", t, func() {
+		patches := patchClusterFaults()
+		defer patches.Reset()
+		recorder := serve(http.MethodGet, Path+"nodes/"+node2)
+		convey.So(recorder.Code, convey.ShouldEqual, http.StatusOK)
+		convey.So(recorder.Body.String(), convey.ShouldContainSubstring, switchFault)
+		convey.So(serve(http.MethodGet, Path+"nodes/node3").Code, convey.ShouldEqual, http.StatusNotFound)
+	})
+}
@@ -0,0 +1,85 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package restapi read-only http api to query fault and job state of clusterd
+package restapi
+
+import (
+	"net/http"
+	"sort"
+
+	"clusterd/pkg/domain/job"
+)
+
+const (
+	namespaceParam = "namespace"
+	statusParam    = "status"
+)
+
+// jobSummary brief of a job in job storage
+type jobSummary struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace"`
+	JobType     string `json:"jobType"`
+	Framework   string `json:"framework,omitempty"`
+	Status      string `json:"status"`
+	Replicas    int    `json:"replicas"`
+	IsPreDelete bool   `json:"isPreDelete"`
+	AddTime     int64  `json:"addTime"`
+	NodeNum     int    `json:"nodeNum"`
+}
+
+// listJobs list jobs in job storage, filtered by namespace and status
+func listJobs(w http.ResponseWriter, r *http.Request) {
+	p, err := parsePage(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	query := r.URL.Query()
+	namespace, status := query.Get(namespaceParam), query.Get(statusParam)
+	items := make([]jobSummary, 0)
+	for id, info := range job.GetAllJobCache() {
+		if (namespace != "" && namespace != info.NameSpace) || (status != "" && status != info.Status) {
+			continue
+		}
+		items = append(items, jobSummary{Id: id, Name: info.Name, Namespace: info.NameSpace, JobType: info.JobType,
+			Framework: info.Framework, Status: info.Status, Replicas: info.Replicas, IsPreDelete: info.IsPreDelete,
+			AddTime: info.AddTime, NodeNum: len(info.NodeNames)})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Namespace != items[j].Namespace {
+			return items[i].Namespace < items[j].Namespace
+		}
+		if items[i].Name != items[j].Name {
+			return items[i].Name < items[j].Name
+		}
+		return items[i].Id < items[j].Id
+	})
+	writePage(w, p, items)
+}
+
+// getJob get job info in job storage, including its rank table
+func getJob(w http.ResponseWriter, jobId string) {
+	info, ok := job.GetJobCache(jobId)
+	if !ok {
+		writeError(w, http.StatusNotFound, "job not found: "+jobId)
+		return
+	}
+	writeJSON(w, http.StatusOK, info)
+}
+
+// getJobRecover get recover state and state machine path of a job
+func getJobRecover(w http.ResponseWriter, jobId string) {
+	query := getRecoverQuery()
+	if query == nil {
+		writeError(w, http.StatusServiceUnavailable, "recover service is not running on this clusterd")
+		return
+	}
+	info, ok := query(jobId)
+	if !ok {
+		writeError(w, http.StatusNotFound, "job has not registered recover service: "+jobId)
+		return
+	}
+	writeJSON(w, http.StatusOK, info)
+}
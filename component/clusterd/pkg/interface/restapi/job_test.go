@@ -0,0 +1,78 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package restapi test for job api
+package restapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/smartystreets/goconvey/convey"
+
+	"clusterd/pkg/common/constant"
+	"clusterd/pkg/domain/common"
+	"clusterd/pkg/domain/job"
+)
+
+const (
+	jobId1     = "job-uid-1"
+	jobId2     = "job-uid-2"
+	namespaceA = "team-a"
+)
+
+func TestListJobs(t *testing.T) {
+	convey.Convey("test func 'listJobs'", t, func() {
+		patches := gomonkey.ApplyFuncReturn(job.GetAllJobCache, map[string]constant.JobInfo{
+			jobId1: {Name: "b", NameSpace: namespaceA, Status: "running"},
+			jobId2: {Name: "a", NameSpace: namespaceA, Status: "pending"},
+			"uid3": {Name: "c", NameSpace: "team-b", Status: "running"},
+		})
+		defer patches.Reset()
+		var items []jobSummary
+		result := decodeList(serve(http.MethodGet, Path+"jobs?namespace=team-a"), &items)
+		convey.So(result.Total, convey.ShouldEqual, 2)
+		convey.So(items[0].Id, convey.ShouldEqual, jobId2)
+		convey.So(items[1].Id, convey.ShouldEqual, jobId1)
+		result = decodeList(serve(http.MethodGet, Path+"jobs?status=running&limit=1"), &items)
+		convey.So(result.Total, convey.ShouldEqual, 2)
+		convey.So(len(items), convey.ShouldEqual, 1)
+		convey.So(serve(http.MethodGet, Path+"jobs?offset=a").Code, convey.ShouldEqual, http.StatusBadRequest)
+	})
+}
+
+func TestGetJob(t *testing.T) {
+	convey.Convey("test func 'getJob'", t, func() {
+		patches := gomonkey.ApplyFunc(job.GetJobCache, func(jobKey string) (constant.JobInfo, bool) {
+			return constant.JobInfo{Key: jobKey, NameSpace: namespaceA}, jobKey == jobId1
+		})
+		defer patches.Reset()
+		recorder := serve(http.MethodGet, Path+"jobs/"+jobId1)
+		convey.So(recorder.Code, convey.ShouldEqual, http.StatusOK)
+		var info constant.JobInfo
+		convey.So(json.Unmarshal(recorder.Body.Bytes(), &info), convey.ShouldBeNil)
+		convey.So(info.NameSpace, convey.ShouldEqual, namespaceA)
+		convey.So(serve(http.MethodGet, Path+"jobs/"+jobId2).Code, convey.ShouldEqual, http.StatusNotFound)
+	})
+}
+
+func TestGetJobRecover(t *testing.T) {
+	convey.Convey("test func 'getJobRecover'", t, func() {
+		defer RegisterRecoverQuery(nil)
+		RegisterRecoverQuery(nil)
+		convey.So(serve(http.MethodGet, Path+"jobs/"+jobId1+"/recover").Code, convey.ShouldEqual,
+			http.StatusServiceUnavailable)
+		RegisterRecoverQuery(func(jobId string) (common.RecoverInfo, bool) {
+			return common.RecoverInfo{JobId: jobId, State: common.InitState, PathGraph: common.InitState},
+				jobId == jobId1
+		})
+		recorder := serve(http.MethodGet, Path+"jobs/"+jobId1+"/recover")
+		convey.So(recorder.Code, convey.ShouldEqual, http.StatusOK)
+		var info common.RecoverInfo
+		convey.So(json.Unmarshal(recorder.Body.Bytes(), &info), convey.ShouldBeNil)
+		convey.So(info.State, convey.ShouldEqual, common.InitState)
+		convey.So(serve(http.MethodGet, Path+"jobs/"+jobId2+"/recover").Code, convey.ShouldEqual,
+			http.StatusNotFound)
+	})
+}
@@ -0,0 +1,189 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package restapi read-only http api to query fault and job state of clusterd
+package restapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"ascend-common/common-utils/hwlog"
+	"clusterd/pkg/domain/common"
+)
+
+const (
+	// Path url path prefix of the api
+	Path = "/api/v1/"
+
+	faultsResource   = "faults"
+	nodesResource    = "nodes"
+	jobsResource     = "jobs"
+	timelineResource = "timeline"
+	recoverAction    = "recover"
+
+	limitParam      = "limit"
+	offsetParam     = "offset"
+	defaultLimit    = 100
+	maxLimit        = 1000
+	maxPathSegments = 3
+	qpsLimit        = 20
+	qpsBurst        = 40
+)
+
+// listResult paged result of list api
+type listResult struct {
+	Total  int         `json:"total"`
+	Offset int         `json:"offset"`
+	Limit  int         `json:"limit"`
+	Items  interface{} `json:"items"`
+}
+
+type errorResult struct {
+	Error string `json:"error"`
+}
+
+type page struct {
+	offset int
+	limit  int
+}
+
+var (
+	recoverQuery func(jobId string) (common.RecoverInfo, bool)
+	queryLock    sync.RWMutex
+)
+
+// RegisterRecoverQuery register the function to query recover state of job, it is registered by recover service,
+// which only runs on the leader
+func RegisterRecoverQuery(query func(jobId string) (common.RecoverInfo, bool)) {
+	queryLock.Lock()
+	defer queryLock.Unlock()
+	recoverQuery = query
+}
+
+func getRecoverQuery() func(jobId string) (common.RecoverInfo, bool) {
+	queryLock.RLock()
+	defer queryLock.RUnlock()
+	return recoverQuery
+}
+
+type handler struct {
+	limiter *rate.Limiter
+}
+
+// Handler return http handler of the api, serves GET requests under Path:
+// faults, nodes, nodes/{name}, jobs, jobs/{id}, jobs/{id}/recover, timeline
+func Handler() http.Handler {
+	return &handler{limiter: rate.NewLimiter(rate.Every(time.Second/qpsLimit), qpsBurst)}
+}
+
+// ServeHTTP implement http.Handler
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "only GET method is supported")
+		return
+	}
+	if !h.limiter.Allow() {
+		writeError(w, http.StatusTooManyRequests, "too many requests")
+		return
+	}
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, Path), "/"), "/")
+	if len(segments) > maxPathSegments {
+		writeError(w, http.StatusNotFound, "resource not found")
+		return
+	}
+	switch segments[0] {
+	case faultsResource:
+		if len(segments) == 1 {
+			listFaults(w, r)
+			return
+		}
+	case nodesResource:
+		if len(segments) == 1 {
+			listNodes(w, r)
+			return
+		}
+		if len(segments) == maxPathSegments-1 {
+			getNode(w, segments[1])
+			return
+		}
+	case jobsResource:
+		if len(segments) == 1 {
+			listJobs(w, r)
+			return
+		}
+		if len(segments) == maxPathSegments-1 {
+			getJob(w, segments[1])
+			return
+		}
+		if segments[maxPathSegments-1] == recoverAction {
+			getJobRecover(w, segments[1])
+			return
+		}
+	case timelineResource:
+		if len(segments) == 1 {
+			listTimeline(w, r)
+			return
+		}
+	default:
+	}
+	writeError(w, http.StatusNotFound, "resource not found")
+}
+
+// parsePage get offset and limit from query, limit is default 100 and at most 1000
+func parsePage(r *http.Request) (page, error) {
+	p := page{limit: defaultLimit}
+	query := r.URL.Query()
+	if value := query.Get(offsetParam); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			return p, fmt.Errorf("invalid %s: %s", offsetParam, value)
+		}
+		p.offset = offset
+	}
+	if value := query.Get(limitParam); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 || limit > maxLimit {
+			return p, fmt.Errorf("invalid %s: %s, should be in [1, %d]", limitParam, value, maxLimit)
+		}
+		p.limit = limit
+	}
+	return p, nil
+}
+
+// writePage write items of the page, items should be sorted
+func writePage[T any](w http.ResponseWriter, p page, items []T) {
+	result := listResult{Total: len(items), Offset: p.offset, Limit: p.limit, Items: []T{}}
+	if p.offset < len(items) {
+		end := p.offset + p.limit
+		if end > len(items) {
+			end = len(items)
+		}
+		result.Items = items[p.offset:end]
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func writeJSON(w http.ResponseWriter, code int, body interface{}) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		hwlog.RunLog.Errorf("marshal response of rest api failed, err: %v", err)
+		code = http.StatusInternalServerError
+		data = []byte(`{"error":"marshal response failed"}`)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if _, err = w.Write(data); err != nil {
+		hwlog.RunLog.Debugf("write response of rest api failed, err: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, msg string) {
+	writeJSON(w, code, errorResult{Error: msg})
+}
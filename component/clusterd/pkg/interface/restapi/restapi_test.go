@@ -0,0 +1,95 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package restapi test for rest api
+package restapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+
+	"ascend-common/common-utils/hwlog"
+)
+
+func init() {
+	if err := hwlog.InitRunLogger(&hwlog.LogConfig{OnlyToStdout: true}, context.Background()); err != nil {
+		fmt.Printf("init hwlog failed, %v\n", err)
+	}
+}
+
+func serve(method, url string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	Handler().ServeHTTP(recorder, httptest.NewRequest(method, url, nil))
+	return recorder
+}
+
+func decodeList(recorder *httptest.ResponseRecorder, items interface{}) listResult {
+	result := listResult{Items: items}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &result); err != nil {
+		fmt.Printf("unmarshal response failed, %v\n", err)
+	}
+	return result
+}
+
+func TestServeHTTP(t *testing.T) {
+	convey.Convey("test method 'ServeHTTP'", t, func() {
+		convey.So(serve(http.MethodPost, Path+faultsResource).Code, convey.ShouldEqual, http.StatusMethodNotAllowed)
+		convey.So(serve(http.MethodGet, Path+"unknown").Code, convey.ShouldEqual, http.StatusNotFound)
+		convey.So(serve(http.MethodGet, Path+"jobs/a/b/c").Code, convey.ShouldEqual, http.StatusNotFound)
+		convey.So(serve(http.MethodGet, Path+"jobs/a/unknown").Code, convey.ShouldEqual, http.StatusNotFound)
+		convey.So(serve(http.MethodGet, Path+"faults/a").Code, convey.ShouldEqual, http.StatusNotFound)
+		convey.So(serve(http.MethodGet, Path+"faults?limit=0").Code, convey.ShouldEqual, http.StatusBadRequest)
+	})
+	convey.Convey("requests over the limit should be rejected", t, func() {
+		h := Handler()
+		code := http.StatusOK
+		for i := 0; i <= qpsBurst; i++ {
+			recorder := httptest.NewRecorder()
+			h.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, Path+"unknown", nil))
+			code = recorder.Code
+		}
+		convey.So(code, convey.ShouldEqual, http.StatusTooManyRequests)
+	})
+}
+
+func TestParsePage(t *testing.T) {
+	convey.Convey("test func 'parsePage'", t, func() {
+		p, err := parsePage(httptest.NewRequest(http.MethodGet, Path, nil))
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(p, convey.ShouldResemble, page{limit: defaultLimit})
+		p, err = parsePage(httptest.NewRequest(http.MethodGet, Path+"?offset=5&limit=10", nil))
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(p, convey.ShouldResemble, page{offset: 5, limit: 10})
+		_, err = parsePage(httptest.NewRequest(http.MethodGet, Path+"?offset=-1", nil))
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = parsePage(httptest.NewRequest(http.MethodGet, Path+"?limit=1001", nil))
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = parsePage(httptest.NewRequest(http.MethodGet, Path+"?limit=a", nil))
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+}
+
+func TestWritePage(t *testing.T) {
+	convey.Convey("test func 'writePage'", t, func() {
+		items := []int{1, 2, 3}
+		recorder := httptest.NewRecorder()
+		writePage(recorder, page{offset: 1, limit: 1}, items)
+		var got []int
+		result := decodeList(recorder, &got)
+		convey.So(result.Total, convey.ShouldEqual, len(items))
+		convey.So(got, convey.ShouldResemble, []int{2})
+		recorder = httptest.NewRecorder()
+		writePage(recorder, page{offset: 2, limit: 5}, items)
+		decodeList(recorder, &got)
+		convey.So(got, convey.ShouldResemble, []int{3})
+		recorder = httptest.NewRecorder()
+		writePage(recorder, page{offset: 5, limit: 5}, items)
+		decodeList(recorder, &got)
+		convey.So(got, convey.ShouldResemble, []int{})
+	})
+}
@@ -0,0 +1,67 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package restapi read-only http api to query fault and job state of clusterd
+package restapi
+
+import (
+	"net/http"
+	"strconv"
+
+	"clusterd/pkg/common/journal"
+)
+
+const (
+	deviceParam = "device"
+	jobIdParam  = "jobId"
+	sinceParam  = "since"
+	untilParam  = "until"
+)
+
+func parseMilliTime(r *http.Request, param string) (int64, bool) {
+	value := r.URL.Query().Get(param)
+	if value == "" {
+		return 0, true
+	}
+	t, err := strconv.ParseInt(value, 10, 64)
+	return t, err == nil && t >= 0
+}
+
+// listTimeline list fault journal events ordered by time, filtered by node, device, jobId, faultCode and time range
+// in milliseconds, at least one of node, device and jobId is required. at most 7 days of events before until are
+// returned
+func listTimeline(w http.ResponseWriter, r *http.Request) {
+	p, err := parsePage(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	query := r.URL.Query()
+	filter := journal.Filter{Node: query.Get(nodeParam), Device: query.Get(deviceParam),
+		JobId: query.Get(jobIdParam), FaultCode: query.Get(faultCodeParam)}
+	if filter.Node == "" && filter.Device == "" && filter.JobId == "" {
+		writeError(w, http.StatusBadRequest, "one of node, device and jobId is required")
+		return
+	}
+	var ok bool
+	if filter.Since, ok = parseMilliTime(r, sinceParam); !ok {
+		writeError(w, http.StatusBadRequest, "invalid since: "+query.Get(sinceParam))
+		return
+	}
+	if filter.Until, ok = parseMilliTime(r, untilParam); !ok {
+		writeError(w, http.StatusBadRequest, "invalid until: "+query.Get(untilParam))
+		return
+	}
+	if !journal.Enabled() {
+		writeError(w, http.StatusServiceUnavailable, "fault journal is not enabled")
+		return
+	}
+	events, err := journal.Query(filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "query fault journal failed: "+err.Error())
+		return
+	}
+	if events == nil {
+		events = []journal.Event{}
+	}
+	writePage(w, p, events)
+}
@@ -0,0 +1,54 @@
+// Copyright (c) Huawei Technologies Co., Ltd. 2025-2025. All rights reserved.
+
+// Package restapi test for timeline api
+package restapi
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/smartystreets/goconvey/convey"
+
+	"clusterd/pkg/common/journal"
+)
+
+func TestListTimeline(t *testing.T) {
+	convey.Convey("test func 'listTimeline'", t, func() {
+		convey.Convey("events should be queried by filter and limited", func() {
+			var filter journal.Filter
+			patches := gomonkey.ApplyFuncReturn(journal.Enabled, true).
+				ApplyFunc(journal.Query, func(f journal.Filter) ([]journal.Event, error) {
+					filter = f
+					return []journal.Event{{Time: 1, Stage: journal.StageReceived, Node: node1},
+						{Time: 2, Stage: journal.StageFiltered, Node: node1}}, nil
+				})
+			defer patches.Reset()
+			var items []journal.Event
+			result := decodeList(serve(http.MethodGet, Path+"timeline?node=node1&since=1&until=3&limit=1"), &items)
+			convey.So(result.Total, convey.ShouldEqual, 2)
+			convey.So(items, convey.ShouldResemble,
+				[]journal.Event{{Time: 1, Stage: journal.StageReceived, Node: node1}})
+			convey.So(filter, convey.ShouldResemble, journal.Filter{Node: node1, Since: 1, Until: 3})
+			convey.So(serve(http.MethodGet, Path+"timeline").Code, convey.ShouldEqual, http.StatusBadRequest)
+			convey.So(serve(http.MethodGet, Path+"timeline?jobId=a&since=-1").Code, convey.ShouldEqual,
+				http.StatusBadRequest)
+			convey.So(serve(http.MethodGet, Path+"timeline?jobId=a&until=b").Code, convey.ShouldEqual,
+				http.StatusBadRequest)
+		})
+		convey.Convey("query failed should return internal error", func() {
+			patches := gomonkey.ApplyFuncReturn(journal.Enabled, true).
+				ApplyFuncReturn(journal.Query, nil, errors.New("read failed"))
+			defer patches.Reset()
+			convey.So(serve(http.MethodGet, Path+"timeline?device=Ascend910-0").Code, convey.ShouldEqual,
+				http.StatusInternalServerError)
+		})
+		convey.Convey("disabled journal should return service unavailable", func() {
+			patches := gomonkey.ApplyFuncReturn(journal.Enabled, false)
+			defer patches.Reset()
+			convey.So(serve(http.MethodGet, Path+"timeline?device=Ascend910-0").Code, convey.ShouldEqual,
+				http.StatusServiceUnavailable)
+		})
+	})
+}
@@ -28,11 +28,13 @@ import (
 	"ascend-common/common-utils/agreement"
 	"ascend-common/common-utils/healthz"
 	"ascend-common/common-utils/hwlog"
+	"ascend-common/common-utils/tlsutil"
 	fdol "ascend-faultdiag-online"
 	"nodeD/pkg/common"
 	snapshot "nodeD/pkg/containersnapshot"
 	"nodeD/pkg/control"
 	"nodeD/pkg/device"
+	"nodeD/pkg/grpcclient"
 	"nodeD/pkg/kubeclient"
 	"nodeD/pkg/monitoring"
 	"nodeD/pkg/monitoring/config"
@@ -87,6 +89,10 @@ var (
 	// deviceResetTimeout device reset timeout duration
 	deviceResetTimeout int
 	hzFlags            = healthz.RegisterFlags()
+	// grpcTLS tls files to connect clusterd
+	grpcTLS tlsutil.Config
+	// grpcTokenFile projected service account token file to authenticate to clusterd
+	grpcTokenFile string
 )
 
 func main() {
@@ -150,6 +156,12 @@ func init() {
 	flag.IntVar(&deviceResetTimeout, api.DeviceResetTimeout, api.DefaultDeviceResetTimeout,
 		"when noded starts, if the number of chips is insufficient, the maximum duration to wait for "+
 			"the driver to report all chips, unit second, range [10, 600]")
+	flag.StringVar(&grpcTLS.CAFile, "grpcCAFile", "",
+		"CA file to verify clusterd grpc server, enable tls to clusterd when set")
+	flag.StringVar(&grpcTLS.CertFile, "grpcCertFile", "", "Client certificate file sent to clusterd")
+	flag.StringVar(&grpcTLS.KeyFile, "grpcKeyFile", "", "Private key file of client certificate")
+	flag.StringVar(&grpcTokenFile, "grpcTokenFile", "",
+		"Projected service account token file sent to clusterd, can be used instead of client certificate")
 }
 
 func checkParameters() bool {
@@ -171,6 +183,10 @@ func checkParameters() bool {
 			api.MinDeviceResetTimeout, api.MaxDeviceResetTimeout)
 		return false
 	}
+	if err := grpcTLS.Validate(); err != nil {
+		hwlog.RunLog.Errorf("grpc tls parameters are invalid, err is %v", err)
+		return false
+	}
 	return true
 }
 
@@ -212,6 +228,11 @@ func createWorkers() error {
 }
 
 func initFunction(ctx context.Context) error {
+	// the grpc reporter of plugins connects clusterd with the security config
+	if err := grpcclient.InitSecurity(ctx, grpcTLS, grpcTokenFile); err != nil {
+		hwlog.RunLog.Errorf("init grpc security failed when start, err is %v", err)
+		return err
+	}
 	if err := processmanager.InitPlugin(ctx); err != nil {
 		hwlog.RunLog.Errorf("init controller failed when start, err is %v", err)
 		return err
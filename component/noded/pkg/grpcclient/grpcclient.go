@@ -22,25 +22,65 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 
 	"ascend-common/common-utils/hwlog"
+	"ascend-common/common-utils/tlsutil"
 	"nodeD/pkg/grpcclient/pubfault"
 )
 
+var (
+	tlsReloader *tlsutil.Reloader
+	tokenCreds  *tlsutil.TokenFileCredentials
+)
+
 // Client is a grpc client struct
 type Client struct {
 	conn *grpc.ClientConn
 	pf   pubfault.PubFaultClient
 }
 
+// InitSecurity enable mutual tls for the connections to clusterd, the files are reloaded when rotated until ctx
+// done. The service account token is sent to authenticate client if tokenFile is set
+func InitSecurity(ctx context.Context, cfg tlsutil.Config, tokenFile string) error {
+	if !cfg.Enabled() {
+		if tokenFile != "" {
+			return errors.New("token can only be sent over tls, ca file should be set")
+		}
+		return nil
+	}
+	reloader, err := tlsutil.NewReloader(cfg)
+	if err != nil {
+		return err
+	}
+	go reloader.Watch(ctx, tlsutil.DefaultReloadInterval)
+	tlsReloader = reloader
+	if tokenFile != "" {
+		tokenCreds = tlsutil.NewTokenFileCredentials(tokenFile)
+	}
+	return nil
+}
+
+func dialOptions() []grpc.DialOption {
+	if tlsReloader == nil {
+		return []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+	// server name is taken from the address
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tlsReloader.ClientConfig("")))}
+	if tokenCreds != nil {
+		opts = append(opts, grpc.WithPerRPCCredentials(tokenCreds))
+	}
+	return opts
+}
+
 // New get a new grpc client
 func New(serverAddr string) (*Client, error) {
 	c := Client{}
 	var err error
 	c.conn, err = grpc.Dial(
 		serverAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		dialOptions()...,
 	)
 	if err != nil {
 		return &Client{}, fmt.Errorf("failed to connect to grpc server: %v", err)
@@ -0,0 +1,78 @@
+/* Copyright(C) 2025. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package grpcclient for grpc client
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/smartystreets/goconvey/convey"
+
+	"ascend-common/common-utils/hwlog"
+	"ascend-common/common-utils/tlsutil"
+)
+
+const (
+	testAddr      = "127.0.0.1:8899"
+	testTokenFile = "/var/run/secrets/tokens/clusterd-token"
+)
+
+func init() {
+	config := hwlog.LogConfig{
+		OnlyToStdout: true,
+	}
+	if err := hwlog.InitRunLogger(&config, context.Background()); err != nil {
+		fmt.Printf("%v", err)
+	}
+}
+
+func TestInitSecurity(t *testing.T) {
+	convey.Convey("Test InitSecurity", t, func() {
+		defer func() {
+			tlsReloader, tokenCreds = nil, nil
+		}()
+		convey.Convey("tls is not configured, should dial insecurely", func() {
+			convey.So(InitSecurity(context.Background(), tlsutil.Config{}, ""), convey.ShouldBeNil)
+			convey.So(len(dialOptions()), convey.ShouldEqual, 1)
+		})
+		convey.Convey("token without tls, should return error", func() {
+			convey.So(InitSecurity(context.Background(), tlsutil.Config{}, testTokenFile), convey.ShouldNotBeNil)
+		})
+		convey.Convey("load tls files failed, should return error", func() {
+			patches := gomonkey.ApplyFuncReturn(tlsutil.NewReloader, (*tlsutil.Reloader)(nil),
+				errors.New("load failed"))
+			defer patches.Reset()
+			err := InitSecurity(context.Background(), tlsutil.Config{CAFile: "ca.crt"}, testTokenFile)
+			convey.So(err, convey.ShouldNotBeNil)
+			convey.So(tlsReloader, convey.ShouldBeNil)
+		})
+		convey.Convey("tls and token are configured, should dial with both", func() {
+			patches := gomonkey.ApplyFuncReturn(tlsutil.NewReloader, &tlsutil.Reloader{}, nil)
+			defer patches.Reset()
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			err := InitSecurity(ctx, tlsutil.Config{CAFile: "ca.crt"}, testTokenFile)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(len(dialOptions()), convey.ShouldEqual, 2)
+			client, err := New(testAddr)
+			convey.So(err, convey.ShouldBeNil)
+			client.SafeClose()
+		})
+	})
+}
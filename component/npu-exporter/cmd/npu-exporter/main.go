@@ -44,10 +44,13 @@ import (
 	"ascend-common/common-utils/limiter"
 	"ascend-common/devmanager"
 	"ascend-common/devmanager/common"
+	"ascend-common/devmanager/hccn"
 	colcommon "huawei.com/npu-exporter/v6/collector/common"
 	"huawei.com/npu-exporter/v6/collector/config"
 	"huawei.com/npu-exporter/v6/collector/container"
+	"huawei.com/npu-exporter/v6/collector/metrics"
 	_ "huawei.com/npu-exporter/v6/platforms/inputs/npu"
+	"huawei.com/npu-exporter/v6/platforms/otlp"
 	"huawei.com/npu-exporter/v6/platforms/prom"
 	"huawei.com/npu-exporter/v6/plugins"
 	"huawei.com/npu-exporter/v6/utils/logger"
@@ -63,9 +66,16 @@ var (
 	containerMode       = ""
 	containerd          = ""
 	endpoint            = ""
+	deviceResolver      = ""
+	podResourcesSocket  = ""
+	workloadLabelKeys   = ""
+	processTopN         int
+	processMaxSeries    int
+	hccnToolCacheTime   int
 	limitIPReq          = ""
 	platform            = ""
 	textMetricsFilePath = ""
+	textMetricsDir      = ""
 	limitIPConn         int
 	limitTotalConn      int
 	cacheSize           int
@@ -75,6 +85,7 @@ var (
 	deviceResetTimeout  int
 	enableLegacyMetrics bool
 	hzFlags             = healthz.RegisterFlags()
+	otlpCfg             otlp.Config
 	isAtlas350Devices   = map[uint32]bool{
 		api.Atlas3504PMainBoardID: true,
 		api.Atlas3502PMainBoardID: true,
@@ -94,6 +105,8 @@ const (
 	containerModeDocker     = "docker"
 	containerModeContainerd = "containerd"
 	containerModeIsula      = "isula"
+	deviceResolverRuntime   = "runtime"
+	deviceResolverPodRes    = "podresources"
 	unixPre                 = "unix://"
 	timeout                 = 10
 	maxHeaderBytes          = 1024
@@ -106,16 +119,20 @@ const (
 	minHccsBWProfilingTime = 1
 	maxHccsBWProfilingTime = 1000
 	defaultShutDownTimeout = 30 * time.Second
+	maxProcessTopN         = 128
+	maxProcessSeries       = 10000
 )
 
 const (
 	prometheusPlatform         = "Prometheus"
 	telegrafPlatform           = "Telegraf"
+	otlpPlatform               = "OTLP"
 	pollIntervalStr            = "poll_interval"
 	platformStr                = "platform"
 	updateTimeStr              = "updateTime"
 	profilingTimeStr           = "profilingTime"
 	textMetricsFilePathStr     = "textMetricsFilePath"
+	textMetricsDirStr          = "textMetricsDir"
 	logLevelStr                = "logLevel"
 	maxAgeStr                  = "maxAge"
 	logFileStr                 = "logFile"
@@ -178,6 +195,8 @@ func main() {
 		prometheusProcss(wg, ctx, cancel)
 	case telegrafPlatform:
 		telegrafProcess()
+	case otlpPlatform:
+		otlpProcess(wg, ctx, cancel)
 	default:
 		err = fmt.Errorf("err platform input")
 	}
@@ -196,10 +215,39 @@ func prometheusProcss(wg *sync.WaitGroup, ctx context.Context, cancel context.Ca
 	}()
 }
 
+// otlpProcess push metrics of the same collectors as prometheus to OpenTelemetry collector
+func otlpProcess(wg *sync.WaitGroup, ctx context.Context, cancel context.CancelFunc) {
+	c := prom.NewPrometheusCollector(colcommon.Collector)
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(c)
+	resource := otlp.Resource{
+		NodeName:     otlp.NodeName(),
+		ChipModel:    colcommon.Collector.Dmgr.GetDevType(),
+		BuildVersion: versions.BuildVersion,
+	}
+	exporter, err := otlp.NewExporter(ctx, otlpCfg, reg, resource)
+	if err != nil {
+		logger.Errorf("create otlp exporter failed, error: %v", err)
+		cancel()
+		return
+	}
+
+	wg.Add(1)
+	go func() {
+		exporter.Run(ctx, time.Duration(updateTime)*time.Second)
+		wg.Done()
+	}()
+}
+
 func initParams() {
 	common.SetHccsBWProfilingTime(hccsBWProfilingTime)
 	common.SetExternalParams(profilingTime)
 	plugins.SetTextMetricsFilePath(textMetricsFilePath)
+	plugins.SetTextMetricsDir(textMetricsDir)
+	metrics.SetProcessLimits(processTopN, processMaxSeries)
+	if err := hccn.SetCacheTTL(time.Duration(hccnToolCacheTime) * time.Second); err != nil {
+		logger.Warnf("set cache time of hccn_tool failed, err: %v", err)
+	}
 }
 
 func paramValid(platform string) error {
@@ -209,6 +257,8 @@ func paramValid(platform string) error {
 		err = paramValidInPrometheus()
 	case telegrafPlatform:
 		err = paramValidInTelegraf()
+	case otlpPlatform:
+		err = paramValidInOTLP()
 	default:
 		err = fmt.Errorf("err platform input")
 	}
@@ -288,6 +338,9 @@ func readCntMonitoringFlags() container.CntNpuMonitorOpts {
 		opts.CriEndpoint = endpoint
 		opts.UseCriBackup = false
 	}
+	if deviceResolver == deviceResolverPodRes {
+		opts.PodResourcesSocket = podResourcesSocket
+	}
 	return opts
 }
 
@@ -311,6 +364,7 @@ func paramValidInPrometheus() error {
 		checkIPAndPortInPrometheus,
 		checkUpdateTime,
 		containerSockCheck,
+		checkDeviceResolver,
 		checkLimitIPReqFormat,
 		checkLimitIPConn,
 		checkLimitTotalConn,
@@ -320,6 +374,30 @@ func paramValidInPrometheus() error {
 		checkHccsBWProfilingTime,
 		checkDeviceResetTimeout,
 		checkPollIntervalInCmdLine,
+		checkProcessLimits,
+		checkHccnToolCacheTime,
+	}
+
+	for _, check := range checks {
+		if err := check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func paramValidInOTLP() error {
+	checks := []func() error{
+		checkUpdateTime,
+		containerSockCheck,
+		checkDeviceResolver,
+		checkProfilingTime,
+		checkHccsBWProfilingTime,
+		checkDeviceResetTimeout,
+		checkPollIntervalInCmdLine,
+		checkProcessLimits,
+		checkHccnToolCacheTime,
+		otlpCfg.Validate,
 	}
 
 	for _, check := range checks {
@@ -396,6 +474,23 @@ func checkDeviceResetTimeout() error {
 	return nil
 }
 
+func checkProcessLimits() error {
+	if processTopN < 1 || processTopN > maxProcessTopN {
+		return errors.New("processTopN range error")
+	}
+	if processMaxSeries < 1 || processMaxSeries > maxProcessSeries {
+		return errors.New("processMaxSeries range error")
+	}
+	return nil
+}
+
+func checkHccnToolCacheTime() error {
+	if hccnToolCacheTime < 0 || time.Duration(hccnToolCacheTime)*time.Second > hccn.MaxCacheTTL {
+		return errors.New("hccnToolCacheTime range error")
+	}
+	return nil
+}
+
 func checkEnableLegacyMetrics(dmgr devmanager.DeviceInterface) {
 	if !enableLegacyMetrics {
 		return
@@ -434,6 +529,27 @@ func containerSockCheck() error {
 	return nil
 }
 
+func checkDeviceResolver() error {
+	switch deviceResolver {
+	case deviceResolverRuntime:
+	case deviceResolverPodRes:
+		if !strings.Contains(podResourcesSocket, ".sock") {
+			return errors.New("podResourcesSocket file is not sock address")
+		}
+		if !strings.Contains(podResourcesSocket, unixPre) {
+			podResourcesSocket = unixPre + podResourcesSocket
+		}
+	default:
+		return fmt.Errorf("deviceResolver should be %s or %s", deviceResolverRuntime, deviceResolverPodRes)
+	}
+	labels, err := container.ParseWorkloadLabels(workloadLabelKeys, colcommon.CardLabel)
+	if err != nil {
+		return err
+	}
+	container.SetWorkloadLabels(labels)
+	return nil
+}
+
 func init() {
 	agreement.PrintAgreement()
 	flag.IntVar(&port, "port", portConst,
@@ -451,6 +567,14 @@ func init() {
 		"The endpoint of containerd used for listening containers' events")
 	flag.StringVar(&endpoint, "endpoint", "",
 		"The endpoint of the CRI  server to which will be connected")
+	flag.StringVar(&deviceResolver, "deviceResolver", deviceResolverRuntime,
+		"how devices are mapped to containers, 'runtime' parses container specs and envs, 'podresources' "+
+			"lists devices by kubelet pod resources api, which also covers devices allocated by CDI or DRA")
+	flag.StringVar(&podResourcesSocket, "podResourcesSocket", container.DefaultPodResourcesSocket,
+		"The socket of kubelet pod resources api, used when -deviceResolver=podresources")
+	flag.StringVar(&workloadLabelKeys, "workloadLabels", "",
+		"comma separated keys of pod labels or annotations reported by npu_container_workload_info, "+
+			"e.g. volcano.sh/job-name,hccl/rankIndex, at most 10 keys")
 	flag.IntVar(&concurrency, "concurrency", defaultConcurrency,
 		"The max concurrency of the http server, range is [1-512]")
 	// hwlog configuration
@@ -471,9 +595,12 @@ func init() {
 	flag.StringVar(&limitIPReq, "limitIPReq", "20/1",
 		"the http request limit counts for each Ip,20/1 means allow 20 request in 1 seconds")
 	flag.StringVar(&platform, platformStr, "Prometheus", "the data reporting platform, "+
-		"just support Prometheus and Telegraf")
+		"support Prometheus, Telegraf and OTLP")
 	flag.StringVar(&textMetricsFilePath, textMetricsFilePathStr, "",
 		"text indicator collection path, support specified multiple file path")
+	flag.StringVar(&textMetricsDir, textMetricsDirStr, "",
+		"directory of Prometheus text exposition files, files with .prom suffix in it are collected, "+
+			"metrics with npu_id label are reported with the labels of the npu")
 	flag.DurationVar(&pollInterval, pollIntervalStr, 1*time.Second,
 		"how often to send metrics when use Telegraf plugin, "+
 			"needs to be used with -platform=Telegraf, otherwise, it does not take effect")
@@ -484,6 +611,33 @@ func init() {
 	flag.IntVar(&deviceResetTimeout, api.DeviceResetTimeout, api.DefaultDeviceResetTimeout,
 		"when npu-exporter starts, if the number of chips is insufficient, the maximum duration to wait for "+
 			"the driver to report all chips, unit second, range [10, 600]")
+	flag.StringVar(&otlpCfg.Endpoint, "otlpEndpoint", "",
+		"endpoint of OpenTelemetry collector when -platform=OTLP, host:port for grpc, "+
+			"url such as https://collector:4318/v1/metrics for http/protobuf")
+	flag.StringVar(&otlpCfg.Protocol, "otlpProtocol", otlp.ProtocolGRPC,
+		"protocol of OTLP, grpc or http/protobuf")
+	flag.BoolVar(&otlpCfg.Insecure, "otlpInsecure", false,
+		"push OTLP metrics in plain text, tls is used by default")
+	flag.StringVar(&otlpCfg.TLS.CAFile, "otlpCAFile", "",
+		"CA file to verify OpenTelemetry collector, system CAs are used when empty")
+	flag.StringVar(&otlpCfg.TLS.CertFile, "otlpCertFile", "",
+		"client certificate file for mutual tls with OpenTelemetry collector, reloaded when rotated")
+	flag.StringVar(&otlpCfg.TLS.KeyFile, "otlpKeyFile", "",
+		"client key file for mutual tls with OpenTelemetry collector, reloaded when rotated")
+	flag.StringVar(&otlpCfg.HeadersFile, "otlpHeadersFile", "",
+		"file of key=value lines sent as OTLP headers, e.g. authorization=Bearer <token>, read on every push")
+	flag.IntVar(&otlpCfg.Timeout, "otlpTimeout", otlp.DefaultTimeout,
+		"timeout seconds of each OTLP push, range is [1, 60]")
+	flag.IntVar(&otlpCfg.MaxBuffered, "otlpMaxBuffered", otlp.DefaultMaxBuffered,
+		"pushes buffered and retried when OpenTelemetry collector is unreachable, range is [1, 100]")
+	flag.IntVar(&processTopN, "processTopN", metrics.DefaultProcessTopN,
+		"number of processes with the most npu memory reported for each npu by the process metrics group, "+
+			"range is [1, 128]")
+	flag.IntVar(&processMaxSeries, "processMaxSeries", metrics.DefaultProcessMaxSeries,
+		"max number of process series reported for all npus by the process metrics group, range is [1, 10000]")
+	flag.IntVar(&hccnToolCacheTime, "hccnToolCacheTime", 0,
+		"seconds to reuse the result of the same hccn_tool command among collectors, 0 means disabled, "+
+			"range is [0, 60]")
 	flag.BoolVar(&enableLegacyMetrics, "enableLegacyMetrics", false,
 		"enable legacy metrics with _X_Y suffix for Atlas 350 backward compatibility, only support Prometheus")
 }
@@ -544,6 +698,7 @@ func paramValidInTelegraf() error {
 		pollIntervalStr:            true,
 		api.HccsBWProfilingTimeStr: true,
 		textMetricsFilePathStr:     true,
+		textMetricsDirStr:          true,
 		updateTimeStr:              true,
 		logLevelStr:                true,
 		maxAgeStr:                  true,
@@ -210,6 +210,17 @@ func GetInfoFromCache[T any](n *NpuCollector, cacheKey string) map[int32]T {
 	return res
 }
 
+// LookupInfoFromCache get info of another collector from cache, ok is false when the cache is not built yet or the
+// type of cache is not T, nothing is logged because the collector of the cache may be disabled
+func LookupInfoFromCache[T any](n *NpuCollector, cacheKey string) (map[int32]T, bool) {
+	obj, err := n.cache.Get(cacheKey)
+	if err != nil {
+		return nil, false
+	}
+	data, ok := obj.(map[int32]T)
+	return data, ok
+}
+
 // GetCacheKey Obtain the name of the struct pointer as the key of the cache
 func GetCacheKey(ptr interface{}) string {
 	v := reflect.ValueOf(ptr)
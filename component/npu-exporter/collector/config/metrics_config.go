@@ -46,6 +46,8 @@ var (
 		groupRoce:        &metrics.RoceCollector{},
 		groupOptical:     &metrics.OpticalCollector{},
 		groupUb:          &metrics.UbCollector{},
+		groupProcess:     &metrics.ProcessCollector{},
+		groupHealth:      &metrics.HealthCollector{},
 	}
 	// singleGoroutineMap filled by classifyCollectors; IsParallel=false collectors go here.
 	singleGoroutineMap = map[string]common.MetricsCollector{}
@@ -81,6 +83,8 @@ const (
 	groupUb          = "ub"
 	groupNodeBase    = "nodeBase"
 	groupUtilization = "utilization"
+	groupProcess     = "process"
+	groupHealth      = "health"
 
 	stateOn  = "ON"
 	stateOFF = "OFF"
@@ -99,11 +103,14 @@ var (
 		buildDefaultConfig(groupPcie, stateOn, defaultIntervalSeconds),
 		buildDefaultConfig(groupVnpu, stateOn, defaultIntervalSeconds),
 		buildDefaultConfig(groupNodeBase, stateOn, maxIntervalSeconds),
+		buildDefaultConfig(groupProcess, stateOFF, intervalSeconds10),
 		// hccn_tool
 		buildDefaultConfig(groupRoce, stateOn, defaultIntervalSeconds),
 		buildDefaultConfig(groupOptical, stateOn, defaultIntervalSeconds),
 		buildDefaultConfig(groupNetwork, stateOn, defaultIntervalSeconds),
 		buildDefaultConfig(groupUb, stateOn, defaultIntervalSeconds),
+		// derived from the caches of other groups
+		buildDefaultConfig(groupHealth, stateOFF, defaultIntervalSeconds),
 	}
 	defaultPluginConfigs = []MetricsGroupConfig{
 		buildDefaultConfig(groupText, stateOn, defaultIntervalSeconds),
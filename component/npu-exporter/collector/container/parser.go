@@ -90,6 +90,9 @@ type CntNpuMonitorOpts struct {
 	UseCriBackup bool   // whether try to use cri backup address
 	OciEndpoint  string // OCI server, now is containerd address
 	UseOciBackup bool   // whether try to use oci backup address
+	// PodResourcesSocket kubelet pod resources socket, devices are resolved by kubelet instead of container specs
+	// when it is set
+	PodResourcesSocket string
 }
 
 // MakeDevicesParser evaluates option settings and make an instance according to it
@@ -103,6 +106,9 @@ func MakeDevicesParser(opts CntNpuMonitorOpts) *DevicesParser {
 	parser := &DevicesParser{
 		RuntimeOperator: runtimeOperator,
 	}
+	if opts.PodResourcesSocket != "" {
+		parser.PodResources = &PodResourcesClient{Socket: opts.PodResourcesSocket}
+	}
 
 	switch opts.EndpointType {
 	case EndpointTypeContainerd:
@@ -131,6 +137,9 @@ type DevicesInfo struct {
 	// container in container mode. The set enables O(1) membership lookup per
 	// container without building an intermediate PID → container map.
 	PIDs map[int32]struct{}
+	// WorkloadLabels values of pod labels or annotations promoted to metric labels, in the order of
+	// GetWorkloadLabels, nil when the pod is not found
+	WorkloadLabels []string
 }
 
 // DevicesInfos the device information storage map
@@ -143,11 +152,18 @@ type DevicesParser struct {
 	err    chan error
 	// configuration
 	RuntimeOperator RuntimeOperator
+	// PodResources resolves devices by kubelet when it is set, container runtime is only used to find container ids,
+	// pids and pod labels
+	PodResources    PodResourcesLister
 	Timeout         time.Duration
+	runtimeDisabled bool
 }
 
 // Init initializes connection to containerd daemon and to CRI server or dockerd daemon based on name fetcher setting
 func (dp *DevicesParser) Init() error {
+	if dp.PodResources != nil {
+		return dp.initWithPodResources()
+	}
 	if err := dp.RuntimeOperator.Init(); err != nil {
 		return contactError(err, "connecting to container runtime failed")
 	}
@@ -156,6 +172,20 @@ func (dp *DevicesParser) Init() error {
 	return nil
 }
 
+func (dp *DevicesParser) initWithPodResources() error {
+	if err := dp.PodResources.Init(); err != nil {
+		return err
+	}
+	if err := dp.RuntimeOperator.Init(); err != nil {
+		logger.Warnf("connecting to container runtime failed, container ids, pids and workload labels are not "+
+			"available: %v", err)
+		dp.runtimeDisabled = true
+	}
+	dp.result = make(chan DevicesInfos, 1)
+	dp.err = make(chan error, 1)
+	return nil
+}
+
 // RecvResult exposes the channel used for receiving devices info analyzing result
 func (dp *DevicesParser) RecvResult() <-chan DevicesInfos {
 	return dp.result
@@ -168,7 +198,12 @@ func (dp *DevicesParser) RecvErr() <-chan error {
 
 // Close closes all connections and channels established during initializing
 func (dp *DevicesParser) Close() {
-	_ = dp.RuntimeOperator.Close()
+	if dp.PodResources != nil {
+		_ = dp.PodResources.Close()
+	}
+	if !dp.runtimeDisabled {
+		_ = dp.RuntimeOperator.Close()
+	}
 }
 
 func (dp *DevicesParser) parseDevices(ctx context.Context, c *CommonContainer, rs chan<- DevicesInfo) error {
@@ -507,6 +542,7 @@ func (dp *DevicesParser) doParse(resultOut chan<- DevicesInfos) {
 	}
 
 	if result != nil {
+		dp.fillWorkloadLabels(ctx, result)
 		dp.result <- result
 	}
 	wg.Wait()
@@ -519,6 +555,10 @@ func (dp *DevicesParser) FetchAndParse(resultOut chan<- DevicesInfos) {
 		logger.Debug("device paster is not initialized")
 		return
 	}
+	if dp.PodResources != nil {
+		go dp.doParseByPodResources(resultOut)
+		return
+	}
 	go dp.doParse(resultOut)
 }
 
@@ -0,0 +1,201 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package container for monitoring containers' npu allocation
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	podresourcesv1 "k8s.io/kubelet/pkg/apis/podresources/v1"
+
+	"ascend-common/api"
+	"huawei.com/npu-exporter/v6/utils/logger"
+)
+
+const (
+	// DefaultPodResourcesSocket default socket of kubelet pod resources api
+	DefaultPodResourcesSocket = "unix:///var/lib/kubelet/pod-resources/kubelet.sock"
+
+	// device id formats allocated by device plugin: Ascend910-0, Ascend910-0-1 in share mode and
+	// Ascend910-2c-100-0 of vnpu, the vnpu id is used as the device id of vnpu
+	commonDeviceIDParts = 2
+	shareDeviceIDParts  = 3
+	vnpuDeviceIDParts   = 4
+	vnpuIDIndex         = 2
+	shareDeviceIDSuffix = "_"
+)
+
+// PodResourcesLister lists npu devices allocated to containers by kubelet
+type PodResourcesLister interface {
+	Init() error
+	Close() error
+	List(ctx context.Context) (DevicesInfos, error)
+}
+
+// PodResourcesClient lists devices by kubelet pod resources api, devices which are allocated by CDI or DRA are
+// also reported by kubelet, which can not be found in container specs
+type PodResourcesClient struct {
+	conn   *grpc.ClientConn
+	client podresourcesv1.PodResourcesListerClient
+	// Socket endpoint of kubelet pod resources api
+	Socket string
+}
+
+// Init connects to kubelet pod resources socket
+func (c *PodResourcesClient) Init() error {
+	conn, err := GetConnection(c.Socket)
+	if err != nil {
+		return fmt.Errorf("connecting to kubelet pod resources failed: %v", err)
+	}
+	c.conn = conn
+	c.client = podresourcesv1.NewPodResourcesListerClient(conn)
+	return nil
+}
+
+// Close closes connection to kubelet
+func (c *PodResourcesClient) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+// List returns npu devices of containers, key is the container name with format PodNameSpace_PodName_ContainerName
+// because container id is not reported by kubelet
+func (c *PodResourcesClient) List(ctx context.Context) (DevicesInfos, error) {
+	if c.client == nil {
+		return nil, errors.New("pod resources client is empty")
+	}
+	resp, err := c.client.List(ctx, &podresourcesv1.ListPodResourcesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("list pod resources failed: %v", err)
+	}
+	return parsePodResources(resp.GetPodResources()), nil
+}
+
+func parsePodResources(pods []*podresourcesv1.PodResources) DevicesInfos {
+	result := make(DevicesInfos)
+	for _, pod := range pods {
+		for _, ctr := range pod.GetContainers() {
+			devices := containerNPUDevices(ctr)
+			if len(devices) == 0 {
+				continue
+			}
+			name := pod.GetNamespace() + nameSeparator + pod.GetName() + nameSeparator + ctr.GetName()
+			result[name] = DevicesInfo{ID: name, Name: name, Devices: devices, PIDs: map[int32]struct{}{}}
+		}
+	}
+	return result
+}
+
+func containerNPUDevices(ctr *podresourcesv1.ContainerResources) []int {
+	var devices []int
+	exists := make(map[int]struct{})
+	for _, dev := range ctr.GetDevices() {
+		if !strings.HasPrefix(dev.GetResourceName(), api.ResourceNamePrefix) {
+			continue
+		}
+		for _, deviceID := range dev.GetDeviceIds() {
+			id, err := parseAllocatedDeviceID(deviceID)
+			if err != nil {
+				logger.Debugf("skip device %s of container %s, err: %v", deviceID, ctr.GetName(), err)
+				continue
+			}
+			if _, ok := exists[id]; ok {
+				continue
+			}
+			exists[id] = struct{}{}
+			devices = append(devices, id)
+		}
+	}
+	return devices
+}
+
+func parseAllocatedDeviceID(deviceID string) (int, error) {
+	parts := strings.Split(deviceID, minus)
+	var idStr string
+	switch len(parts) {
+	case commonDeviceIDParts, shareDeviceIDParts:
+		idStr, _, _ = strings.Cut(parts[1], shareDeviceIDSuffix)
+	case vnpuDeviceIDParts:
+		idStr = parts[vnpuIDIndex]
+	default:
+		return 0, fmt.Errorf("unknown device id format")
+	}
+	id, err := strconv.Atoi(idStr)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("invalid device id %s", idStr)
+	}
+	return id, nil
+}
+
+// fillContainers sets container id and pids of containers found in container runtime, the container name is kept as
+// the id of containers which are not found
+func (dp *DevicesParser) fillContainers(ctx context.Context, infos DevicesInfos) DevicesInfos {
+	if len(infos) == 0 || dp.runtimeDisabled {
+		return infos
+	}
+	containers, err := dp.RuntimeOperator.GetContainers(ctx)
+	if err != nil {
+		logger.Warnf("get containers from container runtime failed, container ids and pids are not available: %v", err)
+		return infos
+	}
+	result := make(DevicesInfos, len(infos))
+	for _, c := range containers {
+		name := c.Labels[labelK8sPodNamespace] + nameSeparator + c.Labels[labelK8sPodName] + nameSeparator +
+			c.Labels[labelContainerName]
+		info, ok := infos[name]
+		if !ok {
+			continue
+		}
+		delete(infos, name)
+		info.ID = c.Id
+		pids, err := dp.RuntimeOperator.GetContainerPIDs(ctx, c.Id)
+		if err != nil {
+			logger.Debugf("failed to get container %s pids: %v", c.Id, err)
+		}
+		info.PIDs = toPIDSet(pids)
+		result[info.ID] = info
+	}
+	for name, info := range infos {
+		result[name] = info
+	}
+	return result
+}
+
+func (dp *DevicesParser) doParseByPodResources(resultOut chan<- DevicesInfos) {
+	var result DevicesInfos
+	defer func() {
+		if resultOut != nil {
+			resultOut <- result
+			close(resultOut)
+		}
+	}()
+	ctx, cancelFn := context.WithTimeout(context.Background(), withDefault(dp.Timeout, parsingNpuDefaultTimeout))
+	defer cancelFn()
+	infos, err := dp.PodResources.List(ctx)
+	if err != nil {
+		dp.err <- err
+		return
+	}
+	result = dp.fillContainers(ctx, infos)
+	dp.fillWorkloadLabels(ctx, result)
+	dp.result <- result
+}
@@ -0,0 +1,168 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package container test for resolving devices by kubelet pod resources
+package container
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+	"google.golang.org/grpc"
+	podresourcesv1 "k8s.io/kubelet/pkg/apis/podresources/v1"
+
+	"huawei.com/npu-exporter/v6/collector/container/isula"
+	"huawei.com/npu-exporter/v6/collector/container/v1"
+)
+
+const (
+	testResNamespace = "default"
+	testResPodName   = "job-master-0"
+	testCtrName      = "ascend"
+	testCtrID        = "ctr-id-0"
+	testCtrFullName  = testResNamespace + "_" + testResPodName + "_" + testCtrName
+	testPid          = 100
+	testJobLabel     = "volcano.sh/job-name"
+	testJobName      = "job"
+	testRankIndex    = "0"
+)
+
+type fakePodResourcesClient struct {
+	resp *podresourcesv1.ListPodResourcesResponse
+	err  error
+}
+
+func (f *fakePodResourcesClient) List(context.Context, *podresourcesv1.ListPodResourcesRequest,
+	...grpc.CallOption) (*podresourcesv1.ListPodResourcesResponse, error) {
+	return f.resp, f.err
+}
+
+func (f *fakePodResourcesClient) GetAllocatableResources(context.Context, *podresourcesv1.AllocatableResourcesRequest,
+	...grpc.CallOption) (*podresourcesv1.AllocatableResourcesResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+// fakeRuntime runtime with one running container of the test pod
+type fakeRuntime struct {
+	RuntimeOperator
+	sandboxErr error
+}
+
+func (f *fakeRuntime) GetContainers(context.Context) ([]*CommonContainer, error) {
+	return []*CommonContainer{{Id: testCtrID, Labels: map[string]string{labelK8sPodNamespace: testResNamespace,
+		labelK8sPodName: testResPodName, labelContainerName: testCtrName}}}, nil
+}
+
+func (f *fakeRuntime) GetContainerPIDs(context.Context, string) ([]uint32, error) {
+	return []uint32{testPid}, nil
+}
+
+func (f *fakeRuntime) GetContainerInfoByID(context.Context, string) (v1.Spec, error) {
+	return v1.Spec{}, nil
+}
+
+func (f *fakeRuntime) GetIsulaContainerInfoByID(context.Context, string) (isula.ContainerJson, error) {
+	return isula.ContainerJson{}, nil
+}
+
+func (f *fakeRuntime) GetPodSandboxes(context.Context) ([]*PodSandbox, error) {
+	return []*PodSandbox{{Namespace: testResNamespace, Name: testResPodName,
+		Labels:      map[string]string{testJobLabel: testJobName},
+		Annotations: map[string]string{"hccl/rankIndex": testRankIndex}}}, f.sandboxErr
+}
+
+func newTestPodResources() *podresourcesv1.ListPodResourcesResponse {
+	return &podresourcesv1.ListPodResourcesResponse{PodResources: []*podresourcesv1.PodResources{
+		{Namespace: testResNamespace, Name: testResPodName, Containers: []*podresourcesv1.ContainerResources{
+			{Name: testCtrName, Devices: []*podresourcesv1.ContainerDevices{
+				{ResourceName: "huawei.com/Ascend910", DeviceIds: []string{"Ascend910-0", "Ascend910-1"}},
+				{ResourceName: "nvidia.com/gpu", DeviceIds: []string{"GPU-2"}},
+			}},
+			{Name: "sidecar"},
+		}},
+		{Namespace: testResNamespace, Name: "vnpu-pod", Containers: []*podresourcesv1.ContainerResources{
+			{Name: testCtrName, Devices: []*podresourcesv1.ContainerDevices{
+				{ResourceName: "huawei.com/Ascend310P-2c", DeviceIds: []string{"Ascend310P-2c-100-3"}},
+			}},
+		}},
+	}}
+}
+
+func TestParseAllocatedDeviceID(t *testing.T) {
+	convey.Convey("test func 'parseAllocatedDeviceID'", t, func() {
+		testCases := map[string]int{"Ascend910-3": 3, "Ascend910-3_1": 3, "Ascend310P-3-1": 3,
+			"Ascend310P-2c-100-3": 100}
+		for deviceID, expected := range testCases {
+			id, err := parseAllocatedDeviceID(deviceID)
+			convey.So(err, convey.ShouldBeNil)
+			convey.So(id, convey.ShouldEqual, expected)
+		}
+		_, err := parseAllocatedDeviceID("Ascend910")
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = parseAllocatedDeviceID("Ascend910-x")
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+}
+
+func TestPodResourcesClientList(t *testing.T) {
+	convey.Convey("test method 'List' of PodResourcesClient", t, func() {
+		client := &PodResourcesClient{client: &fakePodResourcesClient{resp: newTestPodResources()}}
+		infos, err := client.List(context.Background())
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(infos), convey.ShouldEqual, 2)
+		convey.So(infos[testCtrFullName].Devices, convey.ShouldResemble, []int{device0, device1})
+		convey.So(infos[testResNamespace+"_vnpu-pod_"+testCtrName].Devices, convey.ShouldResemble, []int{100})
+
+		client.client = &fakePodResourcesClient{err: errors.New("unavailable")}
+		_, err = client.List(context.Background())
+		convey.So(err, convey.ShouldNotBeNil)
+		_, err = (&PodResourcesClient{}).List(context.Background())
+		convey.So(err, convey.ShouldNotBeNil)
+	})
+}
+
+func TestDoParseByPodResources(t *testing.T) {
+	convey.Convey("test method 'doParseByPodResources' of DevicesParser", t, func() {
+		SetWorkloadLabels([]WorkloadLabel{{Key: testJobLabel, Name: "volcano_sh_job_name"},
+			{Key: "hccl/rankIndex", Name: "hccl_rankIndex"}})
+		defer SetWorkloadLabels(nil)
+		dp := &DevicesParser{
+			RuntimeOperator: &fakeRuntime{},
+			PodResources:    &PodResourcesClient{client: &fakePodResourcesClient{resp: newTestPodResources()}},
+			result:          make(chan DevicesInfos, 1),
+			err:             make(chan error, 1),
+		}
+		out := make(chan DevicesInfos, 1)
+		dp.FetchAndParse(out)
+		result := <-out
+		convey.So(len(result), convey.ShouldEqual, 2)
+		info := result[testCtrID]
+		convey.So(info.Name, convey.ShouldEqual, testCtrFullName)
+		convey.So(info.PIDs, convey.ShouldContainKey, int32(testPid))
+		convey.So(info.WorkloadLabels, convey.ShouldResemble, []string{testJobName, testRankIndex})
+		// container not found in runtime is kept with its name as id
+		convey.So(result[testResNamespace+"_vnpu-pod_"+testCtrName].WorkloadLabels, convey.ShouldBeNil)
+		convey.So(len(<-dp.RecvResult()), convey.ShouldEqual, 2)
+
+		convey.Convey("runtime is not available, devices are still resolved", func() {
+			dp.runtimeDisabled = true
+			dp.FetchAndParse(nil)
+			result := <-dp.RecvResult()
+			convey.So(result[testCtrFullName].Devices, convey.ShouldResemble, []int{device0, device1})
+			convey.So(result[testCtrFullName].WorkloadLabels, convey.ShouldBeNil)
+		})
+	})
+}
@@ -264,6 +264,61 @@ func (operator *RuntimeOperatorTool) getContainerdContainers(ctx context.Context
 	return containers, err
 }
 
+// GetPodSandboxes returns labels and annotations of ready pod sandboxes, isula is not supported
+func (operator *RuntimeOperatorTool) GetPodSandboxes(ctx context.Context) ([]*PodSandbox, error) {
+	if utils.IsNil(operator.criClient) || operator.criConn == nil {
+		return nil, errors.New("criClient is empty")
+	}
+	client, ok := operator.criClient.(v1alpha2.RuntimeServiceClient)
+	if !ok {
+		return nil, errors.New("listing pod sandboxes is not supported by the container runtime")
+	}
+	if operator.criVersion == criVersionV1alpha2 {
+		return getPodSandboxesV1alpha2(ctx, client)
+	}
+	sandboxes, err := getPodSandboxesV1(ctx, criv1.NewRuntimeServiceClient(operator.criConn))
+	if isUnimplementedError(err, criV1) {
+		logger.Infof("CRI v1 not supported, falling back to v1alpha2")
+		operator.criVersion = criVersionV1alpha2
+		return getPodSandboxesV1alpha2(ctx, client)
+	}
+	return sandboxes, err
+}
+
+func getPodSandboxesV1(ctx context.Context, client criv1.RuntimeServiceClient) ([]*PodSandbox, error) {
+	r, err := client.ListPodSandbox(ctx, &criv1.ListPodSandboxRequest{Filter: &criv1.PodSandboxFilter{
+		State: &criv1.PodSandboxStateValue{State: criv1.PodSandboxState_SANDBOX_READY}}})
+	if err != nil {
+		return nil, err
+	}
+	sandboxes := make([]*PodSandbox, 0, len(r.Items))
+	for _, item := range r.Items {
+		if item.Metadata == nil {
+			continue
+		}
+		sandboxes = append(sandboxes, &PodSandbox{Namespace: item.Metadata.Namespace, Name: item.Metadata.Name,
+			Labels: item.Labels, Annotations: item.Annotations})
+	}
+	return sandboxes, nil
+}
+
+func getPodSandboxesV1alpha2(ctx context.Context, client v1alpha2.RuntimeServiceClient) ([]*PodSandbox, error) {
+	r, err := client.ListPodSandbox(ctx, &v1alpha2.ListPodSandboxRequest{Filter: &v1alpha2.PodSandboxFilter{
+		State: &v1alpha2.PodSandboxStateValue{State: v1alpha2.PodSandboxState_SANDBOX_READY}}})
+	if err != nil {
+		return nil, err
+	}
+	sandboxes := make([]*PodSandbox, 0, len(r.Items))
+	for _, item := range r.Items {
+		if item.Metadata == nil {
+			continue
+		}
+		sandboxes = append(sandboxes, &PodSandbox{Namespace: item.Metadata.Namespace, Name: item.Metadata.Name,
+			Labels: item.Labels, Annotations: item.Annotations})
+	}
+	return sandboxes, nil
+}
+
 func isUnimplementedError(err error, serviceName string) bool {
 	if err == nil {
 		return false
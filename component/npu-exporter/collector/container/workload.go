@@ -0,0 +1,163 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package container for monitoring containers' npu allocation
+package container
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"huawei.com/npu-exporter/v6/utils/logger"
+)
+
+const (
+	maxWorkloadLabels     = 10
+	maxWorkloadLabelValue = 256
+	nameSeparator         = "_"
+	namePartsNum          = 3
+)
+
+var (
+	invalidLabelNameChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+	// workloadLabels pod labels or annotations promoted to metric labels, set once at start up
+	workloadLabels []WorkloadLabel
+)
+
+// WorkloadLabel pod label or annotation which is promoted to metric label
+type WorkloadLabel struct {
+	// Key of pod label or annotation, e.g. volcano.sh/job-name
+	Key string
+	// Name of metric label, characters which are not allowed by prometheus are replaced by "_"
+	Name string
+}
+
+// PodSandbox labels and annotations of pod, which are copied to the pod sandbox by kubelet
+type PodSandbox struct {
+	Namespace   string
+	Name        string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// PodSandboxLister is implemented by container runtime which can list pod sandboxes
+type PodSandboxLister interface {
+	GetPodSandboxes(ctx context.Context) ([]*PodSandbox, error)
+}
+
+// ParseWorkloadLabels parses comma separated keys of pod labels or annotations, reserved are the names of labels
+// already used by metrics
+func ParseWorkloadLabels(keys string, reserved []string) ([]WorkloadLabel, error) {
+	if strings.TrimSpace(keys) == "" {
+		return nil, nil
+	}
+	used := make(map[string]struct{}, len(reserved))
+	for _, name := range reserved {
+		used[name] = struct{}{}
+	}
+	var labels []WorkloadLabel
+	for _, key := range strings.Split(keys, comma) {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		name := invalidLabelNameChars.ReplaceAllString(key, nameSeparator)
+		if name[0] >= '0' && name[0] <= '9' {
+			name = nameSeparator + name
+		}
+		if strings.HasPrefix(name, "__") {
+			return nil, fmt.Errorf("workload label %s is reserved by prometheus", key)
+		}
+		if _, ok := used[name]; ok {
+			return nil, fmt.Errorf("workload label %s is duplicated or conflicts with label %s", key, name)
+		}
+		used[name] = struct{}{}
+		labels = append(labels, WorkloadLabel{Key: key, Name: name})
+	}
+	if len(labels) > maxWorkloadLabels {
+		return nil, fmt.Errorf("at most %d workload labels are supported", maxWorkloadLabels)
+	}
+	return labels, nil
+}
+
+// SetWorkloadLabels sets the pod labels or annotations promoted to metric labels
+func SetWorkloadLabels(labels []WorkloadLabel) {
+	workloadLabels = labels
+}
+
+// GetWorkloadLabels returns the pod labels or annotations promoted to metric labels
+func GetWorkloadLabels() []WorkloadLabel {
+	return workloadLabels
+}
+
+// WorkloadLabelNames returns the metric label names of workload labels
+func WorkloadLabelNames() []string {
+	names := make([]string, 0, len(workloadLabels))
+	for _, label := range workloadLabels {
+		names = append(names, label.Name)
+	}
+	return names
+}
+
+// workloadLabelValues values of workload labels, pod labels are preferred to annotations
+func workloadLabelValues(sandbox *PodSandbox) []string {
+	values := make([]string, 0, len(workloadLabels))
+	for _, label := range workloadLabels {
+		value, ok := sandbox.Labels[label.Key]
+		if !ok {
+			value = sandbox.Annotations[label.Key]
+		}
+		if len(value) > maxWorkloadLabelValue {
+			value = value[:maxWorkloadLabelValue]
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+// fillWorkloadLabels fills workload label values of containers by the pod sandbox they belong to
+func (dp *DevicesParser) fillWorkloadLabels(ctx context.Context, infos DevicesInfos) {
+	if len(workloadLabels) == 0 || len(infos) == 0 {
+		return
+	}
+	lister, ok := dp.RuntimeOperator.(PodSandboxLister)
+	if !ok || dp.runtimeDisabled {
+		logger.Debug("container runtime can not list pod sandboxes, workload labels are not available")
+		return
+	}
+	sandboxes, err := lister.GetPodSandboxes(ctx)
+	if err != nil {
+		logger.Warnf("list pod sandboxes failed, workload labels are not available: %v", err)
+		return
+	}
+	pods := make(map[string]*PodSandbox, len(sandboxes))
+	for _, sandbox := range sandboxes {
+		pods[sandbox.Namespace+nameSeparator+sandbox.Name] = sandbox
+	}
+	for key, info := range infos {
+		// name format is PodNameSpace_PodName_ContainerName, "_" is not allowed in any of them
+		parts := strings.SplitN(info.Name, nameSeparator, namePartsNum)
+		if len(parts) != namePartsNum {
+			continue
+		}
+		sandbox, ok := pods[parts[0]+nameSeparator+parts[1]]
+		if !ok {
+			continue
+		}
+		info.WorkloadLabels = workloadLabelValues(sandbox)
+		infos[key] = info
+	}
+}
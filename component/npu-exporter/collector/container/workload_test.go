@@ -0,0 +1,79 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package container test for workload labels of pods
+package container
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/smartystreets/goconvey/convey"
+)
+
+var testReservedLabels = []string{"id", "namespace", "pod_name"}
+
+func TestParseWorkloadLabels(t *testing.T) {
+	convey.Convey("test func 'ParseWorkloadLabels'", t, func() {
+		labels, err := ParseWorkloadLabels(" volcano.sh/job-name, hccl/rankIndex,,1key ", testReservedLabels)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(labels, convey.ShouldResemble, []WorkloadLabel{
+			{Key: "volcano.sh/job-name", Name: "volcano_sh_job_name"},
+			{Key: "hccl/rankIndex", Name: "hccl_rankIndex"},
+			{Key: "1key", Name: "_1key"},
+		})
+		labels, err = ParseWorkloadLabels("", testReservedLabels)
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(labels, convey.ShouldBeNil)
+		convey.Convey("duplicated, conflicted, reserved or too many labels, should return error", func() {
+			_, err = ParseWorkloadLabels("a.b,a/b", testReservedLabels)
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = ParseWorkloadLabels("pod_name", testReservedLabels)
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = ParseWorkloadLabels("__name", testReservedLabels)
+			convey.So(err, convey.ShouldNotBeNil)
+			keys := make([]string, 0, maxWorkloadLabels+1)
+			for i := 0; i <= maxWorkloadLabels; i++ {
+				keys = append(keys, "key"+strings.Repeat("a", i))
+			}
+			_, err = ParseWorkloadLabels(strings.Join(keys, comma), testReservedLabels)
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestFillWorkloadLabels(t *testing.T) {
+	convey.Convey("test method 'fillWorkloadLabels' of DevicesParser", t, func() {
+		SetWorkloadLabels([]WorkloadLabel{{Key: "hccl/rankIndex", Name: "hccl_rankIndex"},
+			{Key: "missing", Name: "missing"}})
+		defer SetWorkloadLabels(nil)
+		convey.So(WorkloadLabelNames(), convey.ShouldResemble, []string{"hccl_rankIndex", "missing"})
+		runtime := &fakeRuntime{}
+		dp := &DevicesParser{RuntimeOperator: runtime}
+		infos := DevicesInfos{testCtrID: {ID: testCtrID, Name: testCtrFullName}, "other": {ID: "other",
+			Name: "kube-system_other_ctr"}}
+		dp.fillWorkloadLabels(context.Background(), infos)
+		convey.So(infos[testCtrID].WorkloadLabels, convey.ShouldResemble, []string{testRankIndex, ""})
+		convey.So(infos["other"].WorkloadLabels, convey.ShouldBeNil)
+
+		convey.Convey("list pod sandboxes failed, labels should not be filled", func() {
+			runtime.sandboxErr = errors.New("unavailable")
+			infos = DevicesInfos{testCtrID: {ID: testCtrID, Name: testCtrFullName}}
+			dp.fillWorkloadLabels(context.Background(), infos)
+			convey.So(infos[testCtrID].WorkloadLabels, convey.ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,537 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package metrics for general collector
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"ascend-common/devmanager/common"
+	colcommon "huawei.com/npu-exporter/v6/collector/common"
+	"huawei.com/npu-exporter/v6/collector/container"
+	"huawei.com/npu-exporter/v6/utils/logger"
+)
+
+const (
+	// HealthConfigPath is the path to the rules of chip health score, default rules are used when it does not exist
+	HealthConfigPath = "/user/mind-cluster/npu-exporter-config/healthConfiguration.json"
+
+	// counter signals, the rate of them is the increase per minute in the history window
+	signalHbmSingleBitError = "hbm_single_bit_error"
+	signalHbmDoubleBitError = "hbm_double_bit_error"
+	signalHccsCrcError      = "hccs_crc_error"
+	signalSioCrcError       = "sio_crc_error"
+	signalRoceErrorPackets  = "roce_error_packets"
+	signalLinkUpCount       = "link_up_count"
+	// gauge signals
+	signalHbmIsolatedPages   = "hbm_isolated_pages"
+	signalTemperature        = "temperature"
+	signalLinkDown           = "link_down"
+	signalOpticalRxPowerMin  = "optical_rx_power_min"
+	signalOpticalTemperature = "optical_temperature"
+
+	healthHistoryWindow  = 10 * time.Minute
+	maxHealthHistorySize = 64
+	maxHealthScore       = 100
+	maxHealthRules       = 64
+	healthReasonNone     = "none"
+
+	opGreater      = ">"
+	opGreaterEqual = ">="
+	opLess         = "<"
+	opLessEqual    = "<="
+)
+
+var (
+	healthCounterSignals = map[string]bool{
+		signalHbmSingleBitError:  true,
+		signalHbmDoubleBitError:  true,
+		signalHccsCrcError:       true,
+		signalSioCrcError:        true,
+		signalRoceErrorPackets:   true,
+		signalLinkUpCount:        true,
+		signalHbmIsolatedPages:   false,
+		signalTemperature:        false,
+		signalLinkDown:           false,
+		signalOpticalRxPowerMin:  false,
+		signalOpticalTemperature: false,
+	}
+
+	defaultHealthRules = []healthRule{
+		{Name: "hbm_double_bit_error_increase", Signal: signalHbmDoubleBitError, Rate: true, Operator: opGreater,
+			Threshold: 0, Penalty: 50},
+		{Name: "hbm_isolated_pages", Signal: signalHbmIsolatedPages, Operator: opGreater, Threshold: 0, Penalty: 20},
+		{Name: "hbm_single_bit_error_increase", Signal: signalHbmSingleBitError, Rate: true, Operator: opGreater,
+			Threshold: 0, Penalty: 10},
+		{Name: "link_down", Signal: signalLinkDown, Operator: opGreater, Threshold: 0, Penalty: 40},
+		{Name: "link_flapping", Signal: signalLinkUpCount, Rate: true, Operator: opGreater, Threshold: 0, Penalty: 20},
+		{Name: "hccs_crc_error_increase", Signal: signalHccsCrcError, Rate: true, Operator: opGreater, Threshold: 0,
+			Penalty: 20},
+		{Name: "sio_crc_error_increase", Signal: signalSioCrcError, Rate: true, Operator: opGreater, Threshold: 0,
+			Penalty: 20},
+		{Name: "roce_error_packets_increase", Signal: signalRoceErrorPackets, Rate: true, Operator: opGreater,
+			Threshold: 10, Penalty: 10},
+		{Name: "high_temperature", Signal: signalTemperature, Operator: opGreaterEqual, Threshold: 95, Penalty: 20},
+		{Name: "high_optical_temperature", Signal: signalOpticalTemperature, Operator: opGreaterEqual,
+			Threshold: 75, Penalty: 10},
+	}
+
+	cardLabelForHealthScore = append(append(make([]string, 0, len(colcommon.CardLabel)+1),
+		colcommon.CardLabel...), "reason")
+	cardLabelForHealthRate = append(append(make([]string, 0, len(colcommon.CardLabel)+1),
+		colcommon.CardLabel...), "signal")
+	cardLabelForHealthRule = append(append(make([]string, 0, len(colcommon.CardLabel)+1),
+		colcommon.CardLabel...), "rule")
+
+	descHealthScore = colcommon.BuildDescWithLabel("npu_chip_health_score",
+		"the health score of the npu chip from 0 to 100 derived by the health rules, reason is the breached rule "+
+			"with the highest penalty, none when no rule is breached", cardLabelForHealthScore)
+	descHealthSignalRate = colcommon.BuildDescWithLabel("npu_chip_health_signal_rate",
+		"the increase per minute of the error counter of the npu chip in the recent 10 minutes", cardLabelForHealthRate)
+	descHealthRuleBreached = colcommon.BuildDescWithLabel("npu_chip_health_rule_breached",
+		"whether the health rule is breached by the npu chip, 1 is breached and 0 is not", cardLabelForHealthRule)
+)
+
+// healthRule a threshold of a signal, the penalty is subtracted from the health score when it is breached
+type healthRule struct {
+	Name      string  `json:"name"`
+	Signal    string  `json:"signal"`
+	Rate      bool    `json:"rate"`
+	Operator  string  `json:"operator"`
+	Threshold float64 `json:"threshold"`
+	Penalty   int     `json:"penalty"`
+}
+
+func (r healthRule) breached(value float64) bool {
+	switch r.Operator {
+	case opGreater:
+		return value > r.Threshold
+	case opGreaterEqual:
+		return value >= r.Threshold
+	case opLess:
+		return value < r.Threshold
+	case opLessEqual:
+		return value <= r.Threshold
+	default:
+		return false
+	}
+}
+
+func validateHealthRules(rules []healthRule) error {
+	if len(rules) > maxHealthRules {
+		return fmt.Errorf("the number of rules is more than %d", maxHealthRules)
+	}
+	names := make(map[string]struct{}, len(rules))
+	for _, rule := range rules {
+		if rule.Name == "" {
+			return fmt.Errorf("rule name is empty")
+		}
+		if _, ok := names[rule.Name]; ok {
+			return fmt.Errorf("rule %s is duplicated", rule.Name)
+		}
+		names[rule.Name] = struct{}{}
+		isCounter, ok := healthCounterSignals[rule.Signal]
+		if !ok {
+			return fmt.Errorf("signal %s of rule %s is unknown", rule.Signal, rule.Name)
+		}
+		if rule.Rate && !isCounter {
+			return fmt.Errorf("signal %s of rule %s is not a counter, rate is not supported", rule.Signal, rule.Name)
+		}
+		switch rule.Operator {
+		case opGreater, opGreaterEqual, opLess, opLessEqual:
+		default:
+			return fmt.Errorf("operator %s of rule %s is not supported", rule.Operator, rule.Name)
+		}
+		if rule.Penalty < 0 || rule.Penalty > maxHealthScore {
+			return fmt.Errorf("penalty %d of rule %s is out of range [0, %d]", rule.Penalty, rule.Name,
+				maxHealthScore)
+		}
+	}
+	return nil
+}
+
+type healthSample struct {
+	value     float64
+	timestamp time.Time
+}
+
+type ruleResult struct {
+	name     string
+	breached bool
+}
+
+type healthCache struct {
+	chip      colcommon.HuaWeiAIChip
+	timestamp time.Time
+	// valid whether any signal of the chip is collected, metrics are not reported when it is false
+	valid  bool
+	score  int
+	reason string
+	// rates increase per minute of counter signals
+	rates map[string]float64
+	// rules results of the rules whose signal is collected
+	rules []ruleResult
+}
+
+// HealthCollector derives health score of chips from the caches of hbm, network, optical, hccs, roce and sio
+// collectors, it keeps a short history of error counters to compute their rate
+type HealthCollector struct {
+	colcommon.MetricsCollectorAdapter
+	mu sync.Mutex
+	// history samples of counter signals by chip phyID and signal
+	history map[int32]map[string][]healthSample
+	rules   []healthRule
+	// rulesModTime modification time of the loaded config file, zero when default rules are used
+	rulesModTime time.Time
+}
+
+// Describe description of the metric
+func (c *HealthCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- descHealthScore
+	ch <- descHealthSignalRate
+	ch <- descHealthRuleBreached
+}
+
+// CollectToCache collect the metric to cache
+func (c *HealthCollector) CollectToCache(n *colcommon.NpuCollector, chipList []colcommon.HuaWeiAIChip) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loadRules(HealthConfigPath)
+	if c.history == nil {
+		c.history = make(map[int32]map[string][]healthSample)
+	}
+	sources := lookupHealthSources(n)
+	now := time.Now()
+	for _, chip := range chipList {
+		signals := sources.signals(chip.PhyId)
+		cache := healthCache{chip: chip, timestamp: now, valid: len(signals) > 0, rates: c.updateRates(chip.PhyId,
+			signals, now)}
+		cache.score, cache.reason, cache.rules = evaluateHealthRules(c.rules, signals, cache.rates)
+		c.LocalCache.Store(chip.PhyId, cache)
+	}
+	colcommon.UpdateCache[healthCache](n, colcommon.GetCacheKey(c), &c.LocalCache)
+}
+
+// UpdatePrometheus update prometheus metrics
+func (c *HealthCollector) UpdatePrometheus(ch chan<- prometheus.Metric, n *colcommon.NpuCollector,
+	containerMap map[int32][]container.DevicesInfo, chips []colcommon.HuaWeiAIChip) {
+	updateSingleChip := func(chipWithVnpu colcommon.HuaWeiAIChip, cache healthCache, cardLabel []string) {
+		if !cache.valid {
+			return
+		}
+		doUpdateMetric(ch, cache.timestamp, cache.score, appendLabel(cardLabel, cache.reason), descHealthScore)
+		for signal, rate := range cache.rates {
+			doUpdateMetric(ch, cache.timestamp, rate, appendLabel(cardLabel, signal), descHealthSignalRate)
+		}
+		for _, result := range cache.rules {
+			doUpdateMetric(ch, cache.timestamp, boolToInt(result.breached), appendLabel(cardLabel, result.name),
+				descHealthRuleBreached)
+		}
+	}
+	updateFrame[healthCache](colcommon.GetCacheKey(c), n, containerMap, chips, updateSingleChip)
+}
+
+// UpdateTelegraf update telegraf metrics
+func (c *HealthCollector) UpdateTelegraf(ch chan<- colcommon.TelegrafMetric, n *colcommon.NpuCollector,
+	containerMap map[int32][]container.DevicesInfo, chips []colcommon.HuaWeiAIChip) {
+	caches := colcommon.GetInfoFromCache[healthCache](n, colcommon.GetCacheKey(c))
+	for _, chip := range chips {
+		cache, ok := caches[chip.PhyId]
+		if !ok {
+			logger.Debugf("cacheKey(%v) not found", chip.PhyId)
+			continue
+		}
+		if !cache.valid {
+			continue
+		}
+		metric := colcommon.NewDeviceMetric(cache.chip.LogicID)
+		doUpdateTelegraf(metric.Fields, descHealthScore, cache.score, "")
+		for signal, rate := range cache.rates {
+			doUpdateTelegraf(metric.Fields, descHealthSignalRate, rate, "_"+signal)
+		}
+		for _, result := range cache.rules {
+			doUpdateTelegraf(metric.Fields, descHealthRuleBreached, boolToInt(result.breached), "_"+result.name)
+		}
+		ch <- metric
+	}
+}
+
+// loadRules reloads rules when the config file is modified, default rules are used when the file does not exist
+// or is invalid
+func (c *HealthCollector) loadRules(path string) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if c.rules == nil || !c.rulesModTime.IsZero() {
+			logger.Infof("health config %s is not available, use default rules: %v", path, err)
+			c.useDefaultRules()
+		}
+		return
+	}
+	if c.rules != nil && info.ModTime().Equal(c.rulesModTime) {
+		return
+	}
+	c.rulesModTime = info.ModTime()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		logger.Errorf("read health config %s failed, use default rules: %v", path, err)
+		c.rules = defaultHealthRules
+		return
+	}
+	var rules []healthRule
+	if err = json.Unmarshal(data, &rules); err != nil {
+		logger.Errorf("unmarshal health config %s failed, use default rules: %v", path, err)
+		c.rules = defaultHealthRules
+		return
+	}
+	if err = validateHealthRules(rules); err != nil {
+		logger.Errorf("health config %s is invalid, use default rules: %v", path, err)
+		c.rules = defaultHealthRules
+		return
+	}
+	logger.Infof("load %d health rules from %s", len(rules), path)
+	c.rules = rules
+}
+
+func (c *HealthCollector) useDefaultRules() {
+	c.rules = defaultHealthRules
+	c.rulesModTime = time.Time{}
+}
+
+// updateRates appends samples of counter signals to the history and returns the increase per minute of them, the
+// history of a counter is restarted when the counter is reset
+func (c *HealthCollector) updateRates(phyID int32, signals map[string]healthSample,
+	now time.Time) map[string]float64 {
+	history, ok := c.history[phyID]
+	if !ok {
+		history = make(map[string][]healthSample)
+		c.history[phyID] = history
+	}
+	rates := make(map[string]float64)
+	for signal, isCounter := range healthCounterSignals {
+		sample, ok := signals[signal]
+		if !isCounter || !ok {
+			continue
+		}
+		samples := history[signal]
+		if len(samples) > 0 {
+			last := samples[len(samples)-1]
+			if sample.value < last.value {
+				samples = nil
+			} else if !sample.timestamp.After(last.timestamp) {
+				// the cache of the source collector is not updated since last collection
+				sample = healthSample{}
+			}
+		}
+		if !sample.timestamp.IsZero() {
+			samples = append(samples, sample)
+		}
+		for len(samples) > 1 && (now.Sub(samples[0].timestamp) > healthHistoryWindow ||
+			len(samples) > maxHealthHistorySize) {
+			samples = samples[1:]
+		}
+		history[signal] = samples
+		if len(samples) < 2 {
+			continue
+		}
+		first, last := samples[0], samples[len(samples)-1]
+		rates[signal] = (last.value - first.value) / last.timestamp.Sub(first.timestamp).Minutes()
+	}
+	return rates
+}
+
+// evaluateHealthRules returns the health score, the breached rule with the highest penalty and results of rules
+// whose signal is collected
+func evaluateHealthRules(rules []healthRule, signals map[string]healthSample,
+	rates map[string]float64) (int, string, []ruleResult) {
+	score, reason, maxPenalty := maxHealthScore, healthReasonNone, -1
+	var results []ruleResult
+	for _, rule := range rules {
+		var value float64
+		var ok bool
+		if rule.Rate {
+			value, ok = rates[rule.Signal]
+		} else {
+			var sample healthSample
+			sample, ok = signals[rule.Signal]
+			value = sample.value
+		}
+		if !ok {
+			continue
+		}
+		breached := rule.breached(value)
+		results = append(results, ruleResult{name: rule.Name, breached: breached})
+		if !breached {
+			continue
+		}
+		score -= rule.Penalty
+		if rule.Penalty > maxPenalty {
+			maxPenalty = rule.Penalty
+			reason = rule.Name
+		}
+	}
+	if score < 0 {
+		score = 0
+	}
+	return score, reason, results
+}
+
+// healthSources caches of the other collectors, the cache is nil when the collector is disabled or not supported
+type healthSources struct {
+	chips      map[int32]chipCache
+	hbm        map[int32]hbmCache
+	hccs       map[int32]hccsCache
+	sio        map[int32]sioCache
+	roce       map[int32]roceCache
+	net        map[int32]netInfoCache
+	netNpu     map[int32]netInfoNPUCache
+	optical    map[int32]opticalCache
+	opticalNpu map[int32]opticalNpuCache
+}
+
+func lookupHealthSources(n *colcommon.NpuCollector) *healthSources {
+	sources := &healthSources{}
+	sources.chips, _ = colcommon.LookupInfoFromCache[chipCache](n, colcommon.GetCacheKey(&BaseInfoCollector{}))
+	sources.hbm, _ = colcommon.LookupInfoFromCache[hbmCache](n, colcommon.GetCacheKey(&HbmCollector{}))
+	sources.hccs, _ = colcommon.LookupInfoFromCache[hccsCache](n, colcommon.GetCacheKey(&HccsCollector{}))
+	sources.sio, _ = colcommon.LookupInfoFromCache[sioCache](n, colcommon.GetCacheKey(&SioCollector{}))
+	sources.roce, _ = colcommon.LookupInfoFromCache[roceCache](n, colcommon.GetCacheKey(&RoceCollector{}))
+	netKey := colcommon.GetCacheKey(&NetworkCollector{})
+	sources.net, _ = colcommon.LookupInfoFromCache[netInfoCache](n, netKey)
+	sources.netNpu, _ = colcommon.LookupInfoFromCache[netInfoNPUCache](n, netKey)
+	opticalKey := colcommon.GetCacheKey(&OpticalCollector{})
+	sources.optical, _ = colcommon.LookupInfoFromCache[opticalCache](n, opticalKey)
+	sources.opticalNpu, _ = colcommon.LookupInfoFromCache[opticalNpuCache](n, opticalKey)
+	return sources
+}
+
+// signals collects signals of the chip, values failed to be collected are skipped
+func (s *healthSources) signals(phyID int32) map[string]healthSample {
+	signals := make(map[string]healthSample)
+	add := func(signal string, timestamp time.Time, values ...float64) {
+		sum := 0.0
+		for _, value := range values {
+			if !isValidHealthValue(value) {
+				return
+			}
+			sum += value
+		}
+		signals[signal] = healthSample{value: sum, timestamp: timestamp}
+	}
+	if cache, ok := s.chips[phyID]; ok {
+		add(signalTemperature, cache.timestamp, float64(cache.Temperature))
+	}
+	if cache, ok := s.hbm[phyID]; ok && cache.extInfo != nil && cache.extInfo.ECCInfo != nil {
+		ecc := cache.extInfo.ECCInfo
+		add(signalHbmSingleBitError, cache.timestamp, float64(ecc.TotalSingleBitErrorCnt))
+		add(signalHbmDoubleBitError, cache.timestamp, float64(ecc.TotalDoubleBitErrorCnt))
+		add(signalHbmIsolatedPages, cache.timestamp, float64(ecc.SingleBitIsolatedPagesCnt),
+			float64(ecc.DoubleBitIsolatedPagesCnt))
+	}
+	if cache, ok := s.hccs[phyID]; ok && cache.hccsStat != nil {
+		values := make([]float64, 0, len(cache.hccsStat.CrcErrCnt))
+		for _, cnt := range cache.hccsStat.CrcErrCnt {
+			values = append(values, float64(cnt))
+		}
+		add(signalHccsCrcError, cache.timestamp, values...)
+	}
+	if cache, ok := s.sio[phyID]; ok && cache.extInfo != nil {
+		add(signalSioCrcError, cache.timestamp, float64(cache.extInfo.TxErrCnt), float64(cache.extInfo.RxErrCnt))
+	}
+	if cache, ok := s.roce[phyID]; ok && cache.extInfo != nil {
+		stat := cache.extInfo
+		add(signalRoceErrorPackets, cache.timestamp, stat.MacRxBadPktNum, stat.MacTxBadPktNum, stat.RoceRxErrPktNum,
+			stat.RoceTxErrPktNum)
+	}
+	if cache, ok := s.net[phyID]; ok {
+		addNetSignals(add, cache.timestamp, []*common.NpuNetInfo{cache.extInfo})
+	} else if cache, ok := s.netNpu[phyID]; ok {
+		addNetSignals(add, cache.timestamp, cache.extInfo)
+	}
+	if cache, ok := s.optical[phyID]; ok && cache.extInfo != nil {
+		info := cache.extInfo
+		addMinSignal(add, signalOpticalRxPowerMin, cache.timestamp, info.OpticalRxPower0, info.OpticalRxPower1,
+			info.OpticalRxPower2, info.OpticalRxPower3)
+		add(signalOpticalTemperature, cache.timestamp, info.OpticalTemp)
+	} else if cache, ok := s.opticalNpu[phyID]; ok {
+		var values []float64
+		for _, info := range cache.extInfo {
+			if info != nil {
+				values = append(values, info.OpticalRxPower0, info.OpticalRxPower1, info.OpticalRxPower2,
+					info.OpticalRxPower3)
+			}
+		}
+		addMinSignal(add, signalOpticalRxPowerMin, cache.timestamp, values...)
+	}
+	return signals
+}
+
+func addNetSignals(add func(string, time.Time, ...float64), timestamp time.Time, infos []*common.NpuNetInfo) {
+	var linkUps []float64
+	linkDown, hasLinkStatus := 0.0, false
+	for _, info := range infos {
+		if info == nil {
+			continue
+		}
+		if info.LinkStatInfo != nil {
+			linkUps = append(linkUps, info.LinkStatInfo.LinkUPNum)
+		}
+		if info.LinkStatusInfo != nil && info.LinkStatusInfo.LinkState != "" {
+			hasLinkStatus = true
+			if info.LinkStatusInfo.LinkState != colcommon.LinkUp {
+				linkDown++
+			}
+		}
+	}
+	if len(linkUps) > 0 {
+		add(signalLinkUpCount, timestamp, linkUps...)
+	}
+	if hasLinkStatus {
+		add(signalLinkDown, timestamp, linkDown)
+	}
+}
+
+func addMinSignal(add func(string, time.Time, ...float64), signal string, timestamp time.Time, values ...float64) {
+	minValue, found := math.MaxFloat64, false
+	for _, value := range values {
+		if isValidHealthValue(value) && value < minValue {
+			minValue, found = value, true
+		}
+	}
+	if found {
+		add(signal, timestamp, minValue)
+	}
+}
+
+func isValidHealthValue(value float64) bool {
+	return validateNum(value) && value != common.FailedValue && !math.IsNaN(value)
+}
+
+func appendLabel(cardLabel []string, value string) []string {
+	return append(append(make([]string, 0, len(cardLabel)+1), cardLabel...), value)
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
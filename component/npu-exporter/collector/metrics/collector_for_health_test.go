@@ -0,0 +1,179 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package metrics for general collector
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+
+	colcommon "huawei.com/npu-exporter/v6/collector/common"
+)
+
+const (
+	testHealthPenalty = 30
+	testHighTemp      = 100
+	testErrCnt        = 10
+)
+
+func TestValidateHealthRules(t *testing.T) {
+	convey.Convey("test func 'validateHealthRules'", t, func() {
+		convey.So(validateHealthRules(defaultHealthRules), convey.ShouldBeNil)
+		invalidRules := [][]healthRule{
+			{{Name: "a", Signal: signalTemperature, Operator: opGreater}, {Name: "a", Signal: signalTemperature,
+				Operator: opGreater}},
+			{{Name: "a", Signal: "unknown", Operator: opGreater}},
+			{{Name: "a", Signal: signalTemperature, Rate: true, Operator: opGreater}},
+			{{Name: "a", Signal: signalTemperature, Operator: "=="}},
+			{{Name: "a", Signal: signalTemperature, Operator: opGreater, Penalty: maxHealthScore + 1}},
+			{{Signal: signalTemperature, Operator: opGreater}},
+		}
+		for _, rules := range invalidRules {
+			convey.So(validateHealthRules(rules), convey.ShouldNotBeNil)
+		}
+	})
+}
+
+func TestEvaluateHealthRules(t *testing.T) {
+	convey.Convey("test func 'evaluateHealthRules'", t, func() {
+		rules := []healthRule{
+			{Name: "hot", Signal: signalTemperature, Operator: opGreaterEqual, Threshold: 95, Penalty: testHealthPenalty},
+			{Name: "ecc", Signal: signalHbmDoubleBitError, Rate: true, Operator: opGreater, Penalty: maxHealthScore},
+			{Name: "low_power", Signal: signalOpticalRxPowerMin, Operator: opLess, Threshold: 1, Penalty: 1},
+		}
+		signals := map[string]healthSample{signalTemperature: {value: testHighTemp}}
+		score, reason, results := evaluateHealthRules(rules, signals, nil)
+		convey.So(score, convey.ShouldEqual, maxHealthScore-testHealthPenalty)
+		convey.So(reason, convey.ShouldEqual, "hot")
+		// rules of signals not collected are not evaluated
+		convey.So(results, convey.ShouldResemble, []ruleResult{{name: "hot", breached: true}})
+
+		score, reason, results = evaluateHealthRules(rules, signals, map[string]float64{signalHbmDoubleBitError: 1})
+		convey.So(score, convey.ShouldEqual, 0)
+		convey.So(reason, convey.ShouldEqual, "ecc")
+		convey.So(len(results), convey.ShouldEqual, 2)
+
+		score, reason, _ = evaluateHealthRules(rules, map[string]healthSample{signalTemperature: {value: 1}}, nil)
+		convey.So(score, convey.ShouldEqual, maxHealthScore)
+		convey.So(reason, convey.ShouldEqual, healthReasonNone)
+	})
+}
+
+func TestHealthUpdateRates(t *testing.T) {
+	convey.Convey("test method 'updateRates' of HealthCollector", t, func() {
+		c := &HealthCollector{history: make(map[int32]map[string][]healthSample)}
+		start := time.Now()
+		sample := func(value float64, minutes int) map[string]healthSample {
+			return map[string]healthSample{signalHccsCrcError: {value: value,
+				timestamp: start.Add(time.Duration(minutes) * time.Minute)},
+				signalTemperature: {value: testHighTemp, timestamp: start}}
+		}
+		rates := c.updateRates(0, sample(0, 0), start)
+		convey.So(rates, convey.ShouldBeEmpty)
+		rates = c.updateRates(0, sample(testErrCnt, 2), start.Add(2*time.Minute))
+		convey.So(rates[signalHccsCrcError], convey.ShouldEqual, testErrCnt/2)
+		convey.So(rates, convey.ShouldNotContainKey, signalTemperature)
+
+		convey.Convey("source cache is not updated, sample should not be appended", func() {
+			rates = c.updateRates(0, sample(testErrCnt, 2), start.Add(3*time.Minute))
+			convey.So(len(c.history[0][signalHccsCrcError]), convey.ShouldEqual, 2)
+			convey.So(rates[signalHccsCrcError], convey.ShouldEqual, testErrCnt/2)
+		})
+		convey.Convey("samples out of window should be removed", func() {
+			rates = c.updateRates(0, sample(testErrCnt, 15), start.Add(15*time.Minute))
+			convey.So(len(c.history[0][signalHccsCrcError]), convey.ShouldEqual, 1)
+			convey.So(rates, convey.ShouldBeEmpty)
+		})
+		convey.Convey("counter is reset, history should be restarted", func() {
+			rates = c.updateRates(0, sample(1, 3), start.Add(3*time.Minute))
+			convey.So(len(c.history[0][signalHccsCrcError]), convey.ShouldEqual, 1)
+			convey.So(rates, convey.ShouldBeEmpty)
+		})
+	})
+}
+
+func TestHealthLoadRules(t *testing.T) {
+	convey.Convey("test method 'loadRules' of HealthCollector", t, func() {
+		c := &HealthCollector{}
+		path := filepath.Join(t.TempDir(), "healthConfiguration.json")
+		c.loadRules(path)
+		convey.So(c.rules, convey.ShouldResemble, defaultHealthRules)
+
+		content := `[{"name":"hot","signal":"temperature","operator":">","threshold":90,"penalty":30}]`
+		convey.So(os.WriteFile(path, []byte(content), 0600), convey.ShouldBeNil)
+		c.loadRules(path)
+		convey.So(c.rules, convey.ShouldResemble, []healthRule{{Name: "hot", Signal: signalTemperature,
+			Operator: opGreater, Threshold: 90, Penalty: testHealthPenalty}})
+
+		convey.Convey("config is invalid, default rules should be used", func() {
+			convey.So(os.WriteFile(path, []byte(`[{"name":"hot","signal":"unknown"}]`), 0600), convey.ShouldBeNil)
+			convey.So(os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)), convey.ShouldBeNil)
+			c.loadRules(path)
+			convey.So(c.rules, convey.ShouldResemble, defaultHealthRules)
+		})
+		convey.Convey("config is removed, default rules should be used", func() {
+			convey.So(os.Remove(path), convey.ShouldBeNil)
+			c.loadRules(path)
+			convey.So(c.rules, convey.ShouldResemble, defaultHealthRules)
+			convey.So(c.rulesModTime.IsZero(), convey.ShouldBeTrue)
+		})
+	})
+}
+
+func TestHealthCollector(t *testing.T) {
+	convey.Convey("test HealthCollector derives metrics from caches of other collectors", t, func() {
+		n := mockNewNpuCollector()
+		chips := mockGetNPUChipList()[:2]
+		mockHbmCache(n, chips, colcommon.GetCacheKey(&HbmCollector{}))
+		// link state of mock net info is not UP
+		mockNetInfoCache(n, chips, colcommon.GetCacheKey(&NetworkCollector{}))
+		c := &HealthCollector{}
+		c.CollectToCache(n, chips)
+		caches := colcommon.GetInfoFromCache[healthCache](n, colcommon.GetCacheKey(c))
+		convey.So(len(caches), convey.ShouldEqual, len(chips))
+		convey.So(caches[0].valid, convey.ShouldBeTrue)
+		convey.So(caches[0].reason, convey.ShouldEqual, "link_down")
+
+		ch := make(chan prometheus.Metric, maxMetricsCount)
+		c.UpdatePrometheus(ch, n, nil, chips)
+		close(ch)
+		scores := 0
+		for metric := range ch {
+			if metric.Desc() != descHealthScore {
+				continue
+			}
+			m := &dto.Metric{}
+			convey.So(metric.Write(m), convey.ShouldBeNil)
+			convey.So(m.GetGauge().GetValue(), convey.ShouldBeLessThan, maxHealthScore)
+			scores++
+		}
+		convey.So(scores, convey.ShouldEqual, len(chips))
+
+		convey.Convey("no signal of the chip is collected, metrics should not be reported", func() {
+			n = mockNewNpuCollector()
+			c.CollectToCache(n, chips)
+			ch = make(chan prometheus.Metric, maxMetricsCount)
+			c.UpdatePrometheus(ch, n, nil, chips)
+			close(ch)
+			convey.So(len(ch), convey.ShouldEqual, 0)
+		})
+	})
+}
@@ -104,6 +104,10 @@ var (
 
 	npuCtrInfo  *prometheus.Desc = nil
 	descNpuName *prometheus.Desc = nil
+
+	// npuCtrWorkloadInfo is built after workload labels are configured, nil when no workload label is configured
+	npuCtrWorkloadInfo     *prometheus.Desc = nil
+	npuCtrWorkloadInfoOnce sync.Once
 )
 
 func init() {
@@ -124,6 +128,22 @@ func init() {
 		cardLabelForNpuName)
 }
 
+// workloadInfoDesc card labels and workload labels of the pod, e.g. job name and rank index, dashboards join it
+// with other metrics by card and pod labels
+func workloadInfoDesc() *prometheus.Desc {
+	npuCtrWorkloadInfoOnce.Do(func() {
+		names := container.WorkloadLabelNames()
+		if len(names) == 0 {
+			return
+		}
+		label := append(append(make([]string, 0, len(colcommon.CardLabel)+len(names)), colcommon.CardLabel...),
+			names...)
+		npuCtrWorkloadInfo = colcommon.BuildDescWithLabel("npu_container_workload_info",
+			"the workload labels of the pod which the npu is allocated to, with value '1'", label)
+	})
+	return npuCtrWorkloadInfo
+}
+
 type chipCache struct {
 	chip      colcommon.HuaWeiAIChip
 	timestamp time.Time
@@ -172,6 +192,9 @@ func (c *BaseInfoCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- descNetworkStatus
 	// container
 	ch <- npuCtrInfo
+	if desc := workloadInfoDesc(); desc != nil {
+		ch <- desc
+	}
 	ch <- npuCtrTotalMemory
 	ch <- npuCtrUsedMemory
 
@@ -331,6 +354,9 @@ func updateContainerInfo(ch chan<- prometheus.Metric, containerInfo container.De
 	// based on chipType , container_npu_total_memory、container_npu_used_memory reported in hbm or ddr group
 	doUpdateMetric(ch, chip.timestamp, 1, append(cardLabel, containerInfo.ID, strings.Join(containerName, "_")),
 		npuCtrInfo)
+	if desc := workloadInfoDesc(); desc != nil && len(containerInfo.WorkloadLabels) > 0 {
+		doUpdateMetric(ch, chip.timestamp, 1, append(cardLabel, containerInfo.WorkloadLabels...), desc)
+	}
 }
 
 func updateErrorCodesInfo(ch chan<- prometheus.Metric, chip *chipCache, timestamp time.Time, cardLabel []string) {
@@ -351,23 +377,30 @@ func updateProcessInfoForPrometheus(ch chan<- prometheus.Metric, chip *chipCache
 	}
 	doUpdateMetric(ch, timestamp, devProcessInfo.ProcNum, cardLabel, descDevProcessNum)
 	for i := int32(0); i < devProcessInfo.ProcNum; i++ {
-		containerID := ""
-		newCardLabel := make([]string, len(cardLabel))
-		if len(cardLabel) > cardLabelWihtContainerInfoLen {
-			copy(newCardLabel, cardLabel)
-			newCardLabel[len(newCardLabel)-1] = ""
-			newCardLabel[len(newCardLabel)-2] = ""
-			newCardLabel[len(newCardLabel)-3] = ""
-		}
 		procInfo := devProcessInfo.DevProcArray[i]
-		if containerInfo, ok := findContainerForPID(procInfo.Pid, containerInfos); ok {
-			newCardLabel, containerID = buildProcessCardLabel(cardLabel, containerInfo)
-		}
+		newCardLabel, containerID := processCardLabel(cardLabel, procInfo.Pid, containerInfos)
 		doUpdateMetric(ch, timestamp, procInfo.MemUsage,
 			append(newCardLabel, strconv.FormatInt(int64(procInfo.Pid), colcommon.Base), containerID), descDevProcessInfo)
 	}
 }
 
+// processCardLabel card label and container id of a chip process, container labels are cleared when the process
+// does not belong to any container of the chip
+func processCardLabel(cardLabel []string, pid int32, containerInfos []container.DevicesInfo) ([]string, string) {
+	newCardLabel := make([]string, len(cardLabel))
+	if len(cardLabel) > cardLabelWihtContainerInfoLen {
+		copy(newCardLabel, cardLabel)
+		newCardLabel[len(newCardLabel)-1] = ""
+		newCardLabel[len(newCardLabel)-2] = ""
+		newCardLabel[len(newCardLabel)-3] = ""
+	}
+	if containerInfo, ok := findContainerForPID(pid, containerInfos); ok {
+		copy(newCardLabel, cardLabel)
+		return buildProcessCardLabel(newCardLabel, containerInfo)
+	}
+	return newCardLabel, ""
+}
+
 // getDefaultProcessLabel builds the card label used before/without PID-container
 // matching, taking the first associated container's joined name as the container
 // label while preserving the namespace/podName slots of the incoming card label.
@@ -0,0 +1,205 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package metrics for general collector
+package metrics
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"ascend-common/common-utils/hwlog"
+	colcommon "huawei.com/npu-exporter/v6/collector/common"
+	"huawei.com/npu-exporter/v6/collector/container"
+	"huawei.com/npu-exporter/v6/utils/logger"
+)
+
+const (
+	// DefaultProcessTopN default number of processes reported for each chip
+	DefaultProcessTopN = 10
+	// DefaultProcessMaxSeries default number of process series reported for all chips
+	DefaultProcessMaxSeries = 1024
+
+	// hostPIDNamespace link of the initial pid namespace, process names are only read in it, otherwise the pid
+	// reported by driver refers to another process in /proc of the exporter
+	hostPIDNamespace   = "pid:[4026531836]"
+	selfPIDNamespace   = "/proc/self/ns/pid"
+	maxProcessNameSize = 64
+)
+
+var (
+	cardLabelForProcessMemory = append(append(make([]string, 0, len(colcommon.CardLabel)+3),
+		colcommon.CardLabel...), "process_id", "process_name", "container_id")
+
+	descProcessMemoryUsed = colcommon.BuildDescWithLabel("npu_process_memory_used",
+		"the npu memory used by the process, unit is 'MB'. only the top processes by memory of each npu are "+
+			"reported, process_name is empty when npu-exporter is not in the host pid namespace",
+		cardLabelForProcessMemory)
+	descProcessDropped = colcommon.BuildDesc("npu_process_dropped",
+		"the number of processes on the npu which are not reported by npu_process_memory_used")
+
+	processTopN      = DefaultProcessTopN
+	processMaxSeries = DefaultProcessMaxSeries
+
+	inHostPIDNamespace     bool
+	inHostPIDNamespaceOnce sync.Once
+)
+
+// SetProcessLimits set cardinality limits of process metrics, topN processes by memory are reported for each chip
+// and at most maxSeries processes are reported for all chips
+func SetProcessLimits(topN, maxSeries int) {
+	processTopN = topN
+	processMaxSeries = maxSeries
+}
+
+type processInfo struct {
+	pid      int32
+	name     string
+	memUsage float64
+}
+
+type processCache struct {
+	chip      colcommon.HuaWeiAIChip
+	timestamp time.Time
+	// processes top processes by memory usage
+	processes []processInfo
+	// dropped processes not in top processes
+	dropped int
+}
+
+// ProcessCollector collects memory usage of processes running on the chip
+type ProcessCollector struct {
+	colcommon.MetricsCollectorAdapter
+}
+
+// Describe description of the metric
+func (c *ProcessCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- descProcessMemoryUsed
+	ch <- descProcessDropped
+}
+
+// CollectToCache collect the metric to cache
+func (c *ProcessCollector) CollectToCache(n *colcommon.NpuCollector, chipList []colcommon.HuaWeiAIChip) {
+	for _, chip := range chipList {
+		logicID := chip.LogicID
+		info, err := n.Dmgr.GetDevProcessInfo(logicID)
+		if err != nil {
+			logErrMetricsWithLimit(colcommon.DomainForProcess, logicID, err)
+			continue
+		}
+		hwlog.ResetErrCnt(colcommon.DomainForProcess, logicID)
+
+		procNum := int(info.ProcNum)
+		if procNum > len(info.DevProcArray) {
+			procNum = len(info.DevProcArray)
+		}
+		processes := make([]processInfo, 0, procNum)
+		for _, proc := range info.DevProcArray[:procNum] {
+			processes = append(processes, processInfo{pid: proc.Pid, memUsage: proc.MemUsage})
+		}
+		sort.SliceStable(processes, func(i, j int) bool {
+			return processes[i].memUsage > processes[j].memUsage
+		})
+		dropped := 0
+		if len(processes) > processTopN {
+			dropped = len(processes) - processTopN
+			processes = processes[:processTopN]
+		}
+		for i := range processes {
+			processes[i].name = getProcessName(processes[i].pid)
+		}
+		c.LocalCache.Store(chip.PhyId, processCache{chip: chip, timestamp: time.Now(), processes: processes,
+			dropped: dropped})
+	}
+	colcommon.UpdateCache[processCache](n, colcommon.GetCacheKey(c), &c.LocalCache)
+}
+
+// UpdatePrometheus update prometheus metrics
+func (c *ProcessCollector) UpdatePrometheus(ch chan<- prometheus.Metric, n *colcommon.NpuCollector,
+	containerMap map[int32][]container.DevicesInfo, chips []colcommon.HuaWeiAIChip) {
+	series := 0
+	updateSingleChip := func(chipWithVnpu colcommon.HuaWeiAIChip, cache processCache, cardLabel []string) {
+		containerInfos := geenContainerInfos(&chipWithVnpu, containerMap)
+		dropped := cache.dropped
+		for _, proc := range cache.processes {
+			if series >= processMaxSeries {
+				dropped++
+				continue
+			}
+			series++
+			label, containerID := processCardLabel(cardLabel, proc.pid, containerInfos)
+			doUpdateMetric(ch, cache.timestamp, proc.memUsage, append(label,
+				strconv.FormatInt(int64(proc.pid), colcommon.Base), proc.name, containerID), descProcessMemoryUsed)
+		}
+		doUpdateMetric(ch, cache.timestamp, dropped, cardLabel, descProcessDropped)
+	}
+	updateFrame[processCache](colcommon.GetCacheKey(c), n, containerMap, chips, updateSingleChip)
+}
+
+// UpdateTelegraf update telegraf metrics
+func (c *ProcessCollector) UpdateTelegraf(ch chan<- colcommon.TelegrafMetric, n *colcommon.NpuCollector,
+	containerMap map[int32][]container.DevicesInfo, chips []colcommon.HuaWeiAIChip) {
+	caches := colcommon.GetInfoFromCache[processCache](n, colcommon.GetCacheKey(c))
+	for _, chip := range chips {
+		cache, ok := caches[chip.PhyId]
+		if !ok {
+			logger.Debugf("cacheKey(%v) not found", chip.PhyId)
+			continue
+		}
+		metric := colcommon.NewDeviceMetric(cache.chip.LogicID)
+		for _, proc := range cache.processes {
+			doUpdateTelegraf(metric.Fields, descProcessMemoryUsed, proc.memUsage, "_"+strconv.Itoa(int(proc.pid)))
+		}
+		doUpdateTelegraf(metric.Fields, descProcessDropped, cache.dropped, "")
+		ch <- metric
+	}
+}
+
+func isInHostPIDNamespace() bool {
+	inHostPIDNamespaceOnce.Do(func() {
+		link, err := os.Readlink(selfPIDNamespace)
+		if err != nil {
+			logger.Warnf("read pid namespace failed, process names are not reported: %v", err)
+			return
+		}
+		inHostPIDNamespace = link == hostPIDNamespace
+		if !inHostPIDNamespace {
+			logger.Infof("npu-exporter is not in the host pid namespace, process names are not reported")
+		}
+	})
+	return inHostPIDNamespace
+}
+
+// getProcessName name of the host process, empty when it can not be read
+func getProcessName(pid int32) string {
+	if pid <= 0 || !isInHostPIDNamespace() {
+		return ""
+	}
+	data, err := os.ReadFile("/proc/" + strconv.Itoa(int(pid)) + "/comm")
+	if err != nil {
+		logger.Debugf("read name of process %d failed: %v", pid, err)
+		return ""
+	}
+	name := strings.TrimSpace(string(data))
+	if len(name) > maxProcessNameSize {
+		name = name[:maxProcessNameSize]
+	}
+	return name
+}
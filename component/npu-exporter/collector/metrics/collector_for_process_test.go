@@ -0,0 +1,126 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package metrics for general collector
+package metrics
+
+import (
+	"testing"
+
+	"github.com/agiledragon/gomonkey/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+
+	"ascend-common/devmanager/common"
+	colcommon "huawei.com/npu-exporter/v6/collector/common"
+	"huawei.com/npu-exporter/v6/collector/container"
+)
+
+const (
+	testProcTopN      = 2
+	testProcMaxSeries = 3
+	testProcPid1      = 101
+	testProcPid2      = 102
+	testProcPid3      = 103
+	testProcMem1      = 100
+	testProcMem2      = 300
+	testProcMem3      = 200
+)
+
+func mockMultiProcessInfo() *common.DevProcessInfo {
+	return &common.DevProcessInfo{ProcNum: 3, DevProcArray: []common.DevProcInfo{
+		{Pid: testProcPid1, MemUsage: testProcMem1},
+		{Pid: testProcPid2, MemUsage: testProcMem2},
+		{Pid: testProcPid3, MemUsage: testProcMem3},
+	}}
+}
+
+func TestProcessCollectToCache(t *testing.T) {
+	convey.Convey("test method 'CollectToCache' of ProcessCollector, top processes by memory are cached", t, func() {
+		SetProcessLimits(testProcTopN, DefaultProcessMaxSeries)
+		defer SetProcessLimits(DefaultProcessTopN, DefaultProcessMaxSeries)
+		n := mockNewNpuCollector()
+		patches := gomonkey.NewPatches()
+		defer patches.Reset()
+		patches.ApplyMethodReturn(n.Dmgr, "GetDevProcessInfo", mockMultiProcessInfo(), nil)
+		patches.ApplyFuncReturn(getProcessName, "python")
+		c := &ProcessCollector{}
+		chips := mockGetNPUChipList()
+		c.CollectToCache(n, chips)
+		caches := colcommon.GetInfoFromCache[processCache](n, colcommon.GetCacheKey(c))
+		convey.So(len(caches), convey.ShouldEqual, len(chips))
+		cache := caches[0]
+		convey.So(cache.dropped, convey.ShouldEqual, 1)
+		convey.So(cache.processes, convey.ShouldResemble, []processInfo{
+			{pid: testProcPid2, name: "python", memUsage: testProcMem2},
+			{pid: testProcPid3, name: "python", memUsage: testProcMem3},
+		})
+	})
+}
+
+func TestProcessUpdatePrometheus(t *testing.T) {
+	convey.Convey("test method 'UpdatePrometheus' of ProcessCollector", t, func() {
+		SetProcessLimits(DefaultProcessTopN, testProcMaxSeries)
+		defer SetProcessLimits(DefaultProcessTopN, DefaultProcessMaxSeries)
+		n := mockNewNpuCollector()
+		c := &ProcessCollector{}
+		chips := mockGetNPUChipList()[:2]
+		for _, chip := range chips {
+			c.LocalCache.Store(chip.PhyId, processCache{chip: chip, processes: []processInfo{
+				{pid: testProcPid2, memUsage: testProcMem2}, {pid: testProcPid1, memUsage: testProcMem1}}})
+		}
+		colcommon.UpdateCache[processCache](n, colcommon.GetCacheKey(c), &c.LocalCache)
+		containerMap := map[int32][]container.DevicesInfo{0: {{ID: "ctr0", Name: "ns_pod_ctr",
+			PIDs: map[int32]struct{}{testProcPid2: {}}}}}
+		ch := make(chan prometheus.Metric, maxMetricsCount)
+		c.UpdatePrometheus(ch, n, containerMap, chips)
+		close(ch)
+
+		var memSeries, dropped int
+		for metric := range ch {
+			m := &dto.Metric{}
+			convey.So(metric.Write(m), convey.ShouldBeNil)
+			if metric.Desc() == descProcessDropped {
+				dropped += int(m.GetGauge().GetValue())
+				continue
+			}
+			memSeries++
+			labels := make(map[string]string)
+			for _, label := range m.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["process_id"] == "102" && labels["id"] == "0" {
+				convey.So(labels["container_id"], convey.ShouldEqual, "ctr0")
+				convey.So(labels["pod_name"], convey.ShouldEqual, "pod")
+			}
+			if labels["process_id"] == "101" {
+				convey.So(labels["container_id"], convey.ShouldEqual, "")
+				convey.So(labels["pod_name"], convey.ShouldEqual, "")
+			}
+		}
+		// series over the max series are dropped
+		convey.So(memSeries, convey.ShouldEqual, testProcMaxSeries)
+		convey.So(dropped, convey.ShouldEqual, 1)
+	})
+}
+
+func TestGetProcessName(t *testing.T) {
+	convey.Convey("test func 'getProcessName'", t, func() {
+		convey.So(getProcessName(0), convey.ShouldEqual, "")
+		patches := gomonkey.ApplyFuncReturn(isInHostPIDNamespace, false)
+		defer patches.Reset()
+		convey.So(getProcessName(1), convey.ShouldEqual, "")
+	})
+}
@@ -117,6 +117,11 @@ func getContainerLabels(containerInfos []container.DevicesInfo) (containerName,
 	}
 }
 
+// GeneralCardLabel values of colcommon.CardLabel of the chip, used by plugin collectors to report metrics of chips
+func GeneralCardLabel(chip *colcommon.HuaWeiAIChip, containerMap map[int32][]container.DevicesInfo) []string {
+	return geenGeneralCardLabel(chip, containerMap)
+}
+
 func geenGeneralCardLabel(chip *colcommon.HuaWeiAIChip, containerMap map[int32][]container.DevicesInfo) []string {
 
 	containerInfos := geenContainerInfos(chip, containerMap)
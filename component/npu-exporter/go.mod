@@ -9,11 +9,15 @@ require (
 	github.com/golang/protobuf v1.5.4
 	github.com/influxdata/telegraf v1.34.4
 	github.com/prometheus/client_golang v1.22.0
+	github.com/prometheus/client_model v0.6.2
+	github.com/prometheus/common v0.63.0
 	github.com/smartystreets/goconvey v1.6.4
 	github.com/stretchr/testify v1.10.0
+	go.opentelemetry.io/proto/otlp v1.9.0
 	google.golang.org/grpc v1.79.3
 	google.golang.org/protobuf v1.36.10
 	k8s.io/cri-api v0.25.13
+	k8s.io/kubelet v0.24.2
 	k8s.io/utils v0.0.0-20250321185631-1f6e0b77f77e
 )
 
@@ -39,6 +43,7 @@ require (
 	github.com/google/cel-go v0.25.0 // indirect
 	github.com/gopherjs/gopherjs v0.0.0-20181017120253-0766667cb4d1 // indirect
 	github.com/gosnmp/gosnmp v1.40.0 // indirect
+	github.com/grpc-ecosystem/grpc-gateway/v2 v2.27.2 // indirect
 	github.com/influxdata/toml v0.0.0-20190415235208-270119a8ce65 // indirect
 	github.com/jedib0t/go-pretty/v6 v6.6.7 // indirect
 	github.com/jtolds/gls v4.20.0+incompatible // indirect
@@ -51,8 +56,6 @@ require (
 	github.com/naoina/go-stringutil v0.1.0 // indirect
 	github.com/pkg/errors v0.9.1 // indirect
 	github.com/pmezard/go-difflib v1.0.1-0.20181226105442-5d4384ee4fb2 // indirect
-	github.com/prometheus/client_model v0.6.2 // indirect
-	github.com/prometheus/common v0.63.0 // indirect
 	github.com/prometheus/procfs v0.16.1 // indirect
 	github.com/rivo/uniseg v0.4.7 // indirect
 	github.com/sirupsen/logrus v1.9.3 // indirect
@@ -0,0 +1,225 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package otlp push metrics of all collectors to an OpenTelemetry collector by OTLP
+package otlp
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+)
+
+// attribute keys of resource, following OpenTelemetry semantic conventions where one exists
+const (
+	attrServiceName    = "service.name"
+	attrServiceVersion = "service.version"
+	attrHostName       = "host.name"
+	attrK8sNodeName    = "k8s.node.name"
+	attrChipModel      = "npu.chip.model"
+	attrCardID         = "npu.card.id"
+
+	serviceName = "npu-exporter"
+	scopeName   = "huawei.com/npu-exporter"
+	// cardIDLabel label of card id in metrics of collectors, it is moved to resource attributes
+	cardIDLabel = "id"
+)
+
+func stringAttr(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{
+		StringValue: value}}}
+}
+
+func (r Resource) attributes(cardID string) []*commonpb.KeyValue {
+	attrs := []*commonpb.KeyValue{
+		stringAttr(attrServiceName, serviceName),
+		stringAttr(attrServiceVersion, r.BuildVersion),
+		stringAttr(attrHostName, r.NodeName),
+		stringAttr(attrK8sNodeName, r.NodeName),
+		stringAttr(attrChipModel, r.ChipModel),
+	}
+	if cardID != "" {
+		attrs = append(attrs, stringAttr(attrCardID, cardID))
+	}
+	return attrs
+}
+
+// resourceBuilder metrics of one card, metrics without card id belong to the node
+type resourceBuilder struct {
+	metrics map[string]*metricspb.Metric
+	order   []string
+}
+
+func (b *resourceBuilder) metric(family *dto.MetricFamily) *metricspb.Metric {
+	name := family.GetName()
+	if m, ok := b.metrics[name]; ok {
+		return m
+	}
+	m := &metricspb.Metric{Name: name, Description: family.GetHelp()}
+	switch family.GetType() {
+	case dto.MetricType_COUNTER:
+		m.Data = &metricspb.Metric_Sum{Sum: &metricspb.Sum{IsMonotonic: true,
+			AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE}}
+	case dto.MetricType_HISTOGRAM, dto.MetricType_GAUGE_HISTOGRAM:
+		m.Data = &metricspb.Metric_Histogram{Histogram: &metricspb.Histogram{
+			AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE}}
+	case dto.MetricType_SUMMARY:
+		m.Data = &metricspb.Metric_Summary{Summary: &metricspb.Summary{}}
+	default:
+		m.Data = &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{}}
+	}
+	b.metrics[name] = m
+	b.order = append(b.order, name)
+	return m
+}
+
+// pointMeta the fields shared by the data points of all metric types
+type pointMeta struct {
+	startTime  uint64
+	time       uint64
+	attributes []*commonpb.KeyValue
+}
+
+func (b *resourceBuilder) addDataPoint(family *dto.MetricFamily, metric *dto.Metric, meta pointMeta) {
+	switch data := b.metric(family).Data.(type) {
+	case *metricspb.Metric_Sum:
+		data.Sum.DataPoints = append(data.Sum.DataPoints, numberDataPoint(meta, metric.GetCounter().GetValue()))
+	case *metricspb.Metric_Histogram:
+		data.Histogram.DataPoints = append(data.Histogram.DataPoints, histogramDataPoint(meta, metric.GetHistogram()))
+	case *metricspb.Metric_Summary:
+		data.Summary.DataPoints = append(data.Summary.DataPoints, summaryDataPoint(meta, metric.GetSummary()))
+	case *metricspb.Metric_Gauge:
+		value := metric.GetGauge().GetValue()
+		if family.GetType() == dto.MetricType_UNTYPED {
+			value = metric.GetUntyped().GetValue()
+		}
+		data.Gauge.DataPoints = append(data.Gauge.DataPoints, numberDataPoint(meta, value))
+	default:
+	}
+}
+
+func numberDataPoint(meta pointMeta, value float64) *metricspb.NumberDataPoint {
+	return &metricspb.NumberDataPoint{
+		StartTimeUnixNano: meta.startTime,
+		TimeUnixNano:      meta.time,
+		Attributes:        meta.attributes,
+		Value:             &metricspb.NumberDataPoint_AsDouble{AsDouble: value},
+	}
+}
+
+// histogramDataPoint buckets of prometheus are cumulative, while bucket counts of OTLP are not, and the last
+// bucket of OTLP is the +Inf one which is implicit in prometheus
+func histogramDataPoint(meta pointMeta, histogram *dto.Histogram) *metricspb.HistogramDataPoint {
+	sum := histogram.GetSampleSum()
+	point := &metricspb.HistogramDataPoint{
+		StartTimeUnixNano: meta.startTime,
+		TimeUnixNano:      meta.time,
+		Attributes:        meta.attributes,
+		Count:             histogram.GetSampleCount(),
+		Sum:               &sum,
+	}
+	var last uint64
+	for _, bucket := range histogram.GetBucket() {
+		if math.IsInf(bucket.GetUpperBound(), 1) {
+			continue
+		}
+		point.ExplicitBounds = append(point.ExplicitBounds, bucket.GetUpperBound())
+		point.BucketCounts = append(point.BucketCounts, bucket.GetCumulativeCount()-last)
+		last = bucket.GetCumulativeCount()
+	}
+	point.BucketCounts = append(point.BucketCounts, point.Count-last)
+	return point
+}
+
+func summaryDataPoint(meta pointMeta, summary *dto.Summary) *metricspb.SummaryDataPoint {
+	point := &metricspb.SummaryDataPoint{
+		StartTimeUnixNano: meta.startTime,
+		TimeUnixNano:      meta.time,
+		Attributes:        meta.attributes,
+		Count:             summary.GetSampleCount(),
+		Sum:               summary.GetSampleSum(),
+	}
+	for _, quantile := range summary.GetQuantile() {
+		point.QuantileValues = append(point.QuantileValues, &metricspb.SummaryDataPoint_ValueAtQuantile{
+			Quantile: quantile.GetQuantile(), Value: quantile.GetValue()})
+	}
+	return point
+}
+
+func isCumulative(metricType dto.MetricType) bool {
+	switch metricType {
+	case dto.MetricType_COUNTER, dto.MetricType_HISTOGRAM, dto.MetricType_SUMMARY:
+		return true
+	default:
+		return false
+	}
+}
+
+// convert metric families gathered from collectors into OTLP resource metrics, one resource for each card and
+// one for the node. Counters are cumulative sums started at startTime, histograms and summaries are cumulative
+// too, gauges and untyped metrics are gauges
+func convert(families []*dto.MetricFamily, resource Resource, startTime, now time.Time) []*metricspb.ResourceMetrics {
+	builders := make(map[string]*resourceBuilder)
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			meta := pointMeta{time: uint64(now.UnixNano())}
+			if metric.TimestampMs != nil {
+				meta.time = uint64(time.UnixMilli(metric.GetTimestampMs()).UnixNano())
+			}
+			if isCumulative(family.GetType()) {
+				meta.startTime = uint64(startTime.UnixNano())
+			}
+			cardID := ""
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == cardIDLabel {
+					cardID = label.GetValue()
+					continue
+				}
+				meta.attributes = append(meta.attributes, stringAttr(label.GetName(), label.GetValue()))
+			}
+			builder, ok := builders[cardID]
+			if !ok {
+				builder = &resourceBuilder{metrics: make(map[string]*metricspb.Metric)}
+				builders[cardID] = builder
+			}
+			builder.addDataPoint(family, metric, meta)
+		}
+	}
+	cardIDs := make([]string, 0, len(builders))
+	for cardID := range builders {
+		cardIDs = append(cardIDs, cardID)
+	}
+	sort.Strings(cardIDs)
+	resourceMetrics := make([]*metricspb.ResourceMetrics, 0, len(cardIDs))
+	for _, cardID := range cardIDs {
+		builder := builders[cardID]
+		metrics := make([]*metricspb.Metric, 0, len(builder.order))
+		for _, name := range builder.order {
+			metrics = append(metrics, builder.metrics[name])
+		}
+		resourceMetrics = append(resourceMetrics, &metricspb.ResourceMetrics{
+			Resource: &resourcepb.Resource{Attributes: resource.attributes(cardID)},
+			ScopeMetrics: []*metricspb.ScopeMetrics{{
+				Scope:   &commonpb.InstrumentationScope{Name: scopeName, Version: resource.BuildVersion},
+				Metrics: metrics,
+			}},
+		})
+	}
+	return resourceMetrics
+}
@@ -0,0 +1,171 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package otlp test for converting metrics to otlp
+package otlp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/smartystreets/goconvey/convey"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+
+	"ascend-common/common-utils/hwlog"
+	"huawei.com/npu-exporter/v6/utils/logger"
+)
+
+const (
+	testNodeName  = "node1"
+	testChipModel = "Ascend910B"
+	testVersion   = "v7.0.0"
+	testTemp      = 45
+	testErrCount  = 3
+)
+
+var testResource = Resource{NodeName: testNodeName, ChipModel: testChipModel, BuildVersion: testVersion}
+
+func init() {
+	logger.HwLogConfig = &hwlog.LogConfig{
+		OnlyToStdout: true,
+	}
+	logger.InitLogger(logger.OTLPPlatform)
+}
+
+// newTestRegistry registry with a gauge of two cards, a counter of one card and a gauge of the node
+func newTestRegistry() *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	temp := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "npu_chip_info_temperature", Help: "temperature"},
+		[]string{cardIDLabel, "model_name"})
+	temp.WithLabelValues("0", "910B").Set(testTemp)
+	temp.WithLabelValues("1", "910B").Set(testTemp + 1)
+	errCount := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "npu_chip_info_error_count", Help: "errors"},
+		[]string{cardIDLabel})
+	errCount.WithLabelValues("0").Add(testErrCount)
+	cardNum := prometheus.NewGauge(prometheus.GaugeOpts{Name: "machine_npu_nums", Help: "card number"})
+	cardNum.Set(1)
+	reg.MustRegister(temp, errCount, cardNum)
+	return reg
+}
+
+func attrValue(attrs []*commonpb.KeyValue, key string) string {
+	for _, attr := range attrs {
+		if attr.Key == key {
+			return attr.Value.GetStringValue()
+		}
+	}
+	return ""
+}
+
+func TestConvert(t *testing.T) {
+	convey.Convey("test func 'convert'", t, func() {
+		families, err := newTestRegistry().Gather()
+		convey.So(err, convey.ShouldBeNil)
+		start := time.Now().Add(-time.Minute)
+		now := time.Now()
+		resourceMetrics := convert(families, testResource, start, now)
+		// node, card 0 and card 1
+		convey.So(len(resourceMetrics), convey.ShouldEqual, 3)
+		node, card0, card1 := resourceMetrics[0], resourceMetrics[1], resourceMetrics[2]
+		convey.So(attrValue(node.Resource.Attributes, attrCardID), convey.ShouldEqual, "")
+		convey.So(attrValue(node.Resource.Attributes, attrK8sNodeName), convey.ShouldEqual, testNodeName)
+		convey.So(attrValue(node.Resource.Attributes, attrChipModel), convey.ShouldEqual, testChipModel)
+		convey.So(attrValue(card0.Resource.Attributes, attrCardID), convey.ShouldEqual, "0")
+		convey.So(attrValue(card1.Resource.Attributes, attrCardID), convey.ShouldEqual, "1")
+
+		metrics := card0.ScopeMetrics[0].Metrics
+		convey.So(len(metrics), convey.ShouldEqual, 2)
+		sum := metrics[0].GetSum()
+		convey.So(metrics[0].Name, convey.ShouldEqual, "npu_chip_info_error_count")
+		convey.So(sum.IsMonotonic, convey.ShouldBeTrue)
+		convey.So(sum.AggregationTemporality, convey.ShouldEqual,
+			metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE)
+		convey.So(sum.DataPoints[0].GetAsDouble(), convey.ShouldEqual, testErrCount)
+		convey.So(sum.DataPoints[0].StartTimeUnixNano, convey.ShouldEqual, uint64(start.UnixNano()))
+		gauge := metrics[1].GetGauge()
+		convey.So(gauge.DataPoints[0].GetAsDouble(), convey.ShouldEqual, testTemp)
+		convey.So(gauge.DataPoints[0].TimeUnixNano, convey.ShouldEqual, uint64(now.UnixNano()))
+		// card id is moved to resource, other labels are kept as attributes
+		convey.So(attrValue(gauge.DataPoints[0].Attributes, cardIDLabel), convey.ShouldEqual, "")
+		convey.So(attrValue(gauge.DataPoints[0].Attributes, "model_name"), convey.ShouldEqual, "910B")
+	})
+}
+
+func TestConvertWithTimestamp(t *testing.T) {
+	convey.Convey("test func 'convert', timestamp of metric should be used", t, func() {
+		timestamp := time.UnixMilli(time.Now().Add(-time.Second).UnixMilli())
+		desc := prometheus.NewDesc("npu_chip_info_power", "power", nil, nil)
+		metric := prometheus.NewMetricWithTimestamp(timestamp, prometheus.MustNewConstMetric(desc,
+			prometheus.GaugeValue, 1))
+		reg := prometheus.NewRegistry()
+		reg.MustRegister(&constCollector{desc: desc, metric: metric})
+		families, err := reg.Gather()
+		convey.So(err, convey.ShouldBeNil)
+		resourceMetrics := convert(families, testResource, time.Now(), time.Now())
+		point := resourceMetrics[0].ScopeMetrics[0].Metrics[0].GetGauge().DataPoints[0]
+		convey.So(point.TimeUnixNano, convey.ShouldEqual, uint64(timestamp.UnixNano()))
+		convey.So(point.StartTimeUnixNano, convey.ShouldEqual, 0)
+	})
+}
+
+type constCollector struct {
+	desc   *prometheus.Desc
+	metric prometheus.Metric
+}
+
+func (c *constCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *constCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- c.metric
+}
+
+func TestConvertHistogramAndSummary(t *testing.T) {
+	convey.Convey("test func 'convert', histograms and summaries should be converted", t, func() {
+		histogram := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "npu_chip_info_latency",
+			Help: "latency", Buckets: []float64{1, 10}}, []string{cardIDLabel})
+		for _, value := range []float64{0.5, 5, 5, 50} {
+			histogram.WithLabelValues("0").Observe(value)
+		}
+		summary := prometheus.NewSummary(prometheus.SummaryOpts{Name: "npu_exporter_collect_seconds",
+			Help: "collect time", Objectives: map[float64]float64{0.5: 0.05}})
+		summary.Observe(testTemp)
+		reg := prometheus.NewRegistry()
+		reg.MustRegister(histogram, summary)
+		families, err := reg.Gather()
+		convey.So(err, convey.ShouldBeNil)
+		start := time.Now().Add(-time.Minute)
+		resourceMetrics := convert(families, testResource, start, time.Now())
+		convey.So(len(resourceMetrics), convey.ShouldEqual, 2)
+
+		summaryPoint := resourceMetrics[0].ScopeMetrics[0].Metrics[0].GetSummary().DataPoints[0]
+		convey.So(summaryPoint.Count, convey.ShouldEqual, 1)
+		convey.So(summaryPoint.Sum, convey.ShouldEqual, testTemp)
+		convey.So(summaryPoint.QuantileValues[0].Quantile, convey.ShouldEqual, 0.5)
+		convey.So(summaryPoint.QuantileValues[0].Value, convey.ShouldEqual, testTemp)
+
+		data := resourceMetrics[1].ScopeMetrics[0].Metrics[0].GetHistogram()
+		convey.So(data.AggregationTemporality, convey.ShouldEqual,
+			metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE)
+		point := data.DataPoints[0]
+		convey.So(point.Count, convey.ShouldEqual, 4)
+		convey.So(point.GetSum(), convey.ShouldEqual, 60.5)
+		convey.So(point.ExplicitBounds, convey.ShouldResemble, []float64{1, 10})
+		convey.So(point.BucketCounts, convey.ShouldResemble, []uint64{1, 2, 1})
+		convey.So(point.StartTimeUnixNano, convey.ShouldEqual, uint64(start.UnixNano()))
+	})
+}
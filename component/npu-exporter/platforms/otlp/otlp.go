@@ -0,0 +1,232 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package otlp push metrics of all collectors to an OpenTelemetry collector by OTLP
+package otlp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+
+	"ascend-common/common-utils/tlsutil"
+	"huawei.com/npu-exporter/v6/utils/logger"
+)
+
+const (
+	// ProtocolGRPC OTLP over grpc
+	ProtocolGRPC = "grpc"
+	// ProtocolHTTP OTLP over http with protobuf body
+	ProtocolHTTP = "http/protobuf"
+	// DefaultTimeout default timeout in seconds of each push
+	DefaultTimeout = 10
+	// DefaultMaxBuffered default number of pushes buffered when the collector is unreachable
+	DefaultMaxBuffered = 10
+	// DefaultPushInterval push interval when updateTime is not set, the same as default interval of npu group
+	DefaultPushInterval = 5 * time.Second
+
+	maxTimeout     = 60
+	maxBuffered    = 100
+	nodeNameEnv    = "NODE_NAME"
+	defaultURLPath = "/v1/metrics"
+)
+
+// Config of OTLP exporter
+type Config struct {
+	// Endpoint host:port of grpc, or url of http such as https://collector:4318/v1/metrics
+	Endpoint string
+	Protocol string
+	// Insecure push in plain text, tls is used by default
+	Insecure bool
+	TLS      tlsutil.Config
+	// HeadersFile file of "key=value" lines sent as headers or grpc metadata, e.g. authorization tokens.
+	// It is read on every push, so that rotated tokens take effect
+	HeadersFile string
+	// Timeout seconds of each push
+	Timeout int
+	// MaxBuffered pushes kept when the collector is unreachable, the oldest one is dropped when exceeded
+	MaxBuffered int
+}
+
+// Validate check the config
+func (c *Config) Validate() error {
+	if c.Endpoint == "" {
+		return errors.New("otlp endpoint should be set")
+	}
+	switch c.Protocol {
+	case ProtocolGRPC:
+	case ProtocolHTTP:
+		target, err := url.Parse(c.Endpoint)
+		if err != nil || target.Host == "" || (target.Scheme != "http" && target.Scheme != "https") {
+			return errors.New("otlp endpoint should be a http or https url when protocol is http/protobuf")
+		}
+		if target.Scheme == "http" && !c.Insecure {
+			return errors.New("otlp endpoint is http, otlpInsecure should be set to push in plain text")
+		}
+	default:
+		return fmt.Errorf("otlp protocol should be %s or %s", ProtocolGRPC, ProtocolHTTP)
+	}
+	if c.Insecure && c.TLS.Enabled() {
+		return errors.New("otlp tls files should not be set when otlpInsecure is set")
+	}
+	if err := c.TLS.Validate(); err != nil {
+		return fmt.Errorf("otlp tls config is invalid: %v", err)
+	}
+	if c.Timeout < 1 || c.Timeout > maxTimeout {
+		return fmt.Errorf("otlp timeout should be in range [1, %d]", maxTimeout)
+	}
+	if c.MaxBuffered < 1 || c.MaxBuffered > maxBuffered {
+		return fmt.Errorf("otlp max buffered pushes should be in range [1, %d]", maxBuffered)
+	}
+	return nil
+}
+
+// Resource attributes of the node, attribute of card id is added for each card
+type Resource struct {
+	NodeName     string
+	ChipModel    string
+	BuildVersion string
+}
+
+// NodeName name of the node from env NODE_NAME, host name is used when the env is absent
+func NodeName() string {
+	if name := os.Getenv(nodeNameEnv); name != "" {
+		return name
+	}
+	name, err := os.Hostname()
+	if err != nil {
+		logger.Warnf("get host name failed, error: %v", err)
+	}
+	return name
+}
+
+// Exporter gather metrics and push them periodically
+type Exporter struct {
+	gatherer    prometheus.Gatherer
+	sender      sender
+	resource    Resource
+	startTime   time.Time
+	maxBuffered int
+	buffered    []*colmetricspb.ExportMetricsServiceRequest
+	failing     bool
+}
+
+// NewExporter create exporter pushing metrics gathered from gatherer
+func NewExporter(ctx context.Context, cfg Config, gatherer prometheus.Gatherer, resource Resource) (*Exporter, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	s, err := newSender(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &Exporter{
+		gatherer:    gatherer,
+		sender:      s,
+		resource:    resource,
+		startTime:   time.Now(),
+		maxBuffered: cfg.MaxBuffered,
+	}, nil
+}
+
+// Run push metrics every interval until ctx done
+func (e *Exporter) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultPushInterval
+	}
+	defer e.sender.close()
+	logger.Infof("push metrics by otlp every %v", interval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("received stop signal, STOP otlp exporter")
+			return
+		case <-ticker.C:
+			e.push(ctx)
+		}
+	}
+}
+
+// push gather metrics into the buffer and send buffered requests in order, the rest are kept until next push
+// when one fails
+func (e *Exporter) push(ctx context.Context) {
+	families, err := e.gatherer.Gather()
+	if err != nil {
+		// gatherer returns as many metrics as possible with the error
+		logger.Warnf("gather metrics failed, error: %v", err)
+	}
+	if resourceMetrics := convert(families, e.resource, e.startTime, time.Now()); len(resourceMetrics) > 0 {
+		e.enqueue(&colmetricspb.ExportMetricsServiceRequest{ResourceMetrics: resourceMetrics})
+	}
+	for len(e.buffered) > 0 {
+		retryable, err := e.sender.send(ctx, e.buffered[0])
+		if err != nil && retryable {
+			if !e.failing {
+				logger.Errorf("push metrics by otlp failed, %d pushes are buffered, error: %v", len(e.buffered), err)
+			}
+			e.failing = true
+			return
+		}
+		if err != nil {
+			logger.Errorf("push metrics by otlp is rejected and dropped, error: %v", err)
+		}
+		e.buffered = e.buffered[1:]
+	}
+	if e.failing {
+		logger.Info("push metrics by otlp recovered, buffered pushes are sent")
+		e.failing = false
+	}
+}
+
+func (e *Exporter) enqueue(req *colmetricspb.ExportMetricsServiceRequest) {
+	if len(e.buffered) >= e.maxBuffered {
+		logger.Warnf("otlp buffer is full, drop the oldest push of %d resources", len(e.buffered[0].ResourceMetrics))
+		e.buffered = e.buffered[1:]
+	}
+	e.buffered = append(e.buffered, req)
+}
+
+// countDataPoints number of data points in request, used in logs of partial success
+func countDataPoints(req *colmetricspb.ExportMetricsServiceRequest) int {
+	count := 0
+	for _, rm := range req.ResourceMetrics {
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				switch data := m.Data.(type) {
+				case *metricspb.Metric_Gauge:
+					count += len(data.Gauge.DataPoints)
+				case *metricspb.Metric_Sum:
+					count += len(data.Sum.DataPoints)
+				case *metricspb.Metric_Histogram:
+					count += len(data.Histogram.DataPoints)
+				case *metricspb.Metric_ExponentialHistogram:
+					count += len(data.ExponentialHistogram.DataPoints)
+				case *metricspb.Metric_Summary:
+					count += len(data.Summary.DataPoints)
+				default:
+				}
+			}
+		}
+	}
+	return count
+}
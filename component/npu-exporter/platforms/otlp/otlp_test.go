@@ -0,0 +1,246 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package otlp test for otlp exporter
+package otlp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/smartystreets/goconvey/convey"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	"ascend-common/common-utils/tlsutil"
+)
+
+const (
+	testToken    = "Bearer token"
+	testFileMode = 0600
+	testMaxBuf   = 2
+)
+
+func validConfig() Config {
+	return Config{Endpoint: "collector:4317", Protocol: ProtocolGRPC, Timeout: DefaultTimeout,
+		MaxBuffered: DefaultMaxBuffered}
+}
+
+func writeHeadersFile(dir string) string {
+	file := filepath.Join(dir, "headers")
+	convey.So(os.WriteFile(file, []byte("# auth\nauthorization="+testToken+"\n"), testFileMode), convey.ShouldBeNil)
+	return file
+}
+
+func TestConfigValidate(t *testing.T) {
+	convey.Convey("test method 'Validate' of config", t, func() {
+		cfg := validConfig()
+		convey.So(cfg.Validate(), convey.ShouldBeNil)
+		convey.Convey("endpoint is empty or protocol is unknown, should return error", func() {
+			cfg.Endpoint = ""
+			convey.So(cfg.Validate(), convey.ShouldNotBeNil)
+			cfg = validConfig()
+			cfg.Protocol = "http/json"
+			convey.So(cfg.Validate(), convey.ShouldNotBeNil)
+		})
+		convey.Convey("http endpoint should be url, plain http requires insecure", func() {
+			cfg.Protocol = ProtocolHTTP
+			convey.So(cfg.Validate(), convey.ShouldNotBeNil)
+			cfg.Endpoint = "http://collector:4318"
+			convey.So(cfg.Validate(), convey.ShouldNotBeNil)
+			cfg.Insecure = true
+			convey.So(cfg.Validate(), convey.ShouldBeNil)
+		})
+		convey.Convey("tls files with insecure or without ca, should return error", func() {
+			cfg.TLS = tlsutil.Config{CertFile: "cert", KeyFile: "key"}
+			convey.So(cfg.Validate(), convey.ShouldNotBeNil)
+			cfg.TLS.CAFile = "ca"
+			convey.So(cfg.Validate(), convey.ShouldBeNil)
+			cfg.Insecure = true
+			convey.So(cfg.Validate(), convey.ShouldNotBeNil)
+		})
+		convey.Convey("timeout or buffer out of range, should return error", func() {
+			cfg.Timeout = maxTimeout + 1
+			convey.So(cfg.Validate(), convey.ShouldNotBeNil)
+			cfg = validConfig()
+			cfg.MaxBuffered = 0
+			convey.So(cfg.Validate(), convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestHTTPURL(t *testing.T) {
+	convey.Convey("test func 'httpURL'", t, func() {
+		convey.So(httpURL("http://collector:4318"), convey.ShouldEqual, "http://collector:4318/v1/metrics")
+		convey.So(httpURL("https://collector/otlp/v1/metrics"), convey.ShouldEqual, "https://collector/otlp/v1/metrics")
+	})
+}
+
+// otlpReceiver records export requests received by test http and grpc servers
+type otlpReceiver struct {
+	colmetricspb.UnimplementedMetricsServiceServer
+	lock     sync.Mutex
+	requests []*colmetricspb.ExportMetricsServiceRequest
+	auth     []string
+	status   int
+}
+
+func (r *otlpReceiver) record(req *colmetricspb.ExportMetricsServiceRequest, auth string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.requests = append(r.requests, req)
+	r.auth = append(r.auth, auth)
+}
+
+func (r *otlpReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if r.status != 0 {
+		w.WriteHeader(r.status)
+		return
+	}
+	body, err := io.ReadAll(req.Body)
+	exportReq := &colmetricspb.ExportMetricsServiceRequest{}
+	if err != nil || req.Header.Get("Content-Type") != contentTypeProtobuf || proto.Unmarshal(body, exportReq) != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	r.record(exportReq, req.Header.Get("Authorization"))
+	w.WriteHeader(http.StatusOK)
+}
+
+func (r *otlpReceiver) Export(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) (
+	*colmetricspb.ExportMetricsServiceResponse, error) {
+	auth := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("authorization")) > 0 {
+		auth = md.Get("authorization")[0]
+	}
+	r.record(req, auth)
+	return &colmetricspb.ExportMetricsServiceResponse{}, nil
+}
+
+func TestHTTPExporter(t *testing.T) {
+	convey.Convey("test exporter of http/protobuf", t, func() {
+		recv := &otlpReceiver{}
+		server := httptest.NewServer(recv)
+		defer server.Close()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		cfg := Config{Endpoint: server.URL, Protocol: ProtocolHTTP, Insecure: true, Timeout: DefaultTimeout,
+			MaxBuffered: testMaxBuf, HeadersFile: writeHeadersFile(t.TempDir())}
+		exporter, err := NewExporter(ctx, cfg, newTestRegistry(), testResource)
+		convey.So(err, convey.ShouldBeNil)
+		convey.Convey("metrics are pushed with headers", func() {
+			exporter.push(ctx)
+			convey.So(len(recv.requests), convey.ShouldEqual, 1)
+			convey.So(len(recv.requests[0].ResourceMetrics), convey.ShouldEqual, 3)
+			convey.So(recv.auth[0], convey.ShouldEqual, testToken)
+		})
+		convey.Convey("collector is down, pushes are buffered and the oldest is dropped", func() {
+			recv.status = http.StatusServiceUnavailable
+			exporter.push(ctx)
+			exporter.push(ctx)
+			exporter.push(ctx)
+			convey.So(len(exporter.buffered), convey.ShouldEqual, testMaxBuf)
+			convey.So(exporter.failing, convey.ShouldBeTrue)
+			recv.status = 0
+			exporter.push(ctx)
+			convey.So(len(exporter.buffered), convey.ShouldEqual, 0)
+			convey.So(len(recv.requests), convey.ShouldEqual, testMaxBuf)
+			convey.So(exporter.failing, convey.ShouldBeFalse)
+		})
+		convey.Convey("request is rejected, should be dropped", func() {
+			recv.status = http.StatusBadRequest
+			exporter.push(ctx)
+			convey.So(len(exporter.buffered), convey.ShouldEqual, 0)
+		})
+	})
+}
+
+func TestGRPCExporter(t *testing.T) {
+	convey.Convey("test exporter of grpc", t, func() {
+		recv := &otlpReceiver{}
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		convey.So(err, convey.ShouldBeNil)
+		server := grpc.NewServer()
+		colmetricspb.RegisterMetricsServiceServer(server, recv)
+		go func() {
+			if err := server.Serve(listener); err != nil {
+				t.Logf("grpc server stopped: %v", err)
+			}
+		}()
+		defer server.Stop()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		cfg := Config{Endpoint: listener.Addr().String(), Protocol: ProtocolGRPC, Insecure: true,
+			Timeout: DefaultTimeout, MaxBuffered: testMaxBuf, HeadersFile: writeHeadersFile(t.TempDir())}
+		exporter, err := NewExporter(ctx, cfg, newTestRegistry(), testResource)
+		convey.So(err, convey.ShouldBeNil)
+		exporter.push(ctx)
+		convey.So(len(recv.requests), convey.ShouldEqual, 1)
+		convey.So(recv.auth[0], convey.ShouldEqual, testToken)
+		convey.So(countDataPoints(recv.requests[0]), convey.ShouldEqual, 4)
+	})
+}
+
+type fakeSender struct {
+	err error
+}
+
+func (s *fakeSender) send(context.Context, *colmetricspb.ExportMetricsServiceRequest) (bool, error) {
+	return true, s.err
+}
+
+func (s *fakeSender) close() {}
+
+func TestRun(t *testing.T) {
+	convey.Convey("test method 'Run', should push until ctx done", t, func() {
+		exporter := &Exporter{gatherer: newTestRegistry(), sender: &fakeSender{err: errors.New("unavailable")},
+			resource: testResource, maxBuffered: testMaxBuf}
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		exporter.Run(ctx, 10*time.Millisecond)
+		convey.So(len(exporter.buffered), convey.ShouldEqual, testMaxBuf)
+	})
+}
+
+func TestCountDataPoints(t *testing.T) {
+	convey.Convey("test func 'countDataPoints', data points of all metric types are counted", t, func() {
+		metrics := []*metricspb.Metric{
+			{Data: &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{
+				DataPoints: []*metricspb.NumberDataPoint{{}}}}},
+			{Data: &metricspb.Metric_Sum{Sum: &metricspb.Sum{
+				DataPoints: []*metricspb.NumberDataPoint{{}}}}},
+			{Data: &metricspb.Metric_Histogram{Histogram: &metricspb.Histogram{
+				DataPoints: []*metricspb.HistogramDataPoint{{}, {}}}}},
+			{Data: &metricspb.Metric_ExponentialHistogram{ExponentialHistogram: &metricspb.ExponentialHistogram{
+				DataPoints: []*metricspb.ExponentialHistogramDataPoint{{}}}}},
+			{Data: &metricspb.Metric_Summary{Summary: &metricspb.Summary{
+				DataPoints: []*metricspb.SummaryDataPoint{{}, {}}}}},
+		}
+		req := &colmetricspb.ExportMetricsServiceRequest{ResourceMetrics: []*metricspb.ResourceMetrics{
+			{ScopeMetrics: []*metricspb.ScopeMetrics{{Metrics: metrics}}}}}
+		convey.So(countDataPoints(req), convey.ShouldEqual, 7)
+	})
+}
@@ -0,0 +1,242 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package otlp push metrics of all collectors to an OpenTelemetry collector by OTLP
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"ascend-common/common-utils/tlsutil"
+	"ascend-common/common-utils/utils"
+	"huawei.com/npu-exporter/v6/utils/logger"
+)
+
+const (
+	contentTypeProtobuf = "application/x-protobuf"
+	maxHeadersFileSize  = 64 * 1024
+	maxResponseSize     = 64 * 1024
+	headerSeparator     = "="
+)
+
+// retryableCodes grpc codes which OTLP specification allows to retry
+var retryableCodes = map[codes.Code]bool{
+	codes.Canceled:          true,
+	codes.DeadlineExceeded:  true,
+	codes.Aborted:           true,
+	codes.OutOfRange:        true,
+	codes.Unavailable:       true,
+	codes.DataLoss:          true,
+	codes.ResourceExhausted: true,
+}
+
+// sender send one export request, returns whether the error is retryable
+type sender interface {
+	send(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) (bool, error)
+	close()
+}
+
+func newSender(ctx context.Context, cfg Config) (sender, error) {
+	tlsConfig, err := clientTLSConfig(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if cfg.Protocol == ProtocolHTTP {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		return &httpSender{url: httpURL(cfg.Endpoint), headersFile: cfg.HeadersFile,
+			client: &http.Client{Transport: transport, Timeout: timeout}}, nil
+	}
+	creds := insecure.NewCredentials()
+	if tlsConfig != nil {
+		creds = credentials.NewTLS(tlsConfig)
+	}
+	conn, err := grpc.NewClient(cfg.Endpoint, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("create otlp grpc client failed: %v", err)
+	}
+	return &grpcSender{conn: conn, client: colmetricspb.NewMetricsServiceClient(conn), headersFile: cfg.HeadersFile,
+		timeout: timeout}, nil
+}
+
+// clientTLSConfig nil for plain text, client certificate and ca are reloaded when tls files are set, otherwise
+// the server is verified by system certificates
+func clientTLSConfig(ctx context.Context, cfg Config) (*tls.Config, error) {
+	if cfg.Insecure {
+		logger.Warn("otlp exporter pushes metrics in plain text")
+		return nil, nil
+	}
+	if !cfg.TLS.Enabled() {
+		return &tls.Config{MinVersion: tls.VersionTLS12}, nil
+	}
+	reloader, err := tlsutil.NewReloader(cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+	go reloader.Watch(ctx, tlsutil.DefaultReloadInterval)
+	return reloader.ClientConfig(serverName(cfg)), nil
+}
+
+func serverName(cfg Config) string {
+	hostPort := cfg.Endpoint
+	if cfg.Protocol == ProtocolHTTP {
+		if target, err := url.Parse(cfg.Endpoint); err == nil {
+			hostPort = target.Host
+		}
+	}
+	host, _, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return hostPort
+	}
+	return host
+}
+
+// httpURL default path of OTLP metrics is used when url has no path
+func httpURL(endpoint string) string {
+	target, err := url.Parse(endpoint)
+	if err != nil || strings.Trim(target.Path, "/") != "" {
+		return endpoint
+	}
+	target.Path = defaultURLPath
+	return target.String()
+}
+
+// readHeaders read "key=value" lines of headers file, empty lines and lines started with "#" are ignored
+func readHeaders(file string) (map[string]string, error) {
+	if file == "" {
+		return nil, nil
+	}
+	data, err := utils.ReadMountedFile(file, maxHeadersFileSize)
+	if err != nil {
+		return nil, fmt.Errorf("read otlp headers file failed: %v", err)
+	}
+	headers := make(map[string]string)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(line, headerSeparator)
+		if !found || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("otlp headers file has invalid line, should be key=value")
+		}
+		headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
+
+func logPartialSuccess(req *colmetricspb.ExportMetricsServiceRequest, resp *colmetricspb.ExportMetricsServiceResponse) {
+	partial := resp.GetPartialSuccess()
+	if partial.GetRejectedDataPoints() > 0 || partial.GetErrorMessage() != "" {
+		logger.Warnf("otlp collector rejected %d of %d data points, message: %s", partial.GetRejectedDataPoints(),
+			countDataPoints(req), partial.GetErrorMessage())
+	}
+}
+
+type grpcSender struct {
+	conn        *grpc.ClientConn
+	client      colmetricspb.MetricsServiceClient
+	headersFile string
+	timeout     time.Duration
+}
+
+func (s *grpcSender) send(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) (bool, error) {
+	headers, err := readHeaders(s.headersFile)
+	if err != nil {
+		return true, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	if len(headers) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(headers))
+	}
+	resp, err := s.client.Export(ctx, req)
+	if err != nil {
+		return retryableCodes[status.Code(err)], err
+	}
+	logPartialSuccess(req, resp)
+	return false, nil
+}
+
+func (s *grpcSender) close() {
+	if err := s.conn.Close(); err != nil {
+		logger.Warnf("close otlp grpc connection failed, error: %v", err)
+	}
+}
+
+type httpSender struct {
+	url         string
+	headersFile string
+	client      *http.Client
+}
+
+func (s *httpSender) send(ctx context.Context, req *colmetricspb.ExportMetricsServiceRequest) (bool, error) {
+	headers, err := readHeaders(s.headersFile)
+	if err != nil {
+		return true, err
+	}
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return false, fmt.Errorf("marshal otlp request failed: %v", err)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	httpReq.Header.Set("Content-Type", contentTypeProtobuf)
+	for key, value := range headers {
+		httpReq.Header.Set(key, value)
+	}
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return true, fmt.Errorf("read otlp response failed: %v", err)
+	}
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		exportResp := &colmetricspb.ExportMetricsServiceResponse{}
+		if resp.Header.Get("Content-Type") == contentTypeProtobuf && proto.Unmarshal(respBody, exportResp) == nil {
+			logPartialSuccess(req, exportResp)
+		}
+		return false, nil
+	}
+	retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+	return retryable, fmt.Errorf("otlp collector responds status %d", resp.StatusCode)
+}
+
+func (s *httpSender) close() {
+	s.client.CloseIdleConnections()
+}
@@ -0,0 +1,337 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package plugins for custom metrics
+package plugins
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+
+	"ascend-common/common-utils/hwlog"
+	"ascend-common/common-utils/utils"
+	"huawei.com/npu-exporter/v6/collector/common"
+	"huawei.com/npu-exporter/v6/collector/container"
+	"huawei.com/npu-exporter/v6/collector/metrics"
+	"huawei.com/npu-exporter/v6/utils/logger"
+)
+
+const (
+	promFileSuffix    = ".prom"
+	maxPromFileNumber = 100
+	// npuIDLabel metrics with this label are reported with the card labels of the npu whose physical id is its value
+	npuIDLabel = "npu_id"
+	sumSuffix  = "_sum"
+	cntSuffix  = "_count"
+)
+
+var (
+	metricsDir = ""
+)
+
+// SetTextMetricsDir set the directory of Prometheus text exposition files, files with .prom suffix in it are
+// collected, the same as the textfile collector of node_exporter
+func SetTextMetricsDir(dir string) {
+	metricsDir = dir
+}
+
+// promFiles metric families of .prom files, key is the file path
+type promFiles map[string][]*dto.MetricFamily
+
+func promCacheKey() string {
+	return fmt.Sprintf("%s-%s", baseCacheKey, metricsDir)
+}
+
+// isMetricsDirOk checks the metrics directory, files in it are checked in every collection because they may be
+// added or removed at any time
+func isMetricsDirOk() bool {
+	if metricsDir == "" {
+		return false
+	}
+	absPath, err := utils.CheckPath(metricsDir)
+	if err != nil {
+		logger.Warnf("check directory %s failed: %v, %s", metricsDir, err, fileMetricsDisabledMsg)
+		return false
+	}
+	if !utils.IsDir(absPath) {
+		logger.Warnf("path %s is not a directory, %s", metricsDir, fileMetricsDisabledMsg)
+		return false
+	}
+	logger.Infof("successfully initialized text metric directory %s", metricsDir)
+	return true
+}
+
+func listPromFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read directory %s failed: %v", dir, err)
+	}
+	paths := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), promFileSuffix) {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, entry.Name()))
+	}
+	if len(paths) > maxPromFileNumber {
+		logger.LogfWithOptions(logger.WarnLevel, logger.LogOptions{Domain: logDomain, ID: dir + "tooManyFiles"},
+			"the number of %s files in %s is more than max allowed number(%d), only the first %d files will be "+
+				"collected", promFileSuffix, dir, maxPromFileNumber, maxPromFileNumber)
+		paths = paths[:maxPromFileNumber]
+	} else {
+		hwlog.ResetErrCnt(logDomain, dir+"tooManyFiles")
+	}
+	return paths, nil
+}
+
+func readPromFile(path string) ([]*dto.MetricFamily, error) {
+	if err := checkFilePermission(path); err != nil {
+		return nil, fmt.Errorf("check file %s failed: %v", path, err)
+	}
+	// read one more byte to find files larger than the limit, a truncated file is parsed as different metrics
+	fileData, err := utils.ReadLimitBytes(path, size100k+1)
+	if err != nil {
+		return nil, fmt.Errorf("read file %s failed: %v", path, err)
+	}
+	if len(fileData) > size100k {
+		return nil, fmt.Errorf("size of file %s is more than 100KB", path)
+	}
+	var parser expfmt.TextParser
+	familyMap, err := parser.TextToMetricFamilies(bytes.NewReader(fileData))
+	if err != nil {
+		return nil, fmt.Errorf("parse file %s failed: %v", path, err)
+	}
+	families := make([]*dto.MetricFamily, 0, len(familyMap))
+	for _, family := range familyMap {
+		if err = isFamilyOk(family); err != nil {
+			return nil, fmt.Errorf("%v, file: %s", err, path)
+		}
+		families = append(families, family)
+	}
+	sort.Slice(families, func(i, j int) bool {
+		return families[i].GetName() < families[j].GetName()
+	})
+	return families, nil
+}
+
+func isFamilyOk(family *dto.MetricFamily) error {
+	if len(family.GetName()) > maxMetricNameSize {
+		return fmt.Errorf("length of metric name should not larger than %d, but current is %d",
+			maxMetricNameSize, len(family.GetName()))
+	}
+	if len(family.GetHelp()) > maxDescSize {
+		return fmt.Errorf("length of help of metric %s should not larger than %d, but current is %d",
+			family.GetName(), maxDescSize, len(family.GetHelp()))
+	}
+	if len(family.GetMetric()) > maxDataListSize {
+		return fmt.Errorf("size of metric %s(%d) is more than max allowed size(%d)", family.GetName(),
+			len(family.GetMetric()), maxDataListSize)
+	}
+	for _, metric := range family.GetMetric() {
+		if len(metric.GetLabel()) > maxLabelSize {
+			return fmt.Errorf("size of labels(%d) of metric %s is more than max allowed label size(%d)",
+				len(metric.GetLabel()), family.GetName(), maxLabelSize)
+		}
+	}
+	return nil
+}
+
+// collectPromFiles parses .prom files to cache, the cached metrics of a file are reported when it fails to be
+// parsed, metrics already described by json files or former .prom files are ignored
+func (c *TextMetricsInfoCollector) collectPromFiles() {
+	paths, err := listPromFiles(metricsDir)
+	if err != nil {
+		logger.LogfWithOptions(logger.WarnLevel, logger.LogOptions{Domain: logDomain, ID: metricsDir + "readDirErr"},
+			"%v, %s", err, skipCurrentCollectionMsg)
+		return
+	}
+	hwlog.ResetErrCnt(logDomain, metricsDir+"readDirErr")
+
+	previous := c.loadPromFiles()
+	result := make(promFiles, len(paths))
+	names := make(map[string]string)
+	for _, path := range paths {
+		logId := path + "promFileErr"
+		families, err := readPromFile(path)
+		if err != nil {
+			logger.LogfWithOptions(logger.WarnLevel, logger.LogOptions{Domain: logDomain, ID: logId},
+				"%v, %s", err, skipCurrentCollectionMsg)
+			if families = previous[path]; families == nil {
+				continue
+			}
+		} else {
+			hwlog.ResetErrCnt(logDomain, logId)
+		}
+		result[path] = filterDuplicatedFamilies(path, families, names)
+	}
+	c.Cache.Store(promCacheKey(), result)
+}
+
+func filterDuplicatedFamilies(path string, families []*dto.MetricFamily,
+	names map[string]string) []*dto.MetricFamily {
+	filtered := make([]*dto.MetricFamily, 0, len(families))
+	for _, family := range families {
+		mPath, ok := existMetrics[family.GetName()]
+		if !ok {
+			mPath, ok = names[family.GetName()]
+		}
+		logId := path + family.GetName() + "duplicated"
+		if ok {
+			logger.LogfWithOptions(logger.WarnLevel, logger.LogOptions{Domain: logDomain, ID: logId},
+				"metric [%s] already described in file [%s], ignore it in file [%s]", family.GetName(), mPath, path)
+			continue
+		}
+		hwlog.ResetErrCnt(logDomain, logId)
+		names[family.GetName()] = path
+		filtered = append(filtered, family)
+	}
+	return filtered
+}
+
+func (c *TextMetricsInfoCollector) loadPromFiles() promFiles {
+	data, ok := c.Cache.Load(promCacheKey())
+	if !ok {
+		return nil
+	}
+	files, ok := data.(promFiles)
+	if !ok {
+		logger.Warnf("cache data type mismatch for key %s", promCacheKey())
+		return nil
+	}
+	return files
+}
+
+// updatePromFiles walks the cached metrics with their label names and values
+func (c *TextMetricsInfoCollector) updatePromFiles(containerMap map[int32][]container.DevicesInfo,
+	chips []common.HuaWeiAIChip, doUpdate func(string, *dto.MetricFamily, *dto.Metric, []string, []string)) {
+	if metricsDir == "" {
+		return
+	}
+	chipMap := make(map[string]common.HuaWeiAIChip, len(chips))
+	for _, chip := range chips {
+		phyID := strconv.Itoa(int(chip.PhyId))
+		if _, ok := chipMap[phyID]; !ok {
+			chipMap[phyID] = chip
+		}
+	}
+	for path, families := range c.loadPromFiles() {
+		for _, family := range families {
+			for _, metric := range family.GetMetric() {
+				names, values := promLabels(metric, chipMap, containerMap)
+				doUpdate(path, family, metric, names, values)
+			}
+		}
+	}
+}
+
+// promLabels returns the card labels followed by the other labels when the npu of npu_id label is found,
+// otherwise the labels of the metric are returned as they are
+func promLabels(metric *dto.Metric, chipMap map[string]common.HuaWeiAIChip,
+	containerMap map[int32][]container.DevicesInfo) ([]string, []string) {
+	var names, values []string
+	cardLabels := make(map[string]struct{}, len(common.CardLabel))
+	for _, pair := range metric.GetLabel() {
+		if pair.GetName() != npuIDLabel {
+			continue
+		}
+		if chip, ok := chipMap[pair.GetValue()]; ok {
+			names = append(names, common.CardLabel...)
+			values = append(values, metrics.GeneralCardLabel(&chip, containerMap)...)
+			for _, name := range common.CardLabel {
+				cardLabels[name] = struct{}{}
+			}
+			cardLabels[npuIDLabel] = struct{}{}
+		}
+	}
+	for _, pair := range metric.GetLabel() {
+		if _, ok := cardLabels[pair.GetName()]; ok {
+			continue
+		}
+		names = append(names, pair.GetName())
+		values = append(values, pair.GetValue())
+	}
+	return names, values
+}
+
+func newPromMetric(family *dto.MetricFamily, metric *dto.Metric, names, values []string) (prometheus.Metric, error) {
+	desc := prometheus.NewDesc(family.GetName(), family.GetHelp(), names, nil)
+	var result prometheus.Metric
+	var err error
+	switch family.GetType() {
+	case dto.MetricType_COUNTER:
+		result, err = prometheus.NewConstMetric(desc, prometheus.CounterValue, metric.GetCounter().GetValue(),
+			values...)
+	case dto.MetricType_GAUGE:
+		result, err = prometheus.NewConstMetric(desc, prometheus.GaugeValue, metric.GetGauge().GetValue(), values...)
+	case dto.MetricType_HISTOGRAM:
+		histogram := metric.GetHistogram()
+		buckets := make(map[float64]uint64, len(histogram.GetBucket()))
+		for _, bucket := range histogram.GetBucket() {
+			// +Inf bucket is added by the sample count
+			if !math.IsInf(bucket.GetUpperBound(), 1) {
+				buckets[bucket.GetUpperBound()] = bucket.GetCumulativeCount()
+			}
+		}
+		result, err = prometheus.NewConstHistogram(desc, histogram.GetSampleCount(), histogram.GetSampleSum(),
+			buckets, values...)
+	case dto.MetricType_SUMMARY:
+		summary := metric.GetSummary()
+		quantiles := make(map[float64]float64, len(summary.GetQuantile()))
+		for _, quantile := range summary.GetQuantile() {
+			quantiles[quantile.GetQuantile()] = quantile.GetValue()
+		}
+		result, err = prometheus.NewConstSummary(desc, summary.GetSampleCount(), summary.GetSampleSum(), quantiles,
+			values...)
+	default:
+		result, err = prometheus.NewConstMetric(desc, prometheus.UntypedValue, metric.GetUntyped().GetValue(),
+			values...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if metric.TimestampMs != nil {
+		result = prometheus.NewMetricWithTimestamp(time.UnixMilli(metric.GetTimestampMs()), result)
+	}
+	return result, nil
+}
+
+// promFields returns the fields of telegraf, only sum and count are reported for histograms and summaries
+func promFields(family *dto.MetricFamily, metric *dto.Metric) map[string]interface{} {
+	name := family.GetName()
+	switch family.GetType() {
+	case dto.MetricType_COUNTER:
+		return map[string]interface{}{name: metric.GetCounter().GetValue()}
+	case dto.MetricType_GAUGE:
+		return map[string]interface{}{name: metric.GetGauge().GetValue()}
+	case dto.MetricType_HISTOGRAM:
+		return map[string]interface{}{name + sumSuffix: metric.GetHistogram().GetSampleSum(),
+			name + cntSuffix: metric.GetHistogram().GetSampleCount()}
+	case dto.MetricType_SUMMARY:
+		return map[string]interface{}{name + sumSuffix: metric.GetSummary().GetSampleSum(),
+			name + cntSuffix: metric.GetSummary().GetSampleCount()}
+	default:
+		return map[string]interface{}{name: metric.GetUntyped().GetValue()}
+	}
+}
@@ -0,0 +1,164 @@
+/* Copyright(C) 2026. Huawei Technologies Co.,Ltd. All rights reserved.
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package plugins
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/smartystreets/goconvey/convey"
+
+	"huawei.com/npu-exporter/v6/collector/common"
+	"huawei.com/npu-exporter/v6/collector/container"
+)
+
+const (
+	testPromFileMode = 0600
+	testPromContent  = `# HELP sidecar_link_errors link errors of npu
+# TYPE sidecar_link_errors counter
+sidecar_link_errors{npu_id="0",port="1"} 3
+sidecar_link_errors{port="host"} 1
+# HELP sidecar_latency_seconds latency of the sidecar
+# TYPE sidecar_latency_seconds histogram
+sidecar_latency_seconds_bucket{le="0.1"} 1
+sidecar_latency_seconds_bucket{le="1"} 2
+sidecar_latency_seconds_bucket{le="+Inf"} 3
+sidecar_latency_seconds_sum 2.5
+sidecar_latency_seconds_count 3
+# TYPE sidecar_duration_seconds summary
+sidecar_duration_seconds{quantile="0.5"} 0.2
+sidecar_duration_seconds_sum 1
+sidecar_duration_seconds_count 4
+sidecar_up 1 1700000000000
+`
+	testPromMetricCount = 5
+)
+
+func writePromFile(dir, name, content string) string {
+	path := filepath.Join(dir, name)
+	convey.So(os.WriteFile(path, []byte(content), testPromFileMode), convey.ShouldBeNil)
+	return path
+}
+
+func TestReadPromFile(t *testing.T) {
+	convey.Convey("test func 'readPromFile'", t, func() {
+		dir := t.TempDir()
+		families, err := readPromFile(writePromFile(dir, "a.prom", testPromContent))
+		convey.So(err, convey.ShouldBeNil)
+		convey.So(len(families), convey.ShouldEqual, 4)
+		convey.So(families[0].GetName(), convey.ShouldEqual, "sidecar_duration_seconds")
+		convey.So(families[0].GetType(), convey.ShouldEqual, dto.MetricType_SUMMARY)
+		convey.So(families[1].GetType(), convey.ShouldEqual, dto.MetricType_HISTOGRAM)
+		convey.So(families[2].GetHelp(), convey.ShouldEqual, "link errors of npu")
+
+		convey.Convey("invalid file should return error", func() {
+			_, err = readPromFile(writePromFile(dir, "b.prom", "metric{"))
+			convey.So(err, convey.ShouldNotBeNil)
+			labels := make([]string, 0, maxLabelSize+1)
+			for i := 0; i <= maxLabelSize; i++ {
+				labels = append(labels, "l"+strings.Repeat("a", i)+`="v"`)
+			}
+			_, err = readPromFile(writePromFile(dir, "c.prom", "metric{"+strings.Join(labels, ",")+"} 1\n"))
+			convey.So(err, convey.ShouldNotBeNil)
+			_, err = readPromFile(writePromFile(dir, "d.prom", "# "+strings.Repeat("a", size100k)))
+			convey.So(err, convey.ShouldNotBeNil)
+			path := writePromFile(dir, "e.prom", testPromContent)
+			convey.So(os.Chmod(path, 0700), convey.ShouldBeNil)
+			_, err = readPromFile(path)
+			convey.So(err, convey.ShouldNotBeNil)
+		})
+	})
+}
+
+func TestCollectPromFiles(t *testing.T) {
+	convey.Convey("test method 'collectPromFiles' of TextMetricsInfoCollector", t, func() {
+		dir := t.TempDir()
+		SetTextMetricsDir(dir)
+		defer SetTextMetricsDir("")
+		resetGlobalMaps()
+		existMetrics[testMetricName] = testFilePath
+		defer resetGlobalMaps()
+		aPath := writePromFile(dir, "a.prom", testPromContent)
+		bPath := writePromFile(dir, "b.prom", "sidecar_up 2\n"+testMetricName+" 1\nother_metric 1\n")
+		writePromFile(dir, "c.txt", "ignored_metric 1\n")
+		convey.So(isMetricsDirOk(), convey.ShouldBeTrue)
+
+		c := &TextMetricsInfoCollector{}
+		c.CollectToCache(nil, nil)
+		files := c.loadPromFiles()
+		convey.So(len(files), convey.ShouldEqual, 2)
+		convey.So(len(files[aPath]), convey.ShouldEqual, 4)
+		// metrics described by json files or former files are ignored
+		convey.So(len(files[bPath]), convey.ShouldEqual, 1)
+		convey.So(files[bPath][0].GetName(), convey.ShouldEqual, "other_metric")
+
+		convey.Convey("file is invalid, cached metrics of it should be kept", func() {
+			writePromFile(dir, "a.prom", "metric{")
+			c.CollectToCache(nil, nil)
+			convey.So(len(c.loadPromFiles()[aPath]), convey.ShouldEqual, 4)
+		})
+		convey.Convey("file is removed, metrics of it should be removed", func() {
+			convey.So(os.Remove(aPath), convey.ShouldBeNil)
+			c.CollectToCache(nil, nil)
+			convey.So(c.loadPromFiles(), convey.ShouldNotContainKey, aPath)
+		})
+	})
+}
+
+func TestUpdatePromFiles(t *testing.T) {
+	convey.Convey("test method 'UpdatePrometheus' of TextMetricsInfoCollector with .prom files", t, func() {
+		dir := t.TempDir()
+		SetTextMetricsDir(dir)
+		defer SetTextMetricsDir("")
+		resetGlobalMaps()
+		defer resetGlobalMaps()
+		writePromFile(dir, "a.prom", testPromContent)
+		c := &TextMetricsInfoCollector{}
+		c.CollectToCache(nil, nil)
+
+		chips := []common.HuaWeiAIChip{{PhyId: 0, DeviceID: 0}}
+		containerMap := map[int32][]container.DevicesInfo{0: {{Name: "ns_pod_ctr"}}}
+		ch := make(chan prometheus.Metric, testPromMetricCount)
+		c.UpdatePrometheus(ch, nil, containerMap, chips)
+		close(ch)
+		convey.So(len(ch), convey.ShouldEqual, testPromMetricCount)
+		for metric := range ch {
+			m := &dto.Metric{}
+			convey.So(metric.Write(m), convey.ShouldBeNil)
+			labels := make(map[string]string)
+			for _, label := range m.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["port"] == "1" {
+				// merged under the card labels of npu 0
+				convey.So(labels, convey.ShouldNotContainKey, npuIDLabel)
+				convey.So(labels["id"], convey.ShouldEqual, "0")
+				convey.So(labels["pod_name"], convey.ShouldEqual, "pod")
+			}
+			if m.GetHistogram() != nil {
+				convey.So(m.GetHistogram().GetSampleCount(), convey.ShouldEqual, 3)
+			}
+		}
+
+		telegrafCh := make(chan common.TelegrafMetric, testPromMetricCount)
+		c.UpdateTelegraf(telegrafCh, nil, containerMap, chips)
+		close(telegrafCh)
+		convey.So(len(telegrafCh), convey.ShouldEqual, testPromMetricCount)
+	})
+}
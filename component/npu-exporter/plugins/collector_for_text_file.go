@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 
 	"ascend-common/common-utils/hwlog"
 	"ascend-common/common-utils/utils"
@@ -199,6 +200,9 @@ func (c *TextMetricsInfoCollector) CollectToCache(n *common.NpuCollector, chipLi
 
 		c.Cache.Store(fmt.Sprintf("%s-%s", baseCacheKey, jsonFilePath), metricsData)
 	}
+	if metricsDir != "" {
+		c.collectPromFiles()
+	}
 }
 
 func isStructInfoChangedForFile(jsonFilePath string, data TextMetricData) bool {
@@ -239,6 +243,17 @@ func (c *TextMetricsInfoCollector) UpdatePrometheus(ch chan<- prometheus.Metric,
 		ch <- prometheus.NewMetricWithTimestamp(timestamp,
 			prometheus.MustNewConstMetric(structInfo.metricDesc, prometheus.GaugeValue, item.Value, labelValues...))
 	})
+	c.updatePromFiles(containerMap, chips, func(promFilePath string, family *dto.MetricFamily, metric *dto.Metric,
+		names, values []string) {
+		promMetric, err := newPromMetric(family, metric, names, values)
+		if err != nil {
+			logger.LogfWithOptions(logger.WarnLevel, logger.LogOptions{Domain: logDomain,
+				ID: promFilePath + family.GetName() + "invalidMetric"},
+				"invalid metric [%s] in file [%s]: %v", family.GetName(), promFilePath, err)
+			return
+		}
+		ch <- promMetric
+	})
 }
 
 // UpdateTelegraf update telegraf metric
@@ -261,6 +276,21 @@ func (c *TextMetricsInfoCollector) UpdateTelegraf(ch chan<- common.TelegrafMetri
 		metric.Timestamp = timestamp
 		ch <- metric
 	})
+	c.updatePromFiles(containerMap, chips, func(promFilePath string, family *dto.MetricFamily, promMetric *dto.Metric,
+		names, values []string) {
+		metric := common.NewGeneralMetric()
+		metric.Measurement = promFilePath
+		metric.Labels = make(map[string]string, len(names))
+		for i, name := range names {
+			metric.Labels[name] = values[i]
+		}
+		metric.Fields = promFields(family, promMetric)
+		metric.Timestamp = time.Now()
+		if promMetric.TimestampMs != nil {
+			metric.Timestamp = time.UnixMilli(promMetric.GetTimestampMs())
+		}
+		ch <- metric
+	})
 }
 
 // processFileData processes file data and initializes its metrics
@@ -376,8 +406,9 @@ func preCheckPaths(paths []string) {
 
 // IsSupported Check whether the current hardware supports this metric
 func (c *TextMetricsInfoCollector) IsSupported(n *common.NpuCollector) bool {
+	dirSupported := isMetricsDirOk()
 	if filePath == "" {
-		return false
+		return dirSupported
 	}
 	paths := strings.Split(filePath, ",")
 	if len(paths) > maxFileNumber {
@@ -390,7 +421,7 @@ func (c *TextMetricsInfoCollector) IsSupported(n *common.NpuCollector) bool {
 
 	if len(validPaths) == 0 {
 		logger.Warnf("no valid file paths found in filePath: %s, %s", filePath, fileMetricsDisabledMsg)
-		return false
+		return dirSupported
 	}
 	logger.Infof("successfully initialized %d text metric file(s)", len(validPaths))
 	return true
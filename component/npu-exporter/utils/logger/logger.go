@@ -45,6 +45,8 @@ const (
 	PrometheusPlatform = "Prometheus"
 	// TelegrafPlatform Telegraf platform
 	TelegrafPlatform = "Telegraf"
+	// OTLPPlatform OpenTelemetry OTLP push platform
+	OTLPPlatform = "OTLP"
 )
 
 // HwLogConfig default log file
@@ -76,7 +78,7 @@ func InitLogger(platform string) error {
 		logger = &telegrafLogger{}
 		HwLogConfig.LogFileName = defaultTelegrafLogPath
 		HwLogConfig.OnlyToFile = true
-	} else if platform == PrometheusPlatform {
+	} else if platform == PrometheusPlatform || platform == OTLPPlatform {
 		logger = &generalLogger{}
 	} else {
 		return errors.New("platform is not supported:" + platform)
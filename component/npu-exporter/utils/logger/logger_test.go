@@ -39,6 +39,11 @@ func TestInitLogger(t *testing.T) {
 			platform: PrometheusPlatform,
 			expected: nil,
 		},
+		{
+			name:     "OTLP Platform",
+			platform: OTLPPlatform,
+			expected: nil,
+		},
 		{
 			name:     "Unsupported Platform",
 			platform: "Unsupported",